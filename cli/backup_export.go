@@ -18,8 +18,6 @@ import (
 	"github.com/sourcenetwork/defradb/client"
 )
 
-const jsonFileType = "json"
-
 func MakeBackupExportCommand() *cobra.Command {
 	var collections []string
 	var pretty bool
@@ -32,10 +30,23 @@ func MakeBackupExportCommand() *cobra.Command {
 If the --collection flag is provided, only the data for that collection will be exported.
 Otherwise, all collections in the database will be exported.
 
-If the --pretty flag is provided, the JSON will be pretty printed.
+If the --format flag is provided, the data will be exported in the given format. Supported formats are:
+  - json: a single JSON object holding an array of documents per collection (default)
+  - ndjson: newline delimited JSON, one document per line
+  - cbor: a sequence of CBOR encoded documents
+  - car: the document DAGs, heads and definitions of the collections, and of their related collections.
+    Importing it restores the documents with their history, preserving document IDs and commits.
+
+If the --pretty flag is provided, the JSON will be pretty printed. It only applies to the json format.
 
 Example: export data for the 'Users' collection:
-  defradb client export --collection Users user_data.json`,
+  defradb client export --collection Users user_data.json
+
+Example: export all data as newline delimited JSON:
+  defradb client export --format ndjson data.ndjson
+
+Example: export the full history of the 'Users' collection:
+  defradb client export --collection Users --format car user_data.car`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store := mustGetContextStore(cmd)
@@ -51,7 +62,7 @@ Example: export data for the 'Users' collection:
 
 			data := client.BackupConfig{
 				Filepath:    outputPath,
-				Format:      format,
+				Format:      strings.ToLower(format),
 				Pretty:      pretty,
 				Collections: collections,
 			}
@@ -60,8 +71,8 @@ Example: export data for the 'Users' collection:
 		},
 	}
 	cmd.Flags().BoolVarP(&pretty, "pretty", "p", false, "Set the output JSON to be pretty printed")
-	cmd.Flags().StringVarP(&format, "format", "f", jsonFileType,
-		"Define the output format. Supported formats: [json]")
+	cmd.Flags().StringVarP(&format, "format", "f", client.BackupFormatJSON,
+		"Define the output format. Supported formats: [json, ndjson, cbor, car]")
 	cmd.Flags().StringSliceVarP(&collections, "collections", "c", []string{}, "List of collections")
 
 	return cmd
@@ -69,7 +80,7 @@ Example: export data for the 'Users' collection:
 
 func isValidExportFormat(format string) bool {
 	switch strings.ToLower(format) {
-	case jsonFileType:
+	case client.BackupFormatJSON, client.BackupFormatNDJSON, client.BackupFormatCBOR, client.BackupFormatCAR:
 		return true
 	default:
 		return false
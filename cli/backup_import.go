@@ -17,8 +17,16 @@ import (
 func MakeBackupImportCommand() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "import <input_path>",
-		Short: "Import a JSON data file to the database",
-		Long: `Import a JSON data file to the database.
+		Short: "Import a data file to the database",
+		Long: `Import a data file exported in the json, ndjson, cbor or car format to the database.
+The format of the file is detected automatically.
+
+Documents are imported in batches, each committed in its own transaction. If an import is
+interrupted, importing the same file again resumes after the last committed batch, unless
+the file was modified in the meantime.
+
+Car files restore the collections they hold along with the full history of their documents.
+The collections must either not exist or be identical to the exported ones.
 
 Example: import data to the database:
   defradb client import user_data.json`,
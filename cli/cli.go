@@ -37,11 +37,20 @@ func NewDefraCommand() *cobra.Command {
 		MakeP2PReplicatorDeleteCommand(),
 	)
 
+	p2p_access := MakeP2PAccessCommand()
+	p2p_access.AddCommand(
+		MakeP2PAccessGetAllCommand(),
+		MakeP2PAccessSetCommand(),
+		MakeP2PAccessDeleteCommand(),
+	)
+
 	p2p := MakeP2PCommand()
 	p2p.AddCommand(
 		p2p_replicator,
 		p2p_collection,
+		p2p_access,
 		MakeP2PInfoCommand(),
+		MakeP2PReconcileCommand(),
 	)
 
 	schema_migrate := MakeSchemaMigrationCommand()
@@ -114,6 +123,7 @@ func NewDefraCommand() *cobra.Command {
 		MakeCollectionCreateCommand(),
 		MakeCollectionDescribeCommand(),
 		MakeCollectionPatchCommand(),
+		MakeCollectionStatsCommand(),
 	)
 
 	client := MakeClientCommand()
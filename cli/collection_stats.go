@@ -0,0 +1,45 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeCollectionStatsCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "stats",
+		Short: "View the estimated statistics of a collection.",
+		Long: `View the estimated statistics of a collection.
+
+The statistics contain the number of documents in the collection and the estimated
+number of distinct values of each of its indexes. They are used to plan requests.
+
+Example: view the statistics of the User collection
+  defradb client collection stats --name User
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := mustGetContextStore(cmd)
+
+			col, ok := tryGetContextCollection(cmd)
+			if !ok {
+				return cmd.Usage()
+			}
+
+			stats, err := store.GetCollectionStatistics(cmd.Context(), col.Name().Value())
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, stats)
+		},
+	}
+	return cmd
+}
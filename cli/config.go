@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/sourcenetwork/corelog"
@@ -43,31 +44,44 @@ var configPaths = []string{
 
 // configFlags is a mapping of cli flag names to config keys to bind.
 var configFlags = map[string]string{
-	"log-level":          "log.level",
-	"log-output":         "log.output",
-	"log-format":         "log.format",
-	"log-stacktrace":     "log.stacktrace",
-	"log-source":         "log.source",
-	"log-overrides":      "log.overrides",
-	"no-log-color":       "log.colordisabled",
-	"url":                "api.address",
-	"max-txn-retries":    "datastore.maxtxnretries",
-	"store":              "datastore.store",
-	"no-encryption":      "datastore.noencryption",
-	"valuelogfilesize":   "datastore.badger.valuelogfilesize",
-	"peers":              "net.peers",
-	"p2paddr":            "net.p2paddresses",
-	"no-p2p":             "net.p2pdisabled",
-	"allowed-origins":    "api.allowed-origins",
-	"pubkeypath":         "api.pubkeypath",
-	"privkeypath":        "api.privkeypath",
-	"keyring-namespace":  "keyring.namespace",
-	"keyring-backend":    "keyring.backend",
-	"keyring-path":       "keyring.path",
-	"no-keyring":         "keyring.disabled",
-	"source-hub-address": "acp.sourceHub.address",
-	"development":        "development",
-	"secret-file":        "secretfile",
+	"log-level":                "log.level",
+	"log-output":               "log.output",
+	"log-format":               "log.format",
+	"log-stacktrace":           "log.stacktrace",
+	"log-source":               "log.source",
+	"log-overrides":            "log.overrides",
+	"no-log-color":             "log.colordisabled",
+	"url":                      "api.address",
+	"max-txn-retries":          "datastore.maxtxnretries",
+	"store":                    "datastore.store",
+	"no-encryption":            "datastore.noencryption",
+	"sign-blocks":              "datastore.signblocks",
+	"timestamp-blocks":         "datastore.timestampblocks",
+	"require-signed-blocks":    "datastore.requiresignedblocks",
+	"query-memory-budget":      "datastore.querymemorybudget",
+	"max-request-duration":     "datastore.maxrequestduration",
+	"max-request-scanned-docs": "datastore.maxrequestscanneddocs",
+	"request-cache-size":       "datastore.requestcachesize",
+	"max-prepared-requests":    "datastore.maxpreparedrequests",
+	"slow-request-threshold":   "datastore.slowrequestthreshold",
+	"metrics-otlp-endpoint":    "metrics.otlpendpoint",
+	"valuelogfilesize":         "datastore.badger.valuelogfilesize",
+	"peers":                    "net.peers",
+	"p2paddr":                  "net.p2paddresses",
+	"no-p2p":                   "net.p2pdisabled",
+	"allowed-peers":            "net.allowedpeers",
+	"denied-peers":             "net.deniedpeers",
+	"push-acp":                 "net.pushacpenabled",
+	"allowed-origins":          "api.allowed-origins",
+	"pubkeypath":               "api.pubkeypath",
+	"privkeypath":              "api.privkeypath",
+	"keyring-namespace":        "keyring.namespace",
+	"keyring-backend":          "keyring.backend",
+	"keyring-path":             "keyring.path",
+	"no-keyring":               "keyring.disabled",
+	"source-hub-address":       "acp.sourceHub.address",
+	"development":              "development",
+	"secret-file":              "secretfile",
 }
 
 // configDefaults contains default values for config entries.
@@ -77,13 +91,26 @@ var configDefaults = map[string]any{
 	"datastore.badger.path":             "data",
 	"datastore.maxtxnretries":           5,
 	"datastore.store":                   "badger",
+	"datastore.signblocks":              false,
+	"datastore.timestampblocks":         false,
+	"datastore.requiresignedblocks":     false,
+	"datastore.querymemorybudget":       256 << 20,
+	"datastore.maxrequestduration":      time.Duration(0),
+	"datastore.maxrequestscanneddocs":   uint64(0),
+	"datastore.requestcachesize":        1000,
+	"datastore.maxpreparedrequests":     1000,
+	"datastore.slowrequestthreshold":    time.Duration(0),
 	"datastore.badger.valuelogfilesize": 1 << 30,
 	"development":                       false,
+	"metrics.otlpendpoint":              "",
 	"net.p2pdisabled":                   false,
 	"net.p2paddresses":                  []string{"/ip4/127.0.0.1/tcp/9171"},
 	"net.peers":                         []string{},
 	"net.pubSubEnabled":                 true,
 	"net.relay":                         false,
+	"net.allowedpeers":                  []string{},
+	"net.deniedpeers":                   []string{},
+	"net.pushacpenabled":                false,
 	"keyring.backend":                   "file",
 	"keyring.disabled":                  false,
 	"keyring.namespace":                 "defradb",
@@ -13,6 +13,7 @@ package cli
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +45,16 @@ func TestLoadConfigNotExist(t *testing.T) {
 	assert.Equal(t, filepath.Join(rootdir, "data"), cfg.GetString("datastore.badger.path"))
 	assert.Equal(t, 1<<30, cfg.GetInt("datastore.badger.valuelogfilesize"))
 	assert.Equal(t, "badger", cfg.GetString("datastore.store"))
+	assert.Equal(t, false, cfg.GetBool("datastore.signblocks"))
+	assert.Equal(t, false, cfg.GetBool("datastore.timestampblocks"))
+	assert.Equal(t, false, cfg.GetBool("datastore.requiresignedblocks"))
+	assert.Equal(t, int64(256<<20), cfg.GetInt64("datastore.querymemorybudget"))
+	assert.Equal(t, time.Duration(0), cfg.GetDuration("datastore.maxrequestduration"))
+	assert.Equal(t, uint64(0), cfg.GetUint64("datastore.maxrequestscanneddocs"))
+	assert.Equal(t, 1000, cfg.GetInt("datastore.requestcachesize"))
+	assert.Equal(t, 1000, cfg.GetInt("datastore.maxpreparedrequests"))
+	assert.Equal(t, time.Duration(0), cfg.GetDuration("datastore.slowrequestthreshold"))
+	assert.Equal(t, "", cfg.GetString("metrics.otlpendpoint"))
 
 	assert.Equal(t, "127.0.0.1:9181", cfg.GetString("api.address"))
 	assert.Equal(t, []string{}, cfg.GetStringSlice("api.allowed-origins"))
@@ -55,6 +66,9 @@ func TestLoadConfigNotExist(t *testing.T) {
 	assert.Equal(t, true, cfg.GetBool("net.pubsubenabled"))
 	assert.Equal(t, false, cfg.GetBool("net.relay"))
 	assert.Equal(t, []string{}, cfg.GetStringSlice("net.peers"))
+	assert.Equal(t, []string{}, cfg.GetStringSlice("net.allowedpeers"))
+	assert.Equal(t, []string{}, cfg.GetStringSlice("net.deniedpeers"))
+	assert.Equal(t, false, cfg.GetBool("net.pushacpenabled"))
 
 	assert.Equal(t, "info", cfg.GetString("log.level"))
 	assert.Equal(t, "stderr", cfg.GetString("log.output"))
@@ -11,6 +11,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,13 +24,15 @@ func MakeIndexCreateCommand() *cobra.Command {
 	var nameArg string
 	var fieldsArg []string
 	var uniqueArg bool
+	var filterArg string
 	var cmd = &cobra.Command{
-		Use:   "create -c --collection <collection> --fields <fields[:ASC|:DESC]> [-n --name <name>] [--unique]",
+		Use:   "create -c --collection <collection> --fields <fields[:ASC|:DESC]> [-n --name <name>] [--unique] [--filter <filter>]",
 		Short: "Creates a secondary index on a collection's field(s)",
 		Long: `Creates a secondary index on a collection's field(s).
 		
 The --name flag is optional. If not provided, a name will be generated automatically.
 The --unique flag is optional. If provided, the index will be unique.
+The --filter flag is optional. If provided, only documents matching the filter will be indexed.
 If no order is specified for the field, the default value will be "ASC"
 
 Example: create an index for 'Users' collection on 'name' field:
@@ -40,6 +43,9 @@ Example: create a named index for 'Users' collection on 'name' field:
  
 Example: create a unique index for 'Users' collection on 'name' in ascending order, and 'age' in descending order:
   defradb client index create --collection Users --fields name:ASC,age:DESC --unique
+
+Example: create an index for 'Users' collection on 'name' field that only covers active users:
+  defradb client index create --collection Users --fields name --filter '{"status": {"_eq": "active"}}'
 `,
 		ValidArgs: []string{"collection", "fields", "name"},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -69,10 +75,18 @@ Example: create a unique index for 'Users' collection on 'name' in ascending ord
 				})
 			}
 
+			var filter map[string]any
+			if filterArg != "" {
+				if err := json.Unmarshal([]byte(filterArg), &filter); err != nil {
+					return err
+				}
+			}
+
 			desc := client.IndexDescription{
 				Name:   nameArg,
 				Fields: fields,
 				Unique: uniqueArg,
+				Filter: filter,
 			}
 			col, err := store.GetCollectionByName(cmd.Context(), collectionArg)
 			if err != nil {
@@ -90,6 +104,7 @@ Example: create a unique index for 'Users' collection on 'name' in ascending ord
 	cmd.Flags().StringVarP(&nameArg, "name", "n", "", "Index name")
 	cmd.Flags().StringSliceVar(&fieldsArg, "fields", []string{}, "Fields to index")
 	cmd.Flags().BoolVarP(&uniqueArg, "unique", "u", false, "Make the index unique")
+	cmd.Flags().StringVar(&filterArg, "filter", "", "Only index documents matching this filter")
 
 	return cmd
 }
@@ -0,0 +1,26 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeP2PAccessCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "access",
+		Short: "Configure the peer access system",
+		Long: `Configure the peer access system. Set, delete, or get the list of persisted peer access.
+Blocks pushed by denied peers are rejected. If any peers are allowed, blocks pushed by all
+other peers are rejected.`,
+	}
+	return cmd
+}
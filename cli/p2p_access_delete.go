@@ -0,0 +1,34 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeP2PAccessDeleteCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "delete <peerIDs>",
+		Short: "Delete the access of peers",
+		Long: `Delete the access of peers from the persisted list.
+The access given to peers in the node configuration is not affected.
+
+Example: delete the access of multiple peers
+  defradb client p2p access delete 12D3KooWA,12D3KooWB
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p2p := mustGetContextP2P(cmd)
+			return p2p.DeletePeerAccess(cmd.Context(), splitPeerIDs(args[0]))
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeP2PAccessGetAllCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "getall",
+		Short: "Get the access of all peers",
+		Long: `Get the persisted access of all peers, along with the number of blocks they
+pushed that were rejected since the node was started.
+
+Example:
+  defradb client p2p access getall
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p2p := mustGetContextP2P(cmd)
+
+			access, err := p2p.GetAllPeerAccess(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, access)
+		},
+	}
+	return cmd
+}
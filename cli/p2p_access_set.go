@@ -0,0 +1,62 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func MakeP2PAccessSetCommand() *cobra.Command {
+	var access string
+	var cmd = &cobra.Command{
+		Use:   "set [-a, --access] <peerIDs>",
+		Short: "Allow or deny peers pushing blocks",
+		Long: `Allow or deny peers pushing blocks to this node.
+The given access replaces any access the peers previously had.
+
+Example: allow a single peer
+  defradb client p2p access set 12D3KooW
+
+Example: deny multiple peers
+  defradb client p2p access set --access deny 12D3KooWA,12D3KooWB
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p2p := mustGetContextP2P(cmd)
+
+			params := client.PeerAccessParams{
+				PeerIDs: splitPeerIDs(args[0]),
+				Access:  client.PeerAccessKind(access),
+			}
+			return p2p.SetPeerAccess(cmd.Context(), params)
+		},
+	}
+	cmd.Flags().StringVarP(&access, "access", "a", string(client.PeerAccessAllow),
+		"Access given to the peers (allow or deny)")
+	return cmd
+}
+
+// splitPeerIDs returns the peer IDs contained in the given comma separated list.
+func splitPeerIDs(arg string) []string {
+	var peerIDs []string
+	for _, id := range strings.Split(arg, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		peerIDs = append(peerIDs, id)
+	}
+	return peerIDs
+}
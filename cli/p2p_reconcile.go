@@ -0,0 +1,66 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"encoding/json"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func MakeP2PReconcileCommand() *cobra.Command {
+	var collectionIDs []string
+	var cmd = &cobra.Command{
+		Use:   "reconcile [-c, --collection] <peer>",
+		Short: "Reconcile P2P collections with a peer",
+		Long: `Reconcile P2P collections with a peer.
+The documents that differ between the nodes are found in a number of rounds that is
+logarithmic to the number of documents, and the ones that are missing from this node
+are pulled from the peer. All the P2P collections are reconciled if none are given.
+
+Reconciliation is pull-only: the documents that are missing from the peer are not pushed
+to it, the peer must reconcile with this node to pull them.
+
+Outputs the number of documents that were transferred from the peer.
+
+Example: reconcile all P2P collections
+  defradb client p2p reconcile '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
+
+Example: reconcile specific P2P collections
+  defradb client p2p reconcile -c bae123,bae456 '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p2p := mustGetContextP2P(cmd)
+
+			var info peer.AddrInfo
+			if err := json.Unmarshal([]byte(args[0]), &info); err != nil {
+				return err
+			}
+			params := client.ReconcileParams{
+				Info:          info,
+				CollectionIDs: collectionIDs,
+			}
+			res, err := p2p.ReconcileWithPeer(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, res)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&collectionIDs, "collection", "c",
+		[]string{}, "Collection ID(s) to reconcile")
+	return cmd
+}
@@ -21,6 +21,10 @@ func MakeP2PReplicatorGetAllCommand() *cobra.Command {
 		Long: `Get all the replicators active in the P2P data sync system.
 A replicator synchronizes one or all collection(s) from this node to another.
 
+Each replicator reports the number of documents waiting to be retried after failing
+to replicate, the current retry attempt and the time of the next retry, as well as
+the time of the last successful push and the last error since the node started.
+
 Example:
   defradb client p2p replicator getall
   		`,
@@ -21,14 +21,22 @@ import (
 
 func MakeP2PReplicatorSetCommand() *cobra.Command {
 	var collections []string
+	var filters string
 	var cmd = &cobra.Command{
-		Use:   "set [-c, --collection] <peer>",
+		Use:   "set [-c, --collection] [-f, --filter] <peer>",
 		Short: "Add replicator(s) and start synchronization",
 		Long: `Add replicator(s) and start synchronization.
 A replicator synchronizes one or all collection(s) from this node to another.
 
+Only the documents matching the filter of their collection are synchronized,
+if the collection has a filter.
+
 Example:
   defradb client p2p replicator set -c Users '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
+
+Example: replicate only the users of a region
+  defradb client p2p replicator set -c Users -f '{"Users": {"region": {"_eq": "eu"}}}' \
+    '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -42,11 +50,18 @@ Example:
 				Info:        info,
 				Collections: collections,
 			}
+			if filters != "" {
+				if err := json.Unmarshal([]byte(filters), &rep.Filters); err != nil {
+					return err
+				}
+			}
 			return p2p.SetReplicator(cmd.Context(), rep)
 		},
 	}
 
 	cmd.Flags().StringSliceVarP(&collections, "collection", "c",
 		[]string{}, "Collection(s) to replicate")
+	cmd.Flags().StringVarP(&filters, "filter", "f", "",
+		"Filters of the documents to replicate, keyed by collection name")
 	return cmd
 }
@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -30,6 +31,10 @@ func MakeRequestCommand() *cobra.Command {
 	var filePath string
 	var operationName string
 	var variablesJSON string
+	var maxDuration time.Duration
+	var maxScannedDocs uint64
+	var prepare bool
+	var preparedID string
 	var cmd = &cobra.Command{
 		Use:   "query [-i --identity] [request]",
 		Short: "Send a DefraDB GraphQL query request",
@@ -47,6 +52,16 @@ Do a query request from a file and with an identity. Example command:
 Or it can be sent via stdin by using the '-' special syntax. Example command:
   cat request.graphql | defradb client query -
 
+Prepare a query request, so that it can be executed many times without being parsed again, by using
+the '--prepare' flag. The ID of the prepared request is returned. Example command:
+  defradb client query --prepare 'query ($name: String) { User(filter: {name: {_eq: $name}}) { age } }'
+
+Execute a prepared query request by using the '--prepared' flag. Example command:
+  defradb client query --prepared <id> -v '{"name": "Bob"}'
+
+Limit the duration of a query request and the number of documents it scans. Example command:
+  defradb client query --max-duration 30s --max-scanned-docs 10000 'query { ... }'
+
 A GraphQL client such as GraphiQL (https://github.com/graphql/graphiql) can be used to interact
 with the database more conveniently.
 
@@ -70,10 +85,19 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 				request = string(args[0])
 			}
 
-			if request == "" {
+			if request == "" && preparedID == "" {
 				return errors.New("request cannot be empty")
 			}
 
+			store := mustGetContextStore(cmd)
+			if prepare {
+				id, err := store.PrepareRequest(cmd.Context(), request)
+				if err != nil {
+					return err
+				}
+				return writeJSON(cmd, map[string]string{"id": id})
+			}
+
 			var options []client.RequestOption
 			if variablesJSON != "" {
 				var variables map[string]any
@@ -86,9 +110,19 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 			if operationName != "" {
 				options = append(options, client.WithOperationName(operationName))
 			}
+			if maxDuration > 0 {
+				options = append(options, client.WithMaxDuration(maxDuration))
+			}
+			if maxScannedDocs > 0 {
+				options = append(options, client.WithMaxScannedDocs(maxScannedDocs))
+			}
 
-			store := mustGetContextStore(cmd)
-			result := store.ExecRequest(cmd.Context(), request, options...)
+			var result *client.RequestResult
+			if preparedID != "" {
+				result = store.ExecPreparedRequest(cmd.Context(), preparedID, options...)
+			} else {
+				result = store.ExecRequest(cmd.Context(), request, options...)
+			}
 
 			if result.Subscription == nil {
 				cmd.Print(REQ_RESULTS_HEADER)
@@ -104,5 +138,11 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 	cmd.Flags().StringVarP(&operationName, "operation", "o", "", "Name of the operation to execute in the query")
 	cmd.Flags().StringVarP(&variablesJSON, "variables", "v", "", "JSON encoded variables to use in the query")
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "File containing the query request")
+	cmd.Flags().BoolVar(&prepare, "prepare", false, "Prepare the query request and return its ID instead of executing it")
+	cmd.Flags().StringVar(&preparedID, "prepared", "", "ID of the prepared query request to execute")
+	cmd.Flags().DurationVar(&maxDuration, "max-duration", 0,
+		"Maximum duration of the query request. Defaults to the limit of the node")
+	cmd.Flags().Uint64Var(&maxScannedDocs, "max-scanned-docs", 0,
+		"Maximum number of documents the query request may scan. Defaults to the limit of the node")
 	return cmd
 }
@@ -73,16 +73,29 @@ func MakeStartCommand() *cobra.Command {
 				node.WithSourceHubCometRPCAddress(cfg.GetString("acp.sourceHub.CometRPCAddress")),
 				node.WithLensRuntime(node.LensRuntimeType(cfg.GetString("lens.runtime"))),
 				node.WithEnableDevelopment(cfg.GetBool("development")),
+				node.WithMetricsEndpoint(cfg.GetString("metrics.otlpendpoint")),
 				// store options
 				node.WithStorePath(cfg.GetString("datastore.badger.path")),
 				node.WithBadgerInMemory(cfg.GetString("datastore.store") == configStoreMemory),
 				// db options
 				db.WithMaxRetries(cfg.GetInt("datastore.MaxTxnRetries")),
+				db.WithBlockSigning(cfg.GetBool("datastore.signblocks")),
+				db.WithBlockTimestamps(cfg.GetBool("datastore.timestampblocks")),
+				db.WithRequiredBlockSignatures(cfg.GetBool("datastore.requiresignedblocks")),
+				db.WithQueryMemoryBudget(cfg.GetInt64("datastore.querymemorybudget")),
+				db.WithMaxRequestDuration(cfg.GetDuration("datastore.maxrequestduration")),
+				db.WithMaxRequestScannedDocs(cfg.GetUint64("datastore.maxrequestscanneddocs")),
+				db.WithRequestCacheSize(cfg.GetInt("datastore.requestcachesize")),
+				db.WithMaxPreparedRequests(cfg.GetInt("datastore.maxpreparedrequests")),
+				db.WithSlowRequestThreshold(cfg.GetDuration("datastore.slowrequestthreshold")),
 				// net node options
 				net.WithListenAddresses(cfg.GetStringSlice("net.p2pAddresses")...),
 				net.WithEnablePubSub(cfg.GetBool("net.pubSubEnabled")),
 				net.WithEnableRelay(cfg.GetBool("net.relayEnabled")),
 				net.WithBootstrapPeers(cfg.GetStringSlice("net.peers")...),
+				net.WithAllowedPeers(cfg.GetStringSlice("net.allowedPeers")...),
+				net.WithDeniedPeers(cfg.GetStringSlice("net.deniedPeers")...),
+				net.WithEnablePushACP(cfg.GetBool("net.pushACPEnabled")),
 				// http server options
 				http.WithAddress(cfg.GetString("api.address")),
 				http.WithAllowedOrigins(cfg.GetStringSlice("api.allowed-origins")...),
@@ -205,6 +218,56 @@ func MakeStartCommand() *cobra.Command {
 		cfg.GetInt(configFlags["max-txn-retries"]),
 		"Specify the maximum number of retries per transaction",
 	)
+	cmd.PersistentFlags().Bool(
+		"sign-blocks",
+		cfg.GetBool(configFlags["sign-blocks"]),
+		"Sign new blocks using the request identity, or the node identity if the request has none",
+	)
+	cmd.PersistentFlags().Bool(
+		"timestamp-blocks",
+		cfg.GetBool(configFlags["timestamp-blocks"]),
+		"Store the creation time in new blocks, allowing collections to be queried as of a point in time",
+	)
+	cmd.PersistentFlags().Bool(
+		"require-signed-blocks",
+		cfg.GetBool(configFlags["require-signed-blocks"]),
+		"Reject unsigned blocks received from other nodes",
+	)
+	cmd.PersistentFlags().Int64(
+		"query-memory-budget",
+		cfg.GetInt64(configFlags["query-memory-budget"]),
+		"Maximum number of bytes a request may hold in memory before spilling to disk (0 to disable spilling)",
+	)
+	cmd.PersistentFlags().Duration(
+		"max-request-duration",
+		cfg.GetDuration(configFlags["max-request-duration"]),
+		"Maximum duration of a request, past which it is canceled (0 to disable the limit)",
+	)
+	cmd.PersistentFlags().Uint64(
+		"max-request-scanned-docs",
+		cfg.GetUint64(configFlags["max-request-scanned-docs"]),
+		"Maximum number of documents a request may scan (0 to disable the limit)",
+	)
+	cmd.PersistentFlags().Int(
+		"request-cache-size",
+		cfg.GetInt(configFlags["request-cache-size"]),
+		"Maximum number of validated requests kept in the request cache (0 to disable the cache)",
+	)
+	cmd.PersistentFlags().Int(
+		"max-prepared-requests",
+		cfg.GetInt(configFlags["max-prepared-requests"]),
+		"Maximum number of prepared requests kept, the least recently used are evicted once reached",
+	)
+	cmd.PersistentFlags().Duration(
+		"slow-request-threshold",
+		cfg.GetDuration(configFlags["slow-request-threshold"]),
+		"Duration past which requests are logged as slow (0 to disable the slow request log)",
+	)
+	cmd.PersistentFlags().String(
+		"metrics-otlp-endpoint",
+		cfg.GetString(configFlags["metrics-otlp-endpoint"]),
+		"URL of the OTLP over HTTP endpoint the request and replicator metrics are exported to (empty to disable)",
+	)
 	cmd.PersistentFlags().String(
 		"store",
 		cfg.GetString(configFlags["store"]),
@@ -225,6 +288,21 @@ func MakeStartCommand() *cobra.Command {
 		cfg.GetBool(configFlags["no-p2p"]),
 		"Disable the peer-to-peer network synchronization system",
 	)
+	cmd.PersistentFlags().StringSlice(
+		"allowed-peers",
+		cfg.GetStringSlice(configFlags["allowed-peers"]),
+		"IDs of the peers allowed to push blocks, rejecting all other peers if any are given",
+	)
+	cmd.PersistentFlags().StringSlice(
+		"denied-peers",
+		cfg.GetStringSlice(configFlags["denied-peers"]),
+		"IDs of the peers whose pushed blocks are rejected",
+	)
+	cmd.PersistentFlags().Bool(
+		"push-acp",
+		cfg.GetBool(configFlags["push-acp"]),
+		"Reject the blocks pushed by peers whose signing identity can't write their documents",
+	)
 	cmd.PersistentFlags().StringArray(
 		"allowed-origins",
 		cfg.GetStringSlice(configFlags["allowed-origins"]),
@@ -16,20 +16,44 @@ import (
 
 // Backup contains DefraDB's supported backup operations.
 type Backup interface {
-	// BasicImport imports a json dataset.
+	// BasicImport imports a dataset exported by BasicExport.
 	// filepath must be accessible to the node.
+	//
+	// The format of the dataset is detected from its content. Documents are imported in batches,
+	// each committed in its own transaction unless an explicit transaction is used. If an import
+	// is interrupted, importing the same file again resumes after the last committed batch.
 	BasicImport(ctx context.Context, filepath string) error
-	// BasicExport exports the current data or subset of data to file in json format.
+	// BasicExport exports the current data or subset of data to file in the configured format.
 	BasicExport(ctx context.Context, config *BackupConfig) error
 }
 
+const (
+	// BackupFormatJSON is a backup format consisting of a single JSON object
+	// that holds an array of documents per collection.
+	//
+	// It is the default backup format.
+	BackupFormatJSON = "json"
+	// BackupFormatNDJSON is a backup format consisting of newline delimited JSON
+	// objects, one per document.
+	BackupFormatNDJSON = "ndjson"
+	// BackupFormatCBOR is a backup format consisting of a sequence of CBOR maps,
+	// one per document.
+	BackupFormatCBOR = "cbor"
+	// BackupFormatCAR is a backup format consisting of a CAR file holding the blocks and
+	// heads of the document DAGs along with the schema and collection definitions.
+	//
+	// Unlike the other formats it preserves the document IDs, history and CRDT state, so
+	// that a restored node stays in sync with its peers.
+	BackupFormatCAR = "car"
+)
+
 // BackupConfig holds the configuration parameters for database backups.
 type BackupConfig struct {
 	// If a file already exists at this location, it will be truncated and overwriten.
 	Filepath string `json:"filepath"`
-	// Only JSON is supported at the moment.
+	// Format of the backup. One of json (default), ndjson, cbor or car.
 	Format string `json:"format"`
-	// Pretty print JSON.
+	// Pretty print JSON. Only applies to the json format.
 	Pretty bool `json:"pretty"`
 	// List of collection names to select which one to backup.
 	Collections []string `json:"collections"`
@@ -24,12 +24,15 @@ const (
 	COMPOSITE
 	PN_COUNTER
 	P_COUNTER
+	G_SET
+	OR_SET
+	TEXT
 )
 
 // IsSupportedFieldCType returns true if the type is supported as a document field type.
 func (t CType) IsSupportedFieldCType() bool {
 	switch t {
-	case NONE_CRDT, LWW_REGISTER, PN_COUNTER, P_COUNTER:
+	case NONE_CRDT, LWW_REGISTER, PN_COUNTER, P_COUNTER, G_SET, OR_SET, TEXT:
 		return true
 	default:
 		return false
@@ -44,6 +47,11 @@ func (t CType) IsCompatibleWith(kind FieldKind) bool {
 			return true
 		}
 		return false
+	case G_SET, OR_SET:
+		_, ok := kind.(ScalarArrayKind)
+		return ok
+	case TEXT:
+		return kind == FieldKind_NILLABLE_STRING
 	default:
 		return true
 	}
@@ -64,6 +72,12 @@ func (t CType) String() string {
 		return "pncounter"
 	case P_COUNTER:
 		return "pcounter"
+	case G_SET:
+		return "gset"
+	case OR_SET:
+		return "orset"
+	case TEXT:
+		return "text"
 	default:
 		return "unknown"
 	}
@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/lens-vm/lens/host-go/config/model"
@@ -298,8 +299,31 @@ type Store interface {
 	// GetAllIndexes returns all the indexes that currently exist within this [Store].
 	GetAllIndexes(context.Context) (map[CollectionName][]IndexDescription, error)
 
+	// GetCollectionStatistics returns the estimated statistics of the documents and indexes
+	// of the active collection with the given name.
+	//
+	// The statistics that are not known, or are no longer a good estimate, are rebuilt from
+	// the stored documents and index values before being returned.
+	GetCollectionStatistics(context.Context, string) (CollectionStatistics, error)
+
 	// ExecRequest executes the given GQL request against the [Store].
 	ExecRequest(ctx context.Context, request string, opts ...RequestOption) *RequestResult
+
+	// PrepareRequest parses and validates the given GQL request, returning the ID of the prepared request.
+	//
+	// The prepared request can then be executed any number of times with [Store.ExecPreparedRequest],
+	// with different variables, without being parsed and validated again. Preparing the same request
+	// more than once returns the same ID.
+	//
+	// Only the parsed and validated request is kept, the request is still mapped and planned every
+	// time it is executed. The least recently used prepared requests may be evicted, after which
+	// they must be prepared again.
+	PrepareRequest(ctx context.Context, request string) (string, error)
+
+	// ExecPreparedRequest executes the prepared GQL request with the given ID against the [Store].
+	//
+	// If the schema changed since the request was prepared, it is validated again before being executed.
+	ExecPreparedRequest(ctx context.Context, id string, opts ...RequestOption) *RequestResult
 }
 
 // GQLOptions contains optional arguments for GQL requests.
@@ -308,6 +332,14 @@ type GQLOptions struct {
 	OperationName string
 	// Variables is a map of names to varible values.
 	Variables map[string]any
+	// MaxDuration is the maximum duration the request may run for.
+	//
+	// If zero the limit of the node is used. It can not exceed the limit of the node.
+	MaxDuration time.Duration
+	// MaxScannedDocs is the maximum number of documents the request may scan.
+	//
+	// If zero the limit of the node is used. It can not exceed the limit of the node.
+	MaxScannedDocs uint64
 }
 
 // RequestOption sets an optional request setting.
@@ -327,6 +359,22 @@ func WithVariables(variables map[string]any) RequestOption {
 	}
 }
 
+// WithMaxDuration sets the maximum duration of a GQL request, past which it is
+// canceled with an [ErrRequestTimeout] error.
+func WithMaxDuration(duration time.Duration) RequestOption {
+	return func(o *GQLOptions) {
+		o.MaxDuration = duration
+	}
+}
+
+// WithMaxScannedDocs sets the maximum number of documents a GQL request may scan,
+// past which it fails with an [ErrMaxScannedDocsExceeded] error.
+func WithMaxScannedDocs(count uint64) RequestOption {
+	return func(o *GQLOptions) {
+		o.MaxScannedDocs = count
+	}
+}
+
 // GQLResult represents the immediate results of a GQL request.
 //
 // It does not handle subscription channels. This object and its children are json serializable.
@@ -674,6 +674,69 @@ func (doc *Document) Set(field string, value any) error {
 	return doc.setCBOR(fd.Typ, field, val)
 }
 
+// TextPatch is a positional edit of the value of a text field.
+type TextPatch struct {
+	// Index is the position, in characters, at which the patch is applied.
+	Index int
+	// Delete is the number of characters deleted from the index.
+	Delete int
+	// Insert is the text inserted at the index.
+	Insert string
+}
+
+// PatchText applies the given patches to the value of a text field.
+//
+// The patches are applied in order, so the index of each patch is relative to the text
+// produced by the previous patches. Unless the field was set since the document was last
+// saved, the patches are saved as edits at their positions rather than as a new value.
+func (doc *Document) PatchText(field string, patches []TextPatch) error {
+	fd, exists := doc.collectionDefinition.GetFieldByName(field)
+	if !exists {
+		return NewErrFieldNotExist(field)
+	}
+	if fd.Typ != TEXT {
+		return NewErrTextPatchOnNonTextField(field)
+	}
+
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	var text []rune
+	var applied []TextPatch
+	f, exists := doc.fields[field]
+	if exists {
+		current := doc.values[f]
+		if s, ok := current.Value().(string); ok {
+			text = []rune(s)
+		}
+		if !current.IsDirty() {
+			applied = []TextPatch{}
+		} else if current.textPatches != nil {
+			applied = current.textPatches
+		}
+	} else {
+		f = doc.newField(fd.Typ, field)
+		doc.fields[field] = f
+		applied = []TextPatch{}
+	}
+
+	for _, patch := range patches {
+		start, end := patch.Index, patch.Index+patch.Delete
+		if start < 0 || end < start || end > len(text) {
+			return NewErrTextPatchOutOfRange(field, start, len(text))
+		}
+		text = append(text[:start:start], append([]rune(patch.Insert), text[end:]...)...)
+	}
+
+	value := NewFieldValue(fd.Typ, NewNormalString(string(text)))
+	if applied != nil {
+		value.textPatches = append(applied, patches...)
+	}
+	doc.values[f] = value
+	doc.isDirty = true
+	return nil
+}
+
 func (doc *Document) set(t CType, field string, value *FieldValue) error {
 	doc.mu.Lock()
 	defer doc.mu.Unlock()
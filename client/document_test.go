@@ -287,3 +287,47 @@ func TestIsJSONArray(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchText_WithSavedValue_ShouldRecordPatches(t *testing.T) {
+	textDef := CollectionDefinition{
+		Description: CollectionDescription{
+			Name:   immutable.Some("Note"),
+			Fields: []CollectionFieldDescription{{Name: "Body"}},
+		},
+		Schema: SchemaDescription{
+			Name: "Note",
+			Fields: []SchemaFieldDescription{
+				{
+					Name: "Body",
+					Typ:  TEXT,
+					Kind: FieldKind_NILLABLE_STRING,
+				},
+			},
+		},
+	}
+	doc, err := NewDocFromJSON([]byte(`{"Body": "aaa"}`), textDef)
+	require.NoError(t, err)
+	doc.Clean()
+
+	patches := []TextPatch{{Index: 0, Insert: "b"}, {Index: 2, Delete: 1}}
+	err = doc.PatchText("Body", patches)
+	require.NoError(t, err)
+
+	val, err := doc.GetValue("Body")
+	require.NoError(t, err)
+	assert.Equal(t, "baa", val.Value())
+	assert.Equal(t, patches, val.TextPatches())
+
+	err = doc.Set("Body", "c")
+	require.NoError(t, err)
+	err = doc.PatchText("Body", []TextPatch{{Index: 1, Insert: "d"}})
+	require.NoError(t, err)
+
+	val, err = doc.GetValue("Body")
+	require.NoError(t, err)
+	assert.Equal(t, "cd", val.Value())
+	assert.Nil(t, val.TextPatches())
+
+	err = doc.PatchText("Body", []TextPatch{{Index: 3}})
+	require.ErrorIs(t, err, ErrTextPatchOutOfRange)
+}
@@ -35,6 +35,12 @@ const (
 	errCanNotMakeNormalNilFromFieldKind    string = "can not make normal nil from field kind"
 	errFailedToParseKind                   string = "failed to parse kind"
 	errCannotSetRelationFromSecondarySide  string = "cannot set relation from secondary side"
+	errRequestTimeout                      string = "request exceeded its maximum duration"
+	errRequestCanceled                     string = "request was canceled"
+	errMaxScannedDocsExceeded              string = "request exceeded its maximum number of scanned documents"
+	errPreparedRequestNotFound             string = "prepared request not found"
+	errTextPatchOutOfRange                 string = "text patch is out of range"
+	errTextPatchOnNonTextField             string = "text patches can only be applied to text fields"
 )
 
 // Errors returnable from this package.
@@ -62,6 +68,12 @@ var (
 	ErrCanNotMakeNormalNilFromFieldKind     = errors.New(errCanNotMakeNormalNilFromFieldKind)
 	ErrCollectionNotFound                   = errors.New(errCollectionNotFound)
 	ErrFailedToParseKind                    = errors.New(errFailedToParseKind)
+	ErrRequestTimeout                       = errors.New(errRequestTimeout)
+	ErrRequestCanceled                      = errors.New(errRequestCanceled)
+	ErrMaxScannedDocsExceeded               = errors.New(errMaxScannedDocsExceeded)
+	ErrPreparedRequestNotFound              = errors.New(errPreparedRequestNotFound)
+	ErrTextPatchOutOfRange                  = errors.New(errTextPatchOutOfRange)
+	ErrTextPatchOnNonTextField              = errors.New(errTextPatchOnNonTextField)
 )
 
 // NewErrFieldNotExist returns an error indicating that the given field does not exist.
@@ -187,6 +199,10 @@ func ReviveError(message string) error {
 		return ErrDocumentNotFoundOrNotAuthorized
 	case datastore.ErrTxnConflict.Error():
 		return datastore.ErrTxnConflict
+	case ErrRequestTimeout.Error():
+		return ErrRequestTimeout
+	case ErrRequestCanceled.Error():
+		return ErrRequestCanceled
 	default:
 		return fmt.Errorf("%s", message)
 	}
@@ -195,3 +211,31 @@ func ReviveError(message string) error {
 func NewErrCannotSetRelationFromSecondarySide(name string) error {
 	return errors.New(errCannotSetRelationFromSecondarySide, errors.NewKV("Name", name))
 }
+
+// NewErrMaxScannedDocsExceeded returns an error indicating that a request scanned more
+// documents than the given limit.
+func NewErrMaxScannedDocsExceeded(limit uint64) error {
+	return errors.New(errMaxScannedDocsExceeded, errors.NewKV("Limit", limit))
+}
+
+// NewErrPreparedRequestNotFound returns an error indicating that no request was prepared with the given ID.
+func NewErrPreparedRequestNotFound(id string) error {
+	return errors.New(errPreparedRequestNotFound, errors.NewKV("ID", id))
+}
+
+// NewErrTextPatchOutOfRange returns an error indicating that a text patch targets characters
+// beyond the end of the text.
+func NewErrTextPatchOutOfRange(field string, index int, length int) error {
+	return errors.New(
+		errTextPatchOutOfRange,
+		errors.NewKV("Field", field),
+		errors.NewKV("Index", index),
+		errors.NewKV("Length", length),
+	)
+}
+
+// NewErrTextPatchOnNonTextField returns an error indicating that text patches were applied
+// to a field that does not use the text CRDT.
+func NewErrTextPatchOnNonTextField(field string) error {
+	return errors.New(errTextPatchOnNonTextField, errors.NewKV("Field", field))
+}
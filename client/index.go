@@ -12,16 +12,46 @@ package client
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/sourcenetwork/defradb/datastore"
 )
 
+// IndexType describes how the values of the indexed fields are stored in an index.
+type IndexType uint8
+
+const (
+	// IndexTypeValue indexes the whole value of each indexed field.
+	//
+	// This is the default index type.
+	IndexTypeValue IndexType = iota
+	// IndexTypeFullText indexes the individual words of a String field in an inverted index,
+	// it is used to serve the `_search` filter operator.
+	IndexTypeFullText
+)
+
+// String returns the GQL name of the index type.
+func (t IndexType) String() string {
+	switch t {
+	case IndexTypeFullText:
+		return "FULLTEXT"
+	default:
+		return "VALUE"
+	}
+}
+
 // IndexFieldDescription describes how a field is being indexed.
 type IndexedFieldDescription struct {
 	// Name contains the name of the field.
 	Name string
 	// Descending indicates whether the field is indexed in descending order.
 	Descending bool
+	// JSONPath contains the path of the indexed value within a JSON field, if any.
+	//
+	// The values found at the path are indexed instead of the field value. If the path leads
+	// to an array, each of its elements is indexed separately.
+	JSONPath []string
 }
 
 // IndexDescription describes an index.
@@ -34,6 +64,42 @@ type IndexDescription struct {
 	Fields []IndexedFieldDescription
 	// Unique indicates whether the index is unique.
 	Unique bool
+	// Type is the kind of the index.
+	//
+	// If not set it defaults to [IndexTypeValue].
+	Type IndexType
+	// Filter contains the conditions a document must match to be indexed, if any.
+	//
+	// An index with a filter is a partial index: it holds only the matching documents and
+	// is used only by the requests whose filter implies it. The conditions have the same
+	// shape as a request filter, but may only refer to the scalar fields of the collection.
+	Filter map[string]any
+}
+
+// FilterFieldNames returns the names of the fields referenced by the filter of the index.
+func (d IndexDescription) FilterFieldNames() []string {
+	var names []string
+	collectFilterFieldNames(d.Filter, &names)
+	return names
+}
+
+func collectFilterFieldNames(cond any, names *[]string) {
+	switch t := cond.(type) {
+	case map[string]any:
+		for key, val := range t {
+			if strings.HasPrefix(key, "_") {
+				// operators next to fields are compound, like _and, _or and _not,
+				// and hold further field conditions.
+				collectFilterFieldNames(val, names)
+			} else if !slices.Contains(*names, key) {
+				*names = append(*names, key)
+			}
+		}
+	case []any:
+		for _, val := range t {
+			collectFilterFieldNames(val, names)
+		}
+	}
 }
 
 // CollectionIndex is an interface for indexing documents in a collection.
@@ -56,28 +122,48 @@ type CollectionIndex interface {
 func (d CollectionDefinition) CollectIndexedFields() []FieldDefinition {
 	fieldsMap := make(map[string]bool)
 	fields := make([]FieldDefinition, 0, len(d.Description.Indexes))
+	addField := func(fieldName string) {
+		if fieldsMap[fieldName] {
+			// If the FieldDescription has already been added to the result do not add it a second time
+			// this can happen if a field is referenced by multiple indexes
+			return
+		}
+		colField, ok := d.GetFieldByName(fieldName)
+		if ok {
+			fieldsMap[fieldName] = true
+			fields = append(fields, colField)
+		}
+	}
 	for _, index := range d.Description.Indexes {
 		for _, field := range index.Fields {
-			if fieldsMap[field.Name] {
-				// If the FieldDescription has already been added to the result do not add it a second time
-				// this can happen if a field is referenced by multiple indexes
-				continue
-			}
-			colField, ok := d.GetFieldByName(field.Name)
-			if ok {
-				fields = append(fields, colField)
-			}
+			addField(field.Name)
+		}
+		// the fields of a partial index filter are needed to tell if a document is indexed
+		for _, fieldName := range index.FilterFieldNames() {
+			addField(fieldName)
 		}
 	}
 	return fields
 }
 
-// GetIndexesOnField returns all indexes that are indexing the given field.
+// GetIndexesOnField returns all value indexes that are indexing the given field.
 // If the field is not the first field of a composite index, the index is not returned.
+// Full-text indexes are not returned, use [CollectionDescription.GetFullTextIndexesOnField] for those.
 func (d CollectionDescription) GetIndexesOnField(fieldName string) []IndexDescription {
 	result := []IndexDescription{}
 	for _, index := range d.Indexes {
-		if index.Fields[0].Name == fieldName {
+		if index.Type == IndexTypeValue && index.Fields[0].Name == fieldName {
+			result = append(result, index)
+		}
+	}
+	return result
+}
+
+// GetFullTextIndexesOnField returns all full-text indexes that are indexing the given field.
+func (d CollectionDescription) GetFullTextIndexesOnField(fieldName string) []IndexDescription {
+	result := []IndexDescription{}
+	for _, index := range d.Indexes {
+		if index.Type == IndexTypeFullText && index.Fields[0].Name == fieldName {
 			result = append(result, index)
 		}
 	}
@@ -118,6 +118,22 @@ func TestCollectIndexesOnField(t *testing.T) {
 			field:    "test",
 			expected: []IndexDescription{},
 		},
+		{
+			name: "full-text index on field",
+			desc: CollectionDescription{
+				Indexes: []IndexDescription{
+					{
+						Name: "index1",
+						Fields: []IndexedFieldDescription{
+							{Name: "test"},
+						},
+						Type: IndexTypeFullText,
+					},
+				},
+			},
+			field:    "test",
+			expected: []IndexDescription{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,3 +143,27 @@ func TestCollectIndexesOnField(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFullTextIndexesOnField(t *testing.T) {
+	desc := CollectionDescription{
+		Indexes: []IndexDescription{
+			{
+				Name:   "index1",
+				Fields: []IndexedFieldDescription{{Name: "test"}},
+			},
+			{
+				Name:   "index2",
+				Fields: []IndexedFieldDescription{{Name: "test"}},
+				Type:   IndexTypeFullText,
+			},
+			{
+				Name:   "index3",
+				Fields: []IndexedFieldDescription{{Name: "other"}},
+				Type:   IndexTypeFullText,
+			},
+		},
+	}
+
+	actual := desc.GetFullTextIndexesOnField("test")
+	assert.Equal(t, []IndexDescription{desc.Indexes[1]}, actual)
+}
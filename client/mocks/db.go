@@ -554,6 +554,53 @@ func (_c *DB_DeleteDocActorRelationship_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// DeletePeerAccess provides a mock function with given fields: ctx, peerIDs
+func (_m *DB) DeletePeerAccess(ctx context.Context, peerIDs []string) error {
+	ret := _m.Called(ctx, peerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePeerAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, peerIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_DeletePeerAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePeerAccess'
+type DB_DeletePeerAccess_Call struct {
+	*mock.Call
+}
+
+// DeletePeerAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - peerIDs []string
+func (_e *DB_Expecter) DeletePeerAccess(ctx interface{}, peerIDs interface{}) *DB_DeletePeerAccess_Call {
+	return &DB_DeletePeerAccess_Call{Call: _e.mock.On("DeletePeerAccess", ctx, peerIDs)}
+}
+
+func (_c *DB_DeletePeerAccess_Call) Run(run func(ctx context.Context, peerIDs []string)) *DB_DeletePeerAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *DB_DeletePeerAccess_Call) Return(_a0 error) *DB_DeletePeerAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_DeletePeerAccess_Call) RunAndReturn(run func(context.Context, []string) error) *DB_DeletePeerAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteReplicator provides a mock function with given fields: ctx, rep
 func (_m *DB) DeleteReplicator(ctx context.Context, rep client.ReplicatorParams) error {
 	ret := _m.Called(ctx, rep)
@@ -695,6 +742,70 @@ func (_c *DB_Events_Call) RunAndReturn(run func() *event.Bus) *DB_Events_Call {
 	return _c
 }
 
+// ExecPreparedRequest provides a mock function with given fields: ctx, id, opts
+func (_m *DB) ExecPreparedRequest(ctx context.Context, id string, opts ...client.RequestOption) *client.RequestResult {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecPreparedRequest")
+	}
+
+	var r0 *client.RequestResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...client.RequestOption) *client.RequestResult); ok {
+		r0 = rf(ctx, id, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.RequestResult)
+		}
+	}
+
+	return r0
+}
+
+// DB_ExecPreparedRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecPreparedRequest'
+type DB_ExecPreparedRequest_Call struct {
+	*mock.Call
+}
+
+// ExecPreparedRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - opts ...client.RequestOption
+func (_e *DB_Expecter) ExecPreparedRequest(ctx interface{}, id interface{}, opts ...interface{}) *DB_ExecPreparedRequest_Call {
+	return &DB_ExecPreparedRequest_Call{Call: _e.mock.On("ExecPreparedRequest",
+		append([]interface{}{ctx, id}, opts...)...)}
+}
+
+func (_c *DB_ExecPreparedRequest_Call) Run(run func(ctx context.Context, id string, opts ...client.RequestOption)) *DB_ExecPreparedRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.RequestOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.RequestOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *DB_ExecPreparedRequest_Call) Return(_a0 *client.RequestResult) *DB_ExecPreparedRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_ExecPreparedRequest_Call) RunAndReturn(run func(context.Context, string, ...client.RequestOption) *client.RequestResult) *DB_ExecPreparedRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExecRequest provides a mock function with given fields: ctx, request, opts
 func (_m *DB) ExecRequest(ctx context.Context, request string, opts ...client.RequestOption) *client.RequestResult {
 	_va := make([]interface{}, len(opts))
@@ -875,6 +986,64 @@ func (_c *DB_GetAllP2PCollections_Call) RunAndReturn(run func(context.Context) (
 	return _c
 }
 
+// GetAllPeerAccess provides a mock function with given fields: ctx
+func (_m *DB) GetAllPeerAccess(ctx context.Context) ([]client.PeerAccess, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPeerAccess")
+	}
+
+	var r0 []client.PeerAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]client.PeerAccess, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []client.PeerAccess); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.PeerAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetAllPeerAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPeerAccess'
+type DB_GetAllPeerAccess_Call struct {
+	*mock.Call
+}
+
+// GetAllPeerAccess is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) GetAllPeerAccess(ctx interface{}) *DB_GetAllPeerAccess_Call {
+	return &DB_GetAllPeerAccess_Call{Call: _e.mock.On("GetAllPeerAccess", ctx)}
+}
+
+func (_c *DB_GetAllPeerAccess_Call) Run(run func(ctx context.Context)) *DB_GetAllPeerAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_GetAllPeerAccess_Call) Return(_a0 []client.PeerAccess, _a1 error) *DB_GetAllPeerAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetAllPeerAccess_Call) RunAndReturn(run func(context.Context) ([]client.PeerAccess, error)) *DB_GetAllPeerAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllReplicators provides a mock function with given fields: ctx
 func (_m *DB) GetAllReplicators(ctx context.Context) ([]client.Replicator, error) {
 	ret := _m.Called(ctx)
@@ -992,6 +1161,63 @@ func (_c *DB_GetCollectionByName_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// GetCollectionStatistics provides a mock function with given fields: _a0, _a1
+func (_m *DB) GetCollectionStatistics(_a0 context.Context, _a1 string) (client.CollectionStatistics, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollectionStatistics")
+	}
+
+	var r0 client.CollectionStatistics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.CollectionStatistics, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.CollectionStatistics); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(client.CollectionStatistics)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetCollectionStatistics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCollectionStatistics'
+type DB_GetCollectionStatistics_Call struct {
+	*mock.Call
+}
+
+// GetCollectionStatistics is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *DB_Expecter) GetCollectionStatistics(_a0 interface{}, _a1 interface{}) *DB_GetCollectionStatistics_Call {
+	return &DB_GetCollectionStatistics_Call{Call: _e.mock.On("GetCollectionStatistics", _a0, _a1)}
+}
+
+func (_c *DB_GetCollectionStatistics_Call) Run(run func(_a0 context.Context, _a1 string)) *DB_GetCollectionStatistics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DB_GetCollectionStatistics_Call) Return(_a0 client.CollectionStatistics, _a1 error) *DB_GetCollectionStatistics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetCollectionStatistics_Call) RunAndReturn(run func(context.Context, string) (client.CollectionStatistics, error)) *DB_GetCollectionStatistics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollections provides a mock function with given fields: _a0, _a1
 func (_m *DB) GetCollections(_a0 context.Context, _a1 client.CollectionFetchOptions) ([]client.Collection, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1668,6 +1894,63 @@ func (_c *DB_Peerstore_Call) RunAndReturn(run func() datastore.DSReaderWriter) *
 	return _c
 }
 
+// PrepareRequest provides a mock function with given fields: ctx, request
+func (_m *DB) PrepareRequest(ctx context.Context, request string) (string, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareRequest")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_PrepareRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareRequest'
+type DB_PrepareRequest_Call struct {
+	*mock.Call
+}
+
+// PrepareRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request string
+func (_e *DB_Expecter) PrepareRequest(ctx interface{}, request interface{}) *DB_PrepareRequest_Call {
+	return &DB_PrepareRequest_Call{Call: _e.mock.On("PrepareRequest", ctx, request)}
+}
+
+func (_c *DB_PrepareRequest_Call) Run(run func(ctx context.Context, request string)) *DB_PrepareRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DB_PrepareRequest_Call) Return(_a0 string, _a1 error) *DB_PrepareRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_PrepareRequest_Call) RunAndReturn(run func(context.Context, string) (string, error)) *DB_PrepareRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PrintDump provides a mock function with given fields: ctx
 func (_m *DB) PrintDump(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -1714,6 +1997,63 @@ func (_c *DB_PrintDump_Call) RunAndReturn(run func(context.Context) error) *DB_P
 	return _c
 }
 
+// ReconcileWithPeer provides a mock function with given fields: ctx, params
+func (_m *DB) ReconcileWithPeer(ctx context.Context, params client.ReconcileParams) (client.ReconcileResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileWithPeer")
+	}
+
+	var r0 client.ReconcileResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ReconcileParams) (client.ReconcileResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ReconcileParams) client.ReconcileResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(client.ReconcileResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ReconcileParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ReconcileWithPeer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileWithPeer'
+type DB_ReconcileWithPeer_Call struct {
+	*mock.Call
+}
+
+// ReconcileWithPeer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params client.ReconcileParams
+func (_e *DB_Expecter) ReconcileWithPeer(ctx interface{}, params interface{}) *DB_ReconcileWithPeer_Call {
+	return &DB_ReconcileWithPeer_Call{Call: _e.mock.On("ReconcileWithPeer", ctx, params)}
+}
+
+func (_c *DB_ReconcileWithPeer_Call) Run(run func(ctx context.Context, params client.ReconcileParams)) *DB_ReconcileWithPeer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(client.ReconcileParams))
+	})
+	return _c
+}
+
+func (_c *DB_ReconcileWithPeer_Call) Return(_a0 client.ReconcileResult, _a1 error) *DB_ReconcileWithPeer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ReconcileWithPeer_Call) RunAndReturn(run func(context.Context, client.ReconcileParams) (client.ReconcileResult, error)) *DB_ReconcileWithPeer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshViews provides a mock function with given fields: _a0, _a1
 func (_m *DB) RefreshViews(_a0 context.Context, _a1 client.CollectionFetchOptions) error {
 	ret := _m.Called(_a0, _a1)
@@ -1949,6 +2289,53 @@ func (_c *DB_SetMigration_Call) RunAndReturn(run func(context.Context, client.Le
 	return _c
 }
 
+// SetPeerAccess provides a mock function with given fields: ctx, params
+func (_m *DB) SetPeerAccess(ctx context.Context, params client.PeerAccessParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPeerAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.PeerAccessParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_SetPeerAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPeerAccess'
+type DB_SetPeerAccess_Call struct {
+	*mock.Call
+}
+
+// SetPeerAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params client.PeerAccessParams
+func (_e *DB_Expecter) SetPeerAccess(ctx interface{}, params interface{}) *DB_SetPeerAccess_Call {
+	return &DB_SetPeerAccess_Call{Call: _e.mock.On("SetPeerAccess", ctx, params)}
+}
+
+func (_c *DB_SetPeerAccess_Call) Run(run func(ctx context.Context, params client.PeerAccessParams)) *DB_SetPeerAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(client.PeerAccessParams))
+	})
+	return _c
+}
+
+func (_c *DB_SetPeerAccess_Call) Return(_a0 error) *DB_SetPeerAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_SetPeerAccess_Call) RunAndReturn(run func(context.Context, client.PeerAccessParams) error) *DB_SetPeerAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetReplicator provides a mock function with given fields: ctx, rep
 func (_m *DB) SetReplicator(ctx context.Context, rep client.ReplicatorParams) error {
 	ret := _m.Called(ctx, rep)
@@ -44,4 +44,27 @@ type P2P interface {
 	// GetAllP2PCollections returns the list of persisted collection IDs that
 	// the P2P system subscribes to.
 	GetAllP2PCollections(ctx context.Context) ([]string, error)
+
+	// ReconcileWithPeer reconciles the given P2P collections with the given peer, and pulls the
+	// documents whose heads are missing from this node. Only the collections that the peer
+	// shares over the network can be reconciled.
+	//
+	// The ranges of documents that differ between the peers are found in a number of rounds
+	// that is logarithmic to the number of documents of the collections.
+	//
+	// Reconciliation is pull-only: the documents whose heads are missing from the peer are
+	// not pushed to it, the peer must reconcile with this node to pull them.
+	ReconcileWithPeer(ctx context.Context, params ReconcileParams) (ReconcileResult, error)
+
+	// SetPeerAccess sets the access of the given peers to push blocks to this node, replacing
+	// any access they previously had.
+	//
+	// Denied peers are always rejected. If any peers are allowed, the blocks pushed by all other
+	// peers are rejected. The access set here is combined with the one given in the node options.
+	SetPeerAccess(ctx context.Context, params PeerAccessParams) error
+	// DeletePeerAccess deletes the access of the given peers from the persisted list.
+	DeletePeerAccess(ctx context.Context, peerIDs []string) error
+	// GetAllPeerAccess returns the persisted list of peers that are allowed or denied pushing
+	// blocks to this node, along with the peers whose pushed blocks were rejected.
+	GetAllPeerAccess(ctx context.Context) ([]PeerAccess, error)
 }
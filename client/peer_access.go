@@ -0,0 +1,43 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// PeerAccessKind is the kind of access a peer has to push blocks to this node.
+type PeerAccessKind string
+
+const (
+	// PeerAccessAllow allows a peer to push blocks to this node.
+	//
+	// If any peers are allowed, the blocks pushed by all other peers are rejected.
+	PeerAccessAllow PeerAccessKind = "allow"
+	// PeerAccessDeny rejects the blocks pushed by a peer.
+	PeerAccessDeny PeerAccessKind = "deny"
+)
+
+// PeerAccessParams contains the parameters of an update of the access of peers.
+type PeerAccessParams struct {
+	// PeerIDs is the list of IDs of the peers whose access is set.
+	PeerIDs []string
+	// Access is the access given to the peers.
+	Access PeerAccessKind
+}
+
+// PeerAccess describes the access of a peer to push blocks to this node.
+type PeerAccess struct {
+	// PeerID is the ID of the peer.
+	PeerID string
+	// Access is the access given to the peer, empty if the peer has none but had
+	// pushed blocks rejected.
+	Access PeerAccessKind `json:",omitempty"`
+	// RejectedPushes is the number of blocks pushed by the peer that were rejected
+	// since the node was started.
+	RejectedPushes uint64
+}
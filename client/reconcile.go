@@ -0,0 +1,34 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ReconcileParams contains the parameters of the reconciliation of P2P collections with a peer.
+type ReconcileParams struct {
+	// Info is the address of the peer to reconcile with.
+	Info peer.AddrInfo
+	// CollectionIDs is the list of schema roots of the P2P collections to reconcile.
+	//
+	// All the P2P collections are reconciled if empty.
+	CollectionIDs []string
+}
+
+// ReconcileResult is the result of the reconciliation of P2P collections with a peer.
+type ReconcileResult struct {
+	// Docs is the number of documents that were transferred from the peer.
+	Docs int
+	// Rounds is the number of rounds that were needed to find the documents that differ
+	// between the peers.
+	Rounds int
+}
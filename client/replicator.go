@@ -22,6 +22,12 @@ type ReplicatorParams struct {
 	Info peer.AddrInfo
 	// Collections is the list of collection names to replicate.
 	Collections []string
+	// Filters contains the optional filters of the documents to replicate, keyed by
+	// collection name.
+	//
+	// Only the documents matching the filter of their collection are replicated. Filters
+	// may only target the fields of the collection, for example `{"region": {"_eq": "eu"}}`.
+	Filters map[string]map[string]any
 }
 
 // Replicator is a peer that a set of local collections are replicated to.
@@ -30,6 +36,20 @@ type Replicator struct {
 	Schemas          []string
 	Status           ReplicatorStatus
 	LastStatusChange time.Time
+
+	// Filters contains the filters of the documents to replicate, keyed by schema root.
+	Filters map[string]map[string]any `json:",omitempty"`
+
+	// PendingDocs is the number of documents waiting to be retried after failing to replicate.
+	PendingDocs uint64
+	// RetryAttempt is the number of retries of the pending documents, zero if none are pending.
+	RetryAttempt int
+	// NextRetry is the time at which the pending documents are next retried, zero if none are pending.
+	NextRetry time.Time
+	// LastSuccessfulPush is the time of the last successful push since the node started.
+	LastSuccessfulPush time.Time
+	// LastError is the error of the last failed push since the node started.
+	LastError string
 }
 
 // ReplicatorStatus is the status of a Replicator.
@@ -0,0 +1,42 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+// AsOf is a point in the history of the documents of a collection.
+//
+// Exactly one of its properties must be set.
+type AsOf struct {
+	// Height is an optional value that selects, for each document, the latest
+	// commit with a height lower or equal to the given height.
+	Height immutable.Option[uint64]
+
+	// Time is an optional value that selects, for each document, the latest
+	// commit created at or before the given time.
+	//
+	// Commits without a timestamp are considered to have been created before any time.
+	Time immutable.Option[time.Time]
+}
+
+// AsOfFilter is an embeddable struct that hosts a consistent set of properties
+// for viewing the documents of a request as they were at a point in their history.
+type AsOfFilter struct {
+	// AsOf is an optional value that selects the state of each document at the given
+	// point in its history for processing by the request.
+	//
+	// Documents that did not exist at that point are not returned.
+	AsOf immutable.Option[AsOf]
+}
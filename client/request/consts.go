@@ -23,10 +23,17 @@ const (
 	Input       = "input"
 	CreateInput = "create"
 	UpdateInput = "update"
+	AddInput    = "add"
+	RemoveInput = "remove"
 	FieldName   = "field"
 	FieldIDName = "fieldId"
 	ShowDeleted = "showDeleted"
 
+	TextPatchInput  = "patch"
+	TextPatchIndex  = "index"
+	TextPatchDelete = "delete"
+	TextPatchInsert = "insert"
+
 	EncryptDocArgName    = "encrypt"
 	EncryptFieldsArgName = "encryptFields"
 
@@ -34,21 +41,31 @@ const (
 	GroupByClause = "groupBy"
 	LimitClause   = "limit"
 	OffsetClause  = "offset"
+	FirstClause   = "first"
+	AfterClause   = "after"
+	LastClause    = "last"
+	BeforeClause  = "before"
 	OrderClause   = "order"
 	DepthClause   = "depth"
+	AsOfClause    = "asOf"
+	OfClause      = "of"
 
 	DocIDArgName = "docID"
 
-	AverageFieldName = "_avg"
-	CountFieldName   = "_count"
-	DocIDFieldName   = "_docID"
-	GroupFieldName   = "_group"
-	DeletedFieldName = "_deleted"
-	SumFieldName     = "_sum"
-	VersionFieldName = "_version"
-	MaxFieldName     = "_max"
-	MinFieldName     = "_min"
-	AliasFieldName   = "_alias"
+	AverageFieldName  = "_avg"
+	CountFieldName    = "_count"
+	DocIDFieldName    = "_docID"
+	GroupFieldName    = "_group"
+	DeletedFieldName  = "_deleted"
+	SumFieldName      = "_sum"
+	VersionFieldName  = "_version"
+	MaxFieldName      = "_max"
+	MinFieldName      = "_min"
+	AliasFieldName    = "_alias"
+	CursorFieldName   = "_cursor"
+	PageInfoFieldName = "_pageInfo"
+	KindFieldName     = "_kind"
+	ChangesFieldName  = "_changes"
 
 	// New generated document id from a backed up document,
 	// which might have a different _docID originally.
@@ -68,6 +85,8 @@ const (
 	FieldNameFieldName       = "fieldName"
 	FieldIDFieldName         = "fieldId"
 	DeltaFieldName           = "delta"
+	SignerFieldName          = "signer"
+	TimestampFieldName       = "timestamp"
 
 	DeltaArgFieldName       = "FieldName"
 	DeltaArgData            = "Data"
@@ -84,6 +103,20 @@ const (
 	LinksNameFieldName = "name"
 	LinksCidFieldName  = "cid"
 
+	PageInfoTypeName         = "PageInfo"
+	HasNextPageFieldName     = "hasNextPage"
+	HasPreviousPageFieldName = "hasPreviousPage"
+	StartCursorFieldName     = "startCursor"
+	EndCursorFieldName       = "endCursor"
+
+	AsOfTypeName = "AsOf"
+	AsOfHeight   = "height"
+	AsOfTime     = "time"
+
+	DocChangeKindTypeName = "DocChangeKind"
+	PreviousValueName     = "previous"
+	CurrentValueName      = "current"
+
 	ASC  = OrderDirection("ASC")
 	DESC = OrderDirection("DESC")
 )
@@ -114,6 +147,10 @@ var (
 		DeletedFieldName:  {},
 		MaxFieldName:      {},
 		MinFieldName:      {},
+		CursorFieldName:   {},
+		PageInfoFieldName: {},
+		KindFieldName:     {},
+		ChangesFieldName:  {},
 	}
 
 	Aggregates = map[string]struct{}{
@@ -138,10 +175,19 @@ var (
 		FieldNameFieldName,
 		FieldIDFieldName,
 		DeltaFieldName,
+		SignerFieldName,
+		TimestampFieldName,
 	}
 
 	LinksFields = []string{
 		LinksNameFieldName,
 		LinksCidFieldName,
 	}
+
+	PageInfoFields = []string{
+		HasNextPageFieldName,
+		HasPreviousPageFieldName,
+		StartCursorFieldName,
+		EndCursorFieldName,
+	}
 )
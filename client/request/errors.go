@@ -16,6 +16,11 @@ import (
 
 const (
 	errSelectOfNonGroupField string = "cannot select a non-group-by field at group-level"
+	errPaginationWithLimit   string = "cursor pagination cannot be combined with limit or offset"
+	errPaginationWithGroupBy string = "cursor pagination cannot be combined with groupBy"
+	errInvalidAsOf           string = "asOf requires exactly one of height or time"
+	errAsOfWithCID           string = "asOf cannot be combined with cid"
+	errInvalidFilterPath     string = "_path must be a non-empty list of JSON object keys"
 )
 
 // Errors returnable from this package.
@@ -24,6 +29,11 @@ const (
 // Errors returned from this package may be tested against these errors with errors.Is.
 var (
 	ErrSelectOfNonGroupField = errors.New(errSelectOfNonGroupField)
+	ErrPaginationWithLimit   = errors.New(errPaginationWithLimit)
+	ErrPaginationWithGroupBy = errors.New(errPaginationWithGroupBy)
+	ErrInvalidAsOf           = errors.New(errInvalidAsOf)
+	ErrAsOfWithCID           = errors.New(errAsOfWithCID)
+	ErrInvalidFilterPath     = errors.New(errInvalidFilterPath)
 )
 
 // NewErrSelectOfNonGroupField returns an error indicating that a non-group-by field
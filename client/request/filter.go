@@ -16,6 +16,16 @@ const (
 	FilterOpOr  = "_or"
 	FilterOpAnd = "_and"
 	FilterOpNot = "_not"
+
+	FilterOpSearch = "_search"
+	FilterOpIn     = "_in"
+
+	FilterOpGreater        = "_gt"
+	FilterOpGreaterOrEqual = "_ge"
+
+	// FilterOpPath is used within a JSON field filter to apply the sibling
+	// operators to the value found at the given path of object keys.
+	FilterOpPath = "_path"
 )
 
 // Filter contains the parsed condition map to be
@@ -34,3 +44,47 @@ type Filterable struct {
 	// being processed by the request.
 	Filter immutable.Option[Filter]
 }
+
+// isFilterPathValid returns true if all the `_path` operators within the given
+// conditions hold a non-empty list of JSON object keys.
+func isFilterPathValid(conditions any) bool {
+	switch typedConditions := conditions.(type) {
+	case map[string]any:
+		for key, value := range typedConditions {
+			if key == FilterOpPath {
+				if _, ok := FilterPath(value); !ok {
+					return false
+				}
+				continue
+			}
+			if !isFilterPathValid(value) {
+				return false
+			}
+		}
+	case []any:
+		for _, value := range typedConditions {
+			if !isFilterPathValid(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FilterPath returns the JSON object keys of the given `_path` operator value.
+//
+// It returns false if the value is not a non-empty list of strings.
+func FilterPath(value any) ([]string, bool) {
+	values, ok := value.([]any)
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	path := make([]string, len(values))
+	for i, v := range values {
+		path[i], ok = v.(string)
+		if !ok {
+			return nil, false
+		}
+	}
+	return path, true
+}
@@ -43,6 +43,15 @@ type ObjectMutation struct {
 	// UpdateInput is a map of fields and values used for an update mutation.
 	UpdateInput map[string]any
 
+	// AddInput is a map of set fields and the elements to add to them in an update mutation.
+	AddInput map[string]any
+
+	// RemoveInput is a map of set fields and the elements to remove from them in an update mutation.
+	RemoveInput map[string]any
+
+	// TextPatchInput is a map of text fields and the patches to apply to them in an update mutation.
+	TextPatchInput map[string]any
+
 	// Encrypt is a boolean flag that indicates whether the input data should be encrypted.
 	Encrypt bool
 
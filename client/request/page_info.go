@@ -0,0 +1,26 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+// PageInfo represents a request for the page info of a sibling [Select].
+//
+// The page info is returned once per selection, it is not a property of the selected documents,
+// this way it can be returned for an empty page.
+type PageInfo struct {
+	Field
+
+	// Of is the alias, or name if it has no alias, of the sibling selection to return the page
+	// info of.
+	Of string
+
+	// Fields contains the set of page info properties to return.
+	Fields []*Field
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+import "github.com/sourcenetwork/immutable"
+
+// Pageable is an embeddable struct that hosts a consistent set of properties
+// for cursor-based pagination of a request.
+type Pageable struct {
+	// First is an optional value that caps the number of results to the given number,
+	// taken from the start of the (remaining) result set.
+	First immutable.Option[uint64]
+
+	// After is an optional cursor, only results positioned after it will be returned.
+	After immutable.Option[string]
+
+	// Last is an optional value that caps the number of results to the given number,
+	// taken from the end of the (remaining) result set.
+	Last immutable.Option[uint64]
+
+	// Before is an optional cursor, only results positioned before it will be returned.
+	Before immutable.Option[string]
+}
+
+// HasPaging returns true if any of the pagination properties have been set.
+func (p Pageable) HasPaging() bool {
+	return p.First.HasValue() || p.After.HasValue() || p.Last.HasValue() || p.Before.HasValue()
+}
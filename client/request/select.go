@@ -23,10 +23,12 @@ type Select struct {
 
 	Limitable
 	Offsetable
+	Pageable
 	Orderable
 	Filterable
 	DocIDsFilter
 	CIDFilter
+	AsOfFilter
 	Groupable
 
 	// ShowDeleted will return deleted documents along with non-deleted ones
@@ -66,6 +68,58 @@ func (s *Select) validateShallow() []error {
 	result := []error{}
 
 	result = append(result, s.validateGroupBy()...)
+	result = append(result, s.validatePagination()...)
+	result = append(result, s.validateAsOf()...)
+	result = append(result, s.validateFilter()...)
+
+	return result
+}
+
+func (s *Select) validateFilter() []error {
+	result := []error{}
+
+	if !s.Filter.HasValue() {
+		return result
+	}
+
+	if !isFilterPathValid(s.Filter.Value().Conditions) {
+		result = append(result, ErrInvalidFilterPath)
+	}
+
+	return result
+}
+
+func (s *Select) validateAsOf() []error {
+	result := []error{}
+
+	if !s.AsOf.HasValue() {
+		return result
+	}
+
+	asOf := s.AsOf.Value()
+	if asOf.Height.HasValue() == asOf.Time.HasValue() {
+		result = append(result, ErrInvalidAsOf)
+	}
+	if s.CID.HasValue() {
+		result = append(result, ErrAsOfWithCID)
+	}
+
+	return result
+}
+
+func (s *Select) validatePagination() []error {
+	result := []error{}
+
+	if !s.HasPaging() {
+		return result
+	}
+
+	if s.Limit.HasValue() || s.Offset.HasValue() {
+		result = append(result, ErrPaginationWithLimit)
+	}
+	if s.GroupBy.HasValue() {
+		result = append(result, ErrPaginationWithGroupBy)
+	}
 
 	return result
 }
@@ -116,10 +170,12 @@ type selectJson struct {
 	Field
 	Limitable
 	Offsetable
+	Pageable
 	Orderable
 	Filterable
 	DocIDsFilter
 	CIDFilter
+	AsOfFilter
 	Groupable
 	ShowDeleted bool
 }
@@ -134,8 +190,10 @@ func (s *Select) UnmarshalJSON(bytes []byte) error {
 	s.Field = selectMap.Field
 	s.DocIDs = selectMap.DocIDs
 	s.CID = selectMap.CID
+	s.AsOf = selectMap.AsOf
 	s.Limitable = selectMap.Limitable
 	s.Offsetable = selectMap.Offsetable
+	s.Pageable = selectMap.Pageable
 	s.Orderable = selectMap.Orderable
 	s.Groupable = selectMap.Groupable
 	s.Filterable = selectMap.Filterable
@@ -179,7 +237,15 @@ func (s *ChildSelect) UnmarshalJSON(bytes []byte) error {
 		// They must be non-nillable as nil values may have their keys omitted from
 		// the json. This also relies on the fields being unique.  We may wish to change
 		// this later to custom-serialize with a `_type` property.
-		if _, ok := field["Fields"]; ok {
+		if _, ok := field["Of"]; ok {
+			// This must be a PageInfo, as only the `PageInfo` type has an `Of` field
+			var fieldPageInfo PageInfo
+			err := json.Unmarshal(fieldJson, &fieldPageInfo)
+			if err != nil {
+				return err
+			}
+			fieldValue = &fieldPageInfo
+		} else if _, ok := field["Fields"]; ok {
 			// This must be a Select, as only the `Select` type has a `Fields` field
 			var fieldSelect Select
 			err := json.Unmarshal(fieldJson, &fieldSelect)
@@ -25,6 +25,9 @@ type ObjectSubscription struct {
 
 	// Collection is the target collection name
 	Collection string
+
+	// ShowDeleted will also yield results for documents that have been deleted.
+	ShowDeleted bool
 }
 
 // ToSelect returns a basic Select object, with the same Name, Alias, and Fields as
@@ -43,5 +46,6 @@ func (m ObjectSubscription) ToSelect(docID, cid string) *Select {
 		},
 		ChildSelect: m.ChildSelect,
 		Filterable:  m.Filterable,
+		ShowDeleted: m.ShowDeleted,
 	}
 }
@@ -0,0 +1,40 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// CollectionStatistics contains the estimated statistics of the documents of a collection.
+//
+// The statistics are maintained as documents are written and are only meant to guide
+// the planning of requests, so they may be inexact.
+type CollectionStatistics struct {
+	// CollectionName is the name of the collection.
+	CollectionName string `json:"collectionName"`
+	// DocumentCount is the number of documents in the collection that are not deleted.
+	//
+	// If the collection has a policy, only the documents the identity may read are counted.
+	DocumentCount uint64 `json:"documentCount"`
+	// Indexes contains the statistics of each index of the collection.
+	//
+	// It is empty if the collection has a policy, as the statistics of its indexes would
+	// reveal the values of documents the identity may not read.
+	Indexes []IndexStatistics `json:"indexes"`
+}
+
+// IndexStatistics contains the estimated statistics of the values of an index.
+type IndexStatistics struct {
+	// IndexName is the name of the index.
+	IndexName string `json:"indexName"`
+	// DistinctKeys is the estimated number of distinct values stored in the index.
+	//
+	// Values that are no longer stored in the index may still be counted, until enough
+	// of them have been removed for the estimate to be rebuilt.
+	DistinctKeys uint64 `json:"distinctKeys"`
+}
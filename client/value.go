@@ -18,6 +18,9 @@ type FieldValue struct {
 	t       CType
 	value   NormalValue
 	isDirty bool
+	// textPatches holds the patches that produced the value from the saved value of
+	// a text field, if the value was only patched since it was saved.
+	textPatches []TextPatch
 }
 
 func NewFieldValue(t CType, val NormalValue) *FieldValue {
@@ -52,6 +55,14 @@ func (val FieldValue) IsDirty() bool {
 
 func (val *FieldValue) Clean() {
 	val.isDirty = false
+	val.textPatches = nil
+}
+
+// TextPatches returns the patches that produced the value of a text field from its saved value.
+//
+// It returns nil if the value was set since it was saved.
+func (val FieldValue) TextPatches() []TextPatch {
+	return val.textPatches
 }
 
 func (val *FieldValue) SetType(t CType) {
@@ -12,6 +12,7 @@ package iterable
 
 import (
 	"context"
+	"strings"
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
@@ -76,7 +77,12 @@ func (shim *iteratorShim) IteratePrefix(
 			}
 			lastSharedIndex += 1
 		}
+		// The query prefix only matches whole key segments, so the shared bytes
+		// are cut back to the last segment boundary.
 		query.Prefix = string(startBytes[:lastSharedIndex])
+		if i := strings.LastIndexByte(query.Prefix, '/'); i >= 0 {
+			query.Prefix = query.Prefix[:i]
+		}
 		query.Filters = append(query.Filters, betweenFilter{
 			start: startPrefix.String(),
 			end:   endPrefix.String(),
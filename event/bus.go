@@ -44,6 +44,14 @@ type Bus struct {
 	isClosed        bool
 	// closeMutex is only locked when the bus is closing.
 	closeMutex sync.RWMutex
+
+	// subscribers is the number of subscriptions to each event, it is updated as soon as
+	// the subscriptions are made so that publishers can tell whether anyone will receive
+	// an event.
+	subscribers map[Name]int
+	// subscribed contains the ids of the subscriptions counted in subscribers.
+	subscribed map[uint64]struct{}
+	countMutex sync.Mutex
 }
 
 // NewBus creates a new event bus with the given commandBufferSize and
@@ -57,6 +65,8 @@ func NewBus(commandBufferSize int, eventBufferSize int) *Bus {
 		commandChannel:  make(chan any, commandBufferSize),
 		hasClosedChan:   make(chan struct{}),
 		eventBufferSize: eventBufferSize,
+		subscribers:     make(map[Name]int),
+		subscribed:      make(map[uint64]struct{}),
 	}
 	go bus.handleChannel()
 	return &bus
@@ -87,10 +97,27 @@ func (b *Bus) Subscribe(events ...Name) (*Subscription, error) {
 		value:  make(chan Message, b.eventBufferSize),
 		events: events,
 	}
+	b.countMutex.Lock()
+	b.subscribed[sub.id] = struct{}{}
+	for _, event := range events {
+		b.subscribers[event]++
+	}
+	b.countMutex.Unlock()
 	b.commandChannel <- subscribeCommand(sub)
 	return sub, nil
 }
 
+// HasSubscribers returns true if any subscription will receive the events with the given name.
+//
+// Publishers may use it to skip building events that are expensive to build, events published
+// whilst a subscription is being made may or may not be received by it.
+func (b *Bus) HasSubscribers(name Name) bool {
+	b.countMutex.Lock()
+	defer b.countMutex.Unlock()
+
+	return b.subscribers[name] > 0 || b.subscribers[WildCardName] > 0
+}
+
 // Unsubscribe removes all event subscriptions and closes the subscription channel.
 //
 // Will do nothing if this object is already closed.
@@ -101,6 +128,14 @@ func (b *Bus) Unsubscribe(sub *Subscription) {
 	if b.isClosed {
 		return
 	}
+	b.countMutex.Lock()
+	if _, ok := b.subscribed[sub.id]; ok {
+		delete(b.subscribed, sub.id)
+		for _, event := range sub.events {
+			b.subscribers[event]--
+		}
+	}
+	b.countMutex.Unlock()
 	b.commandChannel <- unsubscribeCommand(sub)
 }
 
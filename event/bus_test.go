@@ -177,3 +177,25 @@ func TestBus_IfSubscribedThenUnsubscribe_SubscriptionShouldNotReceiveEvent(t *te
 	// closing the channel will result in reads yielding the default value
 	assert.Equal(t, Message{}, <-sub.Message())
 }
+
+func TestBus_HasSubscribers(t *testing.T) {
+	bus := NewBus(0, 0)
+	defer bus.Close()
+
+	assert.False(t, bus.HasSubscribers("test"))
+
+	sub, err := bus.Subscribe("test")
+	assert.NoError(t, err)
+	assert.True(t, bus.HasSubscribers("test"))
+	assert.False(t, bus.HasSubscribers("other"))
+
+	wildCardSub, err := bus.Subscribe(WildCardName)
+	assert.NoError(t, err)
+	assert.True(t, bus.HasSubscribers("other"))
+
+	bus.Unsubscribe(wildCardSub)
+	bus.Unsubscribe(sub)
+	bus.Unsubscribe(sub)
+	assert.False(t, bus.HasSubscribers("test"))
+	assert.False(t, bus.HasSubscribers("other"))
+}
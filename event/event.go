@@ -37,12 +37,22 @@ const (
 	ReplicatorName = Name("replicator")
 	// ReplicatorFailureName is the name of the replicator failure event.
 	ReplicatorFailureName = Name("replicator-failure")
+	// ReplicatorSuccessName is the name of the replicator success event.
+	ReplicatorSuccessName = Name("replicator-success")
 	// P2PTopicCompletedName is the name of the network p2p topic update completed event.
 	P2PTopicCompletedName = Name("p2p-topic-completed")
 	// ReplicatorCompletedName is the name of the replicator completed event.
 	ReplicatorCompletedName = Name("replicator-completed")
 	// PurgeName is the name of the purge event.
 	PurgeName = Name("purge")
+	// DocChangeName is the name of the local document change event.
+	DocChangeName = Name("doc-change")
+	// ReconcileName is the name of the network reconcile request event.
+	ReconcileName = Name("reconcile")
+	// PeerAccessName is the name of the network peer access update event.
+	PeerAccessName = Name("peer-access")
+	// PushRejectedName is the name of the network rejected push event.
+	PushRejectedName = Name("push-rejected")
 )
 
 // PubSub is an event that is published when
@@ -78,6 +88,48 @@ type Update struct {
 	Success chan bool
 }
 
+// DocChangeKind identifies the kind of change made to a document.
+type DocChangeKind string
+
+const (
+	// DocCreated is the kind of change made when a document is created.
+	DocCreated = DocChangeKind("CREATE")
+	// DocUpdated is the kind of change made when an existing document is updated.
+	DocUpdated = DocChangeKind("UPDATE")
+	// DocDeleted is the kind of change made when a document is deleted.
+	DocDeleted = DocChangeKind("DELETE")
+)
+
+// DocChange is a notification that a document has been created, updated or deleted locally.
+//
+// Unlike [Update] it contains the values of the document, so it must never be shared
+// outside of the node.
+type DocChange struct {
+	// Kind is the kind of change that was made to the document.
+	Kind DocChangeKind
+
+	// DocID is the unique immutable identifier of the document that was changed.
+	DocID string
+
+	// Cid is the id of the composite commit that formed this change in the DAG.
+	Cid cid.Cid
+
+	// CollectionName is the name of the collection the document belongs to.
+	CollectionName string
+
+	// Values contains the values of the document fields known at the time of the change,
+	// keyed by field name.
+	//
+	// For deletes it contains the values of the document prior to its deletion.
+	Values map[string]any
+
+	// Previous contains the values of the changed fields prior to the change, keyed by
+	// field name.
+	//
+	// It is only populated for updates.
+	Previous map[string]any
+}
+
 // Merge is a notification that a merge can be performed up to the provided CID.
 type Merge struct {
 	// DocID is the unique immutable identifier of the document that was updated.
@@ -148,6 +200,10 @@ type Replicator struct {
 	Info peer.AddrInfo
 	// The map of schema roots that the replicator will receive updates for.
 	Schemas map[string]struct{}
+	// The filters of the documents to replicate, keyed by schema root.
+	//
+	// Collections without a filter have all their documents replicated.
+	Filters map[string]map[string]any
 	// Docs will receive Updates if new collections have been added to the replicator
 	// and those collections have documents to be replicated.
 	Docs <-chan Update
@@ -159,4 +215,60 @@ type ReplicatorFailure struct {
 	PeerID peer.ID
 	// DocID is the unique immutable identifier of the document that failed to replicate.
 	DocID string
+	// Err is the error that caused the failure.
+	Err error
+}
+
+// ReplicatorSuccess is an event that is published when a replicator successfully replicates a document.
+type ReplicatorSuccess struct {
+	// PeerID is the id of the peer that the document was replicated to.
+	PeerID peer.ID
+	// DocID is the unique immutable identifier of the document that was replicated.
+	DocID string
+}
+
+// Reconcile is an event that is published to request the reconciliation of a set of
+// collections with a peer.
+type Reconcile struct {
+	// Info is the address of the peer to reconcile with.
+	Info peer.AddrInfo
+	// SchemaRoots is the list of schema roots of the collections to reconcile.
+	SchemaRoots []string
+	// Result is a channel that will receive the result of the reconciliation.
+	Result chan ReconcileResult
+}
+
+// ReconcileResult is the result of the reconciliation of a set of collections with a peer.
+type ReconcileResult struct {
+	// Docs is the number of documents that were transferred from the peer.
+	Docs int
+	// Rounds is the number of rounds that were needed to find the differing documents.
+	Rounds int
+	// Err is the error that caused the reconciliation to fail, if any.
+	Err error
+}
+
+// PeerAccess is an event that is published when the peers allowed or denied pushing
+// blocks to this node have been updated.
+type PeerAccess struct {
+	// Allowed is the list of peers allowed to push blocks to this node.
+	//
+	// If it is not empty, the blocks pushed by any other peer are rejected.
+	Allowed []peer.ID
+	// Denied is the list of peers whose pushed blocks are rejected.
+	Denied []peer.ID
+}
+
+// PushRejected is an event that is published when a block pushed by a peer has been rejected.
+type PushRejected struct {
+	// FromPeer is the id of the peer that pushed the block.
+	FromPeer peer.ID
+	// ByPeer is the id of the peer that created the block.
+	ByPeer peer.ID
+	// DocID is the unique immutable identifier of the document of the block, if any.
+	DocID string
+	// SchemaRoot is the root identifier of the schema of the block's collection.
+	SchemaRoot string
+	// Reason describes why the block was rejected.
+	Reason string
 }
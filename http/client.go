@@ -364,12 +364,79 @@ func (c *Client) GetAllIndexes(ctx context.Context) (map[client.CollectionName][
 	return indexes, nil
 }
 
+func (c *Client) GetCollectionStatistics(
+	ctx context.Context,
+	collectionName string,
+) (client.CollectionStatistics, error) {
+	methodURL := c.http.baseURL.JoinPath("statistics")
+	params := url.Values{}
+	params.Add("name", collectionName)
+	methodURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, methodURL.String(), nil)
+	if err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	var stats client.CollectionStatistics
+	if err := c.http.requestJson(req, &stats); err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	return stats, nil
+}
+
+// setRequestLimitHeaders sets the headers of the limits set by the given options.
+func setRequestLimitHeaders(options *client.GQLOptions, req *http.Request) {
+	if options.MaxDuration > 0 {
+		req.Header.Set(maxDurationHeaderName, options.MaxDuration.String())
+	}
+	if options.MaxScannedDocs > 0 {
+		req.Header.Set(maxScannedDocsHeaderName, strconv.FormatUint(options.MaxScannedDocs, 10))
+	}
+}
+
 func (c *Client) ExecRequest(
 	ctx context.Context,
 	query string,
 	opts ...client.RequestOption,
 ) *client.RequestResult {
 	methodURL := c.http.baseURL.JoinPath("graphql")
+	return c.execRequest(ctx, methodURL, query, opts...)
+}
+
+func (c *Client) PrepareRequest(ctx context.Context, request string) (string, error) {
+	methodURL := c.http.baseURL.JoinPath("graphql", "prepare")
+
+	body, err := json.Marshal(&GraphQLRequest{Query: request})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	var prepared PreparedRequest
+	if err := c.http.requestJson(req, &prepared); err != nil {
+		return "", err
+	}
+	return prepared.ID, nil
+}
+
+func (c *Client) ExecPreparedRequest(
+	ctx context.Context,
+	id string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	methodURL := c.http.baseURL.JoinPath("graphql", "prepared", id)
+	return c.execRequest(ctx, methodURL, "", opts...)
+}
+
+// execRequest posts the given GraphQL request to the given endpoint.
+func (c *Client) execRequest(
+	ctx context.Context,
+	methodURL *url.URL,
+	query string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
 	result := &client.RequestResult{}
 
 	gqlOptions := &client.GQLOptions{}
@@ -396,6 +463,7 @@ func (c *Client) ExecRequest(
 	err = c.http.setDefaultHeaders(req)
 
 	setDocEncryptionFlagIfNeeded(ctx, req)
+	setRequestLimitHeaders(gqlOptions, req)
 
 	if err != nil {
 		result.GQL.Errors = append(result.GQL.Errors, err)
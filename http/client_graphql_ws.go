@@ -0,0 +1,325 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/db"
+)
+
+// GraphQLWebSocket is a graphql-transport-ws connection to a node.
+//
+// Any number of requests may be executed over a single connection, including
+// multiple concurrent subscriptions.
+type GraphQLWebSocket struct {
+	conn *websocket.Conn
+
+	// writeLock ensures that only a single message is written at a time.
+	writeLock sync.Mutex
+
+	// nextID is the id of the next operation to be executed.
+	nextID atomic.Uint64
+
+	// operations contains all active operations keyed by id.
+	operations map[string]*graphQLWSOperation
+	// operationsLock guards operations, as they are removed once completed.
+	operationsLock sync.Mutex
+
+	// closed is closed once the connection has been closed.
+	closed chan struct{}
+}
+
+// graphQLWSOperation is an operation executed over a [GraphQLWebSocket].
+type graphQLWSOperation struct {
+	// messages yields the messages received for this operation.
+	messages chan graphQLWSMessage
+	// done is closed once the operation has been stopped.
+	done chan struct{}
+}
+
+// DialGraphQLWebSocket opens a new graphql-transport-ws connection to the node.
+//
+// The identity and transaction of the given context, if any, are used for all requests
+// executed over the connection.
+func (c *Client) DialGraphQLWebSocket(ctx context.Context) (*GraphQLWebSocket, error) {
+	methodURL := c.http.baseURL.JoinPath("graphql", "ws")
+	switch methodURL.Scheme {
+	case "https":
+		methodURL.Scheme = "wss"
+	default:
+		methodURL.Scheme = "ws"
+	}
+
+	header := http.Header{}
+	txn, ok := db.TryGetContextTxn(ctx)
+	if ok {
+		header.Set(txHeaderName, fmt.Sprintf("%d", txn.ID()))
+	}
+
+	dialer := websocket.Dialer{
+		Subprotocols:     []string{graphQLWSProtocol},
+		HandshakeTimeout: graphQLWSInitTimeout,
+	}
+	conn, res, err := dialer.DialContext(ctx, methodURL.String(), header)
+	if err != nil {
+		return nil, err
+	}
+	// the body of a successful upgrade response is always empty
+	_ = res.Body.Close()
+
+	var initPayload graphQLWSInitPayload
+	id := identity.FromContext(ctx)
+	if id.HasValue() {
+		initPayload.Authorization = authSchemaPrefix + id.Value().BearerToken
+	}
+	payload, err := json.Marshal(initPayload)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	ws := &GraphQLWebSocket{
+		conn:       conn,
+		operations: make(map[string]*graphQLWSOperation),
+		closed:     make(chan struct{}),
+	}
+	err = ws.write(graphQLWSMessage{Type: graphQLWSConnectionInit, Payload: payload})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(graphQLWSInitTimeout))
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	var ack graphQLWSMessage
+	if err := conn.ReadJSON(&ack); err != nil || ack.Type != graphQLWSConnectionAck {
+		_ = conn.Close()
+		return nil, ErrWebSocketNotAcked
+	}
+	err = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	go ws.read()
+	return ws, nil
+}
+
+// ExecRequest executes the given request over the connection.
+//
+// If the request is a subscription, results will be yielded on the returned subscription
+// channel until the given context is cancelled or the connection is closed.
+func (ws *GraphQLWebSocket) ExecRequest(
+	ctx context.Context,
+	query string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	result := &client.RequestResult{}
+
+	gqlOptions := &client.GQLOptions{}
+	for _, o := range opts {
+		o(gqlOptions)
+	}
+	payload, err := json.Marshal(&GraphQLRequest{
+		Query:         query,
+		OperationName: gqlOptions.OperationName,
+		Variables:     gqlOptions.Variables,
+	})
+	if err != nil {
+		result.GQL.Errors = append(result.GQL.Errors, err)
+		return result
+	}
+
+	id := strconv.FormatUint(ws.nextID.Add(1), 10)
+	operation := ws.startOperation(id)
+
+	err = ws.write(graphQLWSMessage{ID: id, Type: graphQLWSSubscribe, Payload: payload})
+	if err != nil {
+		ws.stopOperation(id)
+		result.GQL.Errors = append(result.GQL.Errors, err)
+		return result
+	}
+
+	if isSubscription(query, gqlOptions.OperationName) {
+		result.Subscription = ws.subscribe(ctx, id, operation)
+		return result
+	}
+
+	defer ws.stopOperation(id)
+	select {
+	case <-ctx.Done():
+		_ = ws.write(graphQLWSMessage{ID: id, Type: graphQLWSComplete})
+		result.GQL.Errors = append(result.GQL.Errors, ctx.Err())
+	case <-ws.closed:
+		result.GQL.Errors = append(result.GQL.Errors, ErrWebSocketClosed)
+	case msg := <-operation.messages:
+		result.GQL = parseGraphQLWSResult(msg)
+	}
+	return result
+}
+
+// Close closes the connection, ending all active subscriptions.
+func (ws *GraphQLWebSocket) Close() error {
+	ws.writeLock.Lock()
+	_ = ws.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(graphQLWSWriteTimeout),
+	)
+	ws.writeLock.Unlock()
+	return ws.conn.Close()
+}
+
+// subscribe yields the results of the subscription with the given id on the returned channel.
+func (ws *GraphQLWebSocket) subscribe(
+	ctx context.Context,
+	id string,
+	operation *graphQLWSOperation,
+) chan client.GQLResult {
+	resCh := make(chan client.GQLResult)
+	go func() {
+		defer func() {
+			ws.stopOperation(id)
+			close(resCh)
+		}()
+
+		for {
+			var msg graphQLWSMessage
+			select {
+			case <-ctx.Done():
+				_ = ws.write(graphQLWSMessage{ID: id, Type: graphQLWSComplete})
+				return
+			case <-ws.closed:
+				return
+			case msg = <-operation.messages:
+			}
+
+			if msg.Type == graphQLWSComplete {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				_ = ws.write(graphQLWSMessage{ID: id, Type: graphQLWSComplete})
+				return
+			case <-ws.closed:
+				return
+			case resCh <- parseGraphQLWSResult(msg):
+			}
+			if msg.Type == graphQLWSError {
+				return
+			}
+		}
+	}()
+	return resCh
+}
+
+// read routes the messages received from the server to their operations until the
+// connection is closed.
+func (ws *GraphQLWebSocket) read() {
+	defer close(ws.closed)
+
+	for {
+		var msg graphQLWSMessage
+		if err := ws.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case graphQLWSPing:
+			if err := ws.write(graphQLWSMessage{Type: graphQLWSPong}); err != nil {
+				return
+			}
+
+		case graphQLWSNext, graphQLWSError, graphQLWSComplete:
+			ws.operationsLock.Lock()
+			operation, ok := ws.operations[msg.ID]
+			ws.operationsLock.Unlock()
+			if !ok {
+				// the operation has already been stopped
+				continue
+			}
+			select {
+			case operation.messages <- msg:
+			case <-operation.done:
+			}
+		}
+	}
+}
+
+func (ws *GraphQLWebSocket) startOperation(id string) *graphQLWSOperation {
+	ws.operationsLock.Lock()
+	defer ws.operationsLock.Unlock()
+
+	operation := &graphQLWSOperation{
+		messages: make(chan graphQLWSMessage),
+		done:     make(chan struct{}),
+	}
+	ws.operations[id] = operation
+	return operation
+}
+
+func (ws *GraphQLWebSocket) stopOperation(id string) {
+	ws.operationsLock.Lock()
+	defer ws.operationsLock.Unlock()
+
+	if operation, ok := ws.operations[id]; ok {
+		close(operation.done)
+		delete(ws.operations, id)
+	}
+}
+
+func (ws *GraphQLWebSocket) write(msg graphQLWSMessage) error {
+	ws.writeLock.Lock()
+	defer ws.writeLock.Unlock()
+
+	err := ws.conn.SetWriteDeadline(time.Now().Add(graphQLWSWriteTimeout))
+	if err != nil {
+		return err
+	}
+	return ws.conn.WriteJSON(msg)
+}
+
+// parseGraphQLWSResult returns the result held by the given next or error message.
+func parseGraphQLWSResult(msg graphQLWSMessage) client.GQLResult {
+	var res client.GQLResult
+	if msg.Type == graphQLWSError {
+		// the payload of an error message is a list of errors
+		var errs []map[string]any
+		if err := json.Unmarshal(msg.Payload, &errs); err != nil {
+			res.Errors = append(res.Errors, err)
+			return res
+		}
+		for _, e := range errs {
+			res.Errors = append(res.Errors, client.ReviveError(fmt.Sprintf("%v", e["message"])))
+		}
+		return res
+	}
+	if err := json.Unmarshal(msg.Payload, &res); err != nil {
+		res.Errors = append(res.Errors, err)
+	}
+	return res
+}
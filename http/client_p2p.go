@@ -122,3 +122,68 @@ func (c *Client) GetAllP2PCollections(ctx context.Context) ([]string, error) {
 	}
 	return cols, nil
 }
+
+func (c *Client) ReconcileWithPeer(
+	ctx context.Context,
+	params client.ReconcileParams,
+) (client.ReconcileResult, error) {
+	methodURL := c.http.baseURL.JoinPath("p2p", "reconcile")
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return client.ReconcileResult{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return client.ReconcileResult{}, err
+	}
+	var res client.ReconcileResult
+	if err := c.http.requestJson(req, &res); err != nil {
+		return client.ReconcileResult{}, err
+	}
+	return res, nil
+}
+
+func (c *Client) SetPeerAccess(ctx context.Context, params client.PeerAccessParams) error {
+	methodURL := c.http.baseURL.JoinPath("p2p", "access")
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.http.request(req)
+	return err
+}
+
+func (c *Client) DeletePeerAccess(ctx context.Context, peerIDs []string) error {
+	methodURL := c.http.baseURL.JoinPath("p2p", "access")
+
+	body, err := json.Marshal(peerIDs)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.http.request(req)
+	return err
+}
+
+func (c *Client) GetAllPeerAccess(ctx context.Context) ([]client.PeerAccess, error) {
+	methodURL := c.http.baseURL.JoinPath("p2p", "access")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, methodURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var access []client.PeerAccess
+	if err := c.http.requestJson(req, &access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
@@ -22,6 +22,7 @@ const (
 	errFailedToLoadKeys       string = "failed to load given keys"
 	errMethodIsNotImplemented string = "the method is not implemented"
 	errFailedToGetContext     string = "failed to get context"
+	errInvalidHeader          string = "invalid header value"
 )
 
 // Errors returnable from this package.
@@ -39,6 +40,8 @@ var (
 	ErrP2PDisabled            = errors.New("p2p network is disabled")
 	ErrMethodIsNotImplemented = errors.New(errMethodIsNotImplemented)
 	ErrMissingIdentity        = errors.New("required identity is missing")
+	ErrWebSocketClosed        = errors.New("websocket connection closed")
+	ErrWebSocketNotAcked      = errors.New("websocket connection was not acknowledged")
 )
 
 type errorResponse struct {
@@ -69,6 +72,14 @@ func NewErrFailedToGetContext(contextType string) error {
 	)
 }
 
+func NewErrInvalidHeader(inner error, name string) error {
+	return errors.Wrap(
+		errInvalidHeader,
+		inner,
+		errors.NewKV("Header", name),
+	)
+}
+
 func NewErrFailedToLoadKeys(inner error, publicKeyPath, privateKeyPath string) error {
 	return errors.Wrap(
 		errFailedToLoadKeys,
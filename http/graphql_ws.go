@@ -0,0 +1,395 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sourcenetwork/graphql-go/language/ast"
+	"github.com/sourcenetwork/graphql-go/language/parser"
+	"github.com/sourcenetwork/immutable"
+
+	acpIdentity "github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// graphQLWSProtocol is the name of the websocket sub-protocol used to transport GraphQL requests.
+//
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const graphQLWSProtocol = "graphql-transport-ws"
+
+// graphql-transport-ws message types.
+const (
+	graphQLWSConnectionInit = "connection_init"
+	graphQLWSConnectionAck  = "connection_ack"
+	graphQLWSPing           = "ping"
+	graphQLWSPong           = "pong"
+	graphQLWSSubscribe      = "subscribe"
+	graphQLWSNext           = "next"
+	graphQLWSError          = "error"
+	graphQLWSComplete       = "complete"
+)
+
+// graphql-transport-ws close codes.
+const (
+	graphQLWSCloseBadRequest         = 4400
+	graphQLWSCloseUnauthorized       = 4401
+	graphQLWSCloseForbidden          = 4403
+	graphQLWSCloseInitTimeout        = 4408
+	graphQLWSCloseSubscriberExists   = 4409
+	graphQLWSCloseTooManyInitRequest = 4429
+)
+
+const (
+	// graphQLWSInitTimeout is the time a client has to initialise the connection after it has been opened.
+	graphQLWSInitTimeout = 10 * time.Second
+	// graphQLWSWriteTimeout is the time allowed to write a single message to the connection.
+	graphQLWSWriteTimeout = 10 * time.Second
+)
+
+// graphQLWSMessage is a message sent in either direction of a graphql-transport-ws connection.
+type graphQLWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// graphQLWSInitPayload is the payload of a connection_init message.
+//
+// The authorization value must contain a bearer token for the ACP identity
+// of the connection, either with or without the bearer prefix.
+type graphQLWSInitPayload struct {
+	Authorization string `json:"authorization"`
+}
+
+var graphQLWSUpgrader = websocket.Upgrader{
+	Subprotocols: []string{graphQLWSProtocol},
+	CheckOrigin:  checkGraphQLWSOrigin,
+}
+
+// checkGraphQLWSOrigin returns true if the websocket connection may be opened from the origin
+// of the given request.
+//
+// Browsers do not apply CORS to websocket connections, so connections from other origins
+// than the server are only allowed from the allowed origins of the server.
+func checkGraphQLWSOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		// the request was not made by a browser
+		return true
+	}
+	allowedOrigins, _ := req.Context().Value(allowedOriginsContextKey).([]string)
+	if isAllowedOrigin(allowedOrigins, origin) {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(originURL.Host, req.Host)
+}
+
+// graphQLWSConn is the server side of a graphql-transport-ws connection.
+type graphQLWSConn struct {
+	conn  *websocket.Conn
+	store client.Store
+	host  string
+
+	// writeLock ensures that only a single message is written at a time.
+	writeLock sync.Mutex
+
+	// operations contains the cancel functions of all active operations keyed by id.
+	operations map[string]context.CancelFunc
+	// operationsLock guards operations, as they are removed once completed.
+	operationsLock sync.Mutex
+}
+
+// ExecRequestWebSocket upgrades the request to a websocket connection speaking the
+// graphql-transport-ws protocol.
+//
+// Any number of queries, mutations and subscriptions may be executed over the connection.
+func (s *storeHandler) ExecRequestWebSocket(rw http.ResponseWriter, req *http.Request) {
+	store := mustGetContextClientStore(req)
+
+	conn, err := graphQLWSUpgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		// the upgrader has already responded to the client
+		return
+	}
+	if conn.Subprotocol() != graphQLWSProtocol {
+		_ = conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseProtocolError, "unsupported sub-protocol"),
+			time.Now().Add(graphQLWSWriteTimeout),
+		)
+		_ = conn.Close()
+		return
+	}
+
+	wsConn := &graphQLWSConn{
+		conn:       conn,
+		store:      store,
+		host:       strings.ToLower(req.Host),
+		operations: make(map[string]context.CancelFunc),
+	}
+	wsConn.serve(req.Context())
+}
+
+// serve reads and handles messages until the connection is closed.
+func (c *graphQLWSConn) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		// stop all active operations
+		cancel()
+		_ = c.conn.Close()
+	}()
+
+	initTimer := time.AfterFunc(graphQLWSInitTimeout, func() {
+		c.close(graphQLWSCloseInitTimeout, "Connection initialisation timeout")
+	})
+	defer initTimer.Stop()
+
+	initialised := false
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			// the connection has been closed
+			return
+		}
+		var msg graphQLWSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.close(graphQLWSCloseBadRequest, "Invalid message received")
+			return
+		}
+
+		switch msg.Type {
+		case graphQLWSConnectionInit:
+			if initialised {
+				c.close(graphQLWSCloseTooManyInitRequest, "Too many initialisation requests")
+				return
+			}
+			initCtx, err := c.init(ctx, msg.Payload)
+			if err != nil {
+				c.close(graphQLWSCloseForbidden, "Forbidden")
+				return
+			}
+			initTimer.Stop()
+			ctx = initCtx
+			initialised = true
+			if err := c.write(graphQLWSMessage{Type: graphQLWSConnectionAck}); err != nil {
+				return
+			}
+
+		case graphQLWSPing:
+			if err := c.write(graphQLWSMessage{Type: graphQLWSPong}); err != nil {
+				return
+			}
+
+		case graphQLWSPong:
+			// nothing to do
+
+		case graphQLWSSubscribe:
+			if !initialised {
+				c.close(graphQLWSCloseUnauthorized, "Unauthorized")
+				return
+			}
+			var request GraphQLRequest
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &request) != nil {
+				c.close(graphQLWSCloseBadRequest, "Invalid message received")
+				return
+			}
+			opCtx, ok := c.startOperation(ctx, msg.ID)
+			if !ok {
+				c.close(graphQLWSCloseSubscriberExists, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+			if isSubscription(request.Query, request.OperationName) {
+				// Subscriptions are registered before the next message is read, only their results
+				// are sent asynchronously.  This ensures that a subscription is active before any
+				// subsequent request is executed.
+				result := c.execRequest(opCtx, request)
+				go c.sendResults(opCtx, msg.ID, result)
+				continue
+			}
+			// Queries and mutations are executed asynchronously, so that long running requests
+			// do not prevent other messages, such as their completion, from being handled.
+			go func() {
+				result := c.execRequest(opCtx, request)
+				c.sendResults(opCtx, msg.ID, result)
+			}()
+
+		case graphQLWSComplete:
+			c.stopOperation(msg.ID)
+
+		default:
+			c.close(graphQLWSCloseBadRequest, "Invalid message received")
+			return
+		}
+	}
+}
+
+// init authenticates the connection using the given connection_init payload and returns
+// the context that all operations should be executed with.
+//
+// If the payload does not contain an authorization value the identity of the upgrade
+// request, if any, is kept.
+func (c *graphQLWSConn) init(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+	var initPayload graphQLWSInitPayload
+	if len(payload) > 0 && string(payload) != "null" {
+		if err := json.Unmarshal(payload, &initPayload); err != nil {
+			return nil, err
+		}
+	}
+	token := strings.TrimPrefix(initPayload.Authorization, authSchemaPrefix)
+	if token == "" {
+		return ctx, nil
+	}
+
+	ident, err := acpIdentity.FromToken([]byte(token))
+	if err != nil {
+		return nil, err
+	}
+	err = verifyAuthToken(ident, c.host)
+	if err != nil {
+		return nil, err
+	}
+	return acpIdentity.WithContext(ctx, immutable.Some(ident)), nil
+}
+
+// isSubscription returns true if the operation executed by the given request is a subscription.
+//
+// If the request cannot be parsed false is returned, and the error is reported when it is executed.
+func isSubscription(query string, operationName string) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return false
+	}
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (operation.Name == nil || operation.Name.Value != operationName) {
+			continue
+		}
+		return operation.Operation == ast.OperationTypeSubscription
+	}
+	return false
+}
+
+// execRequest executes the given request.
+func (c *graphQLWSConn) execRequest(ctx context.Context, request GraphQLRequest) *client.RequestResult {
+	var options []client.RequestOption
+	if request.OperationName != "" {
+		options = append(options, client.WithOperationName(request.OperationName))
+	}
+	if len(request.Variables) > 0 {
+		options = append(options, client.WithVariables(request.Variables))
+	}
+	return c.store.ExecRequest(ctx, request.Query, options...)
+}
+
+// sendResults sends the results of the operation with the given id to the client.
+func (c *graphQLWSConn) sendResults(ctx context.Context, id string, result *client.RequestResult) {
+	defer c.stopOperation(id)
+
+	if result.Subscription == nil {
+		if ctx.Err() != nil {
+			// the operation was completed by the client, or the connection was closed
+			return
+		}
+		if err := c.writeResult(id, result.GQL); err != nil {
+			return
+		}
+		_ = c.write(graphQLWSMessage{ID: id, Type: graphQLWSComplete})
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// the operation was completed by the client, or the connection was closed
+			return
+		case item, open := <-result.Subscription:
+			if !open {
+				_ = c.write(graphQLWSMessage{ID: id, Type: graphQLWSComplete})
+				return
+			}
+			if err := c.writeResult(id, item); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// startOperation registers a new operation with the given id, it returns false if an
+// operation with the same id is already active.
+func (c *graphQLWSConn) startOperation(ctx context.Context, id string) (context.Context, bool) {
+	c.operationsLock.Lock()
+	defer c.operationsLock.Unlock()
+
+	if _, exists := c.operations[id]; exists {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.operations[id] = cancel
+	return ctx, true
+}
+
+// stopOperation cancels and removes the operation with the given id, if it exists.
+func (c *graphQLWSConn) stopOperation(id string) {
+	c.operationsLock.Lock()
+	defer c.operationsLock.Unlock()
+
+	if cancel, exists := c.operations[id]; exists {
+		cancel()
+		delete(c.operations, id)
+	}
+}
+
+func (c *graphQLWSConn) writeResult(id string, result client.GQLResult) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return c.write(graphQLWSMessage{ID: id, Type: graphQLWSNext, Payload: payload})
+}
+
+func (c *graphQLWSConn) write(msg graphQLWSMessage) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	err := c.conn.SetWriteDeadline(time.Now().Add(graphQLWSWriteTimeout))
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteJSON(msg)
+}
+
+// close closes the connection with the given graphql-transport-ws close code.
+func (c *graphQLWSConn) close(code int, reason string) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(graphQLWSWriteTimeout),
+	)
+	_ = c.conn.Close()
+}
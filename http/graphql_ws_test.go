@@ -0,0 +1,310 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func setupGraphQLWebSocketServer(t *testing.T) (client.DB, *httptest.Server) {
+	cdb := setupDatabase(t)
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return cdb, srv
+}
+
+func dialRawGraphQLWebSocket(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLWSProtocol}}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v0/graphql/ws"
+
+	conn, res, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestGraphQLWebSocket_WithQuery(t *testing.T) {
+	ctx := context.Background()
+	_, srv := setupGraphQLWebSocketServer(t)
+
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	ws, err := c.DialGraphQLWebSocket(ctx)
+	require.NoError(t, err)
+	defer ws.Close() //nolint:errcheck
+
+	result := ws.ExecRequest(ctx, `query {
+		User {
+			name
+		}
+	}`)
+	require.Nil(t, result.Subscription)
+	require.Len(t, result.GQL.Errors, 0)
+
+	data, err := json.Marshal(result.GQL.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"User": [{"name": "bob"}]}`, string(data))
+}
+
+func TestGraphQLWebSocket_WithInvalidQuery_ReturnsErrors(t *testing.T) {
+	ctx := context.Background()
+	_, srv := setupGraphQLWebSocketServer(t)
+
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	ws, err := c.DialGraphQLWebSocket(ctx)
+	require.NoError(t, err)
+	defer ws.Close() //nolint:errcheck
+
+	result := ws.ExecRequest(ctx, `query {
+		User {
+			invalid
+		}
+	}`)
+	require.Len(t, result.GQL.Errors, 1)
+	assert.Contains(t, result.GQL.Errors[0].Error(), "Cannot query field \"invalid\"")
+}
+
+func TestGraphQLWebSocket_WithMultipleSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	cdb, srv := setupGraphQLWebSocketServer(t)
+
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	ws, err := c.DialGraphQLWebSocket(ctx)
+	require.NoError(t, err)
+	defer ws.Close() //nolint:errcheck
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	first := ws.ExecRequest(subCtx, `subscription {
+		User {
+			name
+		}
+	}`)
+	require.NotNil(t, first.Subscription)
+
+	second := ws.ExecRequest(subCtx, `subscription {
+		User(filter: {name: {_eq: "alice"}}) {
+			_kind
+		}
+	}`)
+	require.NotNil(t, second.Subscription)
+
+	// ensure both subscriptions are active before any documents are created
+	query := ws.ExecRequest(ctx, `query { User { name } }`)
+	require.Len(t, query.GQL.Errors, 0)
+
+	col, err := cdb.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "alice"}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	firstResult := <-first.Subscription
+	require.Len(t, firstResult.Errors, 0)
+	data, err := json.Marshal(firstResult.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"User": [{"name": "alice"}]}`, string(data))
+
+	secondResult := <-second.Subscription
+	require.Len(t, secondResult.Errors, 0)
+	data, err = json.Marshal(secondResult.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"User": [{"_kind": "CREATE"}]}`, string(data))
+
+	cancel()
+	_, open := <-first.Subscription
+	assert.False(t, open)
+	_, open = <-second.Subscription
+	assert.False(t, open)
+}
+
+func TestGraphQLWebSocket_SubscribeBeforeInit_Closes(t *testing.T) {
+	_, srv := setupGraphQLWebSocketServer(t)
+	conn := dialRawGraphQLWebSocket(t, srv)
+
+	err := conn.WriteJSON(graphQLWSMessage{
+		ID:      "1",
+		Type:    graphQLWSSubscribe,
+		Payload: json.RawMessage(`{"query": "query { User { name } }"}`),
+	})
+	require.NoError(t, err)
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, graphQLWSCloseUnauthorized))
+}
+
+func TestGraphQLWebSocket_WithInvalidAuthorization_Closes(t *testing.T) {
+	_, srv := setupGraphQLWebSocketServer(t)
+	conn := dialRawGraphQLWebSocket(t, srv)
+
+	err := conn.WriteJSON(graphQLWSMessage{
+		Type:    graphQLWSConnectionInit,
+		Payload: json.RawMessage(`{"authorization": "Bearer invalid"}`),
+	})
+	require.NoError(t, err)
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, graphQLWSCloseForbidden))
+}
+
+func TestGraphQLWebSocket_WithDuplicateInit_Closes(t *testing.T) {
+	_, srv := setupGraphQLWebSocketServer(t)
+	conn := dialRawGraphQLWebSocket(t, srv)
+
+	err := conn.WriteJSON(graphQLWSMessage{Type: graphQLWSConnectionInit})
+	require.NoError(t, err)
+
+	var ack graphQLWSMessage
+	err = conn.ReadJSON(&ack)
+	require.NoError(t, err)
+	require.Equal(t, graphQLWSConnectionAck, ack.Type)
+
+	err = conn.WriteJSON(graphQLWSMessage{Type: graphQLWSConnectionInit})
+	require.NoError(t, err)
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, graphQLWSCloseTooManyInitRequest))
+}
+
+func TestGraphQLWebSocket_WithPing_RespondsWithPong(t *testing.T) {
+	_, srv := setupGraphQLWebSocketServer(t)
+	conn := dialRawGraphQLWebSocket(t, srv)
+
+	err := conn.WriteJSON(graphQLWSMessage{Type: graphQLWSPing})
+	require.NoError(t, err)
+
+	var pong graphQLWSMessage
+	err = conn.ReadJSON(&pong)
+	require.NoError(t, err)
+	assert.Equal(t, graphQLWSPong, pong.Type)
+}
+
+func TestGraphQLWebSocket_WithOrigin_ChecksAllowedOrigins(t *testing.T) {
+	cdb := setupDatabase(t)
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(CorsMiddleware([]string{"https://allowed.example.com"})(handler))
+	t.Cleanup(srv.Close)
+
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLWSProtocol}}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v0/graphql/ws"
+
+	for origin, allowed := range map[string]bool{
+		"https://allowed.example.com": true,
+		srv.URL:                       true,
+		"https://other.example.com":   false,
+	} {
+		conn, res, err := dialer.Dial(url, http.Header{"Origin": []string{origin}})
+		if allowed {
+			require.NoError(t, err, origin)
+			require.NoError(t, conn.Close())
+		} else {
+			require.ErrorIs(t, err, websocket.ErrBadHandshake, origin)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		}
+		require.NoError(t, res.Body.Close())
+	}
+}
+
+// blockingRequestStore is a store whose requests run until they are canceled.
+type blockingRequestStore struct {
+	client.Store
+	canceled chan struct{}
+}
+
+func (s *blockingRequestStore) ExecRequest(
+	ctx context.Context,
+	request string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	<-ctx.Done()
+	close(s.canceled)
+	return &client.RequestResult{}
+}
+
+func TestGraphQLWebSocket_WithRunningQuery_HandlesMessages(t *testing.T) {
+	store := &blockingRequestStore{canceled: make(chan struct{})}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := graphQLWSUpgrader.Upgrade(rw, req, nil)
+		require.NoError(t, err)
+		wsConn := &graphQLWSConn{
+			conn:       conn,
+			store:      store,
+			operations: make(map[string]context.CancelFunc),
+		}
+		wsConn.serve(context.Background())
+	}))
+	t.Cleanup(srv.Close)
+
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLWSProtocol}}
+	conn, res, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	t.Cleanup(func() { _ = conn.Close() })
+	err = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	require.NoError(t, err)
+
+	err = conn.WriteJSON(graphQLWSMessage{Type: graphQLWSConnectionInit})
+	require.NoError(t, err)
+	var ack graphQLWSMessage
+	err = conn.ReadJSON(&ack)
+	require.NoError(t, err)
+	require.Equal(t, graphQLWSConnectionAck, ack.Type)
+
+	payload, err := json.Marshal(GraphQLRequest{Query: `query { User { name } }`})
+	require.NoError(t, err)
+	err = conn.WriteJSON(graphQLWSMessage{ID: "1", Type: graphQLWSSubscribe, Payload: payload})
+	require.NoError(t, err)
+
+	// the connection keeps handling messages while the query is running
+	err = conn.WriteJSON(graphQLWSMessage{Type: graphQLWSPing})
+	require.NoError(t, err)
+	var pong graphQLWSMessage
+	err = conn.ReadJSON(&pong)
+	require.NoError(t, err)
+	assert.Equal(t, graphQLWSPong, pong.Type)
+
+	err = conn.WriteJSON(graphQLWSMessage{ID: "1", Type: graphQLWSComplete})
+	require.NoError(t, err)
+	select {
+	case <-store.canceled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the query to be canceled")
+	}
+}
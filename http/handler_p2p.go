@@ -139,6 +139,81 @@ func (s *p2pHandler) GetAllP2PCollections(rw http.ResponseWriter, req *http.Requ
 	responseJSON(rw, http.StatusOK, cols)
 }
 
+func (s *p2pHandler) ReconcileWithPeer(rw http.ResponseWriter, req *http.Request) {
+	p2p, ok := tryGetContextClientP2P(req)
+	if !ok {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrP2PDisabled})
+		return
+	}
+
+	var params client.ReconcileParams
+	if err := requestJSON(req, &params); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	res, err := p2p.ReconcileWithPeer(req.Context(), params)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, res)
+}
+
+func (s *p2pHandler) SetPeerAccess(rw http.ResponseWriter, req *http.Request) {
+	p2p, ok := tryGetContextClientP2P(req)
+	if !ok {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrP2PDisabled})
+		return
+	}
+
+	var params client.PeerAccessParams
+	if err := requestJSON(req, &params); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	err := p2p.SetPeerAccess(req.Context(), params)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (s *p2pHandler) DeletePeerAccess(rw http.ResponseWriter, req *http.Request) {
+	p2p, ok := tryGetContextClientP2P(req)
+	if !ok {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrP2PDisabled})
+		return
+	}
+
+	var peerIDs []string
+	if err := requestJSON(req, &peerIDs); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	err := p2p.DeletePeerAccess(req.Context(), peerIDs)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (s *p2pHandler) GetAllPeerAccess(rw http.ResponseWriter, req *http.Request) {
+	p2p, ok := tryGetContextClientP2P(req)
+	if !ok {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrP2PDisabled})
+		return
+	}
+
+	access, err := p2p.GetAllPeerAccess(req.Context())
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, access)
+}
+
 func (h *p2pHandler) bindRoutes(router *Router) {
 	successResponse := &openapi3.ResponseRef{
 		Ref: "#/components/responses/success",
@@ -155,6 +230,18 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	replicatorParamsSchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/replicator_params",
 	}
+	reconcileParamsSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/reconcile_params",
+	}
+	reconcileResultSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/reconcile_result",
+	}
+	peerAccessSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/peer_access",
+	}
+	peerAccessParamsSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/peer_access_params",
+	}
 
 	peerInfoResponse := openapi3.NewResponse().
 		WithDescription("Peer network info").
@@ -245,6 +332,70 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	removePeerCollections.Responses.Set("200", successResponse)
 	removePeerCollections.Responses.Set("400", errorResponse)
 
+	reconcileRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(reconcileParamsSchema))
+
+	reconcileResponse := openapi3.NewResponse().
+		WithDescription("Reconcile result").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(reconcileResultSchema))
+
+	reconcile := openapi3.NewOperation()
+	reconcile.Description = "Reconcile peer collections with a peer"
+	reconcile.OperationID = "peer_reconcile"
+	reconcile.Tags = []string{"p2p"}
+	reconcile.RequestBody = &openapi3.RequestBodyRef{
+		Value: reconcileRequest,
+	}
+	reconcile.AddResponse(200, reconcileResponse)
+	reconcile.Responses.Set("400", errorResponse)
+
+	getPeerAccessSchema := openapi3.NewArraySchema()
+	getPeerAccessSchema.Items = peerAccessSchema
+	getPeerAccessResponse := openapi3.NewResponse().
+		WithDescription("Peer access").
+		WithContent(openapi3.NewContentWithJSONSchema(getPeerAccessSchema))
+
+	getPeerAccess := openapi3.NewOperation()
+	getPeerAccess.Description = "List the access of peers"
+	getPeerAccess.OperationID = "peer_access_list"
+	getPeerAccess.Tags = []string{"p2p"}
+	getPeerAccess.AddResponse(200, getPeerAccessResponse)
+	getPeerAccess.Responses.Set("400", errorResponse)
+
+	setPeerAccessRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(peerAccessParamsSchema))
+
+	setPeerAccess := openapi3.NewOperation()
+	setPeerAccess.Description = "Allow or deny peers pushing blocks"
+	setPeerAccess.OperationID = "peer_access_set"
+	setPeerAccess.Tags = []string{"p2p"}
+	setPeerAccess.RequestBody = &openapi3.RequestBodyRef{
+		Value: setPeerAccessRequest,
+	}
+	setPeerAccess.Responses = openapi3.NewResponses()
+	setPeerAccess.Responses.Set("200", successResponse)
+	setPeerAccess.Responses.Set("400", errorResponse)
+
+	peerIDsSchema := openapi3.NewArraySchema().
+		WithItems(openapi3.NewStringSchema())
+
+	deletePeerAccessRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithJSONSchema(peerIDsSchema))
+
+	deletePeerAccess := openapi3.NewOperation()
+	deletePeerAccess.Description = "Delete the access of peers"
+	deletePeerAccess.OperationID = "peer_access_delete"
+	deletePeerAccess.Tags = []string{"p2p"}
+	deletePeerAccess.RequestBody = &openapi3.RequestBodyRef{
+		Value: deletePeerAccessRequest,
+	}
+	deletePeerAccess.Responses = openapi3.NewResponses()
+	deletePeerAccess.Responses.Set("200", successResponse)
+	deletePeerAccess.Responses.Set("400", errorResponse)
+
 	router.AddRoute("/p2p/info", http.MethodGet, peerInfo, h.PeerInfo)
 	router.AddRoute("/p2p/replicators", http.MethodGet, getReplicators, h.GetAllReplicators)
 	router.AddRoute("/p2p/replicators", http.MethodPost, setReplicator, h.SetReplicator)
@@ -252,4 +403,8 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	router.AddRoute("/p2p/collections", http.MethodGet, getPeerCollections, h.GetAllP2PCollections)
 	router.AddRoute("/p2p/collections", http.MethodPost, addPeerCollections, h.AddP2PCollection)
 	router.AddRoute("/p2p/collections", http.MethodDelete, removePeerCollections, h.RemoveP2PCollection)
+	router.AddRoute("/p2p/reconcile", http.MethodPost, reconcile, h.ReconcileWithPeer)
+	router.AddRoute("/p2p/access", http.MethodGet, getPeerAccess, h.GetAllPeerAccess)
+	router.AddRoute("/p2p/access", http.MethodPost, setPeerAccess, h.SetPeerAccess)
+	router.AddRoute("/p2p/access", http.MethodDelete, deletePeerAccess, h.DeletePeerAccess)
 }
@@ -16,8 +16,10 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -262,6 +264,17 @@ func (s *storeHandler) GetAllIndexes(rw http.ResponseWriter, req *http.Request)
 	responseJSON(rw, http.StatusOK, indexes)
 }
 
+func (s *storeHandler) GetCollectionStatistics(rw http.ResponseWriter, req *http.Request) {
+	store := mustGetContextClientStore(req)
+
+	stats, err := store.GetCollectionStatistics(req.Context(), req.URL.Query().Get("name"))
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, stats)
+}
+
 func (s *storeHandler) PrintDump(rw http.ResponseWriter, req *http.Request) {
 	db := mustGetContextClientDB(req)
 
@@ -295,15 +308,63 @@ func (s *storeHandler) ExecRequest(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	var options []client.RequestOption
-	if request.OperationName != "" {
-		options = append(options, client.WithOperationName(request.OperationName))
-	}
-	if len(request.Variables) > 0 {
-		options = append(options, client.WithVariables(request.Variables))
+	options, err := requestOptions(req, request)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
 	}
 	result := store.ExecRequest(req.Context(), request.Query, options...)
+	writeRequestResult(rw, req, result)
+}
+
+// PreparedRequest is the response of a request prepared with PrepareRequest.
+type PreparedRequest struct {
+	ID string `json:"id"`
+}
+
+func (s *storeHandler) PrepareRequest(rw http.ResponseWriter, req *http.Request) {
+	store := mustGetContextClientStore(req)
+
+	var request GraphQLRequest
+	if err := requestJSON(req, &request); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	if request.Query == "" {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrMissingRequest})
+		return
+	}
+	id, err := store.PrepareRequest(req.Context(), request.Query)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, PreparedRequest{ID: id})
+}
+
+func (s *storeHandler) ExecPreparedRequest(rw http.ResponseWriter, req *http.Request) {
+	store := mustGetContextClientStore(req)
+
+	var request GraphQLRequest
+	if req.ContentLength != 0 {
+		if err := requestJSON(req, &request); err != nil {
+			responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+			return
+		}
+	}
 
+	options, err := requestOptions(req, request)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	result := store.ExecPreparedRequest(req.Context(), chi.URLParam(req, "id"), options...)
+	writeRequestResult(rw, req, result)
+}
+
+// writeRequestResult writes the given request result to the response, streaming
+// the results of subscriptions until the request is done.
+func writeRequestResult(rw http.ResponseWriter, req *http.Request, result *client.RequestResult) {
 	if result.Subscription == nil {
 		responseJSON(rw, http.StatusOK, result.GQL)
 		return
@@ -342,6 +403,33 @@ func (s *storeHandler) ExecRequest(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// requestOptions returns the request options set by the given GraphQL request,
+// and by the limit headers of the given HTTP request.
+func requestOptions(req *http.Request, request GraphQLRequest) ([]client.RequestOption, error) {
+	var options []client.RequestOption
+	if request.OperationName != "" {
+		options = append(options, client.WithOperationName(request.OperationName))
+	}
+	if len(request.Variables) > 0 {
+		options = append(options, client.WithVariables(request.Variables))
+	}
+	if value := req.Header.Get(maxDurationHeaderName); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, NewErrInvalidHeader(err, maxDurationHeaderName)
+		}
+		options = append(options, client.WithMaxDuration(duration))
+	}
+	if value := req.Header.Get(maxScannedDocsHeaderName); value != "" {
+		count, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, NewErrInvalidHeader(err, maxScannedDocsHeaderName)
+		}
+		options = append(options, client.WithMaxScannedDocs(count))
+	}
+	return options, nil
+}
+
 func (s *storeHandler) GetNodeIdentity(rw http.ResponseWriter, req *http.Request) {
 	db := mustGetContextClientDB(req)
 
@@ -387,6 +475,9 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	identitySchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/identity",
 	}
+	collectionStatisticsSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/collection_statistics",
+	}
 
 	graphQLResponseSchema := openapi3.NewObjectSchema().
 		WithProperties(map[string]*openapi3.Schema{
@@ -452,7 +543,7 @@ func (h *storeHandler) bindRoutes(router *Router) {
 
 	backupExport := openapi3.NewOperation()
 	backupExport.OperationID = "backup_export"
-	backupExport.Description = "Export a database backup to file"
+	backupExport.Description = "Export a database backup to file in json, ndjson, cbor or car format"
 	backupExport.Tags = []string{"backup"}
 	backupExport.Responses = openapi3.NewResponses()
 	backupExport.Responses.Set("200", successResponse)
@@ -463,7 +554,7 @@ func (h *storeHandler) bindRoutes(router *Router) {
 
 	backupImport := openapi3.NewOperation()
 	backupImport.OperationID = "backup_import"
-	backupImport.Description = "Import a database backup from file"
+	backupImport.Description = "Import a database backup from a json, ndjson, cbor or car file"
 	backupImport.Tags = []string{"backup"}
 	backupImport.Responses = openapi3.NewResponses()
 	backupImport.Responses.Set("200", successResponse)
@@ -614,6 +705,14 @@ func (h *storeHandler) bindRoutes(router *Router) {
 		WithDescription("GraphQL response").
 		WithContent(openapi3.NewContentWithJSONSchema(graphQLResponseSchema))
 
+	graphQLMaxDurationHeaderParam := openapi3.NewHeaderParameter(maxDurationHeaderName).
+		WithDescription("Maximum duration of the request, such as 1m30s").
+		WithSchema(openapi3.NewStringSchema())
+
+	graphQLMaxScannedDocsHeaderParam := openapi3.NewHeaderParameter(maxScannedDocsHeaderName).
+		WithDescription("Maximum number of documents the request may scan").
+		WithSchema(openapi3.NewInt64Schema())
+
 	graphQLPost := openapi3.NewOperation()
 	graphQLPost.Description = "GraphQL POST endpoint"
 	graphQLPost.OperationID = "graphql_post"
@@ -621,6 +720,8 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	graphQLPost.RequestBody = &openapi3.RequestBodyRef{
 		Value: graphQLRequest,
 	}
+	graphQLPost.AddParameter(graphQLMaxDurationHeaderParam)
+	graphQLPost.AddParameter(graphQLMaxScannedDocsHeaderParam)
 	graphQLPost.AddResponse(200, graphQLResponse)
 	graphQLPost.Responses.Set("400", errorResponse)
 
@@ -632,9 +733,55 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	graphQLGet.OperationID = "graphql_get"
 	graphQLGet.Tags = []string{"graphql"}
 	graphQLGet.AddParameter(graphQLQueryParam)
+	graphQLGet.AddParameter(graphQLMaxDurationHeaderParam)
+	graphQLGet.AddParameter(graphQLMaxScannedDocsHeaderParam)
 	graphQLGet.AddResponse(200, graphQLResponse)
 	graphQLGet.Responses.Set("400", errorResponse)
 
+	preparedRequestSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/prepared_request",
+	}
+
+	graphQLPrepare := openapi3.NewOperation()
+	graphQLPrepare.Description = "Parse and validate a GraphQL request, so that it can be executed many times"
+	graphQLPrepare.OperationID = "graphql_prepare"
+	graphQLPrepare.Tags = []string{"graphql"}
+	graphQLPrepare.RequestBody = &openapi3.RequestBodyRef{
+		Value: graphQLRequest,
+	}
+	graphQLPrepare.AddResponse(200, openapi3.NewResponse().
+		WithDescription("Prepared request").
+		WithJSONSchemaRef(preparedRequestSchema))
+	graphQLPrepare.Responses.Set("400", errorResponse)
+
+	preparedRequestIDPathParam := openapi3.NewPathParameter("id").
+		WithDescription("Prepared request id").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	graphQLExecPrepared := openapi3.NewOperation()
+	graphQLExecPrepared.Description = "Execute a prepared GraphQL request"
+	graphQLExecPrepared.OperationID = "graphql_exec_prepared"
+	graphQLExecPrepared.Tags = []string{"graphql"}
+	graphQLExecPrepared.RequestBody = &openapi3.RequestBodyRef{
+		Value: graphQLRequest,
+	}
+	graphQLExecPrepared.AddParameter(preparedRequestIDPathParam)
+	graphQLExecPrepared.AddParameter(graphQLMaxDurationHeaderParam)
+	graphQLExecPrepared.AddParameter(graphQLMaxScannedDocsHeaderParam)
+	graphQLExecPrepared.AddResponse(200, graphQLResponse)
+	graphQLExecPrepared.Responses.Set("400", errorResponse)
+
+	graphQLWebSocket := openapi3.NewOperation()
+	graphQLWebSocket.Description = "GraphQL websocket endpoint using the graphql-transport-ws protocol"
+	graphQLWebSocket.OperationID = "graphql_ws"
+	graphQLWebSocket.Tags = []string{"graphql"}
+	graphQLWebSocket.Responses = openapi3.NewResponses()
+	graphQLWebSocket.Responses.Set("101", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Switching protocols"),
+	})
+	graphQLWebSocket.Responses.Set("400", errorResponse)
+
 	debugDump := openapi3.NewOperation()
 	debugDump.Description = "Dump database"
 	debugDump.OperationID = "debug_dump"
@@ -643,6 +790,23 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	debugDump.Responses.Set("200", successResponse)
 	debugDump.Responses.Set("400", errorResponse)
 
+	statisticsNameQueryParam := openapi3.NewQueryParameter("name").
+		WithDescription("Collection name").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	statisticsResponse := openapi3.NewResponse().
+		WithDescription("Collection statistics").
+		WithJSONSchemaRef(collectionStatisticsSchema)
+
+	collectionStatistics := openapi3.NewOperation()
+	collectionStatistics.OperationID = "collection_statistics"
+	collectionStatistics.Description = "Get the estimated statistics of a collection and its indexes"
+	collectionStatistics.Tags = []string{"collection"}
+	collectionStatistics.AddParameter(statisticsNameQueryParam)
+	collectionStatistics.AddResponse(200, statisticsResponse)
+	collectionStatistics.Responses.Set("400", errorResponse)
+
 	identityResponse := openapi3.NewResponse().
 		WithDescription("Identity").
 		WithJSONSchemaRef(identitySchema)
@@ -658,10 +822,14 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	router.AddRoute("/backup/import", http.MethodPost, backupImport, h.BasicImport)
 	router.AddRoute("/collections", http.MethodGet, collectionDescribe, h.GetCollection)
 	router.AddRoute("/collections", http.MethodPatch, patchCollection, h.PatchCollection)
+	router.AddRoute("/statistics", http.MethodGet, collectionStatistics, h.GetCollectionStatistics)
 	router.AddRoute("/view", http.MethodPost, views, h.AddView)
 	router.AddRoute("/view/refresh", http.MethodPost, viewRefresh, h.RefreshViews)
 	router.AddRoute("/graphql", http.MethodGet, graphQLGet, h.ExecRequest)
 	router.AddRoute("/graphql", http.MethodPost, graphQLPost, h.ExecRequest)
+	router.AddRoute("/graphql/ws", http.MethodGet, graphQLWebSocket, h.ExecRequestWebSocket)
+	router.AddRoute("/graphql/prepare", http.MethodPost, graphQLPrepare, h.PrepareRequest)
+	router.AddRoute("/graphql/prepared/{id}", http.MethodPost, graphQLExecPrepared, h.ExecPreparedRequest)
 	router.AddRoute("/debug/dump", http.MethodGet, debugDump, h.PrintDump)
 	router.AddRoute("/schema", http.MethodPost, addSchema, h.AddSchema)
 	router.AddRoute("/schema", http.MethodPatch, patchSchema, h.PatchSchema)
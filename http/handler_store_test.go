@@ -12,6 +12,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -20,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
 )
 
 func TestExecRequest_WithValidQuery_OmitsErrors(t *testing.T) {
@@ -93,3 +96,145 @@ func TestExecRequest_WithInvalidQuery_HasSpecCompliantErrors(t *testing.T) {
 		"message": "Cannot query field \"invalid\" on type \"User\".",
 	}})
 }
+
+func TestExecRequest_WithInvalidMaxDurationHeader_ReturnsBadRequest(t *testing.T) {
+	cdb := setupDatabase(t)
+
+	body, err := json.Marshal(&GraphQLRequest{
+		Query: `query {
+			User {
+				name
+			}
+		}`,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:9181/api/v0/graphql", bytes.NewBuffer(body))
+	req.Header.Set(maxDurationHeaderName, "invalid")
+	rec := httptest.NewRecorder()
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestExecRequest_WithMaxScannedDocsHeaderExceeded_HasError(t *testing.T) {
+	ctx := context.Background()
+	cdb := setupDatabase(t)
+
+	col, err := cdb.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "alice"}`), col.Definition())
+	require.NoError(t, err)
+
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(&GraphQLRequest{
+		Query: `query {
+			User {
+				name
+			}
+		}`,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:9181/api/v0/graphql", bytes.NewBuffer(body))
+	req.Header.Set(maxScannedDocsHeaderName, "1")
+	rec := httptest.NewRecorder()
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	require.NotNil(t, res.Body)
+
+	resData, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var gqlResponse map[string]any
+	err = json.Unmarshal(resData, &gqlResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{map[string]any{
+		"message": "request exceeded its maximum number of scanned documents. Limit: 1",
+	}}, gqlResponse["errors"])
+}
+
+func TestExecPreparedRequest_WithPreparedQuery_OmitsErrors(t *testing.T) {
+	cdb := setupDatabase(t)
+
+	body, err := json.Marshal(&GraphQLRequest{
+		Query: `query {
+			User {
+				name
+			}
+		}`,
+	})
+	require.NoError(t, err)
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:9181/api/v0/graphql/prepare", bytes.NewBuffer(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var prepared PreparedRequest
+	err = json.NewDecoder(res.Body).Decode(&prepared)
+	require.NoError(t, err)
+	require.NotEmpty(t, prepared.ID)
+
+	req = httptest.NewRequest(http.MethodPost, "http://localhost:9181/api/v0/graphql/prepared/"+prepared.ID, nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	res = rec.Result()
+	require.NotNil(t, res.Body)
+
+	resData, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var gqlResponse map[string]any
+	err = json.Unmarshal(resData, &gqlResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"data": map[string]any{
+			"User": []any{map[string]any{"name": "bob"}},
+		},
+	}, gqlResponse)
+}
+
+func TestExecPreparedRequest_WithUnknownID_HasError(t *testing.T) {
+	cdb := setupDatabase(t)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:9181/api/v0/graphql/prepared/unknown", nil)
+	rec := httptest.NewRecorder()
+
+	handler, err := NewHandler(cdb)
+	require.NoError(t, err)
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	require.NotNil(t, res.Body)
+
+	resData, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var gqlResponse map[string]any
+	err = json.Unmarshal(resData, &gqlResponse)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{map[string]any{
+		"message": "prepared request not found. ID: unknown",
+	}}, gqlResponse["errors"])
+}
@@ -27,18 +27,32 @@ import (
 )
 
 // CorsMiddleware handles cross origin request
+//
+// The allowed origins are also set on the request context, so that the origin of
+// websocket connections, which are not subject to CORS, can be checked.
 func CorsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
-	return cors.Handler(cors.Options{
+	corsHandler := cors.Handler(cors.Options{
 		AllowOriginFunc: func(r *http.Request, origin string) bool {
-			if slices.Contains(allowedOrigins, "*") {
-				return true
-			}
-			return slices.Contains(allowedOrigins, strings.ToLower(origin))
+			return isAllowedOrigin(allowedOrigins, origin)
 		},
 		AllowedMethods: []string{"GET", "HEAD", "POST", "PATCH", "DELETE"},
 		AllowedHeaders: []string{"Content-Type", "Authorization"},
 		MaxAge:         300,
 	})
+	return func(next http.Handler) http.Handler {
+		return corsHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			ctx := context.WithValue(req.Context(), allowedOriginsContextKey, allowedOrigins)
+			next.ServeHTTP(rw, req.WithContext(ctx))
+		}))
+	}
+}
+
+// isAllowedOrigin returns true if the given origin is one of the given allowed origins.
+func isAllowedOrigin(allowedOrigins []string, origin string) bool {
+	if slices.Contains(allowedOrigins, "*") {
+		return true
+	}
+	return slices.Contains(allowedOrigins, strings.ToLower(origin))
 }
 
 // ApiMiddleware sets the required context values for all API requests.
@@ -37,6 +37,10 @@ var openApiSchemas = map[string]any{
 	"lens_config":                     &client.LensConfig{},
 	"replicator":                      &client.Replicator{},
 	"replicator_params":               &client.ReplicatorParams{},
+	"reconcile_params":                &client.ReconcileParams{},
+	"reconcile_result":                &client.ReconcileResult{},
+	"peer_access":                     &client.PeerAccess{},
+	"peer_access_params":              &client.PeerAccessParams{},
 	"ccip_request":                    &CCIPRequest{},
 	"ccip_response":                   &CCIPResponse{},
 	"patch_schema_request":            &patchSchemaRequest{},
@@ -49,6 +53,8 @@ var openApiSchemas = map[string]any{
 	"acp_relationship_delete_request": &deleteDocActorRelationshipRequest{},
 	"acp_relationship_delete_result":  &client.DeleteDocActorRelationshipResult{},
 	"identity":                        &identity.PublicRawIdentity{},
+	"collection_statistics":           &client.CollectionStatistics{},
+	"prepared_request":                &PreparedRequest{},
 }
 
 func NewOpenAPISpec() (*openapi3.T, error) {
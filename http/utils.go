@@ -24,6 +24,12 @@ const (
 	// txHeaderName is the name of the transaction header.
 	// This header should contain a valid transaction id.
 	txHeaderName = "x-defradb-tx"
+	// maxDurationHeaderName is the name of the header setting the maximum duration of a request.
+	// This header should contain a duration string, such as "1m30s".
+	maxDurationHeaderName = "x-defradb-max-duration"
+	// maxScannedDocsHeaderName is the name of the header setting the maximum number of
+	// documents a request may scan.
+	maxScannedDocsHeaderName = "x-defradb-max-scanned-docs"
 )
 
 type contextKey string
@@ -38,6 +44,8 @@ var (
 	// If a transaction exists, all operations will be executed
 	// in the current transaction context.
 	colContextKey = contextKey("col")
+	// allowedOriginsContextKey is the context key for the origins allowed to make cross origin requests
+	allowedOriginsContextKey = contextKey("allowedOrigins")
 )
 
 // mustGetContextClientCollection returns the client collection from the http request context or panics.
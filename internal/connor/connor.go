@@ -30,6 +30,7 @@ const (
 	NotLikeOp                = "_nlike"
 	CaseInsensitiveLikeOp    = "_ilike"
 	CaseInsensitiveNotLikeOp = "_nilike"
+	SearchOp                 = "_search"
 )
 
 // IsOpSimple returns true if the given operator is simple (not compound).
@@ -39,7 +40,8 @@ func IsOpSimple(op string) bool {
 	switch op {
 	case EqualOp, GreaterOrEqualOp, GreaterOp, InOp,
 		LesserOrEqualOp, LesserOp, NotEqualOp, NotInOp,
-		LikeOp, NotLikeOp, CaseInsensitiveLikeOp, CaseInsensitiveNotLikeOp:
+		LikeOp, NotLikeOp, CaseInsensitiveLikeOp, CaseInsensitiveNotLikeOp,
+		SearchOp:
 		return true
 	default:
 		return false
@@ -89,6 +91,8 @@ func matchWith(op string, conditions, data any) (bool, error) {
 		return ilike(conditions, data)
 	case CaseInsensitiveNotLikeOp:
 		return nilike(conditions, data)
+	case SearchOp:
+		return search(conditions, data)
 	case NoneOp:
 		return none(conditions, data)
 	case NotOp:
@@ -0,0 +1,44 @@
+package connor
+
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/utils/text"
+)
+
+// search is an operator which performs full-text keyword matching.
+//
+// The check passes if every token of the condition is present in the data.
+func search(condition, data any) (bool, error) {
+	switch d := data.(type) {
+	case immutable.Option[string]:
+		if !d.HasValue() {
+			return condition == nil, nil
+		}
+		data = d.Value()
+	}
+
+	switch cn := condition.(type) {
+	case string:
+		d, ok := data.(string)
+		if !ok {
+			return false, nil
+		}
+		queryTokens := text.UniqueTokens(cn)
+		if len(queryTokens) == 0 {
+			return false, nil
+		}
+		dataTokens := text.TermFrequencies(d)
+		for _, token := range queryTokens {
+			if _, ok := dataTokens[token]; !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	case nil:
+		return data == nil, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
@@ -0,0 +1,42 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	const testString = "Source Is The Glue of Web3"
+
+	// single keyword, case insensitive
+	result, err := search("glue", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// all keywords must be present regardless of order
+	result, err = search("web3 SOURCE", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// a missing keyword fails the check
+	result, err = search("source glue paste", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// partial words are not matched
+	result, err = search("glu", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// a condition without any keywords matches nothing
+	result, err = search(" ,. ", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// nil values do not match
+	result, err = search("glue", immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+}
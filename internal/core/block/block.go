@@ -40,11 +40,14 @@ func init() {
 		"Block",
 		&Block{},
 		&DAGLink{},
+		&Signature{},
 		&crdt.CRDT{},
 		&crdt.LWWRegDelta{},
 		&crdt.CompositeDAGDelta{},
 		&crdt.CounterDelta{},
 		&crdt.CollectionDelta{},
+		&crdt.SetDelta{},
+		&crdt.TextDelta{},
 	)
 
 	EncryptionSchema, EncryptionSchemaPrototype = mustSetSchema(
@@ -145,6 +148,16 @@ type Block struct {
 	// Encryption contains the encryption information for the block's delta.
 	// It needs to be a pointer so that it can be translated from and to `optional` in the IPLD schema.
 	Encryption *cidlink.Link
+
+	// Timestamp is the time, in nanoseconds since the unix epoch, at which the block was created.
+	//
+	// It is only set if the node that created the block has block timestamps enabled.
+	// It needs to be a pointer so that it can be translated from and to `optional` in the IPLD schema.
+	Timestamp *int64
+
+	// Signature contains the signature of the block and the identity that signed it.
+	// It needs to be a pointer so that it can be translated from and to `optional` in the IPLD schema.
+	Signature *Signature
 }
 
 // IsEncrypted returns true if the block is encrypted.
@@ -159,6 +172,8 @@ func (block *Block) Clone() *Block {
 		Heads:      block.Heads,
 		Links:      block.Links,
 		Encryption: block.Encryption,
+		Timestamp:  block.Timestamp,
+		Signature:  block.Signature,
 	}
 }
 
@@ -187,6 +202,8 @@ func (block *Block) IPLDSchemaBytes() []byte {
 			heads       optional [Link]
 			links       optional [DAGLink]
 			encryption  optional Link
+			timestamp   optional Int
+			signature   optional Signature
 		}
 	`)
 }
@@ -23,6 +23,8 @@ const (
 	errGeneratingLink              string = "failed to generate link"
 	errInvalidBlockEncryptionType  string = "invalid block encryption type"
 	errInvalidBlockEncryptionKeyID string = "invalid block encryption key id"
+	errInvalidSignature            string = "invalid block signature"
+	errUnsupportedSignatureType    string = "unsupported block signature type"
 )
 
 // Errors returnable from this package.
@@ -36,6 +38,8 @@ var (
 	ErrGeneratingLink              = errors.New(errGeneratingLink)
 	ErrInvalidBlockEncryptionType  = errors.New(errInvalidBlockEncryptionType)
 	ErrInvalidBlockEncryptionKeyID = errors.New(errInvalidBlockEncryptionKeyID)
+	ErrInvalidSignature            = errors.New(errInvalidSignature)
+	ErrUnsupportedSignatureType    = errors.New(errUnsupportedSignatureType)
 )
 
 // NewErrFailedToGetPriority returns an error indicating that the priority could not be retrieved.
@@ -70,3 +74,19 @@ func NewErrGeneratingLink(err error) error {
 		err,
 	)
 }
+
+// NewErrInvalidSignature returns an error indicating that the block signature is invalid.
+func NewErrInvalidSignature(err error) error {
+	return errors.Wrap(
+		errInvalidSignature,
+		err,
+	)
+}
+
+// NewErrUnsupportedSignatureType returns an error indicating that the block signature type is not supported.
+func NewErrUnsupportedSignatureType(sigType string) error {
+	return errors.New(
+		errUnsupportedSignatureType,
+		errors.NewKV("Type", sigType),
+	)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package coreblock
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/acp/identity"
+)
+
+// SignatureTypeES256K is the signature type of blocks signed using ECDSA over the secp256k1 curve
+// with a SHA-256 digest.
+const SignatureTypeES256K = "ES256K"
+
+// Signature contains the signature of a block and the identity that produced it.
+type Signature struct {
+	// Type is the type of the signature.
+	Type string
+	// Identity is the compressed public key of the signer.
+	Identity []byte
+	// Value is the DER encoded signature of the block.
+	//
+	// The signature is made over the SHA-256 digest of the block encoded without its signature.
+	Value []byte
+}
+
+// IPLDSchemaBytes returns the IPLD schema representation for the signature.
+//
+// This needs to match the [Signature] struct or [mustSetSchema] will panic on init.
+func (sig *Signature) IPLDSchemaBytes() []byte {
+	return []byte(`
+		type Signature struct {
+			type      String
+			identity  Bytes
+			value     Bytes
+		}
+	`)
+}
+
+// signerContextKey is the key type for block signer context values.
+type signerContextKey struct{}
+
+// WithSigner returns a new context with the identity used to sign new blocks set.
+//
+// The identity must have a private key for blocks to be signed.
+func WithSigner(ctx context.Context, signer immutable.Option[identity.Identity]) context.Context {
+	if signer.HasValue() {
+		return context.WithValue(ctx, signerContextKey{}, signer.Value())
+	}
+	return context.WithValue(ctx, signerContextKey{}, nil)
+}
+
+// SignerFromContext returns the identity used to sign new blocks from the given context.
+//
+// None is returned if the context has no signer or if the signer has no private key.
+func SignerFromContext(ctx context.Context) immutable.Option[identity.Identity] {
+	signer, ok := ctx.Value(signerContextKey{}).(identity.Identity)
+	if !ok || signer.PrivateKey == nil {
+		return identity.None
+	}
+	return immutable.Some(signer)
+}
+
+// Sign signs the block with the given private key, replacing any existing signature.
+func (block *Block) Sign(privateKey *secp256k1.PrivateKey) error {
+	digest, err := block.signatureDigest()
+	if err != nil {
+		return err
+	}
+	block.Signature = &Signature{
+		Type:     SignatureTypeES256K,
+		Identity: privateKey.PubKey().SerializeCompressed(),
+		Value:    ecdsa.Sign(privateKey, digest).Serialize(),
+	}
+	return nil
+}
+
+// VerifySignature returns an error if the block is signed and the signature is invalid.
+//
+// Unsigned blocks are always valid.
+func (block *Block) VerifySignature() error {
+	if block.Signature == nil {
+		return nil
+	}
+	if block.Signature.Type != SignatureTypeES256K {
+		return NewErrUnsupportedSignatureType(block.Signature.Type)
+	}
+	publicKey, err := secp256k1.ParsePubKey(block.Signature.Identity)
+	if err != nil {
+		return NewErrInvalidSignature(err)
+	}
+	signature, err := ecdsa.ParseDERSignature(block.Signature.Value)
+	if err != nil {
+		return NewErrInvalidSignature(err)
+	}
+	digest, err := block.signatureDigest()
+	if err != nil {
+		return err
+	}
+	if !signature.Verify(digest, publicKey) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignerDID returns the DID of the identity that signed the block.
+//
+// None is returned if the block is not signed.
+func (block *Block) SignerDID() (immutable.Option[string], error) {
+	if block.Signature == nil {
+		return immutable.None[string](), nil
+	}
+	publicKey, err := secp256k1.ParsePubKey(block.Signature.Identity)
+	if err != nil {
+		return immutable.None[string](), NewErrInvalidSignature(err)
+	}
+	did, err := identity.DIDFromPublicKey(publicKey)
+	if err != nil {
+		return immutable.None[string](), err
+	}
+	return immutable.Some(did), nil
+}
+
+// signatureDigest returns the digest of the block that is signed.
+func (block *Block) signatureDigest() ([]byte, error) {
+	unsigned := *block
+	unsigned.Signature = nil
+	b, err := unsigned.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(b)
+	return digest[:], nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package coreblock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/internal/core/crdt"
+)
+
+func newTestBlock() *Block {
+	return New(&crdt.LWWRegDelta{
+		DocID:           []byte("docID"),
+		FieldName:       "name",
+		Priority:        1,
+		SchemaVersionID: "schemaVersionID",
+		Data:            []byte("John"),
+	}, nil)
+}
+
+func TestBlockSign_WithValidSignature_NoError(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	err = block.VerifySignature()
+	require.NoError(t, err)
+}
+
+func TestBlockSign_MarshalUnmarshal_ShouldKeepSignature(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	b, err := block.Marshal()
+	require.NoError(t, err)
+
+	newBlock, err := GetFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, block.Signature, newBlock.Signature)
+
+	err = newBlock.VerifySignature()
+	require.NoError(t, err)
+}
+
+func TestBlockVerifySignature_WithTamperedDelta_Error(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	block.Delta.SetData([]byte("Johny"))
+
+	err = block.VerifySignature()
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestBlockVerifySignature_WithOtherIdentity_Error(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherPrivateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	block.Signature.Identity = otherPrivateKey.PubKey().SerializeCompressed()
+
+	err = block.VerifySignature()
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestBlockVerifySignature_WithUnsupportedType_Error(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	block.Signature.Type = "Ed25519"
+
+	err = block.VerifySignature()
+	require.ErrorIs(t, err, ErrUnsupportedSignatureType)
+}
+
+func TestBlockVerifySignature_WithoutSignature_NoError(t *testing.T) {
+	block := newTestBlock()
+
+	err := block.VerifySignature()
+	require.NoError(t, err)
+}
+
+func TestBlockSignerDID_WithSignature_ReturnsIdentityDID(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	ident, err := identity.FromPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	block := newTestBlock()
+	err = block.Sign(privateKey)
+	require.NoError(t, err)
+
+	did, err := block.SignerDID()
+	require.NoError(t, err)
+	require.Equal(t, immutable.Some(ident.DID), did)
+}
+
+func TestBlockSignerDID_WithoutSignature_ReturnsNone(t *testing.T) {
+	block := newTestBlock()
+
+	did, err := block.SignerDID()
+	require.NoError(t, err)
+	require.False(t, did.HasValue())
+}
+
+func TestSignerFromContext_WithoutPrivateKey_ReturnsNone(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	ident, err := identity.FromPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	ctx := WithSigner(context.Background(), immutable.Some(ident))
+	require.True(t, SignerFromContext(ctx).HasValue())
+
+	ident.PrivateKey = nil
+	ctx = WithSigner(context.Background(), immutable.Some(ident))
+	require.False(t, SignerFromContext(ctx).HasValue())
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package coreblock
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+// timestampContextKey is the key type for block timestamp context values.
+type timestampContextKey struct{}
+
+// WithTimestamp returns a new context with the time used to timestamp new blocks set.
+//
+// All the blocks created by a single commit should share the same timestamp.
+func WithTimestamp(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, timestampContextKey{}, t)
+}
+
+// TimestampFromContext returns the time used to timestamp new blocks from the given context.
+//
+// None is returned if the context has no timestamp, in which case new blocks are not timestamped.
+func TimestampFromContext(ctx context.Context) immutable.Option[time.Time] {
+	t, ok := ctx.Value(timestampContextKey{}).(time.Time)
+	if !ok {
+		return immutable.None[time.Time]()
+	}
+	return immutable.Some(t)
+}
+
+// SetTimestamp sets the timestamp of the block to the given time.
+func (block *Block) SetTimestamp(t time.Time) {
+	nanos := t.UnixNano()
+	block.Timestamp = &nanos
+}
+
+// GetTimestamp returns the time at which the block was created, if the block has a timestamp.
+func (block *Block) GetTimestamp() immutable.Option[time.Time] {
+	if block.Timestamp == nil {
+		return immutable.None[time.Time]()
+	}
+	return immutable.Some(time.Unix(0, *block.Timestamp).UTC())
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package coreblock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTimestamp_MarshalUnmarshal_ShouldKeepTimestamp(t *testing.T) {
+	now := time.Now()
+
+	block := newTestBlock()
+	block.SetTimestamp(now)
+
+	b, err := block.Marshal()
+	require.NoError(t, err)
+
+	newBlock, err := GetFromBytes(b)
+	require.NoError(t, err)
+	require.True(t, newBlock.GetTimestamp().HasValue())
+	require.True(t, now.Equal(newBlock.GetTimestamp().Value()))
+}
+
+func TestBlockTimestamp_WithSignature_ShouldBeSigned(t *testing.T) {
+	block := newTestBlock()
+	block.SetTimestamp(time.Now())
+
+	withoutTimestamp := newTestBlock()
+
+	digest, err := block.signatureDigest()
+	require.NoError(t, err)
+	otherDigest, err := withoutTimestamp.signatureDigest()
+	require.NoError(t, err)
+	require.NotEqual(t, digest, otherDigest)
+}
+
+func TestBlockGetTimestamp_WithoutTimestamp_ReturnsNone(t *testing.T) {
+	block := newTestBlock()
+	require.False(t, block.GetTimestamp().HasValue())
+}
+
+func TestTimestampFromContext_WithoutTimestamp_ReturnsNone(t *testing.T) {
+	require.False(t, TimestampFromContext(context.Background()).HasValue())
+}
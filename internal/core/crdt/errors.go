@@ -20,6 +20,8 @@ const (
 	errFailedToStoreValue     string = "failed to store value"
 	errNegativeValue          string = "value cannot be negative"
 	errUnsupportedCounterType string = "unsupported counter type. Valid types are int64 and float64"
+	errCannotRemoveFromGSet   string = "elements cannot be removed from a grow-only set"
+	errTextPositionNotFound   string = "text position not found"
 )
 
 // Errors returnable from this package.
@@ -35,6 +37,8 @@ var (
 	// ErrMismatchedMergeType - Tying to merge two ReplicatedData of different types
 	ErrMismatchedMergeType    = errors.New("given type to merge does not match source")
 	ErrUnsupportedCounterType = errors.New(errUnsupportedCounterType)
+	ErrCannotRemoveFromGSet   = errors.New(errCannotRemoveFromGSet)
+	ErrTextPositionNotFound   = errors.New(errTextPositionNotFound)
 )
 
 // NewErrFailedToGetPriority returns an error indicating that the priority could not be retrieved.
@@ -54,3 +58,16 @@ func NewErrNegativeValue[T Incrementable](value T) error {
 func NewErrUnsupportedCounterType(valueType client.ScalarKind) error {
 	return errors.New(errUnsupportedCounterType, errors.NewKV("Type", valueType))
 }
+
+func NewErrCannotRemoveFromGrowOnlySet(fieldName string) error {
+	return errors.New(errCannotRemoveFromGSet, errors.NewKV("Field", fieldName))
+}
+
+func NewErrTextPositionNotFound(fieldName string, seq uint64, site int64) error {
+	return errors.New(
+		errTextPositionNotFound,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Seq", seq),
+		errors.NewKV("Site", site),
+	)
+}
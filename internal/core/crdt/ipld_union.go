@@ -18,6 +18,8 @@ type CRDT struct {
 	CompositeDAGDelta *CompositeDAGDelta
 	CounterDelta      *CounterDelta
 	CollectionDelta   *CollectionDelta
+	SetDelta          *SetDelta
+	TextDelta         *TextDelta
 }
 
 // NewCRDT returns a new CRDT.
@@ -31,6 +33,10 @@ func NewCRDT(delta core.Delta) CRDT {
 		return CRDT{CounterDelta: d}
 	case *CollectionDelta:
 		return CRDT{CollectionDelta: d}
+	case *SetDelta:
+		return CRDT{SetDelta: d}
+	case *TextDelta:
+		return CRDT{TextDelta: d}
 	}
 	return CRDT{}
 }
@@ -45,6 +51,8 @@ func (c CRDT) IPLDSchemaBytes() []byte {
 		| CompositeDAGDelta "composite"
 		| CounterDelta "counter"
 		| CollectionDelta "collection"
+		| SetDelta "set"
+		| TextDelta "text"
 	} representation keyed`)
 }
 
@@ -59,6 +67,10 @@ func (c CRDT) GetDelta() core.Delta {
 		return c.CounterDelta
 	case c.CollectionDelta != nil:
 		return c.CollectionDelta
+	case c.SetDelta != nil:
+		return c.SetDelta
+	case c.TextDelta != nil:
+		return c.TextDelta
 	}
 	return nil
 }
@@ -74,6 +86,10 @@ func (c CRDT) GetPriority() uint64 {
 		return c.CounterDelta.GetPriority()
 	case c.CollectionDelta != nil:
 		return c.CollectionDelta.GetPriority()
+	case c.SetDelta != nil:
+		return c.SetDelta.GetPriority()
+	case c.TextDelta != nil:
+		return c.TextDelta.GetPriority()
 	}
 	return 0
 }
@@ -85,6 +101,10 @@ func (c CRDT) GetFieldName() string {
 		return c.LWWRegDelta.FieldName
 	case c.CounterDelta != nil:
 		return c.CounterDelta.FieldName
+	case c.SetDelta != nil:
+		return c.SetDelta.FieldName
+	case c.TextDelta != nil:
+		return c.TextDelta.FieldName
 	}
 	return ""
 }
@@ -100,6 +120,10 @@ func (c CRDT) GetDocID() []byte {
 		return c.CounterDelta.DocID
 	case c.CollectionDelta != nil:
 		return nil
+	case c.SetDelta != nil:
+		return c.SetDelta.DocID
+	case c.TextDelta != nil:
+		return c.TextDelta.DocID
 	}
 	return nil
 }
@@ -115,6 +139,10 @@ func (c CRDT) GetSchemaVersionID() string {
 		return c.CounterDelta.SchemaVersionID
 	case c.CollectionDelta != nil:
 		return c.CollectionDelta.SchemaVersionID
+	case c.SetDelta != nil:
+		return c.SetDelta.SchemaVersionID
+	case c.TextDelta != nil:
+		return c.TextDelta.SchemaVersionID
 	}
 	return ""
 }
@@ -152,6 +180,23 @@ func (c CRDT) Clone() CRDT {
 			Priority:        c.CollectionDelta.Priority,
 			SchemaVersionID: c.CollectionDelta.SchemaVersionID,
 		}
+	case c.SetDelta != nil:
+		cloned.SetDelta = &SetDelta{
+			DocID:           c.SetDelta.DocID,
+			FieldName:       c.SetDelta.FieldName,
+			Priority:        c.SetDelta.Priority,
+			SchemaVersionID: c.SetDelta.SchemaVersionID,
+			Nonce:           c.SetDelta.Nonce,
+			Data:            c.SetDelta.Data,
+		}
+	case c.TextDelta != nil:
+		cloned.TextDelta = &TextDelta{
+			DocID:           c.TextDelta.DocID,
+			FieldName:       c.TextDelta.FieldName,
+			Priority:        c.TextDelta.Priority,
+			SchemaVersionID: c.TextDelta.SchemaVersionID,
+			Data:            c.TextDelta.Data,
+		}
 	}
 	return cloned
 }
@@ -172,6 +217,10 @@ func (c CRDT) GetData() []byte {
 		return c.LWWRegDelta.Data
 	} else if c.CounterDelta != nil {
 		return c.CounterDelta.Data
+	} else if c.SetDelta != nil {
+		return c.SetDelta.Data
+	} else if c.TextDelta != nil {
+		return c.TextDelta.Data
 	}
 	return nil
 }
@@ -182,6 +231,10 @@ func (c CRDT) SetData(data []byte) {
 		c.LWWRegDelta.Data = data
 	} else if c.CounterDelta != nil {
 		c.CounterDelta.Data = data
+	} else if c.SetDelta != nil {
+		c.SetDelta.Data = data
+	} else if c.TextDelta != nil {
+		c.TextDelta.Data = data
 	}
 }
 
@@ -0,0 +1,334 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/base"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+// SetDelta is a single delta operation for a Set.
+type SetDelta struct {
+	DocID     []byte
+	FieldName string
+	Priority  uint64
+	// Nonce is a randomly generated number that tags the elements added by this delta.
+	//
+	// It ensures that each addition is unique, so that a removal only removes the additions
+	// that it has observed.
+	Nonce int64
+	// SchemaVersionID is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at the time of commit.
+	SchemaVersionID string
+	// Data is the CBOR encoded set of elements added and removed by this delta.
+	Data []byte
+}
+
+var _ core.Delta = (*SetDelta)(nil)
+
+// IPLDSchemaBytes returns the IPLD schema representation for the type.
+//
+// This needs to match the [SetDelta] struct or [coreblock.mustSetSchema] will panic on init.
+func (delta *SetDelta) IPLDSchemaBytes() []byte {
+	return []byte(`
+	type SetDelta struct {
+		docID     		Bytes
+		fieldName 		String
+		priority  		Int
+		nonce 			Int
+		schemaVersionID String
+		data            Bytes
+	}`)
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *SetDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *SetDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// setOperations are the operations held by the data of a [SetDelta].
+type setOperations struct {
+	// Add contains the CBOR encoded elements added to the set.
+	Add []cbor.RawMessage `cbor:"add,omitempty"`
+	// Remove contains the elements removed from the set, along with the tags of
+	// the additions that were observed at the time of removal.
+	Remove []setElement `cbor:"remove,omitempty"`
+}
+
+// setElement is a CBOR encoded element of a set and the tags of the additions of that element.
+type setElement struct {
+	Value cbor.RawMessage `cbor:"value"`
+	Tags  []int64         `cbor:"tags"`
+}
+
+// Set is a CRDT type that holds a set of scalar values.
+//
+// If removals are allowed it is an observed-remove set (OR-Set), where an element is only removed
+// if all of its additions have been observed by the removal. Concurrent additions therefore always
+// win over removals. Otherwise it is a grow-only set (G-Set), from which elements cannot be removed.
+//
+// The value of a set is stored as an array of its elements in a deterministic order.
+type Set struct {
+	store datastore.DSReaderWriter
+	key   keys.DataStoreKey
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at the time of commit.
+	schemaVersionKey keys.CollectionSchemaVersionKey
+
+	// fieldName holds the name of the field hosting this CRDT, if this is a field level
+	// commit.
+	fieldName string
+
+	AllowRemove bool
+}
+
+var _ core.ReplicatedData = (*Set)(nil)
+
+// NewSet returns a new instance of the Set with the given ID.
+func NewSet(
+	store datastore.DSReaderWriter,
+	schemaVersionKey keys.CollectionSchemaVersionKey,
+	key keys.DataStoreKey,
+	fieldName string,
+	allowRemove bool,
+) Set {
+	return Set{
+		store:            store,
+		key:              key,
+		schemaVersionKey: schemaVersionKey,
+		fieldName:        fieldName,
+		AllowRemove:      allowRemove,
+	}
+}
+
+// Update generates a new delta that transforms the current set into the given CBOR
+// encoded array of elements.
+//
+// Elements that are not in the current set are added, and elements of the current set that
+// are not in the given array are removed.
+func (s Set) Update(ctx context.Context, value []byte) (*SetDelta, error) {
+	var elements []cbor.RawMessage
+	if !bytes.Equal(value, client.CborNil) {
+		err := cbor.Unmarshal(value, &elements)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := s.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops setOperations
+	requested := make(map[string]struct{}, len(elements))
+	for _, element := range elements {
+		if _, ok := requested[string(element)]; ok {
+			continue
+		}
+		requested[string(element)] = struct{}{}
+		if _, ok := findSetElement(state, element); !ok {
+			ops.Add = append(ops.Add, element)
+		}
+	}
+	for _, element := range state {
+		if _, ok := requested[string(element.Value)]; ok {
+			continue
+		}
+		if !s.AllowRemove {
+			return nil, NewErrCannotRemoveFromGrowOnlySet(s.fieldName)
+		}
+		ops.Remove = append(ops.Remove, element)
+	}
+
+	data, err := cbor.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	// The elements added by the delta are tagged with a random nonce, so that a removal only
+	// removes the additions it has observed. The nonce is left at zero when the document is
+	// created, which keeps the initial block of a document reproducible: any other addition
+	// of the same element to the new document is made by an identical block.
+	exists, err := s.store.Has(ctx, s.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil {
+		return nil, err
+	}
+	var nonce int64
+	if exists {
+		r, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+		if err != nil {
+			return nil, err
+		}
+		nonce = r.Int64()
+	}
+
+	return &SetDelta{
+		DocID:           []byte(s.key.DocID),
+		FieldName:       s.fieldName,
+		Data:            data,
+		SchemaVersionID: s.schemaVersionKey.SchemaVersionID,
+		Nonce:           nonce,
+	}, nil
+}
+
+// Merge implements ReplicatedData interface.
+// It adds the elements added by the delta and removes the observed additions of the
+// elements removed by the delta.
+func (s Set) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*SetDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	var ops setOperations
+	err := cbor.Unmarshal(d.Data, &ops)
+	if err != nil {
+		return err
+	}
+	if len(ops.Remove) > 0 && !s.AllowRemove {
+		return NewErrCannotRemoveFromGrowOnlySet(s.fieldName)
+	}
+
+	state, err := s.getState(ctx)
+	if err != nil {
+		return err
+	}
+	for _, value := range ops.Add {
+		state = addToSetState(state, value, d.Nonce)
+	}
+	for _, element := range ops.Remove {
+		state = removeFromSetState(state, element)
+	}
+
+	err = s.setState(ctx, state)
+	if err != nil {
+		return err
+	}
+	return setPriority(ctx, s.store, s.key, d.GetPriority())
+}
+
+func (s Set) CType() client.CType {
+	if s.AllowRemove {
+		return client.OR_SET
+	}
+	return client.G_SET
+}
+
+// getState returns the current elements of the set ordered by their encoded value.
+func (s Set) getState(ctx context.Context) ([]setElement, error) {
+	b, err := s.store.Get(ctx, s.key.WithSetStateFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state []setElement
+	err = cbor.Unmarshal(b, &state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// setState stores the given elements as the state and value of the set.
+func (s Set) setState(ctx context.Context, state []setElement) error {
+	stateBytes, err := cbor.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = s.store.Put(ctx, s.key.WithSetStateFlag().ToDS(), stateBytes)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	values := make([]cbor.RawMessage, len(state))
+	for i, element := range state {
+		values[i] = element.Value
+	}
+	valueBytes, err := cbor.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	key := s.key.WithValueFlag()
+	marker, err := s.store.Get(ctx, s.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+	err = s.store.Put(ctx, key.ToDS(), valueBytes)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// findSetElement returns the index of the given value within the state.
+//
+// If the value does not exist the index at which it should be inserted is returned.
+func findSetElement(state []setElement, value cbor.RawMessage) (int, bool) {
+	i := sort.Search(len(state), func(i int) bool {
+		return bytes.Compare(state[i].Value, value) >= 0
+	})
+	return i, i < len(state) && bytes.Equal(state[i].Value, value)
+}
+
+func addToSetState(state []setElement, value cbor.RawMessage, tag int64) []setElement {
+	i, ok := findSetElement(state, value)
+	if !ok {
+		return slices.Insert(state, i, setElement{Value: value, Tags: []int64{tag}})
+	}
+	if !slices.Contains(state[i].Tags, tag) {
+		state[i].Tags = append(state[i].Tags, tag)
+	}
+	return state
+}
+
+func removeFromSetState(state []setElement, removed setElement) []setElement {
+	i, ok := findSetElement(state, removed.Value)
+	if !ok {
+		return state
+	}
+	state[i].Tags = slices.DeleteFunc(state[i].Tags, func(tag int64) bool {
+		return slices.Contains(removed.Tags, tag)
+	})
+	if len(state[i].Tags) == 0 {
+		return slices.Delete(state, i, i+1)
+	}
+	return state
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+func setupSet(allowRemove bool) Set {
+	store := newMockStore()
+	key := keys.DataStoreKey{DocID: "AAAA-BBBB", FieldID: "1"}
+	return NewSet(store, keys.CollectionSchemaVersionKey{}, key, "tags", allowRemove)
+}
+
+func mustMarshalCBOR(t *testing.T, value any) []byte {
+	b, err := cbor.Marshal(value)
+	require.NoError(t, err)
+	return b
+}
+
+func getSetValue(t *testing.T, ctx context.Context, set Set) []string {
+	b, err := set.store.Get(ctx, set.key.WithValueFlag().ToDS())
+	require.NoError(t, err)
+	var values []string
+	err = cbor.Unmarshal(b, &values)
+	require.NoError(t, err)
+	return values
+}
+
+func TestSetUpdate_WithNewElements_ShouldAddOnlyNewElements(t *testing.T) {
+	ctx := context.Background()
+	set := setupSet(true)
+
+	delta, err := set.Update(ctx, mustMarshalCBOR(t, []string{"b", "a", "b"}))
+	require.NoError(t, err)
+	delta.Nonce = 1
+	err = set.Merge(ctx, delta)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, getSetValue(t, ctx, set))
+
+	delta, err = set.Update(ctx, mustMarshalCBOR(t, []string{"a", "b", "c"}))
+	require.NoError(t, err)
+
+	var ops setOperations
+	err = cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	require.Equal(t, []cbor.RawMessage{mustMarshalCBOR(t, "c")}, ops.Add)
+	require.Empty(t, ops.Remove)
+}
+
+func TestSetMerge_WithConcurrentAddAndRemove_AddWins(t *testing.T) {
+	ctx := context.Background()
+	set := setupSet(true)
+
+	delta, err := set.Update(ctx, mustMarshalCBOR(t, []string{"a"}))
+	require.NoError(t, err)
+	delta.Nonce = 1
+	err = set.Merge(ctx, delta)
+	require.NoError(t, err)
+
+	removeDelta, err := set.Update(ctx, mustMarshalCBOR(t, []string{}))
+	require.NoError(t, err)
+
+	// a concurrent addition of the same element that the removal has not observed
+	addDelta := &SetDelta{
+		Nonce: 2,
+		Data:  mustMarshalCBOR(t, setOperations{Add: []cbor.RawMessage{mustMarshalCBOR(t, "a")}}),
+	}
+
+	err = set.Merge(ctx, addDelta)
+	require.NoError(t, err)
+	err = set.Merge(ctx, removeDelta)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, getSetValue(t, ctx, set))
+}
+
+func TestSetMerge_WithObservedRemove_ShouldRemoveElement(t *testing.T) {
+	ctx := context.Background()
+	set := setupSet(true)
+
+	delta, err := set.Update(ctx, mustMarshalCBOR(t, []string{"a", "b"}))
+	require.NoError(t, err)
+	delta.Nonce = 1
+	err = set.Merge(ctx, delta)
+	require.NoError(t, err)
+
+	delta, err = set.Update(ctx, mustMarshalCBOR(t, []string{"b"}))
+	require.NoError(t, err)
+	err = set.Merge(ctx, delta)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, getSetValue(t, ctx, set))
+}
+
+func TestSetUpdate_GrowOnlyWithRemovedElement_Error(t *testing.T) {
+	ctx := context.Background()
+	set := setupSet(false)
+
+	delta, err := set.Update(ctx, mustMarshalCBOR(t, []string{"a"}))
+	require.NoError(t, err)
+	err = set.Merge(ctx, delta)
+	require.NoError(t, err)
+
+	_, err = set.Update(ctx, mustMarshalCBOR(t, []string{}))
+	require.ErrorIs(t, err, ErrCannotRemoveFromGSet)
+}
+
+func TestSetMerge_GrowOnlyWithRemoveDelta_Error(t *testing.T) {
+	ctx := context.Background()
+	set := setupSet(false)
+
+	delta := &SetDelta{
+		Data: mustMarshalCBOR(t, setOperations{
+			Remove: []setElement{{Value: mustMarshalCBOR(t, "a"), Tags: []int64{0}}},
+		}),
+	}
+	err := set.Merge(ctx, delta)
+	require.ErrorIs(t, err, ErrCannotRemoveFromGSet)
+}
@@ -0,0 +1,433 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/base"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+// TextDelta is a single delta operation for a Text.
+type TextDelta struct {
+	DocID     []byte
+	FieldName string
+	Priority  uint64
+	// SchemaVersionID is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at the time of commit.
+	SchemaVersionID string
+	// Data is the CBOR encoded set of positional inserts and deletes of this delta.
+	Data []byte
+}
+
+var _ core.Delta = (*TextDelta)(nil)
+
+// IPLDSchemaBytes returns the IPLD schema representation for the type.
+//
+// This needs to match the [TextDelta] struct or [coreblock.mustSetSchema] will panic on init.
+func (delta *TextDelta) IPLDSchemaBytes() []byte {
+	return []byte(`
+	type TextDelta struct {
+		docID     		Bytes
+		fieldName 		String
+		priority  		Int
+		schemaVersionID String
+		data            Bytes
+	}`)
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *TextDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *TextDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// textID uniquely identifies a character of a text.
+//
+// The zero value identifies the start of the text.
+type textID struct {
+	// Seq is a lamport timestamp that is greater than the Seq of all the characters
+	// known at the time the character was inserted.
+	Seq uint64
+	// Site is a random number shared by all the characters inserted by the same delta.
+	Site int64
+}
+
+// after returns true if the id orders after the given id.
+func (id textID) after(other textID) bool {
+	if id.Seq != other.Seq {
+		return id.Seq > other.Seq
+	}
+	return id.Site > other.Site
+}
+
+// textInsert inserts a run of characters after the character with the given id.
+//
+// The characters of the run are identified by incrementing the Seq of the given ID.
+type textInsert struct {
+	After textID `cbor:"after"`
+	ID    textID `cbor:"id"`
+	Value string `cbor:"value"`
+}
+
+// textOperations are the operations held by the data of a [TextDelta].
+type textOperations struct {
+	Insert []textInsert `cbor:"insert,omitempty"`
+	Delete []textID     `cbor:"delete,omitempty"`
+}
+
+// textNode is a single character of a text.
+//
+// Deleted characters are kept as tombstones so that concurrent inserts can still
+// be positioned relative to them.
+type textNode struct {
+	_       struct{} `cbor:",toarray"`
+	ID      textID
+	Char    rune
+	Deleted bool
+}
+
+// Text is a CRDT type that holds a text that can be edited concurrently.
+//
+// It is a Replicated Growable Array (RGA), where each character is identified by a unique
+// id and inserted after the character that preceded it at the time of the edit. Concurrent
+// inserts at the same position are ordered by their ids, and deletes only affect the
+// characters that they target.
+type Text struct {
+	store datastore.DSReaderWriter
+	key   keys.DataStoreKey
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at the time of commit.
+	schemaVersionKey keys.CollectionSchemaVersionKey
+
+	// fieldName holds the name of the field hosting this CRDT, if this is a field level
+	// commit.
+	fieldName string
+}
+
+var _ core.ReplicatedData = (*Text)(nil)
+
+// NewText returns a new instance of the Text with the given ID.
+func NewText(
+	store datastore.DSReaderWriter,
+	schemaVersionKey keys.CollectionSchemaVersionKey,
+	key keys.DataStoreKey,
+	fieldName string,
+) Text {
+	return Text{
+		store:            store,
+		key:              key,
+		schemaVersionKey: schemaVersionKey,
+		fieldName:        fieldName,
+	}
+}
+
+// Update generates a new delta that replaces the current text with the given CBOR
+// encoded string.
+//
+// All the characters of the current text are deleted and the new characters are inserted
+// at its start. Edits at specific positions of the text are made with [Text.Patch].
+func (t Text) Update(ctx context.Context, value []byte) (*TextDelta, error) {
+	var newValue string
+	if !bytes.Equal(value, client.CborNil) {
+		err := cbor.Unmarshal(value, &newValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := t.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops textOperations
+	var maxSeq uint64
+	for _, node := range state {
+		maxSeq = max(maxSeq, node.ID.Seq)
+		if !node.Deleted {
+			ops.Delete = append(ops.Delete, node.ID)
+		}
+	}
+	if newValue != "" {
+		site, err := t.newSite(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ops.Insert = append(ops.Insert, textInsert{
+			ID:    textID{Seq: maxSeq + 1, Site: site},
+			Value: newValue,
+		})
+	}
+	return t.newDelta(ops)
+}
+
+// Patch generates a new delta that applies the given positional patches to the text.
+//
+// The patches are applied in order, so the index of each patch is relative to the text
+// produced by the previous patches. The inserted characters of a patch are positioned
+// after the character that precedes its index, and the deleted characters are the ones
+// found at its index, so that concurrent edits of other parts of the text are preserved.
+func (t Text) Patch(ctx context.Context, patches []client.TextPatch) (*TextDelta, error) {
+	state, err := t.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[textID]struct{}, len(state))
+	var maxSeq uint64
+	for _, node := range state {
+		known[node.ID] = struct{}{}
+		maxSeq = max(maxSeq, node.ID.Seq)
+	}
+	site, err := t.newSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops textOperations
+	for _, patch := range patches {
+		// visible holds the position within the state of each visible character.
+		var visible []int
+		for i, node := range state {
+			if !node.Deleted {
+				visible = append(visible, i)
+			}
+		}
+		start, end := patch.Index, patch.Index+patch.Delete
+		if start < 0 || end < start || end > len(visible) {
+			return nil, client.NewErrTextPatchOutOfRange(t.fieldName, start, len(visible))
+		}
+
+		for _, i := range visible[start:end] {
+			state[i].Deleted = true
+			ops.Delete = append(ops.Delete, state[i].ID)
+		}
+		if patch.Insert == "" {
+			continue
+		}
+		insert := textInsert{
+			ID:    textID{Seq: maxSeq + 1, Site: site},
+			Value: patch.Insert,
+		}
+		if start > 0 {
+			insert.After = state[visible[start-1]].ID
+		}
+		state, err = t.applyInsert(state, known, insert)
+		if err != nil {
+			return nil, err
+		}
+		ops.Insert = append(ops.Insert, insert)
+		maxSeq += uint64(len([]rune(patch.Insert)))
+	}
+	return t.newDelta(ops)
+}
+
+func (t Text) newDelta(ops textOperations) (*TextDelta, error) {
+	data, err := cbor.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TextDelta{
+		DocID:           []byte(t.key.DocID),
+		FieldName:       t.fieldName,
+		Data:            data,
+		SchemaVersionID: t.schemaVersionKey.SchemaVersionID,
+	}, nil
+}
+
+// Merge implements ReplicatedData interface.
+// It inserts the characters of the delta after the characters they were positioned after,
+// and marks the deleted characters of the delta as deleted.
+func (t Text) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*TextDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	var ops textOperations
+	err := cbor.Unmarshal(d.Data, &ops)
+	if err != nil {
+		return err
+	}
+
+	state, err := t.getState(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[textID]struct{}, len(state))
+	for _, node := range state {
+		known[node.ID] = struct{}{}
+	}
+	for _, insert := range ops.Insert {
+		state, err = t.applyInsert(state, known, insert)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ops.Delete) > 0 {
+		deleted := make(map[textID]struct{}, len(ops.Delete))
+		for _, id := range ops.Delete {
+			deleted[id] = struct{}{}
+		}
+		for i := range state {
+			if _, ok := deleted[state[i].ID]; ok {
+				state[i].Deleted = true
+			}
+		}
+	}
+
+	err = t.setState(ctx, state)
+	if err != nil {
+		return err
+	}
+	return setPriority(ctx, t.store, t.key, d.GetPriority())
+}
+
+func (t Text) CType() client.CType {
+	return client.TEXT
+}
+
+// applyInsert inserts the characters of the given insert into the state.
+//
+// Inserts that are already known are skipped, so that merging a delta more than once
+// has no effect.
+func (t Text) applyInsert(state []textNode, known map[textID]struct{}, insert textInsert) ([]textNode, error) {
+	if _, ok := known[insert.ID]; ok {
+		return state, nil
+	}
+
+	pos := 0
+	if insert.After != (textID{}) {
+		pos = -1
+		for i, node := range state {
+			if node.ID == insert.After {
+				pos = i + 1
+				break
+			}
+		}
+		if pos == -1 {
+			return nil, NewErrTextPositionNotFound(t.fieldName, insert.After.Seq, insert.After.Site)
+		}
+	}
+
+	for i, char := range []rune(insert.Value) {
+		id := textID{Seq: insert.ID.Seq + uint64(i), Site: insert.ID.Site}
+		// Skip any characters concurrently inserted at the same position that order
+		// before this one.
+		for pos < len(state) && state[pos].ID.after(id) {
+			pos++
+		}
+		state = append(state, textNode{})
+		copy(state[pos+1:], state[pos:])
+		state[pos] = textNode{ID: id, Char: char}
+		known[id] = struct{}{}
+		pos++
+	}
+	return state, nil
+}
+
+// newSite returns the site used for the ids of newly inserted characters.
+//
+// The site distinguishes the characters inserted concurrently by different deltas. It is
+// left at zero when the document is created, so that the initial block of a document is
+// reproducible.
+func (t Text) newSite(ctx context.Context) (int64, error) {
+	exists, err := t.store.Has(ctx, t.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+	r, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+	return r.Int64(), nil
+}
+
+// getState returns the characters of the text, including deleted characters, in order.
+func (t Text) getState(ctx context.Context) ([]textNode, error) {
+	b, err := t.store.Get(ctx, t.key.WithTextStateFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state []textNode
+	err = cbor.Unmarshal(b, &state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// setState stores the given characters as the state of the text, and the visible
+// characters as its value.
+func (t Text) setState(ctx context.Context, state []textNode) error {
+	stateBytes, err := cbor.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = t.store.Put(ctx, t.key.WithTextStateFlag().ToDS(), stateBytes)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	chars := make([]rune, 0, len(state))
+	for _, node := range state {
+		if !node.Deleted {
+			chars = append(chars, node.Char)
+		}
+	}
+	valueBytes, err := cbor.Marshal(string(chars))
+	if err != nil {
+		return err
+	}
+
+	key := t.key.WithValueFlag()
+	marker, err := t.store.Get(ctx, t.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+	err = t.store.Put(ctx, key.ToDS(), valueBytes)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+func setupText() Text {
+	store := newMockStore()
+	key := keys.DataStoreKey{DocID: "AAAA-BBBB", FieldID: "1"}
+	return NewText(store, keys.CollectionSchemaVersionKey{}, key, "body")
+}
+
+func getTextValue(t *testing.T, ctx context.Context, text Text) string {
+	b, err := text.store.Get(ctx, text.key.WithValueFlag().ToDS())
+	require.NoError(t, err)
+	var value string
+	err = cbor.Unmarshal(b, &value)
+	require.NoError(t, err)
+	return value
+}
+
+func updateText(t *testing.T, ctx context.Context, text Text, value string) *TextDelta {
+	delta, err := text.Update(ctx, mustMarshalCBOR(t, value))
+	require.NoError(t, err)
+	err = text.Merge(ctx, delta)
+	require.NoError(t, err)
+	return delta
+}
+
+func patchText(t *testing.T, ctx context.Context, text Text, patches ...client.TextPatch) *TextDelta {
+	delta, err := text.Patch(ctx, patches)
+	require.NoError(t, err)
+	err = text.Merge(ctx, delta)
+	require.NoError(t, err)
+	return delta
+}
+
+// withSite sets the site of the inserts of the given delta.
+//
+// The site is only randomized for existing documents, so tests of concurrent edits
+// need to set it explicitly.
+func withSite(t *testing.T, delta *TextDelta, site int64) *TextDelta {
+	var ops textOperations
+	err := cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	for i := range ops.Insert {
+		ops.Insert[i].ID.Site = site
+	}
+	delta.Data = mustMarshalCBOR(t, ops)
+	return delta
+}
+
+func TestTextUpdate_WithNewValue_ShouldReplaceAllCharacters(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "Hello world")
+	delta := updateText(t, ctx, text, "Hello brave world")
+	require.Equal(t, "Hello brave world", getTextValue(t, ctx, text))
+
+	var ops textOperations
+	err := cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	require.Len(t, ops.Delete, 11)
+	require.Len(t, ops.Insert, 1)
+	require.Equal(t, "Hello brave world", ops.Insert[0].Value)
+}
+
+func TestTextPatch_WithInsert_ShouldOnlyContainInsertedCharacters(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "Hello world")
+	delta := patchText(t, ctx, text, client.TextPatch{Index: 6, Insert: "brave "})
+	require.Equal(t, "Hello brave world", getTextValue(t, ctx, text))
+
+	var ops textOperations
+	err := cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	require.Empty(t, ops.Delete)
+	require.Len(t, ops.Insert, 1)
+	require.Equal(t, "brave ", ops.Insert[0].Value)
+
+	delta = patchText(t, ctx, text, client.TextPatch{Index: 6, Delete: 6})
+	require.Equal(t, "Hello world", getTextValue(t, ctx, text))
+
+	ops = textOperations{}
+	err = cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	require.Empty(t, ops.Insert)
+	require.Len(t, ops.Delete, 6)
+}
+
+func TestTextPatch_WithInsertAtStartOfRepeatedCharacters_ShouldInsertAtStart(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "aaa")
+	delta := patchText(t, ctx, text, client.TextPatch{Index: 0, Insert: "a"})
+	require.Equal(t, "aaaa", getTextValue(t, ctx, text))
+
+	var ops textOperations
+	err := cbor.Unmarshal(delta.Data, &ops)
+	require.NoError(t, err)
+	require.Len(t, ops.Insert, 1)
+	require.Equal(t, textID{}, ops.Insert[0].After)
+}
+
+func TestTextPatch_WithSeveralPatches_ShouldApplyPatchesInOrder(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "Buy milk")
+	patchText(
+		t,
+		ctx,
+		text,
+		client.TextPatch{Index: 4, Insert: "oat "},
+		client.TextPatch{Index: 12, Insert: " and eggs"},
+		client.TextPatch{Index: 0, Delete: 3, Insert: "Get"},
+		client.TextPatch{Index: 4, Delete: 4, Insert: "soy "},
+	)
+	require.Equal(t, "Get soy milk and eggs", getTextValue(t, ctx, text))
+}
+
+func TestTextPatch_WithOutOfRangePatch_Error(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "abc")
+	_, err := text.Patch(ctx, []client.TextPatch{{Index: 2, Delete: 2}})
+	require.ErrorIs(t, err, client.ErrTextPatchOutOfRange)
+}
+
+func TestTextMerge_WithConcurrentPatches_ShouldConvergeInAnyOrder(t *testing.T) {
+	ctx := context.Background()
+	text1 := setupText()
+	text2 := setupText()
+
+	initial := updateText(t, ctx, text1, "ac")
+	err := text2.Merge(ctx, initial)
+	require.NoError(t, err)
+
+	// Both replicas insert at the same position, and one deletes a character, concurrently.
+	delta1, err := text1.Patch(ctx, []client.TextPatch{{Index: 1, Insert: "b"}})
+	require.NoError(t, err)
+	delta1 = withSite(t, delta1, 1)
+
+	delta2, err := text2.Patch(ctx, []client.TextPatch{{Index: 0, Insert: "x"}, {Index: 2, Delete: 1}})
+	require.NoError(t, err)
+	delta2 = withSite(t, delta2, 2)
+
+	require.NoError(t, text1.Merge(ctx, delta1))
+	require.NoError(t, text1.Merge(ctx, delta2))
+	require.NoError(t, text2.Merge(ctx, delta2))
+	require.NoError(t, text2.Merge(ctx, delta1))
+
+	require.Equal(t, "xab", getTextValue(t, ctx, text1))
+	require.Equal(t, getTextValue(t, ctx, text1), getTextValue(t, ctx, text2))
+}
+
+func TestTextMerge_WithConcurrentInsertsAtSamePosition_ShouldNotInterleave(t *testing.T) {
+	ctx := context.Background()
+	text1 := setupText()
+	text2 := setupText()
+
+	initial := updateText(t, ctx, text1, "ab")
+	require.NoError(t, text2.Merge(ctx, initial))
+
+	delta1, err := text1.Patch(ctx, []client.TextPatch{{Index: 1, Insert: "123"}})
+	require.NoError(t, err)
+	delta1 = withSite(t, delta1, 1)
+	delta2, err := text2.Patch(ctx, []client.TextPatch{{Index: 1, Insert: "xyz"}})
+	require.NoError(t, err)
+	delta2 = withSite(t, delta2, 2)
+
+	require.NoError(t, text1.Merge(ctx, delta1))
+	require.NoError(t, text1.Merge(ctx, delta2))
+	require.NoError(t, text2.Merge(ctx, delta2))
+	require.NoError(t, text2.Merge(ctx, delta1))
+
+	value := getTextValue(t, ctx, text1)
+	require.Contains(t, []string{"a123xyzb", "axyz123b"}, value)
+	require.Equal(t, value, getTextValue(t, ctx, text2))
+}
+
+func TestTextMerge_WithSameDeltaTwice_ShouldBeIdempotent(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	updateText(t, ctx, text, "abc")
+	delta := patchText(t, ctx, text, client.TextPatch{Index: 2, Insert: "x"})
+	err := text.Merge(ctx, delta)
+	require.NoError(t, err)
+	require.Equal(t, "abxc", getTextValue(t, ctx, text))
+}
+
+func TestTextMerge_WithUnknownPosition_Error(t *testing.T) {
+	ctx := context.Background()
+	text := setupText()
+
+	delta := &TextDelta{
+		Data: mustMarshalCBOR(t, textOperations{
+			Insert: []textInsert{{After: textID{Seq: 5, Site: 1}, ID: textID{Seq: 6, Site: 1}, Value: "a"}},
+		}),
+	}
+	err := text.Merge(ctx, delta)
+	require.ErrorIs(t, err, ErrTextPositionNotFound)
+}
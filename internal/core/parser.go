@@ -47,6 +47,22 @@ type Parser interface {
 	// Parses the given request, returning a strongly typed model of that request.
 	Parse(*ast.Document, *client.GQLOptions) (*request.Request, []error)
 
+	// PrepareRequest builds and validates the AST of the given request.
+	//
+	// The AST is cached by the normalized request until the schema changes, so that
+	// requests executed many times are only built and validated once.
+	PrepareRequest(request string) (*ast.Document, []error)
+
+	// Parses the given request prepared by PrepareRequest, returning a strongly typed
+	// model of that request.
+	ParsePrepared(*ast.Document, *client.GQLOptions) (*request.Request, []error)
+
+	// SchemaVersion returns the version of the schema requests are validated against, which
+	// changes every time the schema is set.
+	//
+	// The ASTs returned by PrepareRequest may only be parsed whilst the version is unchanged.
+	SchemaVersion() uint64
+
 	// NewFilterFromString creates a new filter from a string.
 	NewFilterFromString(collectionType string, body string) (immutable.Option[request.Filter], error)
 
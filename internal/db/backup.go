@@ -13,14 +13,36 @@ package db
 import (
 	"bufio"
 	"context"
-	"encoding/json"
-	"fmt"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
 	"os"
+	fp "path/filepath"
+
+	ds "github.com/ipfs/go-datastore"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/keys"
 )
 
+// backupImportBatchSize is the number of documents imported per transaction.
+var backupImportBatchSize = 1000
+
+// backupImport holds the state of an import of a backup file.
+type backupImport struct {
+	reader backupReader
+	// key is the key under which the progress of the import is stored.
+	key keys.BackupImportKey
+	// skip is the number of documents imported by a previous, interrupted, import of the file.
+	skip uint64
+	// count is the number of documents read so far.
+	count       uint64
+	collections map[string]client.Collection
+}
+
 func (db *db) basicImport(ctx context.Context, filepath string) (err error) {
 	f, err := os.Open(filepath)
 	if err != nil {
@@ -33,89 +55,193 @@ func (db *db) basicImport(ctx context.Context, filepath string) (err error) {
 		}
 	}()
 
-	d := json.NewDecoder(bufio.NewReader(f))
+	format, err := detectBackupFormat(f)
+	if err != nil {
+		return err
+	}
+	if format == client.BackupFormatCAR {
+		return db.dagImport(ctx, f)
+	}
+	reader, err := newBackupReader(format, bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
 
-	t, err := d.Token()
+	info, err := f.Stat()
+	if err != nil {
+		return NewErrOpenFile(err, filepath)
+	}
+	key, err := newBackupImportKey(filepath, info)
 	if err != nil {
 		return err
 	}
-	if t != json.Delim('{') {
-		return ErrExpectedJSONObject
+	skip, err := db.getBackupImportProgress(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	imp := &backupImport{
+		reader:      reader,
+		key:         key,
+		skip:        skip,
+		collections: make(map[string]client.Collection),
 	}
-	for d.More() {
-		t, err := d.Token()
+	for {
+		done, err := db.importBackupBatch(ctx, imp)
 		if err != nil {
 			return err
 		}
-		colName := t.(string)
-		col, err := db.getCollectionByName(ctx, colName)
-		if err != nil {
-			return NewErrFailedToGetCollection(colName, err)
+		if done {
+			return nil
 		}
+	}
+}
 
-		t, err = d.Token()
-		if err != nil {
-			return err
+// importBackupBatch imports the next batch of documents of the given import within a single
+// transaction, along with the progress of the import.
+//
+// If the context holds a transaction all batches are imported within that transaction.
+func (db *db) importBackupBatch(ctx context.Context, imp *backupImport) (done bool, err error) {
+	ctx, txn, err := ensureContextTxn(ctx, db, false)
+	if err != nil {
+		return false, err
+	}
+	defer txn.Discard(ctx)
+
+	for imported := 0; imported < backupImportBatchSize; {
+		record, err := imp.reader.next()
+		if errors.Is(err, io.EOF) {
+			done = true
+			break
 		}
-		if t != json.Delim('[') {
-			return ErrExpectedJSONArray
+		if err != nil {
+			return false, err
 		}
 
-		for d.More() {
-			docMap := map[string]any{}
-			err = d.Decode(&docMap)
+		col, ok := imp.collections[record.Collection]
+		if !ok {
+			col, err = db.getCollectionByName(ctx, record.Collection)
 			if err != nil {
-				return NewErrJSONDecode(err)
+				return false, NewErrFailedToGetCollection(record.Collection, err)
 			}
+			imp.collections[record.Collection] = col
+		}
+		if record.Document == nil {
+			continue
+		}
 
-			// check if self referencing and remove from docMap for key creation
-			resetMap := map[string]any{}
-			for _, field := range col.Schema().Fields {
-				if field.Kind.IsObject() && !field.Kind.IsArray() {
-					if val, ok := docMap[field.Name+request.RelatedObjectID]; ok {
-						if docMap[request.NewDocIDFieldName] == val {
-							resetMap[field.Name+request.RelatedObjectID] = val
-							delete(docMap, field.Name+request.RelatedObjectID)
-						}
-					}
-				}
-			}
+		imp.count++
+		if imp.count <= imp.skip {
+			continue
+		}
+		err = importDocument(ctx, col, record.Document)
+		if err != nil {
+			return false, err
+		}
+		imported++
+	}
 
-			delete(docMap, request.DocIDFieldName)
-			delete(docMap, request.NewDocIDFieldName)
+	if done {
+		err = txn.Systemstore().Delete(ctx, imp.key.ToDS())
+	} else {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], imp.count)
+		err = txn.Systemstore().Put(ctx, imp.key.ToDS(), buf[:])
+	}
+	if err != nil {
+		return false, err
+	}
+	return done, txn.Commit(ctx)
+}
 
-			doc, err := client.NewDocFromMap(docMap, col.Definition())
-			if err != nil {
-				return NewErrDocFromMap(err)
-			}
+// getBackupImportProgress returns the number of documents imported by a previous import
+// of the file identified by the given key.
+func (db *db) getBackupImportProgress(ctx context.Context, key keys.BackupImportKey) (uint64, error) {
+	ctx, txn, err := ensureContextTxn(ctx, db, true)
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Discard(ctx)
 
-			err = col.Create(ctx, doc)
-			if err != nil {
-				return NewErrDocCreate(err)
-			}
+	val, err := txn.Systemstore().Get(ctx, key.ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
 
-			// add back the self referencing fields and update doc.
-			for k, v := range resetMap {
-				err := doc.Set(k, v)
-				if err != nil {
-					return NewErrDocUpdate(err)
-				}
-				err = col.Update(ctx, doc)
-				if err != nil {
-					return NewErrDocUpdate(err)
+// newBackupImportKey returns the key under which the progress of the import of the
+// given file is stored.
+//
+// The key is derived from the size and modification time of the file as well as its path,
+// so that a file that changed since it was partially imported is imported from the start.
+func newBackupImportKey(filepath string, info os.FileInfo) (keys.BackupImportKey, error) {
+	path, err := fp.Abs(filepath)
+	if err != nil {
+		return keys.BackupImportKey{}, err
+	}
+	id := binary.BigEndian.AppendUint64([]byte(path), uint64(info.Size()))
+	id = binary.BigEndian.AppendUint64(id, uint64(info.ModTime().UnixNano()))
+	hash := sha256.Sum256(id)
+	return keys.NewBackupImportKey(hex.EncodeToString(hash[:])), nil
+}
+
+func importDocument(ctx context.Context, col client.Collection, docMap map[string]any) error {
+	// check if self referencing and remove from docMap for key creation
+	resetMap := map[string]any{}
+	for _, field := range col.Schema().Fields {
+		if field.Kind.IsObject() && !field.Kind.IsArray() {
+			if val, ok := docMap[field.Name+request.RelatedObjectID]; ok {
+				if docMap[request.NewDocIDFieldName] == val {
+					resetMap[field.Name+request.RelatedObjectID] = val
+					delete(docMap, field.Name+request.RelatedObjectID)
 				}
 			}
 		}
-		_, err = d.Token()
+	}
+
+	delete(docMap, request.DocIDFieldName)
+	delete(docMap, request.NewDocIDFieldName)
+
+	doc, err := client.NewDocFromMap(docMap, col.Definition())
+	if err != nil {
+		return NewErrDocFromMap(err)
+	}
+
+	err = col.Create(ctx, doc)
+	if err != nil {
+		return NewErrDocCreate(err)
+	}
+
+	// add back the self referencing fields and update doc.
+	for k, v := range resetMap {
+		err := doc.Set(k, v)
 		if err != nil {
-			return err
+			return NewErrDocUpdate(err)
+		}
+		err = col.Update(ctx, doc)
+		if err != nil {
+			return NewErrDocUpdate(err)
 		}
 	}
-
 	return nil
 }
 
 func (db *db) basicExport(ctx context.Context, config *client.BackupConfig) (err error) {
+	format := config.Format
+	if format == "" {
+		format = client.BackupFormatJSON
+	}
+	if !isSupportedBackupFormat(format) {
+		return NewErrUnsupportedBackupFormat(config.Format)
+	}
+	if format == client.BackupFormatCAR {
+		return db.dagExport(ctx, config)
+	}
+
 	// old key -> new Key
 	keyChangeCache := map[string]string{}
 
@@ -161,31 +287,19 @@ func (db *db) basicExport(ctx context.Context, config *client.BackupConfig) (err
 		}
 	}()
 
-	// open the object
-	err = writeString(f, "{", "{\n", config.Pretty)
+	w := bufio.NewWriter(f)
+	writer, err := newBackupWriter(format, w, config.Pretty)
 	if err != nil {
 		return err
 	}
 
-	firstCol := true
-	for _, col := range cols {
-		if firstCol {
-			firstCol = false
-		} else {
-			// add collection separator
-			err = writeString(f, ",", ",\n", config.Pretty)
-			if err != nil {
-				return err
-			}
-		}
+	err = writer.open()
+	if err != nil {
+		return err
+	}
 
-		// set collection
-		err = writeString(
-			f,
-			fmt.Sprintf("\"%s\":[", col.Name().Value()),
-			fmt.Sprintf("  \"%s\": [\n", col.Name().Value()),
-			config.Pretty,
-		)
+	for _, col := range cols {
+		err = writer.beginCollection(col.Name().Value())
 		if err != nil {
 			return err
 		}
@@ -194,17 +308,7 @@ func (db *db) basicExport(ctx context.Context, config *client.BackupConfig) (err
 			return err
 		}
 
-		firstDoc := true
 		for docResultWithID := range docIDsCh {
-			if firstDoc {
-				firstDoc = false
-			} else {
-				// add document separator
-				err = writeString(f, ",", ",\n", config.Pretty)
-				if err != nil {
-					return err
-				}
-			}
 			doc, err := col.Get(ctx, docResultWithID.ID, false)
 			if err != nil {
 				return err
@@ -308,63 +412,32 @@ func (db *db) basicExport(ctx context.Context, config *client.BackupConfig) (err
 				keyChangeCache[doc.ID().String()] = newDoc.ID().String()
 			}
 
-			var b []byte
-			if config.Pretty {
-				_, err = f.WriteString("    ")
-				if err != nil {
-					return NewErrFailedToWriteString(err)
-				}
-				b, err = json.MarshalIndent(docM, "    ", "  ")
-				if err != nil {
-					return NewErrFailedToWriteString(err)
-				}
-			} else {
-				b, err = json.Marshal(docM)
-				if err != nil {
-					return err
-				}
-			}
-
-			// write document
-			_, err = f.Write(b)
+			err = writer.writeDocument(docM)
 			if err != nil {
 				return err
 			}
 		}
 
-		// close collection
-		err = writeString(f, "]", "\n  ]", config.Pretty)
+		err = writer.endCollection()
 		if err != nil {
 			return err
 		}
 	}
 
-	// close object
-	err = writeString(f, "}", "\n}", config.Pretty)
+	err = writer.close()
 	if err != nil {
 		return err
 	}
 
-	err = f.Sync()
+	err = w.Flush()
 	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func writeString(f *os.File, normal, pretty string, isPretty bool) error {
-	if isPretty {
-		_, err := f.WriteString(pretty)
-		if err != nil {
-			return NewErrFailedToWriteString(err)
-		}
-		return nil
+		return NewErrFailedToWriteString(err)
 	}
 
-	_, err := f.WriteString(normal)
+	err = f.Sync()
 	if err != nil {
-		return NewErrFailedToWriteString(err)
+		return err
 	}
+
 	return nil
 }
@@ -0,0 +1,185 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+)
+
+// carVersion is the version of the CAR (Content Addressable aRchive) format
+// used by DAG backups.
+const carVersion = 1
+
+// maxCARSectionSize is the maximum size of the header or of a block of a CAR file.
+//
+// It protects the reader from allocating unbounded amounts of memory when given a corrupted file.
+const maxCARSectionSize = 1 << 25
+
+// carWriter writes blocks to a CARv1 file.
+//
+// A CARv1 file is made of a DAG-CBOR header holding the version and the root CIDs of the
+// archive, followed by a section per block holding its CID and its data. The header and each
+// section are prefixed by their length encoded as an unsigned varint.
+type carWriter struct {
+	w io.Writer
+}
+
+// newCARWriter returns a new carWriter after writing the header with the given roots.
+func newCARWriter(w io.Writer, roots []cid.Cid) (*carWriter, error) {
+	header, err := qp.BuildMap(basicnode.Prototype.Any, 2, func(ma datamodel.MapAssembler) {
+		qp.MapEntry(ma, "roots", qp.List(int64(len(roots)), func(la datamodel.ListAssembler) {
+			for _, root := range roots {
+				qp.ListEntry(la, qp.Link(cidlink.Link{Cid: root}))
+			}
+		}))
+		qp.MapEntry(ma, "version", qp.Int(carVersion))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = dagcbor.Encode(header, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	cw := &carWriter{w: w}
+	err = cw.writeSection(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// writeBlock writes a section holding the given block.
+func (cw *carWriter) writeBlock(c cid.Cid, data []byte) error {
+	return cw.writeSection(c.Bytes(), data)
+}
+
+func (cw *carWriter) writeSection(data ...[]byte) error {
+	length := 0
+	for _, d := range data {
+		length += len(d)
+	}
+	_, err := cw.w.Write(binary.AppendUvarint(nil, uint64(length)))
+	if err != nil {
+		return err
+	}
+	for _, d := range data {
+		_, err = cw.w.Write(d)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// carReader reads the blocks of a CARv1 file.
+type carReader struct {
+	r     *bufio.Reader
+	roots []cid.Cid
+}
+
+// newCARReader returns a new carReader after reading the header of the file.
+func newCARReader(r io.Reader) (*carReader, error) {
+	cr := &carReader{r: bufio.NewReader(r)}
+	data, err := cr.readSection()
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+
+	nb := basicnode.Prototype.Any.NewBuilder()
+	err = dagcbor.Decode(nb, bytes.NewReader(data))
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	header := nb.Build()
+
+	versionNode, err := header.LookupByString("version")
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	version, err := versionNode.AsInt()
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	if version != carVersion {
+		return nil, NewErrUnsupportedCARVersion(version)
+	}
+
+	rootsNode, err := header.LookupByString("roots")
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	it := rootsNode.ListIterator()
+	for it != nil && !it.Done() {
+		_, rootNode, err := it.Next()
+		if err != nil {
+			return nil, NewErrInvalidCARFile(err)
+		}
+		link, err := rootNode.AsLink()
+		if err != nil {
+			return nil, NewErrInvalidCARFile(err)
+		}
+		cr.roots = append(cr.roots, link.(cidlink.Link).Cid)
+	}
+	return cr, nil
+}
+
+// next returns the next block of the file, or io.EOF if all blocks have been read.
+//
+// An error is returned if the data of the block does not match its CID.
+func (cr *carReader) next() (blocks.Block, error) {
+	data, err := cr.readSection()
+	if err != nil {
+		return nil, err
+	}
+	n, c, err := cid.CidFromBytes(data)
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	expected, err := c.Prefix().Sum(data[n:])
+	if err != nil {
+		return nil, NewErrInvalidCARFile(err)
+	}
+	if !expected.Equals(c) {
+		return nil, NewErrInvalidBackupBlock(c)
+	}
+	return blocks.NewBlockWithCid(data[n:], c)
+}
+
+func (cr *carReader) readSection() ([]byte, error) {
+	length, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 || length > maxCARSectionSize {
+		return nil, ErrInvalidCARSectionSize
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(cr.r, data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,656 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
+	"github.com/sourcenetwork/defradb/internal/core"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+	"github.com/sourcenetwork/defradb/internal/db/description"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+// dagBackupVersion is the version of the manifest of DAG backups.
+const dagBackupVersion = 1
+
+// dagBackupManifest describes the content of a DAG backup.
+//
+// It is stored as the first block, and only root, of the CAR file holding the backup.
+type dagBackupManifest struct {
+	Version int `json:"version"`
+	// Schemas contains all the versions of the schemas of the backed up collections.
+	Schemas []client.SchemaDescription `json:"schemas"`
+	// Collections contains all the versions of the backed up collections, along with their indexes.
+	Collections []client.CollectionDescription `json:"collections"`
+	// Heads contains the heads of the backed up DAGs.
+	Heads []dagBackupHeads `json:"heads"`
+}
+
+// dagBackupHeads holds the heads of the composite DAG of a document, or of the DAG of a
+// branchable collection if DocID is empty.
+type dagBackupHeads struct {
+	SchemaRoot string   `json:"schemaRoot"`
+	DocID      string   `json:"docID,omitempty"`
+	Cids       []string `json:"cids"`
+}
+
+// dagExport exports the blocks, heads and definitions of the configured collections to a CAR file.
+//
+// Collections related to the configured collections are also exported so that the backup
+// can be restored on its own.
+func (db *db) dagExport(ctx context.Context, config *client.BackupConfig) (err error) {
+	txn := mustGetContextTxn(ctx)
+
+	manifest, err := db.newDAGBackupManifest(ctx, config.Collections)
+	if err != nil {
+		return err
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifestCid, err := newDAGBackupManifestCid(manifestBytes)
+	if err != nil {
+		return err
+	}
+
+	tempFile := config.Filepath + ".temp"
+	f, err := os.Create(tempFile)
+	if err != nil {
+		return NewErrCreateFile(err, tempFile)
+	}
+	defer func() {
+		closeErr := f.Close()
+		if closeErr != nil {
+			err = NewErrCloseFile(closeErr, err)
+		} else if err != nil {
+			// ensure we cleanup if there was an error
+			removeErr := os.Remove(tempFile)
+			if removeErr != nil {
+				err = NewErrRemoveFile(removeErr, err, tempFile)
+			}
+		} else {
+			_ = os.Rename(tempFile, config.Filepath)
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	cw, err := newCARWriter(w, []cid.Cid{manifestCid})
+	if err != nil {
+		return err
+	}
+	err = cw.writeBlock(manifestCid, manifestBytes)
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[cid.Cid]struct{})
+	for _, heads := range manifest.Heads {
+		for _, c := range heads.Cids {
+			head, err := cid.Decode(c)
+			if err != nil {
+				return err
+			}
+			err = writeDAGBackupBlocks(ctx, txn, cw, head, visited)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return NewErrFailedToWriteString(err)
+	}
+
+	return f.Sync()
+}
+
+// newDAGBackupManifest returns the manifest of a backup of the collections with the given names,
+// or of all collections if no names are given.
+func (db *db) newDAGBackupManifest(ctx context.Context, names []string) (dagBackupManifest, error) {
+	txn := mustGetContextTxn(ctx)
+
+	var cols []client.Collection
+	if len(names) == 0 {
+		var err error
+		cols, err = db.getCollections(ctx, client.CollectionFetchOptions{})
+		if err != nil {
+			return dagBackupManifest{}, NewErrFailedToGetAllCollections(err)
+		}
+	} else {
+		for _, name := range names {
+			col, err := db.getCollectionByName(ctx, name)
+			if err != nil {
+				return dagBackupManifest{}, NewErrFailedToGetCollection(name, err)
+			}
+			cols = append(cols, col)
+		}
+	}
+
+	colRoots := []uint32{}
+	for _, col := range cols {
+		colRoots = append(colRoots, col.Description().RootID)
+	}
+	schemaRoots := []string{}
+	visitedColRoots := make(map[uint32]struct{})
+	visitedSchemaRoots := make(map[string]struct{})
+
+	manifest := dagBackupManifest{Version: dagBackupVersion}
+	// Related collections are appended to colRoots as they are found.
+	for i := 0; i < len(colRoots); i++ {
+		root := colRoots[i]
+		if _, ok := visitedColRoots[root]; ok {
+			continue
+		}
+		visitedColRoots[root] = struct{}{}
+
+		descs, err := description.GetCollectionsByRoot(ctx, txn, root)
+		if err != nil {
+			return dagBackupManifest{}, err
+		}
+
+		var schemaRoot string
+		isBranchable := false
+		for _, desc := range descs {
+			if desc.Policy.HasValue() {
+				return dagBackupManifest{}, NewErrBackupCollectionHasPolicy(desc.Name.Value())
+			}
+			isBranchable = isBranchable || desc.IsBranchable
+
+			desc.Indexes, err = db.fetchCollectionIndexDescriptions(ctx, desc.ID)
+			if err != nil {
+				return dagBackupManifest{}, err
+			}
+			manifest.Collections = append(manifest.Collections, desc)
+
+			for _, field := range desc.Fields {
+				if kind, ok := field.Kind.Value().(*client.CollectionKind); ok {
+					colRoots = append(colRoots, kind.Root)
+				}
+			}
+
+			schema, err := description.GetSchemaVersion(ctx, txn, desc.SchemaVersionID)
+			if err != nil {
+				if errors.Is(err, ds.ErrNotFound) {
+					// The schema may not exist if a migration was registered before it was declared.
+					continue
+				}
+				return dagBackupManifest{}, err
+			}
+			schemaRoot = schema.Root
+			schemaRoots = append(schemaRoots, schema.Root)
+			for _, field := range schema.Fields {
+				switch kind := field.Kind.(type) {
+				case *client.CollectionKind:
+					colRoots = append(colRoots, kind.Root)
+				case *client.SchemaKind:
+					schemaRoots = append(schemaRoots, kind.Root)
+				}
+			}
+		}
+
+		if schemaRoot == "" {
+			continue
+		}
+		heads, err := getDAGBackupHeads(ctx, txn, root, schemaRoot, isBranchable)
+		if err != nil {
+			return dagBackupManifest{}, err
+		}
+		manifest.Heads = append(manifest.Heads, heads...)
+	}
+
+	for _, root := range schemaRoots {
+		if _, ok := visitedSchemaRoots[root]; ok {
+			continue
+		}
+		visitedSchemaRoots[root] = struct{}{}
+
+		schemas, err := description.GetSchemasByRoot(ctx, txn, root)
+		if err != nil {
+			return dagBackupManifest{}, err
+		}
+		manifest.Schemas = append(manifest.Schemas, schemas...)
+	}
+
+	return manifest, nil
+}
+
+// getDAGBackupHeads returns the heads of the collection with the given root.
+//
+// The DAGs of the documents of branchable collections are linked to the DAG of the collection,
+// so only the heads of the collection are returned for those.
+func getDAGBackupHeads(
+	ctx context.Context,
+	txn datastore.Txn,
+	colRoot uint32,
+	schemaRoot string,
+	isBranchable bool,
+) ([]dagBackupHeads, error) {
+	if isBranchable {
+		cids, err := getHeads(ctx, txn, keys.NewHeadstoreColKey(colRoot))
+		if err != nil {
+			return nil, err
+		}
+		if len(cids) == 0 {
+			return nil, nil
+		}
+		return []dagBackupHeads{{SchemaRoot: schemaRoot, Cids: cidStrings(cids)}}, nil
+	}
+
+	// The primary keys include deleted documents.
+	prefix := keys.PrimaryDataStoreKey{CollectionRootID: colRoot}.ToString() + "/"
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []dagBackupHeads
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, errors.Join(res.Error, q.Close())
+		}
+		docID := strings.TrimPrefix(res.Key, prefix)
+		cids, err := getHeads(ctx, txn, keys.HeadstoreDocKey{
+			DocID:   docID,
+			FieldID: core.COMPOSITE_NAMESPACE,
+		})
+		if err != nil {
+			return nil, errors.Join(err, q.Close())
+		}
+		if len(cids) == 0 {
+			continue
+		}
+		result = append(result, dagBackupHeads{SchemaRoot: schemaRoot, DocID: docID, Cids: cidStrings(cids)})
+	}
+	return result, q.Close()
+}
+
+// writeDAGBackupBlocks writes the blocks of the DAG with the given head that have not yet
+// been visited.
+//
+// Encryption blocks are written right after the first block that references them.
+func writeDAGBackupBlocks(
+	ctx context.Context,
+	txn datastore.Txn,
+	cw *carWriter,
+	head cid.Cid,
+	visited map[cid.Cid]struct{},
+) error {
+	stack := []cid.Cid{head}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := visited[c]; ok {
+			continue
+		}
+		visited[c] = struct{}{}
+
+		b, err := txn.Blockstore().Get(ctx, c)
+		if err != nil {
+			return err
+		}
+		err = cw.writeBlock(c, b.RawData())
+		if err != nil {
+			return err
+		}
+
+		block, err := coreblock.GetFromBytes(b.RawData())
+		if err != nil {
+			return err
+		}
+		if block.Encryption != nil {
+			err = writeDAGBackupEncryptionBlock(ctx, txn, cw, block.Encryption.Cid, visited)
+			if err != nil {
+				return err
+			}
+		}
+		for _, link := range block.AllLinks() {
+			stack = append(stack, link.Cid)
+		}
+	}
+	return nil
+}
+
+// writeDAGBackupEncryptionBlock writes the encryption block with the given cid if it has not yet
+// been visited.
+//
+// Encryption blocks that are not available locally are skipped, they may be requested from
+// peers once the backup is restored.
+func writeDAGBackupEncryptionBlock(
+	ctx context.Context,
+	txn datastore.Txn,
+	cw *carWriter,
+	c cid.Cid,
+	visited map[cid.Cid]struct{},
+) error {
+	if _, ok := visited[c]; ok {
+		return nil
+	}
+	visited[c] = struct{}{}
+
+	exists, err := txn.Encstore().Has(ctx, c)
+	if err != nil || !exists {
+		return err
+	}
+	b, err := txn.Encstore().Get(ctx, c)
+	if err != nil {
+		return err
+	}
+	return cw.writeBlock(c, b.RawData())
+}
+
+// dagImport restores a backup exported by dagExport.
+//
+// The collection definitions are restored first, keeping their local IDs so that relations
+// remain valid. The blocks are then stored in batches and the heads of the backup are merged,
+// resulting in the same documents, heads and commits as the exporting node.
+func (db *db) dagImport(ctx context.Context, r io.Reader) error {
+	cr, err := newCARReader(r)
+	if err != nil {
+		return err
+	}
+	if len(cr.roots) != 1 {
+		return NewErrInvalidCARFile(ErrBackupManifestNotFound)
+	}
+	b, err := cr.next()
+	if err != nil {
+		return NewErrInvalidCARFile(err)
+	}
+	if !b.Cid().Equals(cr.roots[0]) {
+		return NewErrBackupManifestNotFound(cr.roots[0])
+	}
+
+	var manifest dagBackupManifest
+	err = json.Unmarshal(b.RawData(), &manifest)
+	if err != nil {
+		return NewErrJSONDecode(err)
+	}
+	if manifest.Version != dagBackupVersion {
+		return NewErrUnsupportedBackupManifestVersion(manifest.Version)
+	}
+
+	err = db.restoreDAGBackupDefinitions(ctx, manifest)
+	if err != nil {
+		return err
+	}
+
+	encBlocks := make(map[cid.Cid]struct{})
+	for {
+		done, err := db.restoreDAGBackupBlockBatch(ctx, cr, encBlocks)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+
+	return db.mergeDAGBackupHeads(ctx, manifest.Heads)
+}
+
+// restoreDAGBackupDefinitions stores the schemas and collections of the given manifest, along
+// with the indexes of the collections, within a single transaction.
+//
+// Definitions that already exist are left untouched, and collections that use the ID or name
+// of a different existing collection return an error.
+func (db *db) restoreDAGBackupDefinitions(ctx context.Context, manifest dagBackupManifest) error {
+	ctx, txn, err := ensureContextTxn(ctx, db, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	for _, schema := range manifest.Schemas {
+		_, err := description.GetSchemaVersion(ctx, txn, schema.VersionID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		_, err = description.CreateSchemaVersion(ctx, txn, schema)
+		if err != nil {
+			return err
+		}
+	}
+
+	var maxColID uint64
+	maxFieldIDs := make(map[uint32]uint64)
+	var indexedCols []client.CollectionDescription
+	for _, col := range manifest.Collections {
+		existing, err := description.GetCollectionByID(ctx, txn, col.ID)
+		if err == nil {
+			if existing.RootID != col.RootID || existing.SchemaVersionID != col.SchemaVersionID {
+				return NewErrBackupCollectionConflict(col.ID)
+			}
+			continue
+		}
+		if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		if col.Name.HasValue() {
+			exists, err := description.HasCollectionByName(ctx, txn, col.Name.Value())
+			if err != nil {
+				return err
+			}
+			if exists {
+				return NewErrBackupCollectionConflict(col.ID)
+			}
+		}
+
+		if len(col.Indexes) > 0 {
+			indexedCols = append(indexedCols, col)
+		}
+		// Indexes are stored separately and are created once the collection is saved.
+		saved := col
+		saved.Indexes = nil
+		_, err = description.SaveCollection(ctx, txn, saved)
+		if err != nil {
+			return err
+		}
+
+		for _, src := range col.CollectionSources() {
+			if src.Transform.HasValue() {
+				err = db.LensRegistry().SetMigration(ctx, col.ID, src.Transform.Value())
+				if err != nil {
+					return err
+				}
+			}
+		}
+		for _, src := range col.QuerySources() {
+			if src.Transform.HasValue() {
+				err = db.LensRegistry().SetMigration(ctx, col.ID, src.Transform.Value())
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		maxColID = max(maxColID, uint64(col.ID))
+		for _, field := range col.Fields {
+			maxFieldIDs[col.RootID] = max(maxFieldIDs[col.RootID], uint64(field.ID))
+		}
+	}
+
+	// The sequences must not assign the restored IDs to new definitions.
+	err = db.ensureSequenceAtLeast(ctx, keys.CollectionIDSequenceKey{}, maxColID)
+	if err != nil {
+		return err
+	}
+	for root, maxFieldID := range maxFieldIDs {
+		err = db.ensureSequenceAtLeast(ctx, keys.NewFieldIDSequenceKey(root), maxFieldID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, desc := range indexedCols {
+		col, err := db.getCollectionByID(ctx, desc.ID)
+		if err != nil {
+			return err
+		}
+		for _, index := range desc.Indexes {
+			index.ID = 0
+			_, err = col.(*collection).createIndex(ctx, index)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = db.loadSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// ensureSequenceAtLeast sets the value of the sequence with the given key to the given value
+// if it is lower.
+func (db *db) ensureSequenceAtLeast(ctx context.Context, key keys.Key, val uint64) error {
+	seq, err := db.getSequence(ctx, key)
+	if err != nil {
+		return err
+	}
+	if seq.val >= val {
+		return nil
+	}
+	seq.val = val
+	return seq.update(ctx)
+}
+
+// restoreDAGBackupBlockBatch stores the next batch of blocks of the backup within a single transaction.
+//
+// Blocks referenced as encryption blocks are stored in the encryption store.
+func (db *db) restoreDAGBackupBlockBatch(
+	ctx context.Context,
+	cr *carReader,
+	encBlocks map[cid.Cid]struct{},
+) (done bool, err error) {
+	ctx, txn, err := ensureContextTxn(ctx, db, false)
+	if err != nil {
+		return false, err
+	}
+	defer txn.Discard(ctx)
+
+	for i := 0; i < backupImportBatchSize; i++ {
+		b, err := cr.next()
+		if errors.Is(err, io.EOF) {
+			done = true
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if _, ok := encBlocks[b.Cid()]; ok {
+			err = txn.Encstore().Put(ctx, b)
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		block, err := coreblock.GetFromBytes(b.RawData())
+		if err != nil {
+			return false, err
+		}
+		if block.Encryption != nil {
+			encBlocks[block.Encryption.Cid] = struct{}{}
+		}
+		err = txn.Blockstore().Put(ctx, b)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return done, txn.Commit(ctx)
+}
+
+// mergeDAGBackupHeads merges the given heads of a restored backup.
+func (db *db) mergeDAGBackupHeads(ctx context.Context, heads []dagBackupHeads) error {
+	cols := make(map[string]*collection)
+	for _, h := range heads {
+		col, ok := cols[h.SchemaRoot]
+		if !ok {
+			var err error
+			col, err = getCollectionFromRootSchema(ctx, db, h.SchemaRoot)
+			if err != nil {
+				return err
+			}
+			cols[h.SchemaRoot] = col
+		}
+
+		for _, c := range h.Cids {
+			head, err := cid.Decode(c)
+			if err != nil {
+				return err
+			}
+			evt := event.Merge{
+				DocID:      h.DocID,
+				Cid:        head,
+				SchemaRoot: h.SchemaRoot,
+			}
+			// retry the merge process if a conflict occurs, like for merges of P2P updates.
+			for i := 0; i < db.MaxTxnRetries(); i++ {
+				err = db.executeMerge(ctx, col, evt)
+				if errors.Is(err, datastore.ErrTxnConflict) {
+					continue
+				}
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newDAGBackupManifestCid returns the cid of the block holding the given manifest.
+func newDAGBackupManifestCid(data []byte) (cid.Cid, error) {
+	return cid.Prefix{
+		Version:  1,
+		Codec:    cid.Raw,
+		MhType:   multihash.SHA2_256,
+		MhLength: -1,
+	}.Sum(data)
+}
+
+func cidStrings(cids []cid.Cid) []string {
+	result := make([]string, len(cids))
+	for i, c := range cids {
+		result[i] = c.String()
+	}
+	slices.Sort(result)
+	return result
+}
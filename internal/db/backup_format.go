@@ -0,0 +1,457 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// backupRecord is a single document of a streamed (ndjson or cbor) backup.
+type backupRecord struct {
+	Collection string         `json:"collection" cbor:"collection"`
+	Document   map[string]any `json:"document" cbor:"document"`
+}
+
+func isSupportedBackupFormat(format string) bool {
+	switch format {
+	case client.BackupFormatJSON, client.BackupFormatNDJSON, client.BackupFormatCBOR, client.BackupFormatCAR:
+		return true
+	default:
+		return false
+	}
+}
+
+// detectBackupFormat returns the format of the backup held by the given file.
+//
+// CAR backups start with a CAR header, CBOR backups with a map, json backups with an object holding an array of documents
+// and ndjson backups with an object holding the collection name of the first document.
+//
+// The file is rewound before returning.
+func detectBackupFormat(f io.ReadSeeker) (format string, err error) {
+	defer func() {
+		_, seekErr := f.Seek(0, io.SeekStart)
+		if err == nil {
+			err = seekErr
+		}
+	}()
+
+	_, carErr := newCARReader(f)
+	if carErr == nil {
+		return client.BackupFormatCAR, nil
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			// Let the json reader report empty files.
+			return client.BackupFormatJSON, nil
+		}
+		switch {
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			continue
+		case b >= 0xa0 && b <= 0xbf:
+			// CBOR map (major type 5).
+			return client.BackupFormatCBOR, nil
+		case b != '{':
+			return client.BackupFormatJSON, nil
+		}
+		break
+	}
+	err = r.UnreadByte()
+	if err != nil {
+		return "", err
+	}
+
+	d := json.NewDecoder(r)
+	tokens := make([]json.Token, 0, 3)
+	for len(tokens) < 3 {
+		t, err := d.Token()
+		if err != nil {
+			// Let the json reader report malformed files.
+			return client.BackupFormatJSON, nil
+		}
+		tokens = append(tokens, t)
+	}
+	if _, ok := tokens[2].(string); ok && tokens[1] == "collection" {
+		return client.BackupFormatNDJSON, nil
+	}
+	return client.BackupFormatJSON, nil
+}
+
+// backupReader reads the documents of a backup one at a time.
+type backupReader interface {
+	// next returns the next record of the backup, or io.EOF if all records have been read.
+	//
+	// A record without a document may be returned to signal the start of a collection.
+	next() (backupRecord, error)
+}
+
+func newBackupReader(format string, r io.Reader) (backupReader, error) {
+	switch format {
+	case client.BackupFormatJSON:
+		return &jsonBackupReader{d: json.NewDecoder(r)}, nil
+	case client.BackupFormatNDJSON:
+		return &ndjsonBackupReader{d: json.NewDecoder(r)}, nil
+	case client.BackupFormatCBOR:
+		dm, err := cbor.DecOptions{
+			DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+			IntDec:         cbor.IntDecConvertSignedOrFail,
+		}.DecMode()
+		if err != nil {
+			return nil, err
+		}
+		return &cborBackupReader{d: dm.NewDecoder(r)}, nil
+	default:
+		return nil, NewErrUnsupportedBackupFormat(format)
+	}
+}
+
+// jsonBackupReader reads a json backup by streaming the documents of each collection array.
+type jsonBackupReader struct {
+	d       *json.Decoder
+	started bool
+	// pending is true if the name of a collection has been read but not its array.
+	pending bool
+	// open is true while reading the array of a collection.
+	open       bool
+	collection string
+}
+
+func (r *jsonBackupReader) next() (backupRecord, error) {
+	if !r.started {
+		t, err := r.token()
+		if err != nil {
+			return backupRecord{}, err
+		}
+		if t != json.Delim('{') {
+			return backupRecord{}, ErrExpectedJSONObject
+		}
+		r.started = true
+	}
+
+	if r.pending {
+		t, err := r.token()
+		if err != nil {
+			return backupRecord{}, err
+		}
+		if t != json.Delim('[') {
+			return backupRecord{}, ErrExpectedJSONArray
+		}
+		r.pending = false
+		r.open = true
+	}
+
+	if r.open {
+		if r.d.More() {
+			docMap := map[string]any{}
+			err := r.d.Decode(&docMap)
+			if err != nil {
+				return backupRecord{}, NewErrJSONDecode(err)
+			}
+			return backupRecord{Collection: r.collection, Document: docMap}, nil
+		}
+		// close collection
+		_, err := r.token()
+		if err != nil {
+			return backupRecord{}, err
+		}
+		r.open = false
+	}
+
+	if !r.d.More() {
+		// close object
+		_, err := r.token()
+		if err != nil {
+			return backupRecord{}, err
+		}
+		return backupRecord{}, io.EOF
+	}
+	t, err := r.token()
+	if err != nil {
+		return backupRecord{}, err
+	}
+	// The array of the collection is read by the next call, so that an unknown
+	// collection is reported before its content.
+	r.collection, _ = t.(string)
+	r.pending = true
+	return backupRecord{Collection: r.collection}, nil
+}
+
+// token returns the next json token.
+//
+// The end of the backup is only expected once its object is closed, so io.EOF is never returned.
+func (r *jsonBackupReader) token() (json.Token, error) {
+	t, err := r.d.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return t, err
+}
+
+// ndjsonBackupReader reads a backup made of newline delimited json records.
+type ndjsonBackupReader struct {
+	d *json.Decoder
+}
+
+func (r *ndjsonBackupReader) next() (backupRecord, error) {
+	var record backupRecord
+	err := r.d.Decode(&record)
+	if errors.Is(err, io.EOF) {
+		return backupRecord{}, err
+	}
+	if err != nil {
+		return backupRecord{}, NewErrJSONDecode(err)
+	}
+	return record, nil
+}
+
+// cborBackupReader reads a backup made of a sequence of cbor records.
+type cborBackupReader struct {
+	d *cbor.Decoder
+}
+
+func (r *cborBackupReader) next() (backupRecord, error) {
+	var record backupRecord
+	err := r.d.Decode(&record)
+	if errors.Is(err, io.EOF) {
+		return backupRecord{}, err
+	}
+	if err != nil {
+		return backupRecord{}, NewErrCBORDecode(err)
+	}
+	return record, nil
+}
+
+// backupWriter writes the documents of a backup.
+type backupWriter interface {
+	// open starts writing the backup.
+	open() error
+	// beginCollection starts writing the documents of the collection with the given name.
+	beginCollection(name string) error
+	// writeDocument writes the given document to the current collection.
+	writeDocument(docMap map[string]any) error
+	// endCollection finishes writing the documents of the current collection.
+	endCollection() error
+	// close finishes writing the backup.
+	close() error
+}
+
+func newBackupWriter(format string, w io.Writer, pretty bool) (backupWriter, error) {
+	switch format {
+	case client.BackupFormatJSON:
+		return &jsonBackupWriter{w: w, pretty: pretty}, nil
+	case client.BackupFormatNDJSON:
+		return &ndjsonBackupWriter{e: json.NewEncoder(w)}, nil
+	case client.BackupFormatCBOR:
+		em, err := cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+		if err != nil {
+			return nil, err
+		}
+		return &cborBackupWriter{e: em.NewEncoder(w)}, nil
+	default:
+		return nil, NewErrUnsupportedBackupFormat(format)
+	}
+}
+
+// jsonBackupWriter writes a single json object holding an array of documents per collection.
+type jsonBackupWriter struct {
+	w        io.Writer
+	pretty   bool
+	firstCol bool
+	firstDoc bool
+}
+
+func (bw *jsonBackupWriter) open() error {
+	bw.firstCol = true
+	return writeString(bw.w, "{", "{\n", bw.pretty)
+}
+
+func (bw *jsonBackupWriter) beginCollection(name string) error {
+	if bw.firstCol {
+		bw.firstCol = false
+	} else {
+		// add collection separator
+		err := writeString(bw.w, ",", ",\n", bw.pretty)
+		if err != nil {
+			return err
+		}
+	}
+	bw.firstDoc = true
+	return writeString(
+		bw.w,
+		fmt.Sprintf("\"%s\":[", name),
+		fmt.Sprintf("  \"%s\": [\n", name),
+		bw.pretty,
+	)
+}
+
+func (bw *jsonBackupWriter) writeDocument(docMap map[string]any) error {
+	if bw.firstDoc {
+		bw.firstDoc = false
+	} else {
+		// add document separator
+		err := writeString(bw.w, ",", ",\n", bw.pretty)
+		if err != nil {
+			return err
+		}
+	}
+
+	var b []byte
+	var err error
+	if bw.pretty {
+		_, err = io.WriteString(bw.w, "    ")
+		if err != nil {
+			return NewErrFailedToWriteString(err)
+		}
+		b, err = json.MarshalIndent(docMap, "    ", "  ")
+		if err != nil {
+			return NewErrFailedToWriteString(err)
+		}
+	} else {
+		b, err = json.Marshal(docMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	// write document
+	_, err = bw.w.Write(b)
+	return err
+}
+
+func (bw *jsonBackupWriter) endCollection() error {
+	return writeString(bw.w, "]", "\n  ]", bw.pretty)
+}
+
+func (bw *jsonBackupWriter) close() error {
+	return writeString(bw.w, "}", "\n}", bw.pretty)
+}
+
+// ndjsonBackupWriter writes a json record per document on its own line.
+type ndjsonBackupWriter struct {
+	e          *json.Encoder
+	collection string
+}
+
+func (bw *ndjsonBackupWriter) open() error {
+	return nil
+}
+
+func (bw *ndjsonBackupWriter) beginCollection(name string) error {
+	bw.collection = name
+	return nil
+}
+
+func (bw *ndjsonBackupWriter) writeDocument(docMap map[string]any) error {
+	return bw.e.Encode(backupRecord{Collection: bw.collection, Document: docMap})
+}
+
+func (bw *ndjsonBackupWriter) endCollection() error {
+	return nil
+}
+
+func (bw *ndjsonBackupWriter) close() error {
+	return nil
+}
+
+// cborBackupWriter writes a cbor record per document.
+type cborBackupWriter struct {
+	e          *cbor.Encoder
+	collection string
+}
+
+func (bw *cborBackupWriter) open() error {
+	return nil
+}
+
+func (bw *cborBackupWriter) beginCollection(name string) error {
+	bw.collection = name
+	return nil
+}
+
+func (bw *cborBackupWriter) writeDocument(docMap map[string]any) error {
+	values := make(map[string]any, len(docMap))
+	for k, v := range docMap {
+		values[k] = toCBORBackupValue(v)
+	}
+	return bw.e.Encode(backupRecord{Collection: bw.collection, Document: values})
+}
+
+func (bw *cborBackupWriter) endCollection() error {
+	return nil
+}
+
+func (bw *cborBackupWriter) close() error {
+	return nil
+}
+
+// toCBORBackupValue converts the given document value into a value that is encoded to cbor
+// the same way it is encoded to json.
+func toCBORBackupValue(value any) any {
+	switch v := value.(type) {
+	case client.JSON:
+		return v.Unwrap()
+	case []immutable.Option[bool]:
+		return optionValues(v)
+	case []immutable.Option[int64]:
+		return optionValues(v)
+	case []immutable.Option[float64]:
+		return optionValues(v)
+	case []immutable.Option[string]:
+		return optionValues(v)
+	case []immutable.Option[time.Time]:
+		return optionValues(v)
+	default:
+		return value
+	}
+}
+
+func optionValues[T any](values []immutable.Option[T]) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		if v.HasValue() {
+			result[i] = v.Value()
+		}
+	}
+	return result
+}
+
+func writeString(w io.Writer, normal, pretty string, isPretty bool) error {
+	if isPretty {
+		_, err := io.WriteString(w, pretty)
+		if err != nil {
+			return NewErrFailedToWriteString(err)
+		}
+		return nil
+	}
+
+	_, err := io.WriteString(w, normal)
+	if err != nil {
+		return NewErrFailedToWriteString(err)
+	}
+	return nil
+}
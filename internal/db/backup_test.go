@@ -576,3 +576,168 @@ func TestBasicImport_WithInvalidCollection_ReturnError(t *testing.T) {
 	err = txn.Commit(ctx)
 	require.NoError(t, err)
 }
+
+func TestBasicExport_WithUnsupportedFormat_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	filepath := t.TempDir() + "/test.xml"
+	err = db.BasicExport(ctx, &client.BackupConfig{Filepath: filepath, Format: "xml"})
+	require.ErrorIs(t, err, ErrUnsupportedBackupFormat)
+
+	_, err = os.Stat(filepath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func detectBackupFormatOfFile(filepath string) (string, error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck
+	return detectBackupFormat(f)
+}
+
+func TestBasicExportImport_WithCARFormat_RestoresHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User {
+		name: String @index
+		age: Int
+		books: [Book]
+	}
+	type Book {
+		title: String
+		author: User
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+
+	deleted, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 40}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, deleted)
+	require.NoError(t, err)
+	_, err = col.Delete(ctx, deleted.ID())
+	require.NoError(t, err)
+
+	bookCol, err := db.GetCollectionByName(ctx, "Book")
+	require.NoError(t, err)
+	book, err := client.NewDocFromJSON(
+		[]byte(`{"title": "Game of Thrones", "author": "`+doc.ID().String()+`"}`),
+		bookCol.Definition(),
+	)
+	require.NoError(t, err)
+	err = bookCol.Create(ctx, book)
+	require.NoError(t, err)
+
+	// Only the User collection is selected, the related Book collection is included.
+	filepath := t.TempDir() + "/test.car"
+	err = db.BasicExport(ctx, &client.BackupConfig{
+		Filepath:    filepath,
+		Format:      client.BackupFormatCAR,
+		Collections: []string{"User"},
+	})
+	require.NoError(t, err)
+
+	detected, err := detectBackupFormatOfFile(filepath)
+	require.NoError(t, err)
+	require.Equal(t, client.BackupFormatCAR, detected)
+
+	newDB, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer newDB.Close()
+
+	err = newDB.BasicImport(ctx, filepath)
+	require.NoError(t, err)
+
+	request := `query {
+		User {
+			_docID
+			name
+			age
+			books {
+				_docID
+				title
+			}
+		}
+		commits {
+			cid
+			docID
+			fieldName
+			height
+		}
+	}`
+	expected := db.ExecRequest(ctx, request)
+	require.Len(t, expected.GQL.Errors, 0)
+	require.Len(t, expected.GQL.Data.(map[string]any)["commits"], 12)
+	actual := newDB.ExecRequest(ctx, request)
+	require.Len(t, actual.GQL.Errors, 0)
+	require.Equal(t, expected.GQL.Data, actual.GQL.Data)
+
+	indexes, err := newDB.GetAllIndexes(ctx)
+	require.NoError(t, err)
+	require.Len(t, indexes["User"], 1)
+
+	// Importing the backup again has no effect.
+	err = newDB.BasicImport(ctx, filepath)
+	require.NoError(t, err)
+	actual = newDB.ExecRequest(ctx, request)
+	require.Len(t, actual.GQL.Errors, 0)
+	require.Equal(t, expected.GQL.Data, actual.GQL.Data)
+}
+
+func TestBasicImport_WithCARFormatAndConflictingCollection_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	filepath := t.TempDir() + "/test.car"
+	err = db.BasicExport(ctx, &client.BackupConfig{Filepath: filepath, Format: client.BackupFormatCAR})
+	require.NoError(t, err)
+
+	newDB, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer newDB.Close()
+
+	_, err = newDB.AddSchema(ctx, `type Book {
+		title: String
+	}`)
+	require.NoError(t, err)
+
+	err = newDB.BasicImport(ctx, filepath)
+	require.ErrorIs(t, err, ErrBackupCollectionConflict)
+}
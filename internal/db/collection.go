@@ -607,12 +607,31 @@ func (c *collection) save(
 	if err := c.validateEncryptedFields(ctx); err != nil {
 		return err
 	}
+	ctx = c.db.setContextSigner(ctx)
+	ctx = c.db.setContextTimestamp(ctx)
+
+	change := event.DocChange{
+		Kind:           event.DocCreated,
+		DocID:          doc.ID().String(),
+		CollectionName: c.Name().Value(),
+		Values:         getDocValues(doc),
+	}
 
 	if !isCreate {
 		err := c.updateIndexedDoc(ctx, doc)
 		if err != nil {
 			return err
 		}
+
+		change.Kind = event.DocUpdated
+		// the previous values must be read before the new ones are written, and are only
+		// read if anyone may receive them
+		if c.db.events.HasSubscribers(event.DocChangeName) {
+			change.Previous, err = c.getPreviousValues(ctx, doc)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	txn := mustGetContextTxn(ctx)
 
@@ -625,6 +644,13 @@ func (c *collection) save(
 		doc.Clean()
 	})
 
+	if isCreate {
+		err := c.db.addDocuments(ctx, txn, c.Description().RootID, 1)
+		if err != nil {
+			return err
+		}
+	}
+
 	// New batch transaction/store (optional/todo)
 	// Ensute/Set doc object marker
 	// Loop through doc values
@@ -707,6 +733,12 @@ func (c *collection) save(
 		c.db.events.Publish(event.NewMessage(event.UpdateName, updateEvent))
 	})
 
+	// publish a change event when the txn succeeds
+	change.Cid = link.Cid
+	txn.OnSuccess(func() {
+		c.db.events.Publish(event.NewMessage(event.DocChangeName, change))
+	})
+
 	txn.OnSuccess(func() {
 		doc.SetHead(link.Cid)
 	})
@@ -737,6 +769,57 @@ func (c *collection) save(
 	return nil
 }
 
+// getDocValues returns the values of the given document keyed by field name.
+func getDocValues(doc *client.Document) map[string]any {
+	values := map[string]any{
+		request.DocIDFieldName: doc.ID().String(),
+	}
+	for field, value := range doc.Values() {
+		values[field.Name()] = value.Value()
+	}
+	return values
+}
+
+// getPreviousValues returns the currently stored values of the fields that have been
+// changed on the given document, keyed by field name.
+func (c *collection) getPreviousValues(
+	ctx context.Context,
+	doc *client.Document,
+) (map[string]any, error) {
+	fields := []client.FieldDefinition{}
+	for field, value := range doc.Values() {
+		if !value.IsDirty() {
+			continue
+		}
+		fieldDefinition, ok := c.Definition().GetFieldByName(field.Name())
+		if !ok {
+			return nil, client.NewErrFieldNotExist(field.Name())
+		}
+		fields = append(fields, fieldDefinition)
+	}
+	if len(fields) == 0 {
+		return map[string]any{}, nil
+	}
+
+	previousDoc, err := c.get(ctx, c.getPrimaryKeyFromDocID(doc.ID()), fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]any, len(fields))
+	for _, field := range fields {
+		previous[field.Name] = nil
+		if previousDoc == nil {
+			continue
+		}
+		value, err := previousDoc.GetValue(field.Name)
+		if err == nil {
+			previous[field.Name] = value.Value()
+		}
+	}
+	return previous, nil
+}
+
 func (c *collection) validateOneToOneLinkDoesntAlreadyExist(
 	ctx context.Context,
 	docID string,
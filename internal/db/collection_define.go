@@ -88,6 +88,13 @@ func (db *db) createCollections(
 			return nil, err
 		}
 
+		if desc.RootID == desc.ID {
+			err = db.stats.initDocumentCount(ctx, txn, desc.RootID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		col := db.newCollection(desc, def.Schema)
 
 		for _, index := range desc.Indexes {
@@ -139,7 +139,26 @@ func (c *collection) applyDelete(
 		return client.ErrDocumentNotFoundOrNotAuthorized
 	}
 
+	change := event.DocChange{
+		Kind:           event.DocDeleted,
+		DocID:          primaryKey.DocID,
+		CollectionName: c.Name().Value(),
+	}
+	// the values must be read before the document is marked as deleted, and are only
+	// read if anyone may receive them
+	if c.db.events.HasSubscribers(event.DocChangeName) {
+		doc, err := c.get(ctx, primaryKey, nil, false)
+		if err != nil {
+			return err
+		}
+		if doc != nil {
+			change.Values = getDocValues(doc)
+		}
+	}
+
 	txn := mustGetContextTxn(ctx)
+	ctx = c.db.setContextSigner(ctx)
+	ctx = c.db.setContextTimestamp(ctx)
 
 	merkleCRDT := merklecrdt.NewMerkleCompositeDAG(
 		txn,
@@ -163,6 +182,17 @@ func (c *collection) applyDelete(
 		c.db.events.Publish(event.NewMessage(event.UpdateName, updateEvent))
 	})
 
+	// publish a change event if the txn succeeds
+	change.Cid = link.Cid
+	txn.OnSuccess(func() {
+		c.db.events.Publish(event.NewMessage(event.DocChangeName, change))
+	})
+
+	err = c.db.addDocuments(ctx, txn, c.Description().RootID, -1)
+	if err != nil {
+		return err
+	}
+
 	if c.def.Description.IsBranchable {
 		collectionCRDT := merklecrdt.NewMerkleCollection(
 			txn,
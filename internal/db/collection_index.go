@@ -15,6 +15,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -250,6 +251,16 @@ func (c *collection) createIndex(
 		return nil, err
 	}
 
+	err = c.checkIndexFilterFields(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	desc.Filter, err = normalizeIndexFilter(desc.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	indexKey, err := c.generateIndexNameIfNeededAndCreateKey(ctx, &desc)
 	if err != nil {
 		return nil, err
@@ -284,6 +295,11 @@ func (c *collection) createIndex(
 	}
 	c.def.Description.Indexes = append(c.def.Description.Indexes, colIndex.Description())
 	c.indexes = append(c.indexes, colIndex)
+	// the sketch must be set before the existing documents are counted in it
+	statsKey := keys.NewIndexStatsKey(c.ID(), desc.ID)
+	txn.OnSuccess(func() {
+		c.db.stats.initIndex(statsKey)
+	})
 	err = c.indexExistingDocs(ctx, colIndex)
 	if err != nil {
 		removeErr := colIndex.RemoveAll(ctx, txn)
@@ -348,9 +364,19 @@ func (c *collection) indexExistingDocs(
 	ctx context.Context,
 	index CollectionIndex,
 ) error {
-	fields := make([]client.FieldDefinition, 0, 1)
+	fieldNames := make([]string, 0, len(index.Description().Fields))
 	for _, field := range index.Description().Fields {
-		colField, ok := c.Definition().GetFieldByName(field.Name)
+		fieldNames = append(fieldNames, field.Name)
+	}
+	// the fields of a partial index filter are needed to tell if a document is indexed
+	for _, fieldName := range index.Description().FilterFieldNames() {
+		if !slices.Contains(fieldNames, fieldName) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	fields := make([]client.FieldDefinition, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		colField, ok := c.Definition().GetFieldByName(fieldName)
 		if ok {
 			fields = append(fields, colField)
 		}
@@ -403,8 +429,10 @@ func (c *collection) dropIndex(ctx context.Context, indexName string) error {
 		return NewErrIndexWithNameDoesNotExists(indexName)
 	}
 
+	var indexID uint32
 	for i := range c.Description().Indexes {
 		if c.Description().Indexes[i].Name == indexName {
+			indexID = c.Description().Indexes[i].ID
 			c.def.Description.Indexes = append(c.Description().Indexes[:i], c.Description().Indexes[i+1:]...)
 			break
 		}
@@ -415,6 +443,15 @@ func (c *collection) dropIndex(ctx context.Context, indexName string) error {
 		return err
 	}
 
+	statsKey := keys.NewIndexStatsKey(c.ID(), indexID)
+	err = txn.Systemstore().Delete(ctx, statsKey.ToDS())
+	if err != nil {
+		return err
+	}
+	txn.OnSuccess(func() {
+		c.db.stats.removeIndex(statsKey)
+	})
+
 	return nil
 }
 
@@ -489,6 +526,36 @@ func (c *collection) checkExistingFieldsAndAdjustRelFieldNames(
 	return nil
 }
 
+// checkIndexFilterFields checks if the fields referred to by the filter of the index
+// are scalar fields of the collection.
+func (c *collection) checkIndexFilterFields(desc client.IndexDescription) error {
+	for _, fieldName := range desc.FilterFieldNames() {
+		field, found := c.Definition().GetFieldByName(fieldName)
+		if !found || field.Kind.IsObject() {
+			return NewErrInvalidIndexFilterField(fieldName)
+		}
+	}
+	return nil
+}
+
+// normalizeIndexFilter returns the given index filter in the form it takes once stored,
+// so that the index behaves the same before and after being loaded again.
+func normalizeIndexFilter(filter map[string]any) (map[string]any, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+	buf, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	err = json.Unmarshal(buf, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *collection) generateIndexNameIfNeededAndCreateKey(
 	ctx context.Context,
 	desc *client.IndexDescription,
@@ -536,6 +603,14 @@ func validateIndexDescription(desc client.IndexDescription) error {
 			return ErrIndexFieldMissingName
 		}
 	}
+	if desc.Type == client.IndexTypeFullText {
+		if len(desc.Fields) > 1 {
+			return ErrFullTextIndexMultipleFields
+		}
+		if desc.Unique {
+			return ErrFullTextIndexUnique
+		}
+	}
 	return nil
 }
 
@@ -16,22 +16,45 @@ import (
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/internal/metric"
+	"github.com/sourcenetwork/defradb/internal/request/graphql"
 )
 
 const (
 	defaultMaxTxnRetries  = 5
 	updateEventBufferSize = 100
+	// defaultQueryMemoryBudget is the default number of bytes a request may hold in memory before
+	// spilling to disk.
+	defaultQueryMemoryBudget = 256 << 20
+	// defaultMaxPreparedRequests is the default maximum number of prepared requests kept.
+	defaultMaxPreparedRequests = 1000
 )
 
 type dbOptions struct {
-	maxTxnRetries  immutable.Option[int]
-	RetryIntervals []time.Duration
-	identity       immutable.Option[identity.Identity]
+	maxTxnRetries     immutable.Option[int]
+	RetryIntervals    []time.Duration
+	identity          immutable.Option[identity.Identity]
+	signBlocks        bool
+	timestampBlocks   bool
+	requireSignatures bool
+	queryMemoryBudget int64
+
+	maxRequestDuration    time.Duration
+	maxRequestScannedDocs uint64
+
+	requestCacheSize    int
+	maxPreparedRequests int
+
+	slowRequestThreshold time.Duration
+	meter                *metric.Meter
 }
 
 // defaultOptions returns the default db options.
 func defaultOptions() *dbOptions {
 	return &dbOptions{
+		queryMemoryBudget:   defaultQueryMemoryBudget,
+		requestCacheSize:    graphql.DefaultRequestCacheSize,
+		maxPreparedRequests: defaultMaxPreparedRequests,
 		RetryIntervals: []time.Duration{
 			// exponential backoff retry intervals
 			time.Second * 30,
@@ -68,3 +91,105 @@ func WithNodeIdentity(ident identity.Identity) Option {
 		opts.identity = immutable.Some(ident)
 	}
 }
+
+// WithBlockSigning enables or disables the signing of new blocks.
+//
+// When enabled, blocks are signed using the request identity if it has a private key,
+// otherwise the node identity is used. Blocks are not signed if neither are available.
+func WithBlockSigning(enable bool) Option {
+	return func(opts *dbOptions) {
+		opts.signBlocks = enable
+	}
+}
+
+// WithBlockTimestamps enables or disables the timestamping of new blocks.
+//
+// When enabled, new blocks hold the time at which they were created, which allows
+// documents to be queried as of a point in time.
+func WithBlockTimestamps(enable bool) Option {
+	return func(opts *dbOptions) {
+		opts.timestampBlocks = enable
+	}
+}
+
+// WithRequiredBlockSignatures enables or disables the rejection of unsigned blocks.
+//
+// When enabled, blocks received from other nodes that do not hold a signature are
+// not merged. Blocks holding an invalid signature are always rejected.
+func WithRequiredBlockSignatures(enable bool) Option {
+	return func(opts *dbOptions) {
+		opts.requireSignatures = enable
+	}
+}
+
+// WithQueryMemoryBudget sets the maximum number of bytes the documents held in memory by
+// a request may take, past which ordered and grouped documents are spilled to disk.
+//
+// A budget of zero or less disables spilling.
+func WithQueryMemoryBudget(budget int64) Option {
+	return func(opts *dbOptions) {
+		opts.queryMemoryBudget = budget
+	}
+}
+
+// WithMaxRequestDuration sets the maximum duration of a request, past which it is canceled.
+// Requests may set their own maximum duration, which is only used if it is lower.
+//
+// A duration of zero or less disables the limit.
+func WithMaxRequestDuration(duration time.Duration) Option {
+	return func(opts *dbOptions) {
+		opts.maxRequestDuration = duration
+	}
+}
+
+// WithMaxRequestScannedDocs sets the maximum number of documents a request may scan.
+// Requests may set their own maximum, which is only used if it is lower.
+//
+// A count of zero disables the limit.
+func WithMaxRequestScannedDocs(count uint64) Option {
+	return func(opts *dbOptions) {
+		opts.maxRequestScannedDocs = count
+	}
+}
+
+// WithRequestCacheSize sets the maximum number of requests whose validated AST, and mapped
+// query, are cached so that requests executed many times are only parsed, validated and
+// mapped once.
+//
+// A size of zero or less disables the cache.
+func WithRequestCacheSize(size int) Option {
+	return func(opts *dbOptions) {
+		opts.requestCacheSize = size
+	}
+}
+
+// WithMaxPreparedRequests sets the maximum number of requests prepared with PrepareRequest
+// that are kept. Once reached, the least recently used prepared requests are evicted and
+// must be prepared again.
+//
+// The count must be greater than zero.
+func WithMaxPreparedRequests(count int) Option {
+	return func(opts *dbOptions) {
+		opts.maxPreparedRequests = count
+	}
+}
+
+// WithSlowRequestThreshold sets the duration past which requests are logged as slow, along with
+// the identity that executed them and the documents scanned by each of their scan nodes.
+//
+// A duration of zero or less disables the slow request log.
+func WithSlowRequestThreshold(duration time.Duration) Option {
+	return func(opts *dbOptions) {
+		opts.slowRequestThreshold = duration
+	}
+}
+
+// WithMeter sets the meter recording the duration of requests and the documents they scan
+// by collection and index, as well as the backlog and pushes of the replicators.
+//
+// The meter must be registered. Without a meter no request metrics are recorded.
+func WithMeter(meter *metric.Meter) Option {
+	return func(opts *dbOptions) {
+		opts.meter = meter
+	}
+}
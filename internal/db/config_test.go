@@ -29,3 +29,27 @@ func TestWithRetryInterval(t *testing.T) {
 	WithRetryInterval([]time.Duration{time.Minute, time.Hour})(&d)
 	assert.Equal(t, []time.Duration{time.Minute, time.Hour}, d.RetryIntervals)
 }
+
+func TestWithMaxRequestDuration(t *testing.T) {
+	d := dbOptions{}
+	WithMaxRequestDuration(time.Minute)(&d)
+	assert.Equal(t, time.Minute, d.maxRequestDuration)
+}
+
+func TestWithMaxRequestScannedDocs(t *testing.T) {
+	d := dbOptions{}
+	WithMaxRequestScannedDocs(100)(&d)
+	assert.Equal(t, uint64(100), d.maxRequestScannedDocs)
+}
+
+func TestWithRequestCacheSize(t *testing.T) {
+	d := dbOptions{}
+	WithRequestCacheSize(10)(&d)
+	assert.Equal(t, 10, d.requestCacheSize)
+}
+
+func TestWithMaxPreparedRequests(t *testing.T) {
+	d := dbOptions{}
+	WithMaxPreparedRequests(10)(&d)
+	assert.Equal(t, 10, d.maxPreparedRequests)
+}
@@ -0,0 +1,464 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package container
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/core"
+)
+
+// The tags below prefix every value written to a spill file, so that the exact Go type
+// of the value can be restored when it is read back.
+const (
+	tagNil byte = iota
+	tagBool
+	tagInt
+	tagInt32
+	tagInt64
+	tagUint64
+	tagFloat32
+	tagFloat64
+	tagString
+	tagBytes
+	tagTime
+	tagDoc
+	tagDocs
+	tagAnys
+	tagMap
+	tagJSON
+	tagBools
+	tagInt64s
+	tagFloat64s
+	tagStrings
+	tagTimes
+	tagNillableBool
+	tagNillableInt64
+	tagNillableFloat64
+	tagNillableString
+	tagNillableBytes
+	tagNillableTime
+	tagNillableBools
+	tagNillableInt64s
+	tagNillableFloat64s
+	tagNillableStrings
+)
+
+// appendDoc appends the binary encoding of the given document to the buffer.
+func appendDoc(buf []byte, doc core.Doc) ([]byte, error) {
+	buf = appendBool(buf, doc.Hidden)
+	buf = append(buf, byte(doc.Status))
+	buf = appendString(buf, doc.SchemaVersionID)
+	buf = binary.AppendUvarint(buf, uint64(len(doc.Fields)))
+	for _, value := range doc.Fields {
+		var err error
+		buf, err = appendValue(buf, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func appendValue(buf []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, tagNil), nil
+	case bool:
+		return appendBool(append(buf, tagBool), v), nil
+	case int:
+		return binary.AppendVarint(append(buf, tagInt), int64(v)), nil
+	case int32:
+		return binary.AppendVarint(append(buf, tagInt32), int64(v)), nil
+	case int64:
+		return binary.AppendVarint(append(buf, tagInt64), v), nil
+	case uint64:
+		return binary.AppendUvarint(append(buf, tagUint64), v), nil
+	case float32:
+		return binary.BigEndian.AppendUint32(append(buf, tagFloat32), math.Float32bits(v)), nil
+	case float64:
+		return appendFloat64(append(buf, tagFloat64), v), nil
+	case string:
+		return appendString(append(buf, tagString), v), nil
+	case []byte:
+		return appendBytes(append(buf, tagBytes), v), nil
+	case time.Time:
+		return appendTime(append(buf, tagTime), v)
+	case core.Doc:
+		return appendDoc(append(buf, tagDoc), v)
+	case []core.Doc:
+		buf = binary.AppendUvarint(append(buf, tagDocs), uint64(len(v)))
+		for _, doc := range v {
+			var err error
+			buf, err = appendDoc(buf, doc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case []any:
+		buf = binary.AppendUvarint(append(buf, tagAnys), uint64(len(v)))
+		for _, item := range v {
+			var err error
+			buf, err = appendValue(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]any:
+		buf = binary.AppendUvarint(append(buf, tagMap), uint64(len(v)))
+		for key, item := range v {
+			buf = appendString(buf, key)
+			var err error
+			buf, err = appendValue(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case client.JSON:
+		data, err := v.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return appendBytes(append(buf, tagJSON), data), nil
+	case []bool:
+		return appendSlice(append(buf, tagBools), v, appendBool), nil
+	case []int64:
+		return appendSlice(append(buf, tagInt64s), v, binary.AppendVarint), nil
+	case []float64:
+		return appendSlice(append(buf, tagFloat64s), v, appendFloat64), nil
+	case []string:
+		return appendSlice(append(buf, tagStrings), v, appendString), nil
+	case []time.Time:
+		buf = binary.AppendUvarint(append(buf, tagTimes), uint64(len(v)))
+		for _, item := range v {
+			var err error
+			buf, err = appendTime(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case immutable.Option[bool]:
+		return appendOption(append(buf, tagNillableBool), v, appendBool), nil
+	case immutable.Option[int64]:
+		return appendOption(append(buf, tagNillableInt64), v, binary.AppendVarint), nil
+	case immutable.Option[float64]:
+		return appendOption(append(buf, tagNillableFloat64), v, appendFloat64), nil
+	case immutable.Option[string]:
+		return appendOption(append(buf, tagNillableString), v, appendString), nil
+	case immutable.Option[[]byte]:
+		return appendOption(append(buf, tagNillableBytes), v, appendBytes), nil
+	case immutable.Option[time.Time]:
+		buf = append(buf, tagNillableTime)
+		buf = appendBool(buf, v.HasValue())
+		if !v.HasValue() {
+			return buf, nil
+		}
+		return appendTime(buf, v.Value())
+	case []immutable.Option[bool]:
+		return appendNillableSlice(append(buf, tagNillableBools), v, appendBool), nil
+	case []immutable.Option[int64]:
+		return appendNillableSlice(append(buf, tagNillableInt64s), v, binary.AppendVarint), nil
+	case []immutable.Option[float64]:
+		return appendNillableSlice(append(buf, tagNillableFloat64s), v, appendFloat64), nil
+	case []immutable.Option[string]:
+		return appendNillableSlice(append(buf, tagNillableStrings), v, appendString), nil
+	default:
+		return nil, NewErrUnsupportedSpillValue(value)
+	}
+}
+
+func appendBool(buf []byte, v bool) []byte {
+	if v {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func appendFloat64(buf []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(v))
+}
+
+func appendString(buf []byte, v string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendBytes(buf []byte, v []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendTime(buf []byte, v time.Time) ([]byte, error) {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return appendBytes(buf, data), nil
+}
+
+func appendSlice[T any](buf []byte, items []T, appendItem func([]byte, T) []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(items)))
+	for _, item := range items {
+		buf = appendItem(buf, item)
+	}
+	return buf
+}
+
+func appendOption[T any](buf []byte, item immutable.Option[T], appendItem func([]byte, T) []byte) []byte {
+	buf = appendBool(buf, item.HasValue())
+	if item.HasValue() {
+		buf = appendItem(buf, item.Value())
+	}
+	return buf
+}
+
+func appendNillableSlice[T any](
+	buf []byte,
+	items []immutable.Option[T],
+	appendItem func([]byte, T) []byte,
+) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(items)))
+	for _, item := range items {
+		buf = appendOption(buf, item, appendItem)
+	}
+	return buf
+}
+
+// docReader reads documents written by appendDoc.
+type docReader struct {
+	r *bufio.Reader
+}
+
+func (d *docReader) readDoc() (core.Doc, error) {
+	hidden, err := d.readBool()
+	if err != nil {
+		return core.Doc{}, err
+	}
+	status, err := d.r.ReadByte()
+	if err != nil {
+		return core.Doc{}, err
+	}
+	schemaVersionID, err := d.readString()
+	if err != nil {
+		return core.Doc{}, err
+	}
+	length, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return core.Doc{}, err
+	}
+	fields := make(core.DocFields, length)
+	for i := range fields {
+		fields[i], err = d.readValue()
+		if err != nil {
+			return core.Doc{}, err
+		}
+	}
+	return core.Doc{
+		Hidden:          hidden,
+		Fields:          fields,
+		Status:          client.DocumentStatus(status),
+		SchemaVersionID: schemaVersionID,
+	}, nil
+}
+
+func (d *docReader) readValue() (any, error) {
+	tag, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case tagNil:
+		return nil, nil
+	case tagBool:
+		return d.readBool()
+	case tagInt:
+		v, err := binary.ReadVarint(d.r)
+		return int(v), err
+	case tagInt32:
+		v, err := binary.ReadVarint(d.r)
+		return int32(v), err
+	case tagInt64:
+		return binary.ReadVarint(d.r)
+	case tagUint64:
+		return binary.ReadUvarint(d.r)
+	case tagFloat32:
+		var data [4]byte
+		if _, err := io.ReadFull(d.r, data[:]); err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(data[:])), nil
+	case tagFloat64:
+		return d.readFloat64()
+	case tagString:
+		return d.readString()
+	case tagBytes:
+		return d.readBytes()
+	case tagTime:
+		return d.readTime()
+	case tagDoc:
+		return d.readDoc()
+	case tagDocs:
+		return readSlice(d, (*docReader).readDoc)
+	case tagAnys:
+		return readSlice(d, (*docReader).readValue)
+	case tagMap:
+		length, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, length)
+		for i := uint64(0); i < length; i++ {
+			key, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			result[key], err = d.readValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	case tagJSON:
+		data, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return client.NewJSON(value)
+	case tagBools:
+		return readSlice(d, (*docReader).readBool)
+	case tagInt64s:
+		return readSlice(d, (*docReader).readInt64)
+	case tagFloat64s:
+		return readSlice(d, (*docReader).readFloat64)
+	case tagStrings:
+		return readSlice(d, (*docReader).readString)
+	case tagTimes:
+		return readSlice(d, (*docReader).readTime)
+	case tagNillableBool:
+		return readOption(d, (*docReader).readBool)
+	case tagNillableInt64:
+		return readOption(d, (*docReader).readInt64)
+	case tagNillableFloat64:
+		return readOption(d, (*docReader).readFloat64)
+	case tagNillableString:
+		return readOption(d, (*docReader).readString)
+	case tagNillableBytes:
+		return readOption(d, (*docReader).readBytes)
+	case tagNillableTime:
+		return readOption(d, (*docReader).readTime)
+	case tagNillableBools:
+		return readNillableSlice(d, (*docReader).readBool)
+	case tagNillableInt64s:
+		return readNillableSlice(d, (*docReader).readInt64)
+	case tagNillableFloat64s:
+		return readNillableSlice(d, (*docReader).readFloat64)
+	case tagNillableStrings:
+		return readNillableSlice(d, (*docReader).readString)
+	default:
+		return nil, NewErrInvalidSpilledValue(tag)
+	}
+}
+
+func (d *docReader) readBool() (bool, error) {
+	b, err := d.r.ReadByte()
+	return b == 1, err
+}
+
+func (d *docReader) readInt64() (int64, error) {
+	return binary.ReadVarint(d.r)
+}
+
+func (d *docReader) readFloat64() (float64, error) {
+	var data [8]byte
+	if _, err := io.ReadFull(d.r, data[:]); err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(data[:])), nil
+}
+
+func (d *docReader) readBytes() ([]byte, error) {
+	length, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (d *docReader) readString() (string, error) {
+	data, err := d.readBytes()
+	return string(data), err
+}
+
+func (d *docReader) readTime() (time.Time, error) {
+	data, err := d.readBytes()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var v time.Time
+	err = v.UnmarshalBinary(data)
+	return v, err
+}
+
+func readSlice[T any](d *docReader, readItem func(*docReader) (T, error)) ([]T, error) {
+	length, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, length)
+	for i := range items {
+		items[i], err = readItem(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func readOption[T any](d *docReader, readItem func(*docReader) (T, error)) (immutable.Option[T], error) {
+	hasValue, err := d.readBool()
+	if err != nil || !hasValue {
+		return immutable.None[T](), err
+	}
+	item, err := readItem(d)
+	if err != nil {
+		return immutable.None[T](), err
+	}
+	return immutable.Some(item), nil
+}
+
+func readNillableSlice[T any](
+	d *docReader,
+	readItem func(*docReader) (T, error),
+) ([]immutable.Option[T], error) {
+	return readSlice(d, func(d *docReader) (immutable.Option[T], error) {
+		return readOption(d, readItem)
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package container
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errUnsupportedSpillValue string = "value can not be spilled to disk"
+	errInvalidSpilledValue   string = "invalid value read from spill file"
+	errFailedToSpill         string = "failed to spill documents to disk"
+)
+
+var (
+	ErrUnsupportedSpillValue = errors.New(errUnsupportedSpillValue)
+	ErrInvalidSpilledValue   = errors.New(errInvalidSpilledValue)
+	ErrFailedToSpill         = errors.New(errFailedToSpill)
+)
+
+// NewErrUnsupportedSpillValue returns an error indicating that a document holds a value
+// of a type that can not be written to a spill file.
+func NewErrUnsupportedSpillValue(value any) error {
+	return errors.New(errUnsupportedSpillValue, errors.NewKV("Type", fmt.Sprintf("%T", value)))
+}
+
+// NewErrInvalidSpilledValue returns an error indicating that a spill file holds an unknown value tag.
+func NewErrInvalidSpilledValue(tag byte) error {
+	return errors.New(errInvalidSpilledValue, errors.NewKV("Tag", tag))
+}
+
+// NewErrFailedToSpill returns an error indicating that documents could not be written to,
+// or read back from, a spill file.
+func NewErrFailedToSpill(inner error) error {
+	return errors.Wrap(errFailedToSpill, inner)
+}
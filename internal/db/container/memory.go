@@ -0,0 +1,160 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package container
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/internal/core"
+)
+
+// The sizes below are rough estimates of the memory held by the values of a document,
+// they do not need to be exact as they are only used to decide when to spill to disk.
+const (
+	docSize    = 64
+	valueSize  = 16
+	optionSize = 24
+	timeSize   = 24
+)
+
+// MemoryBudget tracks the memory held by the operators of a request, so that they
+// can spill their documents to disk once the limit is reached.
+//
+// A nil budget, or one with a limit of zero or less, is unlimited.
+type MemoryBudget struct {
+	limit int64
+	used  int64
+	peak  int64
+}
+
+// NewMemoryBudget returns a new budget with the given limit in bytes.
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{
+		limit: limit,
+	}
+}
+
+// Limit returns the limit of the budget in bytes.
+func (b *MemoryBudget) Limit() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.limit
+}
+
+// Used returns the number of bytes currently reserved from the budget.
+func (b *MemoryBudget) Used() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.used
+}
+
+// Peak returns the highest number of bytes reserved from the budget at once.
+func (b *MemoryBudget) Peak() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.peak
+}
+
+// Reserve reserves the given number of bytes from the budget.
+//
+// It returns false if the budget is exceeded, in which case the caller should release
+// memory, by spilling to disk, as soon as it can.
+func (b *MemoryBudget) Reserve(size int64) bool {
+	if b == nil {
+		return true
+	}
+	b.used += size
+	if b.used > b.peak {
+		b.peak = b.used
+	}
+	return b.limit <= 0 || b.used <= b.limit
+}
+
+// Release returns the given number of bytes to the budget.
+func (b *MemoryBudget) Release(size int64) {
+	if b == nil {
+		return
+	}
+	b.used -= size
+}
+
+// DocSize returns the estimated number of bytes held by the given document.
+func DocSize(doc core.Doc) int64 {
+	size := int64(docSize + len(doc.SchemaVersionID))
+	for _, value := range doc.Fields {
+		size += valueMemorySize(value)
+	}
+	return size
+}
+
+func valueMemorySize(value any) int64 {
+	switch v := value.(type) {
+	case nil:
+		return valueSize
+	case string:
+		return valueSize + int64(len(v))
+	case []byte:
+		return valueSize + int64(len(v))
+	case time.Time:
+		return valueSize + timeSize
+	case core.Doc:
+		return valueSize + DocSize(v)
+	case []core.Doc:
+		size := int64(valueSize)
+		for _, doc := range v {
+			size += DocSize(doc)
+		}
+		return size
+	case []any:
+		size := int64(valueSize)
+		for _, item := range v {
+			size += valueMemorySize(item)
+		}
+		return size
+	case map[string]any:
+		size := int64(valueSize)
+		for key, item := range v {
+			size += int64(len(key)) + valueMemorySize(item)
+		}
+		return size
+	case []string:
+		size := int64(valueSize)
+		for _, item := range v {
+			size += valueSize + int64(len(item))
+		}
+		return size
+	case []bool:
+		return valueSize + int64(len(v))
+	case []int64:
+		return valueSize + 8*int64(len(v))
+	case []float64:
+		return valueSize + 8*int64(len(v))
+	case []immutable.Option[string]:
+		size := int64(valueSize)
+		for _, item := range v {
+			size += optionSize + int64(len(item.Value()))
+		}
+		return size
+	case []immutable.Option[bool]:
+		return valueSize + optionSize*int64(len(v))
+	case []immutable.Option[int64]:
+		return valueSize + optionSize*int64(len(v))
+	case []immutable.Option[float64]:
+		return valueSize + optionSize*int64(len(v))
+	default:
+		return valueSize
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package container
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/sourcenetwork/defradb/internal/core"
+)
+
+// spillFilePattern is the name pattern of the temporary files documents are spilled to.
+const spillFilePattern = "defradb-spill-*"
+
+const (
+	// maxOpenRuns is the maximum number of sorted runs a sorter keeps open at once, once
+	// reached the runs are merged together into a single one.
+	maxOpenRuns = 16
+	// minSpillShare is the share of the memory budget, as a divisor of its limit, the documents
+	// held by a sorter must reach before they are spilled. Below it the budget has been exceeded
+	// by the other operators of the request, which are expected to release their own memory.
+	minSpillShare = 4
+)
+
+// Sorter is a document buffer that yields its documents in the order defined by a compare function.
+//
+// Documents are held in memory until the memory budget is exceeded, at which point they are
+// sorted and written to a temporary file as a sorted run. Once all the documents have been added,
+// the runs are merged back together with the documents remaining in memory. The number of runs
+// open at once is bounded by merging them together in passes whilst documents are being added.
+//
+// The sort is stable, documents that compare as equal are yielded in the order they were added.
+type Sorter struct {
+	budget  *MemoryBudget
+	compare func(a, b core.Doc) int
+
+	docs     []core.Doc
+	reserved int64
+
+	runs        []*spillRun
+	spilledRuns int
+	spilledDocs uint64
+
+	merged   bool
+	merge    mergeHeap
+	docIndex int
+	current  core.Doc
+}
+
+// NewSorter returns a new sorter ordering its documents with the given compare function,
+// which must return a negative number if a must be yielded before b, a positive number if
+// it must be yielded after it and zero otherwise.
+func NewSorter(budget *MemoryBudget, compare func(a, b core.Doc) int) *Sorter {
+	return &Sorter{
+		budget:   budget,
+		compare:  compare,
+		docIndex: -1,
+	}
+}
+
+// Add adds a copy of the given document to the sorter, spilling the documents held
+// in memory to disk if the memory budget is exceeded and they hold a meaningful share of it.
+func (s *Sorter) Add(doc core.Doc) error {
+	s.docs = append(s.docs, doc.Clone())
+	size := DocSize(doc)
+	s.reserved += size
+	if !s.budget.Reserve(size) && s.holdsSpillableShare() {
+		return s.Spill()
+	}
+	return nil
+}
+
+// holdsSpillableShare returns true if the documents held in memory are worth spilling.
+//
+// A single document is never spilled on its own, as that would only produce a run per document.
+func (s *Sorter) holdsSpillableShare() bool {
+	return len(s.docs) > 1 && s.reserved >= s.budget.Limit()/minSpillShare
+}
+
+// Spill writes the documents held in memory to disk as a sorted run, and releases their memory.
+//
+// If the maximum number of open runs is reached, all the runs are merged into a single one.
+func (s *Sorter) Spill() error {
+	if len(s.docs) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(s.docs, s.compare)
+	run, err := writeSpillRun(s.docs)
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, run)
+	s.spilledRuns++
+	s.spilledDocs += uint64(len(s.docs))
+	s.docs = nil
+	s.budget.Release(s.reserved)
+	s.reserved = 0
+
+	if len(s.runs) >= maxOpenRuns {
+		return s.mergeRuns()
+	}
+	return nil
+}
+
+// mergeRuns merges all the spilled runs into a single one, closing the merged runs.
+func (s *Sorter) mergeRuns() error {
+	w, err := createSpillRun()
+	if err != nil {
+		return err
+	}
+	merge := mergeHeap{compare: s.compare}
+	for i, run := range s.runs {
+		if err := pushFromRun(&merge, i, run); err != nil {
+			return closeFailedRun(err, w.run)
+		}
+	}
+	for merge.Len() > 0 {
+		entry := heap.Pop(&merge).(mergeEntry)
+		if err := w.write(entry.doc); err != nil {
+			return err
+		}
+		if err := pushFromRun(&merge, entry.source, s.runs[entry.source]); err != nil {
+			return closeFailedRun(err, w.run)
+		}
+	}
+	merged, err := w.finish()
+	if err != nil {
+		return err
+	}
+
+	var closeErr error
+	for _, run := range s.runs {
+		if err := run.close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	s.runs = []*spillRun{merged}
+	return closeErr
+}
+
+// Finish sorts the documents held in memory and prepares the merge of the spilled runs.
+//
+// No more documents may be added once Finish has been called.
+func (s *Sorter) Finish() error {
+	slices.SortStableFunc(s.docs, s.compare)
+	if len(s.runs) == 0 {
+		return nil
+	}
+	s.merged = true
+	s.merge = mergeHeap{compare: s.compare}
+	for i, run := range s.runs {
+		if err := pushFromRun(&s.merge, i, run); err != nil {
+			return err
+		}
+	}
+	s.pushFromMemory()
+	return nil
+}
+
+// Next moves to the next document in order, returning false once all have been yielded.
+func (s *Sorter) Next() (bool, error) {
+	if !s.merged {
+		if s.docIndex >= len(s.docs)-1 {
+			return false, nil
+		}
+		s.docIndex++
+		s.current = s.docs[s.docIndex]
+		return true, nil
+	}
+
+	if s.merge.Len() == 0 {
+		return false, nil
+	}
+	entry := heap.Pop(&s.merge).(mergeEntry)
+	s.current = entry.doc
+	if entry.source < len(s.runs) {
+		if err := pushFromRun(&s.merge, entry.source, s.runs[entry.source]); err != nil {
+			return false, err
+		}
+	} else {
+		s.pushFromMemory()
+	}
+	return true, nil
+}
+
+// Value returns the current document.
+func (s *Sorter) Value() core.Doc {
+	return s.current
+}
+
+// SpilledRuns returns the number of sorted runs written to disk, excluding the runs
+// produced by merging them together.
+func (s *Sorter) SpilledRuns() int {
+	return s.spilledRuns
+}
+
+// SpilledDocs returns the number of documents written to disk.
+func (s *Sorter) SpilledDocs() uint64 {
+	return s.spilledDocs
+}
+
+// Close releases the documents held in memory and removes the spilled runs from disk.
+func (s *Sorter) Close() error {
+	s.docs = nil
+	s.current = core.Doc{}
+	s.merge.entries = nil
+	s.budget.Release(s.reserved)
+	s.reserved = 0
+
+	var closeErr error
+	for _, run := range s.runs {
+		if err := run.close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	s.runs = nil
+	return closeErr
+}
+
+// pushFromRun pushes the next document of the given run, if any, to the given heap.
+func pushFromRun(merge *mergeHeap, index int, run *spillRun) error {
+	doc, ok, err := run.next()
+	if err != nil {
+		return err
+	}
+	if ok {
+		heap.Push(merge, mergeEntry{doc: doc, source: index})
+	}
+	return nil
+}
+
+func (s *Sorter) pushFromMemory() {
+	if s.docIndex >= len(s.docs)-1 {
+		return
+	}
+	s.docIndex++
+	// The documents held in memory were added after all the spilled ones, so they
+	// are given the last source index to keep the merge stable.
+	heap.Push(&s.merge, mergeEntry{doc: s.docs[s.docIndex], source: len(s.runs)})
+}
+
+// mergeEntry is the next document of a sorted run, or of the documents held in memory.
+type mergeEntry struct {
+	doc    core.Doc
+	source int
+}
+
+// mergeHeap holds the next document of every sorted source, with the first one at its root.
+type mergeHeap struct {
+	entries []mergeEntry
+	compare func(a, b core.Doc) int
+}
+
+var _ heap.Interface = (*mergeHeap)(nil)
+
+func (h *mergeHeap) Len() int      { return len(h.entries) }
+func (h *mergeHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *mergeHeap) Push(x any)    { h.entries = append(h.entries, x.(mergeEntry)) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	if c := h.compare(h.entries[i].doc, h.entries[j].doc); c != 0 {
+		return c < 0
+	}
+	return h.entries[i].source < h.entries[j].source
+}
+
+func (h *mergeHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// spillRun is a sorted run of documents written to a temporary file.
+type spillRun struct {
+	file      *os.File
+	reader    docReader
+	remaining int
+}
+
+// spillRunWriter writes a sorted run of documents to a temporary file.
+type spillRunWriter struct {
+	run    *spillRun
+	writer *bufio.Writer
+	buf    []byte
+}
+
+func createSpillRun() (*spillRunWriter, error) {
+	file, err := os.CreateTemp("", spillFilePattern)
+	if err != nil {
+		return nil, NewErrFailedToSpill(err)
+	}
+	return &spillRunWriter{
+		run:    &spillRun{file: file},
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// write appends the given document to the run, removing the run if it fails.
+func (w *spillRunWriter) write(doc core.Doc) error {
+	var err error
+	w.buf, err = appendDoc(w.buf[:0], doc)
+	if err != nil {
+		return closeFailedRun(err, w.run)
+	}
+	if _, err := w.writer.Write(w.buf); err != nil {
+		return closeFailedRun(NewErrFailedToSpill(err), w.run)
+	}
+	w.run.remaining++
+	return nil
+}
+
+// finish flushes the run to disk and rewinds it so that it can be read.
+func (w *spillRunWriter) finish() (*spillRun, error) {
+	if err := w.writer.Flush(); err != nil {
+		return nil, closeFailedRun(NewErrFailedToSpill(err), w.run)
+	}
+	if _, err := w.run.file.Seek(0, io.SeekStart); err != nil {
+		return nil, closeFailedRun(NewErrFailedToSpill(err), w.run)
+	}
+	w.run.reader = docReader{r: bufio.NewReader(w.run.file)}
+	return w.run, nil
+}
+
+func writeSpillRun(docs []core.Doc) (*spillRun, error) {
+	w, err := createSpillRun()
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if err := w.write(doc); err != nil {
+			return nil, err
+		}
+	}
+	return w.finish()
+}
+
+// next reads the next document of the run, returning false once all have been read.
+func (r *spillRun) next() (core.Doc, bool, error) {
+	if r.remaining == 0 {
+		return core.Doc{}, false, nil
+	}
+	doc, err := r.reader.readDoc()
+	if err != nil {
+		return core.Doc{}, false, NewErrFailedToSpill(err)
+	}
+	r.remaining--
+	return doc, true, nil
+}
+
+// close closes and removes the file of the run.
+func (r *spillRun) close() error {
+	closeErr := r.file.Close()
+	if err := os.Remove(r.file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// closeFailedRun removes a run that failed to be written, returning the original error.
+func closeFailedRun(err error, run *spillRun) error {
+	_ = run.close()
+	return err
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package container
+
+import (
+	"cmp"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/core"
+)
+
+func compareFirstField(a, b core.Doc) int {
+	return cmp.Compare(a.Fields[0].(int64), b.Fields[0].(int64))
+}
+
+func collectSorted(t *testing.T, s *Sorter) []core.Doc {
+	require.NoError(t, s.Finish())
+	var result []core.Doc
+	for {
+		hasNext, err := s.Next()
+		require.NoError(t, err)
+		if !hasNext {
+			return result
+		}
+		result = append(result, s.Value())
+	}
+}
+
+func TestSorter_WithinBudget_ShouldNotSpill(t *testing.T) {
+	s := NewSorter(NewMemoryBudget(1<<20), compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+
+	for _, v := range []int64{3, 1, 2} {
+		require.NoError(t, s.Add(core.Doc{Fields: core.DocFields{v}}))
+	}
+
+	docs := collectSorted(t, s)
+	assert.Equal(t, []core.Doc{
+		{Fields: core.DocFields{int64(1)}},
+		{Fields: core.DocFields{int64(2)}},
+		{Fields: core.DocFields{int64(3)}},
+	}, docs)
+	assert.Equal(t, 0, s.SpilledRuns())
+}
+
+func TestSorter_IfBudgetExceeded_ShouldSpillAndMergeStably(t *testing.T) {
+	budget := NewMemoryBudget(3 * DocSize(core.Doc{Fields: core.DocFields{int64(0), "0"}}))
+	s := NewSorter(budget, compareFirstField)
+
+	const count = 50
+	for i := 0; i < count; i++ {
+		// only a few distinct keys so that the stability of the merge is exercised
+		doc := core.Doc{Fields: core.DocFields{int64((count - i) % 5), string(rune('a' + i%26))}}
+		require.NoError(t, s.Add(doc))
+	}
+
+	docs := collectSorted(t, s)
+	require.Len(t, docs, count)
+	assert.Greater(t, s.SpilledRuns(), 1)
+	assert.Greater(t, s.SpilledDocs(), uint64(0))
+
+	for i := 1; i < len(docs); i++ {
+		assert.LessOrEqual(t, docs[i-1].Fields[0].(int64), docs[i].Fields[0].(int64))
+	}
+	// documents with equal keys must keep the order they were added in
+	var sameKey []string
+	for _, doc := range docs {
+		if doc.Fields[0].(int64) == 0 {
+			sameKey = append(sameKey, doc.Fields[1].(string))
+		}
+	}
+	assert.Equal(t, []string{"a", "f", "k", "p", "u", "z", "e", "j", "o", "t"}, sameKey)
+
+	require.NoError(t, s.Close())
+	assert.Equal(t, int64(0), budget.Used())
+}
+
+func TestSorter_IfBudgetExceeded_ShouldRestoreValueTypes(t *testing.T) {
+	jsonValue, err := client.NewJSON(map[string]any{"name": "John", "tags": []any{"a", "b"}})
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	doc := core.Doc{
+		Hidden:          true,
+		Status:          client.Deleted,
+		SchemaVersionID: "bafy",
+		Fields: core.DocFields{
+			int64(1),
+			nil,
+			true,
+			3,
+			uint64(4),
+			1.5,
+			float32(2.5),
+			"text",
+			[]byte{1, 2},
+			now,
+			jsonValue,
+			[]bool{true, false},
+			[]int64{1, 2},
+			[]float64{1.5},
+			[]string{"a", "b"},
+			[]time.Time{now},
+			immutable.Some[int64](5),
+			immutable.None[string](),
+			immutable.Some(now),
+			[]immutable.Option[int64]{immutable.Some[int64](1), immutable.None[int64]()},
+			[]immutable.Option[string]{immutable.None[string](), immutable.Some("b")},
+			[]any{int64(1), "a"},
+			map[string]any{"key": "value"},
+			core.Doc{Fields: core.DocFields{"child"}},
+			[]core.Doc{{Fields: core.DocFields{"group", int64(2)}}},
+		},
+	}
+
+	s := NewSorter(NewMemoryBudget(1), compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+	require.NoError(t, s.Add(doc))
+	require.NoError(t, s.Spill())
+	require.Equal(t, 1, s.SpilledRuns())
+
+	docs := collectSorted(t, s)
+	require.Len(t, docs, 1)
+	assert.Equal(t, doc.Clone(), docs[0])
+}
+
+func TestSorter_WithUnsupportedValue_ShouldError(t *testing.T) {
+	s := NewSorter(NewMemoryBudget(1), compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+
+	require.NoError(t, s.Add(core.Doc{Fields: core.DocFields{int64(1), struct{}{}}}))
+	err := s.Spill()
+	require.ErrorIs(t, err, ErrUnsupportedSpillValue)
+}
+
+func TestSorter_IfBudgetExceededBySingleDoc_ShouldNotSpill(t *testing.T) {
+	s := NewSorter(NewMemoryBudget(1), compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+
+	require.NoError(t, s.Add(core.Doc{Fields: core.DocFields{int64(1)}}))
+	assert.Equal(t, 0, s.SpilledRuns())
+
+	require.NoError(t, s.Add(core.Doc{Fields: core.DocFields{int64(2)}}))
+	assert.Equal(t, 1, s.SpilledRuns())
+}
+
+func TestSorter_IfBudgetExceededByOtherHolders_ShouldNotSpillSmallBuffer(t *testing.T) {
+	doc := core.Doc{Fields: core.DocFields{int64(0)}}
+	budget := NewMemoryBudget(100 * DocSize(doc))
+	// another operator of the request holds the whole budget
+	require.False(t, budget.Reserve(budget.Limit()+1))
+
+	s := NewSorter(budget, compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, s.Add(doc))
+	}
+	assert.Equal(t, 0, s.SpilledRuns())
+}
+
+func TestSorter_WithManyRuns_ShouldBoundOpenRuns(t *testing.T) {
+	s := NewSorter(NewMemoryBudget(1), compareFirstField)
+	defer func() { require.NoError(t, s.Close()) }()
+
+	const count = 1000
+	for i := 0; i < count; i++ {
+		require.NoError(t, s.Add(core.Doc{Fields: core.DocFields{int64((count - i) % 7), int64(i)}}))
+		require.Less(t, len(s.runs), maxOpenRuns)
+	}
+
+	docs := collectSorted(t, s)
+	require.Len(t, docs, count)
+	assert.Equal(t, count/2, s.SpilledRuns())
+	assert.Equal(t, uint64(count), s.SpilledDocs())
+	for i := 1; i < len(docs); i++ {
+		prev, cur := docs[i-1].Fields, docs[i].Fields
+		require.LessOrEqual(t, prev[0].(int64), cur[0].(int64))
+		if prev[0] == cur[0] {
+			// documents with equal keys must keep the order they were added in
+			require.Less(t, prev[1].(int64), cur[1].(int64))
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/event"
 	"github.com/sourcenetwork/defradb/internal/core"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
 	"github.com/sourcenetwork/defradb/internal/db/permission"
 	"github.com/sourcenetwork/defradb/internal/keys"
 	"github.com/sourcenetwork/defradb/internal/request/graphql"
@@ -80,6 +82,34 @@ type db struct {
 	// The identity of the current node
 	nodeIdentity immutable.Option[identity.Identity]
 
+	// If true new blocks will be signed using the request or node identity.
+	signBlocks bool
+
+	// If true new blocks will hold the time at which they were created.
+	timestampBlocks bool
+
+	// If true blocks received from other nodes must be signed to be merged.
+	requireSignatures bool
+
+	// The maximum number of bytes the documents held in memory by a request may take.
+	queryMemoryBudget int64
+
+	// The most recently used requests prepared with PrepareRequest, by ID.
+	preparedRequests *lru.Cache[string, preparedRequest]
+
+	// The most recently executed queries, mapped against the collections, by normalized request
+	// and variables. It is nil if the request cache is disabled.
+	mappedRequests *lru.Cache[string, mappedRequest]
+
+	// The maximum duration of a request, and number of documents it may scan, that requests may only lower.
+	maxRequestDuration    time.Duration
+	maxRequestScannedDocs uint64
+
+	// The duration past which requests are logged as slow, zero if disabled.
+	slowRequestThreshold time.Duration
+	// The instruments recording the execution of requests, nil if disabled.
+	metrics *requestMetrics
+
 	// Contains ACP if it exists
 	acp immutable.Option[acp.ACP]
 
@@ -87,6 +117,14 @@ type db struct {
 	// if network is enabled. The `atomic.Value` should hold a `peer.AddrInfo` struct.
 	peerInfo atomic.Value
 
+	// The number of blocks pushed by peers that were rejected, by peer ID.
+	rejectedPushes   map[string]uint64
+	rejectedPushesMu sync.Mutex
+
+	// The outcome of the last pushes to replicators, by peer ID.
+	replicatorPushes   map[string]replicatorPushes
+	replicatorPushesMu sync.Mutex
+
 	// To be able to close the context passed to NewDB on DB close,
 	// we need to keep a reference to the cancel function. Otherwise,
 	// some goroutines might leak.
@@ -95,6 +133,9 @@ type db struct {
 	// The intervals at which to retry replicator failures.
 	// For example, this can define an exponential backoff strategy.
 	retryIntervals []time.Duration
+
+	// The estimated statistics of the collections and indexes used to plan requests.
+	stats *statistics
 }
 
 // NewDB creates a new instance of the DB using the given options.
@@ -117,28 +158,53 @@ func newDB(
 ) (*db, error) {
 	multistore := datastore.MultiStoreFrom(rootstore)
 
-	parser, err := graphql.NewParser()
+	opts := defaultOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	parser, err := graphql.NewParser(opts.requestCacheSize)
 	if err != nil {
 		return nil, err
 	}
 
-	opts := defaultOptions()
-	for _, opt := range options {
-		opt(opts)
+	preparedRequests, err := lru.New[string, preparedRequest](opts.maxPreparedRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	var mappedRequests *lru.Cache[string, mappedRequest]
+	if opts.requestCacheSize > 0 {
+		mappedRequests, err = lru.New[string, mappedRequest](opts.requestCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var metrics *requestMetrics
+	if opts.meter != nil {
+		metrics, err = newRequestMetrics(opts.meter)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
 
 	db := &db{
-		rootstore:      rootstore,
-		multistore:     multistore,
-		acp:            acp,
-		lensRegistry:   lens,
-		parser:         parser,
-		options:        options,
-		events:         event.NewBus(commandBufferSize, eventBufferSize),
-		ctxCancel:      cancel,
-		retryIntervals: opts.RetryIntervals,
+		rootstore:        rootstore,
+		multistore:       multistore,
+		acp:              acp,
+		lensRegistry:     lens,
+		parser:           parser,
+		options:          options,
+		events:           event.NewBus(commandBufferSize, eventBufferSize),
+		ctxCancel:        cancel,
+		retryIntervals:   opts.RetryIntervals,
+		preparedRequests: preparedRequests,
+		mappedRequests:   mappedRequests,
+		rejectedPushes:   make(map[string]uint64),
+		replicatorPushes: make(map[string]replicatorPushes),
 	}
 
 	if opts.maxTxnRetries.HasValue() {
@@ -146,6 +212,21 @@ func newDB(
 	}
 
 	db.nodeIdentity = opts.identity
+	db.signBlocks = opts.signBlocks
+	db.timestampBlocks = opts.timestampBlocks
+	db.requireSignatures = opts.requireSignatures
+	db.queryMemoryBudget = opts.queryMemoryBudget
+	db.maxRequestDuration = opts.maxRequestDuration
+	db.maxRequestScannedDocs = opts.maxRequestScannedDocs
+	db.slowRequestThreshold = opts.slowRequestThreshold
+	db.metrics = metrics
+
+	if opts.meter != nil {
+		err = db.registerReplicatorMetrics(opts.meter)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if lens != nil {
 		lens.Init(db)
@@ -156,7 +237,18 @@ func newDB(
 		return nil, err
 	}
 
-	sub, err := db.events.Subscribe(event.MergeName, event.PeerInfoName, event.ReplicatorFailureName)
+	db.stats, err = loadStatistics(ctx, multistore.Systemstore())
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := db.events.Subscribe(
+		event.MergeName,
+		event.PeerInfoName,
+		event.ReplicatorFailureName,
+		event.ReplicatorSuccessName,
+		event.PushRejectedName,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -346,6 +438,31 @@ func (db *db) GetNodeIdentity(context.Context) (immutable.Option[identity.Public
 	return immutable.None[identity.PublicRawIdentity](), nil
 }
 
+// setContextSigner returns a new context with the identity used to sign new blocks set.
+//
+// The request identity is used if it has a private key, otherwise the node identity is used.
+// The context is returned as is if block signing is disabled.
+func (db *db) setContextSigner(ctx context.Context) context.Context {
+	if !db.signBlocks {
+		return ctx
+	}
+	requestIdentity := identity.FromContext(ctx)
+	if requestIdentity.HasValue() && requestIdentity.Value().PrivateKey != nil {
+		return coreblock.WithSigner(ctx, requestIdentity)
+	}
+	return coreblock.WithSigner(ctx, db.nodeIdentity)
+}
+
+// setContextTimestamp returns a new context with the current time set as the timestamp of new blocks.
+//
+// The context is returned as is if block timestamps are disabled.
+func (db *db) setContextTimestamp(ctx context.Context) context.Context {
+	if !db.timestampBlocks {
+		return ctx
+	}
+	return coreblock.WithTimestamp(ctx, time.Now())
+}
+
 // Initialize is called when a database is first run and creates all the db global meta data
 // like Collection ID counters.
 func (db *db) initialize(ctx context.Context) error {
@@ -432,7 +549,12 @@ func (db *db) Close() {
 
 	db.events.Close()
 
-	err := db.rootstore.Close()
+	err := db.stats.close(context.Background())
+	if err != nil {
+		log.ErrorE("Failure storing statistics", err)
+	}
+
+	err = db.rootstore.Close()
 	if err != nil {
 		log.ErrorE("Failure closing running process", err)
 	}
@@ -21,13 +21,13 @@ import (
 	"github.com/sourcenetwork/defradb/datastore/memory"
 )
 
-func newMemoryDB(ctx context.Context) (*db, error) {
-	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
-	rootstore, err := badgerds.NewDatastore("", &opts)
+func newMemoryDB(ctx context.Context, opts ...Option) (*db, error) {
+	badgerOpts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &badgerOpts)
 	if err != nil {
 		return nil, err
 	}
-	return newDB(ctx, rootstore, acp.NoACP, nil)
+	return newDB(ctx, rootstore, acp.NoACP, nil, opts...)
 }
 
 func newDefraMemoryDB(ctx context.Context) (*db, error) {
@@ -11,6 +11,7 @@
 package db
 
 import (
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -63,6 +64,11 @@ const (
 	errInvalidFieldValue                        string = "invalid field value"
 	errUnsupportedIndexFieldType                string = "unsupported index field type"
 	errIndexDescriptionHasNoFields              string = "index description has no fields"
+	errFullTextIndexMultipleFields              string = "full-text index can only be created on a single field"
+	errFullTextIndexUnique                      string = "full-text index can not be unique"
+	errUnsupportedFullTextIndexFieldType        string = "full-text index can only be created on a String field"
+	errInvalidIndexFilterField                  string = "index filter can only refer to scalar fields of the collection"
+	errJSONPathOnNonJSONField                   string = "index JSON path can only be used on a JSON field"
 	errFieldOrAliasToFieldNotExist              string = "The given field or alias to field does not exist"
 	errCreateFile                               string = "failed to create file"
 	errRemoveFile                               string = "failed to remove file"
@@ -77,6 +83,15 @@ const (
 	errDocUpdate                                string = "failed to update doc to collection"
 	errExpectedJSONObject                       string = "expected JSON object"
 	errExpectedJSONArray                        string = "expected JSON array"
+	errCBORDecode                               string = "failed to decode CBOR"
+	errUnsupportedBackupFormat                  string = "unsupported backup format"
+	errInvalidCARFile                           string = "invalid CAR file"
+	errUnsupportedCARVersion                    string = "unsupported CAR version"
+	errInvalidBackupBlock                       string = "backup block does not match its CID"
+	errBackupCollectionHasPolicy                string = "collections with a policy can not be backed up with their DAG"
+	errBackupCollectionConflict                 string = "backup collection conflicts with an existing collection"
+	errBackupManifestNotFound                   string = "backup manifest not found"
+	errUnsupportedBackupManifestVersion         string = "unsupported backup manifest version"
 	errOneOneAlreadyLinked                      string = "target document is already linked to another document"
 	errIndexDoesNotMatchName                    string = "the index used does not match the given name"
 	errCanNotIndexNonUniqueFields               string = "can not index a doc's field(s) that violates unique index"
@@ -100,6 +115,11 @@ const (
 	errReplicatorDocID                          string = "failed to get docID for replicator"
 	errReplicatorCollections                    string = "failed to get collections for replicator"
 	errReplicatorNotFound                       string = "replicator not found"
+	errInvalidReplicatorFilter                  string = "invalid replicator filter"
+	errReplicatorFilterFields                   string = "replicator filters may only target collection fields"
+	errReplicatorFilterOnBranchable             string = "replicator filters are not supported on branchable collections"
+	errReconcileNotP2PCollection                string = "can't reconcile a collection that is not a P2P collection"
+	errInvalidPeerAccess                        string = "invalid peer access"
 	errCanNotEncryptBuiltinField                string = "can not encrypt build-in field"
 	errFailedToHandleEncKeysReceivedEvent       string = "failed to handle encryption-keys-received event"
 	errSelfReferenceWithoutSelf                 string = "must specify 'Self' kind for self referencing relations"
@@ -109,6 +129,9 @@ const (
 	errInvalidDefaultFieldValue                 string = "default field value is invalid"
 	errDocIDNotFound                            string = "docID not found"
 	errCollectionWithSchemaRootNotFound         string = "collection with schema root not found"
+	errInvalidBlockSignature                    string = "invalid block signature"
+	errMissingBlockSignature                    string = "block is not signed"
+	errInvalidStoredStatistics                  string = "invalid stored statistics"
 )
 
 var (
@@ -125,8 +148,18 @@ var (
 	ErrIndexMissingFields                       = errors.New(errIndexMissingFields)
 	ErrIndexFieldMissingName                    = errors.New(errIndexFieldMissingName)
 	ErrCorruptedIndex                           = errors.New(errCorruptedIndex)
+	ErrFullTextIndexMultipleFields              = errors.New(errFullTextIndexMultipleFields)
+	ErrFullTextIndexUnique                      = errors.New(errFullTextIndexUnique)
 	ErrExpectedJSONObject                       = errors.New(errExpectedJSONObject)
 	ErrExpectedJSONArray                        = errors.New(errExpectedJSONArray)
+	ErrUnsupportedBackupFormat                  = errors.New(errUnsupportedBackupFormat)
+	ErrInvalidCARFile                           = errors.New(errInvalidCARFile)
+	ErrInvalidCARSectionSize                    = errors.New("invalid CAR section size")
+	ErrInvalidBackupBlock                       = errors.New(errInvalidBackupBlock)
+	ErrBackupCollectionHasPolicy                = errors.New(errBackupCollectionHasPolicy)
+	ErrBackupCollectionConflict                 = errors.New(errBackupCollectionConflict)
+	ErrBackupManifestNotFound                   = errors.New(errBackupManifestNotFound)
+	ErrUnsupportedBackupManifestVersion         = errors.New(errUnsupportedBackupManifestVersion)
 	ErrInvalidViewQuery                         = errors.New(errInvalidViewQuery)
 	ErrCanNotIndexNonUniqueFields               = errors.New(errCanNotIndexNonUniqueFields)
 	ErrMultipleActiveCollectionVersions         = errors.New(errMultipleActiveCollectionVersions)
@@ -146,8 +179,15 @@ var (
 	ErrNoTransactionInContext                   = errors.New(errNoTransactionInContext)
 	ErrReplicatorColHasPolicy                   = errors.New("replicator collection specified has a policy on it")
 	ErrSelfTargetForReplicator                  = errors.New("can't target ourselves as a replicator")
+	ErrSelfTargetForReconcile                   = errors.New("can't reconcile with ourselves")
+	ErrReconcileP2PDisabled                     = errors.New("can't reconcile with P2P networking disabled")
 	ErrReplicatorCollections                    = errors.New(errReplicatorCollections)
+	ErrInvalidPeerAccess                        = errors.New(errInvalidPeerAccess)
 	ErrReplicatorNotFound                       = errors.New(errReplicatorNotFound)
+	ErrInvalidReplicatorFilter                  = errors.New(errInvalidReplicatorFilter)
+	ErrReplicatorFilterFields                   = errors.New(errReplicatorFilterFields)
+	ErrReplicatorFilterNotReplicated            = errors.New("replicator filter collection is not replicated")
+	ErrReplicatorFilterOnBranchable             = errors.New(errReplicatorFilterOnBranchable)
 	ErrCanNotEncryptBuiltinField                = errors.New(errCanNotEncryptBuiltinField)
 	ErrSelfReferenceWithoutSelf                 = errors.New(errSelfReferenceWithoutSelf)
 	ErrColNotMaterialized                       = errors.New(errColNotMaterialized)
@@ -158,6 +198,9 @@ var (
 	ErrDocIDNotFound                            = errors.New(errDocIDNotFound)
 	ErrorCollectionWithSchemaRootNotFound       = errors.New(errCollectionWithSchemaRootNotFound)
 	ErrColMutatingIsBranchable                  = errors.New(errColMutatingIsBranchable)
+	ErrInvalidBlockSignature                    = errors.New(errInvalidBlockSignature)
+	ErrMissingBlockSignature                    = errors.New(errMissingBlockSignature)
+	ErrInvalidStoredStatistics                  = errors.New(errInvalidStoredStatistics)
 )
 
 // NewErrFailedToGetHeads returns a new error indicating that the heads of a document
@@ -437,6 +480,34 @@ func NewErrUnsupportedIndexFieldType(kind client.FieldKind) error {
 	)
 }
 
+// NewErrUnsupportedFullTextIndexFieldType returns a new error indicating that a full-text
+// index can not be created on a field of the given kind.
+func NewErrUnsupportedFullTextIndexFieldType(kind client.FieldKind) error {
+	return errors.New(
+		errUnsupportedFullTextIndexFieldType,
+		errors.NewKV("Kind", kind),
+	)
+}
+
+// NewErrJSONPathOnNonJSONField returns a new error indicating that an index field with a JSON
+// path refers to a field that is not of JSON kind.
+func NewErrJSONPathOnNonJSONField(field string, kind client.FieldKind) error {
+	return errors.New(
+		errJSONPathOnNonJSONField,
+		errors.NewKV("Field", field),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+// NewErrInvalidIndexFilterField returns a new error indicating that the filter of a partial
+// index refers to a field that does not exist or is not a scalar field of the collection.
+func NewErrInvalidIndexFilterField(field string) error {
+	return errors.New(
+		errInvalidIndexFilterField,
+		errors.NewKV("Field", field),
+	)
+}
+
 // NewErrIndexDescHasNoFields returns a new error indicating that the given index
 // description has no fields.
 func NewErrIndexDescHasNoFields(desc client.IndexDescription) error {
@@ -499,6 +570,60 @@ func NewErrJSONDecode(inner error) error {
 	return errors.Wrap(errJSONDecode, inner)
 }
 
+// NewErrCBORDecode returns a new error indicating there was a failure in decoding some CBOR
+// from the CBOR decoder
+func NewErrCBORDecode(inner error) error {
+	return errors.Wrap(errCBORDecode, inner)
+}
+
+// NewErrUnsupportedBackupFormat returns a new error indicating that the given backup
+// format is not supported.
+func NewErrUnsupportedBackupFormat(format string) error {
+	return errors.New(errUnsupportedBackupFormat, errors.NewKV("Format", format))
+}
+
+// NewErrInvalidCARFile returns a new error indicating that a backup could not be read
+// as a CAR file.
+func NewErrInvalidCARFile(inner error) error {
+	return errors.Wrap(errInvalidCARFile, inner)
+}
+
+// NewErrUnsupportedCARVersion returns a new error indicating that the version of a CAR
+// file is not supported.
+func NewErrUnsupportedCARVersion(version int64) error {
+	return errors.New(errUnsupportedCARVersion, errors.NewKV("Version", version))
+}
+
+// NewErrInvalidBackupBlock returns a new error indicating that the data of a backup block
+// does not match its CID.
+func NewErrInvalidBackupBlock(cid cid.Cid) error {
+	return errors.New(errInvalidBackupBlock, errors.NewKV("Cid", cid))
+}
+
+// NewErrBackupCollectionHasPolicy returns a new error indicating that a collection with a
+// policy can not be part of a DAG backup.
+func NewErrBackupCollectionHasPolicy(collection string) error {
+	return errors.New(errBackupCollectionHasPolicy, errors.NewKV("Collection", collection))
+}
+
+// NewErrBackupCollectionConflict returns a new error indicating that a collection of a
+// backup has the same ID as a different existing collection.
+func NewErrBackupCollectionConflict(collectionID uint32) error {
+	return errors.New(errBackupCollectionConflict, errors.NewKV("CollectionID", collectionID))
+}
+
+// NewErrBackupManifestNotFound returns a new error indicating that the manifest of a DAG
+// backup could not be found.
+func NewErrBackupManifestNotFound(cid cid.Cid) error {
+	return errors.New(errBackupManifestNotFound, errors.NewKV("Cid", cid))
+}
+
+// NewErrUnsupportedBackupManifestVersion returns a new error indicating that the version of the
+// manifest of a DAG backup is not supported.
+func NewErrUnsupportedBackupManifestVersion(version int) error {
+	return errors.New(errUnsupportedBackupManifestVersion, errors.NewKV("Version", version))
+}
+
 // NewErrDocFromMap returns a new error indicating there was a failure to create
 // a new doc from a map
 func NewErrDocFromMap(inner error) error {
@@ -668,6 +793,20 @@ func NewErrReplicatorCollections(inner error, kv ...errors.KV) error {
 	return errors.Wrap(errReplicatorCollections, inner, kv...)
 }
 
+// NewErrInvalidReplicatorFilter returns a new error indicating that the replicator filter
+// of the given collection is invalid.
+func NewErrInvalidReplicatorFilter(collection string, inner error) error {
+	return errors.Wrap(errInvalidReplicatorFilter, inner, errors.NewKV("Collection", collection))
+}
+
+func NewErrReconcileNotP2PCollection(schemaRoot string) error {
+	return errors.New(errReconcileNotP2PCollection, errors.NewKV("SchemaRoot", schemaRoot))
+}
+
+func NewErrInvalidPeerAccess(access client.PeerAccessKind) error {
+	return errors.New(errInvalidPeerAccess, errors.NewKV("Access", access))
+}
+
 func NewErrSelfReferenceWithoutSelf(fieldName string) error {
 	return errors.New(
 		errSelfReferenceWithoutSelf,
@@ -711,3 +850,17 @@ func NewErrDocIDNotFound(docID string) error {
 func NewErrCollectionWithSchemaRootNotFound(schemaRoot string) error {
 	return errors.New(errCollectionWithSchemaRootNotFound, errors.NewKV("SchemaRoot", schemaRoot))
 }
+
+func NewErrInvalidBlockSignature(cid cid.Cid, inner error) error {
+	return errors.Wrap(errInvalidBlockSignature, inner, errors.NewKV("Cid", cid))
+}
+
+func NewErrMissingBlockSignature(cid cid.Cid) error {
+	return errors.New(errMissingBlockSignature, errors.NewKV("Cid", cid))
+}
+
+// NewErrInvalidStoredStatistics returns a new error indicating that the statistics
+// stored under the given key are invalid.
+func NewErrInvalidStoredStatistics(key string) error {
+	return errors.New(errInvalidStoredStatistics, errors.NewKV("Key", key))
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fetcher
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/acp"
+	acpIdentity "github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/internal/core"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/merkle/clock"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+)
+
+var (
+	// interface check
+	_ Fetcher = (*AsOfFetcher)(nil)
+)
+
+// AsOfFetcher fetches the documents of a collection as they were at a point in their history.
+//
+// For each document, the composite DAG is walked back from its current heads until the latest
+// blocks at or before the requested point are found. The state of the document at those blocks
+// is then recomposed using a [VersionedFetcher].
+//
+// Documents that did not exist at the requested point are skipped, and documents that were
+// deleted at that point are only returned if deleted documents are requested.
+//
+// Current limitations:
+// - Related documents and the `_version` field are fetched at their current state.
+type AsOfFetcher struct {
+	asOf request.AsOf
+
+	identity    immutable.Option[acpIdentity.Identity]
+	txn         datastore.Txn
+	acp         immutable.Option[acp.ACP]
+	col         client.Collection
+	fields      []client.FieldDefinition
+	filter      *mapper.Filter
+	docMapper   *core.DocumentMapping
+	showDeleted bool
+	reverse     bool
+
+	// docIDs holds the IDs of the documents left to fetch, in order.
+	docIDs []string
+	// current is the fetcher of the document being fetched, if any.
+	current *VersionedFetcher
+}
+
+// NewAsOfFetcher returns a new fetcher that fetches documents as they were at the given point.
+func NewAsOfFetcher(asOf request.AsOf) *AsOfFetcher {
+	return &AsOfFetcher{asOf: asOf}
+}
+
+func (f *AsOfFetcher) Init(
+	ctx context.Context,
+	identity immutable.Option[acpIdentity.Identity],
+	txn datastore.Txn,
+	acp immutable.Option[acp.ACP],
+	col client.Collection,
+	fields []client.FieldDefinition,
+	filter *mapper.Filter,
+	docMapper *core.DocumentMapping,
+	reverse bool,
+	showDeleted bool,
+) error {
+	f.identity = identity
+	f.txn = txn
+	f.acp = acp
+	f.col = col
+	f.fields = fields
+	f.filter = filter
+	f.docMapper = docMapper
+	f.reverse = reverse
+	f.showDeleted = showDeleted
+	return nil
+}
+
+// Start collects the IDs of the documents matching the given data store prefixes.
+//
+// Prefixes without a document ID match all the documents of the collection, including
+// documents that have since been deleted.
+func (f *AsOfFetcher) Start(ctx context.Context, prefixes ...keys.Walkable) error {
+	docIDs := make(map[string]struct{})
+	for _, prefix := range prefixes {
+		dsKey, ok := prefix.(keys.DataStoreKey)
+		if !ok {
+			return client.NewErrUnexpectedType[keys.DataStoreKey]("prefix", prefix)
+		}
+		if dsKey.DocID != "" {
+			docIDs[dsKey.DocID] = struct{}{}
+			continue
+		}
+		err := f.collectDocIDs(ctx, docIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	f.docIDs = make([]string, 0, len(docIDs))
+	for docID := range docIDs {
+		f.docIDs = append(f.docIDs, docID)
+	}
+	slices.Sort(f.docIDs)
+	if f.reverse {
+		slices.Reverse(f.docIDs)
+	}
+	return nil
+}
+
+// collectDocIDs adds the IDs of all the documents of the collection to the given set.
+func (f *AsOfFetcher) collectDocIDs(ctx context.Context, docIDs map[string]struct{}) error {
+	prefix := keys.PrimaryDataStoreKey{
+		CollectionRootID: f.col.Description().RootID,
+	}
+	q, err := f.txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return res.Error
+		}
+		docIDs[ds.NewKey(res.Key).BaseNamespace()] = struct{}{}
+	}
+	return q.Close()
+}
+
+func (f *AsOfFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo, error) {
+	var execInfo ExecInfo
+	for {
+		if f.current != nil {
+			doc, info, err := f.current.FetchNext(ctx)
+			execInfo.Add(info)
+			if err != nil {
+				return nil, execInfo, err
+			}
+			if doc != nil {
+				return doc, execInfo, nil
+			}
+			err = f.current.Close()
+			f.current = nil
+			if err != nil {
+				return nil, execInfo, err
+			}
+		}
+
+		if len(f.docIDs) == 0 {
+			return nil, execInfo, nil
+		}
+		docID := f.docIDs[0]
+		f.docIDs = f.docIDs[1:]
+
+		targets, err := f.resolveTargets(ctx, docID)
+		if err != nil {
+			return nil, execInfo, err
+		}
+		if len(targets) == 0 {
+			// the document did not exist at the requested point
+			continue
+		}
+
+		vf := new(VersionedFetcher)
+		err = vf.Init(
+			ctx,
+			f.identity,
+			f.txn,
+			f.acp,
+			f.col,
+			f.fields,
+			f.filter,
+			f.docMapper,
+			false,
+			f.showDeleted,
+		)
+		if err != nil {
+			return nil, execInfo, err
+		}
+		vf.mergeDivergedHistory = true
+		f.current = vf
+
+		prefixes := make([]keys.Walkable, len(targets))
+		for i, target := range targets {
+			prefixes[i] = keys.HeadstoreDocKey{DocID: docID, Cid: target}
+		}
+		err = vf.Start(ctx, prefixes...)
+		if err != nil {
+			return nil, execInfo, err
+		}
+	}
+}
+
+// resolveTargets returns the CIDs of the latest composite blocks of the given document
+// at or before the requested point.
+//
+// The composite DAG is walked back from the current heads of the document, and each branch
+// stops at its first block that is at or before the requested point. No CIDs are returned
+// if the document did not exist at that point.
+func (f *AsOfFetcher) resolveTargets(ctx context.Context, docID string) ([]cid.Cid, error) {
+	headset := clock.NewHeadSet(
+		f.txn.Headstore(),
+		keys.HeadstoreDocKey{DocID: docID, FieldID: core.COMPOSITE_NAMESPACE},
+	)
+	heads, _, err := headset.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []cid.Cid
+	visited := make(map[cid.Cid]struct{})
+	stack := heads
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := visited[c]; ok {
+			continue
+		}
+		visited[c] = struct{}{}
+
+		blk, err := f.txn.Blockstore().Get(ctx, c)
+		if err != nil {
+			return nil, NewErrVFetcherFailedToGetBlock(err)
+		}
+		block, err := coreblock.GetFromBytes(blk.RawData())
+		if err != nil {
+			return nil, NewErrVFetcherFailedToDecodeNode(err)
+		}
+
+		if f.isAtOrBefore(block) {
+			targets = append(targets, c)
+			continue
+		}
+		for _, head := range block.Heads {
+			stack = append(stack, head.Cid)
+		}
+	}
+	return targets, nil
+}
+
+// isAtOrBefore returns true if the given block is at or before the requested point.
+//
+// Blocks without a timestamp are considered to have been created before any time.
+func (f *AsOfFetcher) isAtOrBefore(block *coreblock.Block) bool {
+	if f.asOf.Height.HasValue() {
+		return block.Delta.GetPriority() <= f.asOf.Height.Value()
+	}
+	timestamp := block.GetTimestamp()
+	return !timestamp.HasValue() || !timestamp.Value().After(f.asOf.Time.Value())
+}
+
+func (f *AsOfFetcher) Close() error {
+	if f.current == nil {
+		return nil
+	}
+	err := f.current.Close()
+	f.current = nil
+	return err
+}
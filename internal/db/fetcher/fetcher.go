@@ -673,6 +673,12 @@ func (df *DocumentFetcher) fetchNext(ctx context.Context) (EncodedDocument, Exec
 
 		df.execInfo.DocsFetched++
 
+		// documents that are filtered out are skipped without returning, so the context
+		// is checked here to stop long scans of canceled requests.
+		if ctx.Err() != nil {
+			return nil, ExecInfo{}, context.Cause(ctx)
+		}
+
 		if df.passedPermissionCheck {
 			if df.filter != nil {
 				// if we passed, return
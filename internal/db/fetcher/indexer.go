@@ -82,6 +82,7 @@ func (f *IndexFetcher) Init(
 		}
 	}
 
+	isFullText := f.indexDesc.Type == client.IndexTypeFullText
 	f.docFields = make([]client.FieldDefinition, 0, len(fields))
 outer:
 	for i := range fields {
@@ -89,7 +90,10 @@ outer:
 			// If the field is array, we want to keep it also for the document fetcher
 			// because the index only contains one array elements, not the whole array.
 			// The doc fetcher will fetch the whole array for us.
-			if fields[i].Name == f.indexedFields[j].Name && !fields[i].Kind.IsArray() {
+			// The same applies to full-text indexes that contain only single words
+			// and to JSON path indexes that contain only values within the JSON.
+			if fields[i].Name == f.indexedFields[j].Name && !fields[i].Kind.IsArray() && !isFullText &&
+				len(f.indexDesc.Fields[j].JSONPath) == 0 {
 				continue outer
 			}
 		}
@@ -140,6 +144,10 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 	defer func() { f.execInfo.Add(totalExecInfo) }()
 	f.execInfo.Reset()
 	for {
+		if ctx.Err() != nil {
+			return nil, ExecInfo{}, context.Cause(ctx)
+		}
+
 		f.doc.Reset()
 
 		res, err := f.indexIter.Next()
@@ -153,6 +161,11 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 
 		hasNilField := false
 		for i, indexedField := range f.indexedFields {
+			// Full-text index keys contain a single token of the field value, not the value itself.
+			if f.indexDesc.Type == client.IndexTypeFullText {
+				break
+			}
+
 			property := &encProperty{Desc: indexedField}
 
 			field := res.key.Fields[i]
@@ -161,8 +174,8 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 			}
 
 			// Index will fetch only 1 array element. So we skip it here and let doc fetcher
-			// fetch the whole array.
-			if indexedField.Kind.IsArray() {
+			// fetch the whole array. The same goes for values at a JSON path.
+			if indexedField.Kind.IsArray() || len(f.indexDesc.Fields[i].JSONPath) > 0 {
 				continue
 			}
 
@@ -11,9 +11,12 @@
 package fetcher
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"errors"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,9 +24,13 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/datastore/iterable"
 	"github.com/sourcenetwork/defradb/internal/connor"
+	"github.com/sourcenetwork/defradb/internal/db/base"
+	"github.com/sourcenetwork/defradb/internal/encoding"
 	"github.com/sourcenetwork/defradb/internal/keys"
 	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+	"github.com/sourcenetwork/defradb/internal/utils/text"
 
 	"github.com/ipfs/go-datastore/query"
 )
@@ -41,6 +48,7 @@ const (
 	opNlike    = "_nlike"
 	opILike    = "_ilike"
 	opNILike   = "_nilike"
+	opSearch   = "_search"
 	compOpAny  = "_any"
 	compOpAll  = "_all"
 	compOpNone = "_none"
@@ -150,6 +158,91 @@ func (iter *indexPrefixIterator) Close() error {
 	return iter.resultIter.Close()
 }
 
+// indexRangeIterator is an iterator over index keys within a range.
+//
+// Instead of walking all the keys with the prefix of the index, it seeks directly to
+// the lower bound of the range and stops at its upper bound.
+// The bounds are inclusive and may be wider than the range of keys that actually match,
+// so matchers are still executed for every key within the range.
+type indexRangeIterator struct {
+	indexDesc     client.IndexDescription
+	indexedFields []client.FieldDefinition
+	start         ds.Key
+	end           ds.Key
+	matchers      []valueMatcher
+	execInfo      *ExecInfo
+	kvIter        iterable.Iterator
+	resultIter    query.Results
+	ctx           context.Context
+	store         datastore.DSReaderWriter
+}
+
+var _ indexIterator = (*indexRangeIterator)(nil)
+
+func (iter *indexRangeIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	iter.ctx = ctx
+	iter.store = store
+	return iter.Close()
+}
+
+func (iter *indexRangeIterator) checkResultIterator() error {
+	if iter.resultIter != nil {
+		return nil
+	}
+	kvIter, err := iter.store.GetIterator(query.Query{})
+	if err != nil {
+		return err
+	}
+	iter.kvIter = kvIter
+	resultIter, err := kvIter.IteratePrefix(iter.ctx, iter.start, iter.end)
+	if err != nil {
+		return err
+	}
+	iter.resultIter = resultIter
+	return nil
+}
+
+func (iter *indexRangeIterator) Next() (indexIterResult, error) {
+	if err := iter.checkResultIterator(); err != nil {
+		return indexIterResult{}, err
+	}
+
+	for {
+		res, hasVal := iter.resultIter.NextSync()
+		if res.Error != nil {
+			return indexIterResult{}, res.Error
+		}
+		if !hasVal {
+			return indexIterResult{}, nil
+		}
+		key, err := keys.DecodeIndexDataStoreKey([]byte(res.Key), &iter.indexDesc, iter.indexedFields)
+		if err != nil {
+			return indexIterResult{}, err
+		}
+		iter.execInfo.IndexesFetched++
+		didMatch, err := executeValueMatchers(iter.matchers, key.Fields)
+		if err != nil {
+			return indexIterResult{}, err
+		}
+		if didMatch {
+			return indexIterResult{key: key, value: res.Value, foundKey: true}, nil
+		}
+	}
+}
+
+func (iter *indexRangeIterator) Close() error {
+	var err error
+	if iter.resultIter != nil {
+		err = iter.resultIter.Close()
+		iter.resultIter = nil
+	}
+	if iter.kvIter != nil {
+		err = errors.Join(err, iter.kvIter.Close())
+		iter.kvIter = nil
+	}
+	return err
+}
+
 type eqSingleIndexIterator struct {
 	indexKey keys.IndexDataStoreKey
 	execInfo *ExecInfo
@@ -305,6 +398,195 @@ func (iter *arrayIndexIterator) Close() error {
 	return iter.inner.Close()
 }
 
+// fullTextIndexIterator iterates over the documents that contain all tokens of a search string.
+//
+// On the first call to Next it reads the postings of every token, scores the documents that
+// contain all of them and then yields them ordered by descending relevance.
+type fullTextIndexIterator struct {
+	indexDesc     client.IndexDescription
+	indexedFields []client.FieldDefinition
+	indexKey      keys.IndexDataStoreKey
+	tokens        []string
+	execInfo      *ExecInfo
+	// collectionRootID is the root ID of the collection whose documents are counted to weigh
+	// the rarity of the searched tokens.
+	collectionRootID uint32
+
+	results []fullTextMatch
+	nextRes int
+	fetched bool
+
+	ctx   context.Context
+	store datastore.DSReaderWriter
+}
+
+var _ indexIterator = (*fullTextIndexIterator)(nil)
+
+// fullTextMatch is a document matching a full-text search with its relevance score.
+type fullTextMatch struct {
+	docID string
+	score float64
+}
+
+func (iter *fullTextIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	iter.ctx = ctx
+	iter.store = store
+	iter.results = nil
+	iter.nextRes = 0
+	iter.fetched = false
+	return nil
+}
+
+// fetchPostings returns the term frequency of the given token for every document containing it.
+func (iter *fullTextIndexIterator) fetchPostings(token string) (map[string]uint64, error) {
+	prefix := iter.indexKey
+	prefix.Fields = []keys.IndexedField{{Value: client.NewNormalString(token)}}
+
+	resultIter, err := iter.store.Query(iter.ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return nil, err
+	}
+
+	postings := make(map[string]uint64)
+	for {
+		res, hasVal := resultIter.NextSync()
+		if !hasVal {
+			break
+		}
+		if res.Error != nil {
+			return nil, errors.Join(res.Error, resultIter.Close())
+		}
+		key, err := keys.DecodeIndexDataStoreKey([]byte(res.Key), &iter.indexDesc, iter.indexedFields)
+		if err != nil {
+			return nil, errors.Join(err, resultIter.Close())
+		}
+		iter.execInfo.IndexesFetched++
+
+		docID, ok := key.Fields[len(key.Fields)-1].Value.String()
+		if !ok {
+			return nil, errors.Join(
+				NewErrUnexpectedTypeValue[string](key.Fields[len(key.Fields)-1].Value),
+				resultIter.Close(),
+			)
+		}
+		_, freq, err := encoding.DecodeUvarintAscending(res.Value)
+		if err != nil {
+			return nil, errors.Join(err, resultIter.Close())
+		}
+		postings[docID] = freq
+	}
+	return postings, resultIter.Close()
+}
+
+// countDocuments returns the number of documents of the collection that are not deleted.
+func (iter *fullTextIndexIterator) countDocuments() (uint64, error) {
+	prefix := keys.PrimaryDataStoreKey{CollectionRootID: iter.collectionRootID}
+	resultIter, err := iter.store.Query(iter.ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	for {
+		res, hasVal := resultIter.NextSync()
+		if !hasVal {
+			break
+		}
+		if res.Error != nil {
+			return 0, errors.Join(res.Error, resultIter.Close())
+		}
+		if !bytes.Equal(res.Value, []byte{base.DeletedObjectMarker}) {
+			count++
+		}
+	}
+	return count, resultIter.Close()
+}
+
+// fetchResults collects the documents that contain all tokens and sorts them by relevance.
+//
+// The relevance of a document is the sum of tf-idf weights of every searched token, where
+// the document frequency is taken from the postings read during this search and the number
+// of documents is counted within the same transaction.
+func (iter *fullTextIndexIterator) fetchResults() error {
+	postingsByToken := make([]map[string]uint64, 0, len(iter.tokens))
+	allDocs := make(map[string]struct{})
+	for _, token := range iter.tokens {
+		postings, err := iter.fetchPostings(token)
+		if err != nil {
+			return err
+		}
+		if len(postings) == 0 {
+			// no document can contain all tokens
+			return nil
+		}
+		for docID := range postings {
+			allDocs[docID] = struct{}{}
+		}
+		postingsByToken = append(postingsByToken, postings)
+	}
+	if len(postingsByToken) == 0 {
+		return nil
+	}
+
+	// the token with the smallest number of postings drives the intersection
+	slices.SortFunc(postingsByToken, func(a, b map[string]uint64) int {
+		return cmp.Compare(len(a), len(b))
+	})
+
+	docCount, err := iter.countDocuments()
+	if err != nil {
+		return err
+	}
+	totalDocs := float64(max(docCount, uint64(len(allDocs))))
+outer:
+	for docID := range postingsByToken[0] {
+		score := 0.0
+		for _, postings := range postingsByToken {
+			freq, ok := postings[docID]
+			if !ok {
+				continue outer
+			}
+			idf := math.Log(1 + totalDocs/float64(len(postings)))
+			score += (1 + math.Log(float64(freq))) * idf
+		}
+		iter.results = append(iter.results, fullTextMatch{docID: docID, score: score})
+	}
+
+	slices.SortFunc(iter.results, func(a, b fullTextMatch) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.docID, b.docID)
+	})
+	return nil
+}
+
+func (iter *fullTextIndexIterator) Next() (indexIterResult, error) {
+	if !iter.fetched {
+		iter.fetched = true
+		if err := iter.fetchResults(); err != nil {
+			return indexIterResult{}, err
+		}
+	}
+	if iter.nextRes >= len(iter.results) {
+		return indexIterResult{}, nil
+	}
+	match := iter.results[iter.nextRes]
+	iter.nextRes++
+
+	key := iter.indexKey
+	key.Fields = []keys.IndexedField{
+		{Value: client.NewNormalString(iter.tokens[0])},
+		{Value: client.NewNormalString(match.docID)},
+	}
+	return indexIterResult{key: key, foundKey: true}, nil
+}
+
+func (iter *fullTextIndexIterator) Close() error {
+	iter.results = nil
+	return nil
+}
+
 func executeValueMatchers(matchers []valueMatcher, fields []keys.IndexedField) (bool, error) {
 	for i := range matchers {
 		res, err := matchers[i].Match(fields[i].Value)
@@ -529,6 +811,50 @@ func (m *indexLikeMatcher) doesMatch(currentVal string) bool {
 	}
 }
 
+// checks if the index value contains all tokens of the search string
+type indexSearchMatcher struct {
+	tokens []string
+}
+
+func (m *indexSearchMatcher) Match(value client.NormalValue) (bool, error) {
+	strVal, ok := value.String()
+	if !ok {
+		strOptVal, ok := value.NillableString()
+		if !ok {
+			return false, NewErrUnexpectedTypeValue[string](value)
+		}
+		if !strOptVal.HasValue() {
+			return false, nil
+		}
+		strVal = strOptVal.Value()
+	}
+	if len(m.tokens) == 0 {
+		return false, nil
+	}
+	valTokens := text.TermFrequencies(strVal)
+	for _, token := range m.tokens {
+		if _, ok := valTokens[token]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// allMatcher checks if the value satisfies all the inner matchers.
+type allMatcher struct {
+	matchers []valueMatcher
+}
+
+func (m *allMatcher) Match(val client.NormalValue) (bool, error) {
+	for _, matcher := range m.matchers {
+		res, err := matcher.Match(val)
+		if err != nil || !res {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 type anyMatcher struct{}
 
 func (m *anyMatcher) Match(client.NormalValue) (bool, error) { return true, nil }
@@ -546,12 +872,29 @@ func (m *invertedMatcher) Match(val client.NormalValue) (bool, error) {
 	return !res, nil
 }
 
+// jsonPathMatcher matches values at a JSON path with the inner matcher.
+//
+// Values at a JSON path can be of any type, so values that are of a different type
+// than the one the inner matcher expects don't match instead of returning an error.
+type jsonPathMatcher struct {
+	matcher valueMatcher
+}
+
+func (m *jsonPathMatcher) Match(val client.NormalValue) (bool, error) {
+	res, err := m.matcher.Match(val)
+	if err != nil {
+		return false, nil
+	}
+	return res, nil
+}
+
 // newPrefixIteratorFromConditions creates a new eqPrefixIndexIterator for fetching indexed data.
+// If the field following the _eq prefix is filtered by a range, an indexRangeIterator is created instead.
 // It can modify the input matchers slice.
 func (f *IndexFetcher) newPrefixIteratorFromConditions(
 	fieldConditions []fieldFilterCond,
 	matchers []valueMatcher,
-) (*indexPrefixIterator, error) {
+) (indexIterator, error) {
 	keyFieldValues := make([]client.NormalValue, 0, len(fieldConditions))
 	for i := range fieldConditions {
 		c := &fieldConditions[i]
@@ -566,10 +909,18 @@ func (f *IndexFetcher) newPrefixIteratorFromConditions(
 
 	// iterators for _eq filter already iterate over keys with first field value
 	// matching the filter value, so we can skip the first matcher
-	if len(matchers) > 1 {
+	if len(matchers) > 1 && len(fieldConditions[0].extra) == 0 {
 		matchers[0] = &anyMatcher{}
 	}
 
+	// if the field following the prefix is filtered by a range, we can seek directly to it
+	if len(keyFieldValues) < len(fieldConditions) {
+		rangeIter := f.newRangeIterator(keyFieldValues, &fieldConditions[len(keyFieldValues)], matchers)
+		if rangeIter != nil {
+			return rangeIter, nil
+		}
+	}
+
 	key := f.newIndexDataStoreKeyWithValues(keyFieldValues)
 
 	return f.newPrefixIterator(key, matchers, &f.execInfo), nil
@@ -589,6 +940,143 @@ func (f *IndexFetcher) newPrefixIterator(
 	}
 }
 
+// newRangeIterator creates a new indexRangeIterator over the index keys that start with the
+// given prefix values and whose following field is within the range of the given condition.
+//
+// The range is narrowed by every _gt, _ge, _lt and _le condition on the field. Null values
+// never satisfy these conditions, so they are excluded from the range as well.
+// If the field is not filtered by a range that can be used to seek, nil is returned.
+func (f *IndexFetcher) newRangeIterator(
+	prefixValues []client.NormalValue,
+	cond *fieldFilterCond,
+	matchers []valueMatcher,
+) *indexRangeIterator {
+	if cond.arrOp == compOpNone {
+		return nil
+	}
+
+	fieldIndex := len(prefixValues)
+	descending := f.indexDesc.Fields[fieldIndex].Descending
+	kind := cond.kind
+	if arrKind, ok := kind.(client.ScalarArrayKind); ok {
+		kind = arrKind.SubKind()
+	}
+
+	// keyWithValue returns the index key with the prefix values followed by the given value.
+	keyWithValue := func(val client.NormalValue) keys.IndexDataStoreKey {
+		return f.newIndexDataStoreKeyWithValues(append(slices.Clone(prefixValues), val))
+	}
+
+	nilVal, err := client.NewNormalNil(kind)
+	if err != nil {
+		return nil
+	}
+	nilKey := keyWithValue(nilVal)
+	prefixKey := f.newIndexDataStoreKeyWithValues(prefixValues)
+	// nulls are stored before all the values in ascending order and after them in descending order
+	var lower, upper []byte
+	if descending {
+		lower = prefixKey.Bytes()
+		upper = nilKey.Bytes()
+	} else {
+		lower = nilKey.PrefixEndBytes()
+		upper = prefixKey.PrefixEndBytes()
+	}
+
+	hasBound := false
+	for _, c := range append([]fieldFilterCond{*cond}, cond.extra...) {
+		if !isRangeOp(c.op) || !isRangeBoundValue(c.val, kind) {
+			continue
+		}
+		hasBound = true
+
+		valKey := keyWithValue(c.val)
+		// all keys with the value itself are prefixed by valKey, so they sort after valKey
+		// and before its prefix end. In descending order the bounds are swapped.
+		isLowerBound := (c.op == opGt || c.op == opGe) != descending
+		isInclusive := c.op == opGe || c.op == opLe
+		if isLowerBound {
+			bound := valKey.Bytes()
+			if !isInclusive {
+				bound = valKey.PrefixEndBytes()
+			}
+			if bytes.Compare(bound, lower) > 0 {
+				lower = bound
+			}
+		} else {
+			bound := valKey.Bytes()
+			if isInclusive {
+				bound = valKey.PrefixEndBytes()
+			}
+			if bytes.Compare(bound, upper) < 0 {
+				upper = bound
+			}
+		}
+	}
+	if !hasBound {
+		return nil
+	}
+
+	return &indexRangeIterator{
+		indexDesc:     f.indexDesc,
+		indexedFields: f.indexedFields,
+		start:         newRangeStartKey(lower),
+		end:           newRangeEndKey(upper),
+		matchers:      matchers,
+		execInfo:      &f.execInfo,
+	}
+}
+
+// newRangeStartKey returns a datastore key to start a range of keys from.
+//
+// Datastore keys can not end with a '/', so it is trimmed from the given bytes. The
+// returned key may sort before the given bytes, which only widens the range.
+func newRangeStartKey(b []byte) ds.Key {
+	return ds.RawKey(string(bytes.TrimRight(b, "/")))
+}
+
+// newRangeEndKey returns a datastore key to end a range of keys at.
+//
+// Datastore keys can not end with a '/', so a byte that sorts after all the others is
+// appended to such bytes. The returned key may sort after the given bytes, which only
+// widens the range.
+func newRangeEndKey(b []byte) ds.Key {
+	if len(b) > 0 && b[len(b)-1] == '/' {
+		b = append(b, 0xff)
+	}
+	return ds.RawKey(string(b))
+}
+
+// isRangeOp returns true if the given operator filters values by a range.
+func isRangeOp(op string) bool {
+	return op == opGt || op == opGe || op == opLt || op == opLe
+}
+
+// isRangeBoundValue returns true if the given value is encoded in the same way as the values
+// of a field of the given kind, so that it can be used as a bound of a range of index keys.
+func isRangeBoundValue(val client.NormalValue, kind client.FieldKind) bool {
+	if val == nil || val.IsNil() {
+		return false
+	}
+	var ok bool
+	switch kind {
+	case client.FieldKind_NILLABLE_INT:
+		_, ok = val.Int()
+	case client.FieldKind_NILLABLE_FLOAT:
+		_, ok = val.Float()
+	case client.FieldKind_NILLABLE_STRING:
+		_, ok = val.String()
+	case client.FieldKind_NILLABLE_DATETIME:
+		_, ok = val.Time()
+	case client.FieldKind_NILLABLE_JSON:
+		// values at a JSON path are indexed as floats, strings or bools
+		if _, ok = val.Float(); !ok {
+			_, ok = val.String()
+		}
+	}
+	return ok
+}
+
 // newInIndexIterator creates a new inIndexIterator for fetching indexed data.
 // It can modify the input matchers slice.
 func (f *IndexFetcher) newInIndexIterator(
@@ -605,7 +1093,7 @@ func (f *IndexFetcher) newInIndexIterator(
 
 	// iterators for _in filter already iterate over keys with first field value
 	// matching the filter value, so we can skip the first matcher
-	if len(matchers) > 1 {
+	if len(matchers) > 1 && len(fieldConditions[0].extra) == 0 {
 		matchers[0] = &anyMatcher{}
 	}
 
@@ -648,7 +1136,52 @@ func (f *IndexFetcher) newIndexDataStoreKeyWithValues(values []client.NormalValu
 	return keys.NewIndexDataStoreKey(f.col.ID(), f.indexDesc.ID, fields)
 }
 
+// newFullTextIndexIterator creates a new fullTextIndexIterator for the _search condition
+// on the indexed field.
+//
+// If the filter has no _search condition on the field, nil is returned as the index can not be used.
+func (f *IndexFetcher) newFullTextIndexIterator() (indexIterator, error) {
+	fieldInd := f.mapping.FirstIndexOfName(f.indexedFields[0].Name)
+	for filterKey, indexFilterCond := range f.indexFilter.Conditions {
+		propKey, ok := filterKey.(*mapper.PropertyIndex)
+		if !ok || fieldInd != propKey.Index {
+			continue
+		}
+		condMap, ok := indexFilterCond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		for key, filterVal := range condMap {
+			if key.(*mapper.Operator).Operation != opSearch {
+				continue
+			}
+			searchStr, ok := filterVal.(string)
+			if !ok {
+				return nil, NewErrUnexpectedTypeValue[string](filterVal)
+			}
+			return &fullTextIndexIterator{
+				indexDesc:        f.indexDesc,
+				indexedFields:    f.indexedFields,
+				indexKey:         f.newIndexDataStoreKey(),
+				tokens:           text.UniqueTokens(searchStr),
+				execInfo:         &f.execInfo,
+				collectionRootID: f.col.Description().RootID,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
 func (f *IndexFetcher) createIndexIterator() (indexIterator, error) {
+	if f.indexDesc.Type == client.IndexTypeFullText {
+		return f.newFullTextIndexIterator()
+	}
+
+	// without a filter the index is only used to fetch all the documents in its order
+	if f.indexFilter == nil {
+		return f.newPrefixIterator(f.newIndexDataStoreKey(), nil, &f.execInfo), nil
+	}
+
 	fieldConditions, err := f.determineFieldFilterConditions()
 	if err != nil {
 		return nil, err
@@ -672,6 +1205,11 @@ func (f *IndexFetcher) createIndexIterator() (indexIterator, error) {
 				matchers[i] = &invertedMatcher{matcher: matchers[i]}
 			}
 		}
+		if len(f.indexDesc.Fields[i].JSONPath) > 0 {
+			// a document has an index key for every value at the JSON path
+			hasArray = true
+			matchers[i] = &jsonPathMatcher{matcher: matchers[i]}
+		}
 	}
 
 	var iter indexIterator
@@ -690,6 +1228,8 @@ func (f *IndexFetcher) createIndexIterator() (indexIterator, error) {
 		}
 	} else if fieldConditions[0].op == opIn && fieldConditions[0].arrOp != compOpNone {
 		iter, err = f.newInIndexIterator(fieldConditions, matchers)
+	} else if rangeIter := f.newRangeIterator(nil, &fieldConditions[0], matchers); rangeIter != nil {
+		iter = rangeIter
 	} else {
 		iter, err = f.newPrefixIterator(f.newIndexDataStoreKey(), matchers, &f.execInfo), nil
 	}
@@ -753,6 +1293,16 @@ func createValueMatcher(condition *fieldFilterCond) (valueMatcher, error) {
 		isLike := condition.op == opLike || condition.op == opILike
 		isCaseInsensitive := condition.op == opILike || condition.op == opNILike
 		return newLikeIndexCmp(strVal, isLike, isCaseInsensitive)
+	case opSearch:
+		strVal, ok := condition.val.String()
+		if !ok {
+			strOptVal, ok := condition.val.NillableString()
+			if !ok {
+				return nil, NewErrUnexpectedTypeValue[string](condition.val)
+			}
+			strVal = strOptVal.Value()
+		}
+		return &indexSearchMatcher{tokens: text.UniqueTokens(strVal)}, nil
 	case opAny:
 		return &anyMatcher{}, nil
 	}
@@ -767,6 +1317,17 @@ func createValueMatchers(conditions []fieldFilterCond) ([]valueMatcher, error) {
 		if err != nil {
 			return nil, err
 		}
+		if len(conditions[i].extra) > 0 {
+			allM := &allMatcher{matchers: []valueMatcher{m}}
+			for j := range conditions[i].extra {
+				extraM, err := createValueMatcher(&conditions[i].extra[j])
+				if err != nil {
+					return nil, err
+				}
+				allM.matchers = append(allM.matchers, extraM)
+			}
+			m = allM
+		}
 		matchers = append(matchers, m)
 	}
 	return matchers, nil
@@ -777,11 +1338,29 @@ type fieldFilterCond struct {
 	arrOp string
 	val   client.NormalValue
 	kind  client.FieldKind
+	// extra holds the other conditions on the same field, like the upper bound of a range.
+	extra []fieldFilterCond
+}
+
+// getOpPriority returns the priority of the given operator when choosing the main condition
+// of a field. The lower the value, the fewer index keys the operator needs to iterate over.
+func getOpPriority(op string) int {
+	switch op {
+	case opEq:
+		return 0
+	case opIn:
+		return 1
+	case opGt, opGe, opLt, opLe:
+		return 2
+	}
+	return 3
 }
 
 // determineFieldFilterConditions determines the conditions and their corresponding operation
 // for each indexed field.
 // It returns a slice of fieldFilterCond, where each element corresponds to a field in the index.
+// If a field has several conditions, the one that narrows the iteration the most is returned
+// with the rest of them as extra conditions.
 func (f *IndexFetcher) determineFieldFilterConditions() ([]fieldFilterCond, error) {
 	result := make([]fieldFilterCond, 0, len(f.indexedFields))
 	for i := range f.indexedFields {
@@ -797,6 +1376,16 @@ func (f *IndexFetcher) determineFieldFilterConditions() ([]fieldFilterCond, erro
 			found = true
 
 			condMap := indexFilterCond.(map[connor.FilterKey]any)
+			if len(f.indexDesc.Fields[i].JSONPath) > 0 {
+				conds := f.determineJSONPathFilterConditions(i, condMap)
+				if len(conds) > 0 {
+					result = append(result, mainFieldFilterCond(conds))
+				} else {
+					found = false
+				}
+				break
+			}
+			conds := make([]fieldFilterCond, 0, len(condMap))
 			for key, filterVal := range condMap {
 				cond := fieldFilterCond{
 					op:   key.(*mapper.Operator).Operation,
@@ -827,8 +1416,15 @@ func (f *IndexFetcher) determineFieldFilterConditions() ([]fieldFilterCond, erro
 				if err != nil {
 					return nil, err
 				}
-				result = append(result, cond)
-				break
+				conds = append(conds, cond)
+				if f.indexedFields[i].Kind.IsArray() {
+					// only a single condition of an array field is used, as the whole
+					// array is checked again by the filter of the select node.
+					break
+				}
+			}
+			if len(conds) > 0 {
+				result = append(result, mainFieldFilterCond(conds))
 			}
 			break
 		}
@@ -843,6 +1439,127 @@ func (f *IndexFetcher) determineFieldFilterConditions() ([]fieldFilterCond, erro
 	return result, nil
 }
 
+// mainFieldFilterCond returns the condition of a field that narrows the iteration the most
+// with the rest of the given conditions as its extra conditions.
+func mainFieldFilterCond(conds []fieldFilterCond) fieldFilterCond {
+	slices.SortFunc(conds, func(a, b fieldFilterCond) int {
+		if c := cmp.Compare(getOpPriority(a.op), getOpPriority(b.op)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.op, b.op)
+	})
+	cond := conds[0]
+	if len(conds) > 1 {
+		cond.extra = conds[1:]
+	}
+	return cond
+}
+
+// determineJSONPathFilterConditions determines the conditions on the value at the JSON path
+// of the indexed field with the given index.
+//
+// Only the conditions that can be served by the index are returned. The filter of the select
+// node checks the whole JSON value again, so the rest of them can be ignored.
+func (f *IndexFetcher) determineJSONPathFilterConditions(
+	fieldIndex int,
+	condMap map[connor.FilterKey]any,
+) []fieldFilterCond {
+	for _, name := range f.indexDesc.Fields[fieldIndex].JSONPath {
+		var next map[connor.FilterKey]any
+		for key, filterVal := range condMap {
+			if prop, ok := key.(*mapper.ObjectProperty); ok && prop.Name == name {
+				next, _ = filterVal.(map[connor.FilterKey]any)
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		condMap = next
+	}
+
+	conds := make([]fieldFilterCond, 0, len(condMap))
+	for key, filterVal := range condMap {
+		opKey, ok := key.(*mapper.Operator)
+		if !ok {
+			continue
+		}
+		cond := fieldFilterCond{
+			op:   opKey.Operation,
+			kind: f.indexedFields[fieldIndex].Kind,
+		}
+		if cond.op == compOpAny {
+			// the index holds every element of an array found at the path,
+			// so a single condition on any of them can be served as well.
+			subCondMap, ok := filterVal.(map[connor.FilterKey]any)
+			if !ok || len(subCondMap) != 1 {
+				continue
+			}
+			for subKey, subVal := range subCondMap {
+				subOpKey, ok := subKey.(*mapper.Operator)
+				if !ok {
+					break
+				}
+				cond.arrOp = cond.op
+				cond.op = subOpKey.Operation
+				filterVal = subVal
+			}
+		}
+		if !isJSONPathIndexOp(cond.op) {
+			continue
+		}
+		val, err := newJSONPathNormalValue(filterVal)
+		if err != nil {
+			// values that can not be normalized, like lists of mixed types, are not
+			// served by the index.
+			continue
+		}
+		cond.val = val
+		if cond.arrOp != "" {
+			return []fieldFilterCond{cond}
+		}
+		conds = append(conds, cond)
+	}
+	return conds
+}
+
+// isJSONPathIndexOp returns true if a condition with the given operator on a value at a JSON
+// path can be served by the index.
+func isJSONPathIndexOp(op string) bool {
+	return op == opEq || op == opIn || isRangeOp(op)
+}
+
+// newJSONPathNormalValue returns the normal value of a condition on a value at a JSON path.
+//
+// JSON numbers are indexed as floats, so the numbers of the condition are converted to floats as well.
+func newJSONPathNormalValue(val any) (client.NormalValue, error) {
+	if val == nil {
+		return client.NewNormalNil(client.FieldKind_NILLABLE_JSON)
+	}
+	if arr, ok := val.([]any); ok {
+		floatArr := make([]any, len(arr))
+		for i := range arr {
+			floatArr[i] = jsonNumberToFloat(arr[i])
+		}
+		return client.NewNormalValue(floatArr)
+	}
+	return client.NewNormalValue(jsonNumberToFloat(val))
+}
+
+func jsonNumberToFloat(val any) any {
+	switch v := val.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return val
+}
+
 // isUniqueFetchByFullKey checks if the only index key can be fetched by the full index key.
 //
 // This method ignores the first condition (unless it's nil) because it's expected to be called only
@@ -856,6 +1573,11 @@ func isUniqueFetchByFullKey(indexDesc *client.IndexDescription, conditions []fie
 	// first condition is not required to be _eq, but if is, val must be not nil
 	res = res && (conditions[0].op != opEq || !conditions[0].val.IsNil())
 
+	// a full key fetch doesn't run matchers, so there must be no other conditions to check
+	for i := range conditions {
+		res = res && len(conditions[i].extra) == 0
+	}
+
 	// for the rest it must be _eq and val must be not nil
 	for i := 1; i < len(conditions); i++ {
 		res = res && (conditions[i].op == opEq && !conditions[i].val.IsNil())
@@ -11,9 +11,9 @@
 package fetcher
 
 import (
-	"container/list"
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/ipfs/go-cid"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
@@ -65,6 +65,12 @@ var (
 // defined in the version, so that it can be used as a drop in replacement within
 // the scanNode request planner system.
 //
+// When used by the [AsOfFetcher], multiple target versions of the same document may
+// be given and all the heads of the blocks are followed, in which case the returned
+// state is the merged state of all the target versions. This is used to recompose
+// the state of a document whose history has diverged.  Otherwise only the first head
+// of each block is followed.
+//
 // Current limitations:
 // - We can only return a single record from an VersionedFetcher instance.
 // - We can't request related sub objects (at the moment, as related objects
@@ -89,7 +95,12 @@ type VersionedFetcher struct {
 	root  datastore.Rootstore
 	store datastore.Txn
 
-	queuedCids *list.List
+	// queuedCids holds the composite blocks to merge, ordered by priority.
+	queuedCids []queuedCid
+	// mergedCids holds the blocks that have already been merged into the transient store.
+	mergedCids map[cid.Cid]struct{}
+	// mergeDivergedHistory is true if all the heads of the blocks are to be followed.
+	mergeDivergedHistory bool
 
 	acp immutable.Option[acp.ACP]
 
@@ -111,7 +122,6 @@ func (vf *VersionedFetcher) Init(
 ) error {
 	vf.acp = acp
 	vf.col = col
-	vf.queuedCids = list.New()
 	vf.txn = txn
 
 	// create store
@@ -146,16 +156,19 @@ func (vf *VersionedFetcher) Init(
 	)
 }
 
-// Start serializes the correct state according to the Key and CID.
+// Start serializes the correct state according to the Key and CIDs.
 func (vf *VersionedFetcher) Start(ctx context.Context, prefixes ...keys.Walkable) error {
-	// VersionedFetcher only ever recieves a headstore key
-	//nolint:forcetypeassert
-	prefix := prefixes[0].(keys.HeadstoreDocKey)
-
 	vf.ctx = ctx
 
-	if err := vf.seekTo(prefix.Cid); err != nil {
-		return NewErrFailedToSeek(prefix.Cid, err)
+	targets := make([]cid.Cid, len(prefixes))
+	for i, prefix := range prefixes {
+		// VersionedFetcher only ever recieves headstore keys
+		//nolint:forcetypeassert
+		targets[i] = prefix.(keys.HeadstoreDocKey).Cid
+	}
+
+	if err := vf.seekTo(targets...); err != nil {
+		return NewErrFailedToSeek(targets, err)
 	}
 
 	return vf.DocumentFetcher.Start(ctx)
@@ -185,20 +198,29 @@ func (vf *VersionedFetcher) SeekTo(ctx context.Context, c cid.Cid) error {
 	return vf.DocumentFetcher.Start(ctx)
 }
 
-// seekTo seeks to the given CID version by stepping through the CRDT state graph from the beginning
-// to the target state, creating the serialized state at the given version. It starts by seeking
+// seekTo seeks to the given CID versions by stepping through the CRDT state graph from the beginning
+// to the target states, creating the serialized state at the given versions. It starts by seeking
 // to the closest existing state snapshot in the transient Versioned stores, which on the first
-// run is 0. It seeks by iteratively jumping through the state graph via the `_head` link.
-func (vf *VersionedFetcher) seekTo(c cid.Cid) error {
+// run is 0. It seeks by iteratively jumping through the state graph via the `_head` links.
+func (vf *VersionedFetcher) seekTo(targets ...cid.Cid) error {
 	// reinit the queued cids list
-	vf.queuedCids = list.New()
+	vf.queuedCids = nil
+	vf.mergedCids = make(map[cid.Cid]struct{})
 
 	// recursive step through the graph
-	err := vf.seekNext(c, true)
-	if err != nil {
-		return err
+	for _, c := range targets {
+		err := vf.seekNext(c, true)
+		if err != nil {
+			return err
+		}
 	}
 
+	// A block always has a greater priority than its heads, so merging the
+	// blocks by priority merges them in causal order.
+	sort.SliceStable(vf.queuedCids, func(i, j int) bool {
+		return vf.queuedCids[i].priority < vf.queuedCids[j].priority
+	})
+
 	// if we have a queuedCIDs length of 0, means we don't need
 	// to do any more state serialization
 
@@ -212,12 +234,8 @@ func (vf *VersionedFetcher) seekTo(c cid.Cid) error {
 	/// // as a cache, we need to swap out states to the parent of the current
 	/// // CID.
 	// }
-	for ccv := vf.queuedCids.Front(); ccv != nil; ccv = ccv.Next() {
-		cc, ok := ccv.Value.(cid.Cid)
-		if !ok {
-			return client.NewErrUnexpectedType[cid.Cid]("queueudCids", ccv.Value)
-		}
-		err := vf.merge(cc)
+	for _, queued := range vf.queuedCids {
+		err := vf.merge(queued.cid)
 		if err != nil {
 			return NewErrFailedToMergeState(err)
 		}
@@ -265,20 +283,25 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 		return NewErrVFetcherFailedToWriteBlock(err)
 	}
 
-	// add the CID to the queuedCIDs list
-	if topParent {
-		vf.queuedCids.PushFront(c)
-	}
-
 	// decode the block
 	block, err := coreblock.GetFromBytes(blk.RawData())
 	if err != nil {
 		return NewErrVFetcherFailedToDecodeNode(err)
 	}
 
-	// only seekNext on parent if we have a HEAD link
-	if len(block.Heads) != 0 {
-		err := vf.seekNext(block.Heads[0].Cid, true)
+	// add the CID to the queuedCIDs list
+	if topParent {
+		vf.queuedCids = append(vf.queuedCids, queuedCid{cid: c, priority: block.Delta.GetPriority()})
+	}
+
+	heads := block.Heads
+	if !vf.mergeDivergedHistory && len(heads) > 1 {
+		// only seekNext on the first parent, as for the single version of a cid query
+		heads = heads[:1]
+	}
+	// seekNext on the parents, so that diverged histories are fully merged if requested
+	for _, head := range heads {
+		err := vf.seekNext(head.Cid, true)
 		if err != nil {
 			return err
 		}
@@ -303,7 +326,15 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 // gets the existing MerkleClock instance, or creates one.
 //
 // Currently we assume the CID is a CompositeDAG CRDT node.
+//
+// Blocks that have already been merged are skipped, so that non-idempotent CRDTs
+// such as counters are not merged twice.
 func (vf *VersionedFetcher) merge(c cid.Cid) error {
+	if _, ok := vf.mergedCids[c]; ok {
+		return nil
+	}
+	vf.mergedCids[c] = struct{}{}
+
 	// get node
 	block, err := vf.getDAGBlock(c)
 	if err != nil {
@@ -375,6 +406,12 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 	return nil
 }
 
+// queuedCid is a composite block queued to be merged by the VersionedFetcher.
+type queuedCid struct {
+	cid      cid.Cid
+	priority uint64
+}
+
 func (vf *VersionedFetcher) getDAGBlock(c cid.Cid) (*coreblock.Block, error) {
 	// get Block
 	blk, err := vf.store.Blockstore().Get(vf.ctx, c)
@@ -0,0 +1,152 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ipfs/go-cid"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+// headsRetriever is a helper struct that retrieves the heads of the documents
+// shared with peers over the network.
+type headsRetriever struct {
+	db client.DB
+}
+
+// NewHeadsRetriever creates a new HeadsRetriever.
+func NewHeadsRetriever(db client.DB) headsRetriever {
+	return headsRetriever{
+		db: db,
+	}
+}
+
+// GetCollectionHeads returns the composite heads of the documents of the P2P collection
+// with the given schema root.
+//
+// The DAGs of the documents of branchable collections are linked to the DAG of the collection,
+// so only the heads of the collection are returned for those.
+//
+// Nothing is returned for collections that are not P2P collections.
+func (r headsRetriever) GetCollectionHeads(ctx context.Context, schemaRoot string) ([]event.Update, error) {
+	p2pCollections, err := r.db.GetAllP2PCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(p2pCollections, schemaRoot) {
+		return nil, nil
+	}
+
+	ctx, txn, err := ensureContextTxn(ctx, r.db, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	cols, err := r.db.GetCollections(
+		ctx,
+		client.CollectionFetchOptions{
+			SchemaRoot: immutable.Some(schemaRoot),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, nil
+	}
+	desc := cols[0].Description()
+
+	heads, err := getDAGBackupHeads(ctx, txn, desc.RootID, schemaRoot, desc.IsBranchable)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := []event.Update{}
+	for _, head := range heads {
+		for _, c := range head.Cids {
+			headCID, err := cid.Decode(c)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, event.Update{
+				DocID:      head.DocID,
+				Cid:        headCID,
+				SchemaRoot: schemaRoot,
+			})
+		}
+	}
+	return updates, nil
+}
+
+// GetDocHeads returns the composite heads of the document with the given ID.
+//
+// The heads of the collection are returned instead for documents of branchable collections,
+// as their DAGs are linked to the DAG of the collection.
+//
+// The heads of documents of collections with a policy are only returned if the collection
+// is a P2P collection. Nothing is returned for unknown documents.
+func (r headsRetriever) GetDocHeads(ctx context.Context, docID string) ([]event.Update, error) {
+	ctx, txn, err := ensureContextTxn(ctx, r.db, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	col, err := NewCollectionRetriever(r.db).RetrieveCollectionFromDocID(ctx, docID)
+	if errors.Is(err, ErrDocIDNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if col.Description().Policy.HasValue() {
+		p2pCollections, err := r.db.GetAllP2PCollections(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(p2pCollections, col.SchemaRoot()) {
+			return nil, nil
+		}
+	}
+
+	var key keys.HeadstoreKey = keys.HeadstoreDocKey{
+		DocID:   docID,
+		FieldID: core.COMPOSITE_NAMESPACE,
+	}
+	if col.Description().IsBranchable {
+		key = keys.NewHeadstoreColKey(col.Description().RootID)
+		docID = ""
+	}
+	cids, err := getHeads(ctx, txn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]event.Update, len(cids))
+	for i, c := range cids {
+		updates[i] = event.Update{
+			DocID:      docID,
+			Cid:        c,
+			SchemaRoot: col.SchemaRoot(),
+		}
+	}
+	return updates, nil
+}
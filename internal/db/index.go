@@ -14,15 +14,20 @@ import (
 	"context"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/connor"
+	"github.com/sourcenetwork/defradb/internal/encoding"
 	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 	"github.com/sourcenetwork/defradb/internal/utils/slice"
+	"github.com/sourcenetwork/defradb/internal/utils/text"
 )
 
 // CollectionIndex is an interface for collection indexes
 // It abstracts away common index functionality to be implemented
-// by different index types: non-unique, unique, and composite
+// by different index types: non-unique, unique, composite and full-text
 type CollectionIndex interface {
 	client.CollectionIndex
 	// RemoveAll removes all documents from the index
@@ -74,22 +79,41 @@ func NewCollectionIndex(
 			return nil, client.NewErrFieldNotExist(desc.Fields[i].Name)
 		}
 		base.fieldsDescs[i] = field
+		if len(desc.Fields[i].JSONPath) > 0 {
+			if field.Kind != client.FieldKind_NILLABLE_JSON {
+				return nil, NewErrJSONPathOnNonJSONField(field.Name, field.Kind)
+			}
+			// values at a JSON path are indexed the same way as array elements,
+			// as the path may lead to an array.
+			isArray = true
+			continue
+		}
 		if !isSupportedKind(field.Kind) {
 			return nil, NewErrUnsupportedIndexFieldType(field.Kind)
 		}
 		isArray = isArray || field.Kind.IsArray()
 	}
-	if isArray {
+	var index CollectionIndex
+	if desc.Type == client.IndexTypeFullText {
+		if base.fieldsDescs[0].Kind != client.FieldKind_NILLABLE_STRING {
+			return nil, NewErrUnsupportedFullTextIndexFieldType(base.fieldsDescs[0].Kind)
+		}
+		index = &collectionFullTextIndex{collectionBaseIndex: base}
+	} else if isArray {
 		if desc.Unique {
-			return newCollectionArrayUniqueIndex(base), nil
+			index = newCollectionArrayUniqueIndex(base)
 		} else {
-			return newCollectionArrayIndex(base), nil
+			index = newCollectionArrayIndex(base)
 		}
 	} else if desc.Unique {
-		return &collectionUniqueIndex{collectionBaseIndex: base}, nil
+		index = &collectionUniqueIndex{collectionBaseIndex: base}
 	} else {
-		return &collectionSimpleIndex{collectionBaseIndex: base}, nil
+		index = &collectionSimpleIndex{collectionBaseIndex: base}
+	}
+	if len(desc.Filter) > 0 {
+		return newCollectionPartialIndex(index, desc.Filter), nil
 	}
+	return index, nil
 }
 
 type collectionBaseIndex struct {
@@ -139,6 +163,30 @@ func (index *collectionBaseIndex) getDocumentsIndexKey(
 	return keys.NewIndexDataStoreKey(index.collection.ID(), index.desc.ID, fields), nil
 }
 
+// putIndexKey stores the given index key and value, and counts the indexed value in the
+// statistics of the index once the transaction succeeds.
+func (index *collectionBaseIndex) putIndexKey(
+	ctx context.Context,
+	txn datastore.Txn,
+	key keys.IndexDataStoreKey,
+	val []byte,
+) error {
+	err := txn.Datastore().Put(ctx, key.ToDS(), val)
+	if err != nil {
+		return err
+	}
+	if col, ok := index.collection.(*collection); ok {
+		statsKey := keys.NewIndexStatsKey(key.CollectionID, key.IndexID)
+		statsValue := indexStatsValue(&key, len(index.desc.Fields))
+		txn.OnSuccess(func() {
+			col.db.stats.addIndexValue(statsKey, statsValue)
+		})
+	}
+	return nil
+}
+
+// deleteIndexKey deletes the given index key, and counts the removal in the statistics
+// of the index once the transaction succeeds.
 func (index *collectionBaseIndex) deleteIndexKey(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -151,7 +199,20 @@ func (index *collectionBaseIndex) deleteIndexKey(
 	if !exists {
 		return NewErrCorruptedIndex(index.desc.Name)
 	}
-	return txn.Datastore().Delete(ctx, key.ToDS())
+	err = txn.Datastore().Delete(ctx, key.ToDS())
+	if err != nil {
+		return err
+	}
+	if col, ok := index.collection.(*collection); ok {
+		statsKey := keys.NewIndexStatsKey(key.CollectionID, key.IndexID)
+		desc := index.desc
+		txn.OnSuccess(func() {
+			if col.db.stats.removeIndexValue(statsKey) {
+				col.db.rebuildIndexSketchInBackground(col, desc)
+			}
+		})
+	}
+	return nil
 }
 
 // RemoveAll remove all artifacts of the index from the storage, i.e. all index
@@ -211,7 +272,7 @@ func (index *collectionSimpleIndex) Save(
 	if err != nil {
 		return err
 	}
-	err = txn.Datastore().Put(ctx, key.ToDS(), []byte{})
+	err = index.putIndexKey(ctx, txn, key, []byte{})
 	if err != nil {
 		return NewErrFailedToStoreIndexedField(key.ToString(), err)
 	}
@@ -273,7 +334,7 @@ func (index *collectionUniqueIndex) save(
 	key *keys.IndexDataStoreKey,
 	val []byte,
 ) error {
-	err := txn.Datastore().Put(ctx, key.ToDS(), val)
+	err := index.putIndexKey(ctx, txn, *key, val)
 	if err != nil {
 		return NewErrFailedToStoreIndexedField(key.ToDS().String(), err)
 	}
@@ -436,7 +497,7 @@ type collectionArrayBaseIndex struct {
 func newCollectionArrayBaseIndex(base collectionBaseIndex) collectionArrayBaseIndex {
 	ind := collectionArrayBaseIndex{collectionBaseIndex: base}
 	for i := range base.fieldsDescs {
-		if base.fieldsDescs[i].Kind.IsArray() {
+		if base.fieldsDescs[i].Kind.IsArray() || len(base.desc.Fields[i].JSONPath) > 0 {
 			ind.arrFieldsIndexes = append(ind.arrFieldsIndexes, i)
 		}
 	}
@@ -464,8 +525,12 @@ func (index *collectionArrayBaseIndex) newIndexKeyGenerator(
 	// Collect unique values to use as source for generating keys
 	normValsArr := make([][]client.NormalValue, 0, len(index.arrFieldsIndexes))
 	for _, arrFieldIndex := range index.arrFieldsIndexes {
-		arrVal := key.Fields[arrFieldIndex].Value
-		normVals, err := client.ToArrayOfNormalValues(arrVal)
+		var normVals []client.NormalValue
+		if len(index.desc.Fields[arrFieldIndex].JSONPath) > 0 {
+			normVals, err = index.getJSONPathValues(doc, arrFieldIndex)
+		} else {
+			normVals, err = client.ToArrayOfNormalValues(key.Fields[arrFieldIndex].Value)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -517,6 +582,77 @@ func (index *collectionArrayBaseIndex) newIndexKeyGenerator(
 	}, nil
 }
 
+// getJSONPathValues returns the values found at the JSON path of the indexed field
+// with the given index.
+//
+// If the path leads to an array its scalar elements are returned. If no scalar value
+// is found at the path a single nil value is returned so that the document is still indexed.
+func (index *collectionArrayBaseIndex) getJSONPathValues(
+	doc *client.Document,
+	fieldIndex int,
+) ([]client.NormalValue, error) {
+	fieldVal, err := doc.TryGetValue(index.fieldsDescs[fieldIndex].Name)
+	if err != nil {
+		return nil, err
+	}
+	var result []client.NormalValue
+	if fieldVal != nil && fieldVal.Value() != nil {
+		if json, ok := fieldVal.NormalValue().JSON(); ok {
+			result = jsonPathToNormalValues(json, index.desc.Fields[fieldIndex].JSONPath)
+		}
+	}
+	if len(result) == 0 {
+		normalNil, err := client.NewNormalNil(index.fieldsDescs[fieldIndex].Kind)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, normalNil)
+	}
+	return result, nil
+}
+
+// jsonPathToNormalValues returns the scalar values found at the given path of object keys.
+func jsonPathToNormalValues(json client.JSON, path []string) []client.NormalValue {
+	for _, key := range path {
+		obj, ok := json.Object()
+		if !ok {
+			return nil
+		}
+		json, ok = obj[key]
+		if !ok {
+			return nil
+		}
+	}
+	if arr, ok := json.Array(); ok {
+		result := make([]client.NormalValue, 0, len(arr))
+		for _, elem := range arr {
+			if val, ok := jsonToNormalScalar(elem); ok {
+				result = append(result, val)
+			}
+		}
+		return result
+	}
+	if val, ok := jsonToNormalScalar(json); ok {
+		return []client.NormalValue{val}
+	}
+	return nil
+}
+
+// jsonToNormalScalar converts the given JSON value to a normal value if it is a
+// number, string or boolean.
+func jsonToNormalScalar(json client.JSON) (client.NormalValue, bool) {
+	if v, ok := json.Number(); ok {
+		return client.NewNormalFloat(v), true
+	}
+	if v, ok := json.String(); ok {
+		return client.NewNormalString(v), true
+	}
+	if v, ok := json.Bool(); ok {
+		return client.NewNormalBool(v), true
+	}
+	return nil, false
+}
+
 func (index *collectionArrayBaseIndex) getAllKeys(
 	doc *client.Document,
 	appendDocID bool,
@@ -594,7 +730,7 @@ func (index *collectionArrayIndex) Save(
 		if !hasKey {
 			break
 		}
-		err = txn.Datastore().Put(ctx, key.ToDS(), []byte{})
+		err = index.putIndexKey(ctx, txn, key, []byte{})
 		if err != nil {
 			return NewErrFailedToStoreIndexedField(key.ToString(), err)
 		}
@@ -614,7 +750,7 @@ func (index *collectionArrayIndex) Update(
 	}
 
 	for _, key := range newKeys {
-		err = txn.Datastore().Put(ctx, key.ToDS(), []byte{})
+		err = index.putIndexKey(ctx, txn, key, []byte{})
 		if err != nil {
 			return NewErrFailedToStoreIndexedField(key.ToString(), err)
 		}
@@ -693,7 +829,7 @@ func (index *collectionArrayUniqueIndex) addNewUniqueKey(
 	if err != nil {
 		return err
 	}
-	err = txn.Datastore().Put(ctx, key.ToDS(), val)
+	err = index.putIndexKey(ctx, txn, key, val)
 	if err != nil {
 		return NewErrFailedToStoreIndexedField(key.ToString(), err)
 	}
@@ -743,3 +879,215 @@ func (index *collectionArrayUniqueIndex) Delete(
 	}
 	return nil
 }
+
+// collectionFullTextIndex is an inverted index over the words of a single String field.
+//
+// For every distinct token of the field value it stores a key of the form
+// /<collection_id>/<index_id>/<token>/<doc_id> with the number of occurrences
+// of the token in the field value as the stored value.
+type collectionFullTextIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionFullTextIndex)(nil)
+
+// getDocumentsTokenFrequencies returns the frequency of every token of the indexed field.
+// Nil field values produce no tokens.
+func (index *collectionFullTextIndex) getDocumentsTokenFrequencies(
+	doc *client.Document,
+) (map[string]uint64, error) {
+	fieldVal, err := doc.TryGetValue(index.fieldsDescs[0].Name)
+	if err != nil {
+		return nil, err
+	}
+	if fieldVal == nil || fieldVal.Value() == nil {
+		return nil, nil
+	}
+	normalVal := fieldVal.NormalValue()
+	if str, ok := normalVal.String(); ok {
+		return text.TermFrequencies(str), nil
+	}
+	if strOpt, ok := normalVal.NillableString(); ok && strOpt.HasValue() {
+		return text.TermFrequencies(strOpt.Value()), nil
+	}
+	return nil, nil
+}
+
+func (index *collectionFullTextIndex) getTokenKey(token string, doc *client.Document) keys.IndexDataStoreKey {
+	return keys.NewIndexDataStoreKey(
+		index.collection.ID(),
+		index.desc.ID,
+		[]keys.IndexedField{
+			{Value: client.NewNormalString(token)},
+			{Value: client.NewNormalString(doc.ID().String())},
+		},
+	)
+}
+
+func (index *collectionFullTextIndex) saveTokens(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+	tokens map[string]uint64,
+) error {
+	for token, freq := range tokens {
+		key := index.getTokenKey(token, doc)
+		err := index.putIndexKey(ctx, txn, key, encoding.EncodeUvarintAscending(nil, freq))
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(key.ToString(), err)
+		}
+	}
+	return nil
+}
+
+func (index *collectionFullTextIndex) deleteTokens(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+	tokens map[string]uint64,
+) error {
+	for token := range tokens {
+		err := index.deleteIndexKey(ctx, txn, index.getTokenKey(token, doc))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Save indexes a document by storing an entry for every token of the indexed field.
+func (index *collectionFullTextIndex) Save(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	tokens, err := index.getDocumentsTokenFrequencies(doc)
+	if err != nil {
+		return err
+	}
+	return index.saveTokens(ctx, txn, doc, tokens)
+}
+
+func (index *collectionFullTextIndex) Update(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	if !isUpdatingIndexedFields(index, oldDoc, newDoc) {
+		return nil
+	}
+	oldTokens, err := index.getDocumentsTokenFrequencies(oldDoc)
+	if err != nil {
+		return err
+	}
+	newTokens, err := index.getDocumentsTokenFrequencies(newDoc)
+	if err != nil {
+		return err
+	}
+	retiredTokens := make(map[string]uint64, len(oldTokens))
+	for token, freq := range oldTokens {
+		if _, ok := newTokens[token]; !ok {
+			retiredTokens[token] = freq
+		}
+	}
+	err = index.deleteTokens(ctx, txn, oldDoc, retiredTokens)
+	if err != nil {
+		return err
+	}
+	// tokens that are still present are overwritten as their frequency might have changed
+	return index.saveTokens(ctx, txn, newDoc, newTokens)
+}
+
+func (index *collectionFullTextIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	tokens, err := index.getDocumentsTokenFrequencies(doc)
+	if err != nil {
+		return err
+	}
+	return index.deleteTokens(ctx, txn, doc, tokens)
+}
+
+// collectionPartialIndex is an index that holds only the documents matching its filter.
+//
+// It wraps the index of the actual type and forwards to it only the documents that
+// are, or have been, matching the filter.
+type collectionPartialIndex struct {
+	CollectionIndex
+	filter *mapper.Filter
+}
+
+var _ CollectionIndex = (*collectionPartialIndex)(nil)
+
+func newCollectionPartialIndex(index CollectionIndex, filter map[string]any) *collectionPartialIndex {
+	return &collectionPartialIndex{
+		CollectionIndex: index,
+		filter:          mapper.ToFilter(request.Filter{Conditions: filter}, nil),
+	}
+}
+
+// isIndexed returns true if the document matches the filter of the index.
+func (index *collectionPartialIndex) isIndexed(doc *client.Document) (bool, error) {
+	docMap, err := doc.ToMap()
+	if err != nil {
+		return false, err
+	}
+	return connor.Match(index.filter.Conditions, docMap)
+}
+
+// Save indexes the document if it matches the filter of the index.
+func (index *collectionPartialIndex) Save(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	isIndexed, err := index.isIndexed(doc)
+	if err != nil || !isIndexed {
+		return err
+	}
+	return index.CollectionIndex.Save(ctx, txn, doc)
+}
+
+// Update updates the document in the index, adding or removing it if it
+// started or stopped matching the filter of the index.
+func (index *collectionPartialIndex) Update(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	wasIndexed, err := index.isIndexed(oldDoc)
+	if err != nil {
+		return err
+	}
+	isIndexed, err := index.isIndexed(newDoc)
+	if err != nil {
+		return err
+	}
+	switch {
+	case wasIndexed && isIndexed:
+		return index.CollectionIndex.Update(ctx, txn, oldDoc, newDoc)
+	case wasIndexed:
+		return index.CollectionIndex.Delete(ctx, txn, oldDoc)
+	case isIndexed:
+		return index.CollectionIndex.Save(ctx, txn, newDoc)
+	default:
+		return nil
+	}
+}
+
+// Delete removes the document from the index if it matches the filter of the index.
+func (index *collectionPartialIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	isIndexed, err := index.isIndexed(doc)
+	if err != nil || !isIndexed {
+		return err
+	}
+	return index.CollectionIndex.Delete(ctx, txn, doc)
+}
@@ -143,6 +143,9 @@ type mergeProcessor struct {
 	encBlockLS linking.LinkSystem
 	col        *collection
 
+	// requireSignatures is true if unsigned blocks must be rejected
+	requireSignatures bool
+
 	// docIDs contains all docIDs that have been merged so far by the mergeProcessor
 	docIDs map[string]struct{}
 
@@ -169,6 +172,7 @@ func (db *db) newMergeProcessor(
 		blockLS:                   blockLS,
 		encBlockLS:                encBlockLS,
 		col:                       col,
+		requireSignatures:         db.requireSignatures,
 		docIDs:                    make(map[string]struct{}),
 		composites:                list.New(),
 		missingEncryptionBlocks:   make(map[cidlink.Link]struct{}),
@@ -375,6 +379,15 @@ func (mp *mergeProcessor) processBlock(
 	dagBlock *coreblock.Block,
 	blockLink cidlink.Link,
 ) error {
+	if mp.requireSignatures && dagBlock.Signature == nil {
+		return NewErrMissingBlockSignature(blockLink.Cid)
+	}
+
+	err := dagBlock.VerifySignature()
+	if err != nil {
+		return NewErrInvalidBlockSignature(blockLink.Cid, err)
+	}
+
 	block, canRead, err := mp.processEncryptedBlock(ctx, dagBlock)
 	if err != nil {
 		return err
@@ -581,9 +594,20 @@ func syncIndexedDoc(
 		return err
 	}
 
-	if isNewDoc {
+	if isNewDoc && isDeletedDoc {
+		// The document was created and deleted by the merged blocks, there is nothing to index.
+		return nil
+	} else if isNewDoc {
+		err := col.db.addDocuments(ctx, mustGetContextTxn(ctx), col.Description().RootID, 1)
+		if err != nil {
+			return err
+		}
 		return col.indexNewDoc(ctx, doc)
 	} else if isDeletedDoc {
+		err := col.db.addDocuments(ctx, mustGetContextTxn(ctx), col.Description().RootID, -1)
+		if err != nil {
+			return err
+		}
 		return col.deleteIndexedDoc(ctx, oldDoc)
 	} else {
 		return col.updateDocIndex(ctx, oldDoc, doc)
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/ipfs/go-cid"
 	"github.com/ipld/go-ipld-prime"
 	"github.com/ipld/go-ipld-prime/linking"
@@ -201,10 +202,91 @@ func TestMerge_DualBranchWithOneIncomplete_CouldNotFindCID(t *testing.T) {
 	require.Equal(t, expectedDocMap, docMap)
 }
 
+func TestMerge_WithSignedBlocks_NoError(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+
+	_, err = db.AddSchema(ctx, userSchema)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.SetWriteStorage(db.multistore.Blockstore().AsIPLDStorage())
+
+	initialDocState := map[string]any{
+		"name": "John",
+	}
+	d, docID := newDagBuilder(col, initialDocState)
+	d.signer, err = secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	compInfo, err := d.generateCompositeUpdate(&lsys, initialDocState, compositeInfo{})
+	require.NoError(t, err)
+
+	err = db.executeMerge(ctx, col.(*collection), event.Merge{
+		DocID:      docID.String(),
+		Cid:        compInfo.link.Cid,
+		SchemaRoot: col.SchemaRoot(),
+	})
+	require.NoError(t, err)
+
+	_, err = col.Get(ctx, docID, false)
+	require.NoError(t, err)
+}
+
+func TestMerge_WithForgedBlockSignature_Error(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+
+	_, err = db.AddSchema(ctx, userSchema)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.SetWriteStorage(db.multistore.Blockstore().AsIPLDStorage())
+
+	initialDocState := map[string]any{
+		"name": "John",
+	}
+	d, docID := newDagBuilder(col, initialDocState)
+	d.signer, err = secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	impersonated, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	d.forgedIdentity = impersonated.PubKey()
+
+	compInfo, err := d.generateCompositeUpdate(&lsys, initialDocState, compositeInfo{})
+	require.NoError(t, err)
+
+	err = db.executeMerge(ctx, col.(*collection), event.Merge{
+		DocID:      docID.String(),
+		Cid:        compInfo.link.Cid,
+		SchemaRoot: col.SchemaRoot(),
+	})
+	require.ErrorIs(t, err, ErrInvalidBlockSignature)
+
+	_, err = col.Get(ctx, docID, false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFoundOrNotAuthorized)
+}
+
 type dagBuilder struct {
 	fieldsHeight map[string]uint64
 	docID        []byte
 	col          client.Collection
+
+	// signer is the key used to sign the generated blocks, if any.
+	signer *secp256k1.PrivateKey
+	// forgedIdentity is the identity the signatures of the generated blocks claim to be made by,
+	// if it differs from the signer.
+	forgedIdentity *secp256k1.PublicKey
 }
 
 func newDagBuilder(col client.Collection, initalDocState map[string]any) (*dagBuilder, client.DocID) {
@@ -249,6 +331,10 @@ func (d *dagBuilder) generateCompositeUpdate(lsys *linking.LinkSystem, fields ma
 				},
 			},
 		}
+		err := d.sign(&fieldBlock)
+		if err != nil {
+			return compositeInfo{}, err
+		}
 		fieldBlockLink, err := lsys.Store(ipld.LinkContext{}, coreblock.GetLinkPrototype(), fieldBlock.GenerateNode())
 		if err != nil {
 			return compositeInfo{}, err
@@ -269,6 +355,10 @@ func (d *dagBuilder) generateCompositeUpdate(lsys *linking.LinkSystem, fields ma
 		links,
 		heads...,
 	)
+	err := d.sign(compositeBlock)
+	if err != nil {
+		return compositeInfo{}, err
+	}
 
 	compositeBlockLink, err := lsys.Store(ipld.LinkContext{}, coreblock.GetLinkPrototype(), compositeBlock.GenerateNode())
 	if err != nil {
@@ -281,6 +371,20 @@ func (d *dagBuilder) generateCompositeUpdate(lsys *linking.LinkSystem, fields ma
 	}, nil
 }
 
+func (d *dagBuilder) sign(block *coreblock.Block) error {
+	if d.signer == nil {
+		return nil
+	}
+	err := block.Sign(d.signer)
+	if err != nil {
+		return err
+	}
+	if d.forgedIdentity != nil {
+		block.Signature.Identity = d.forgedIdentity.SerializeCompressed()
+	}
+	return nil
+}
+
 func encodeValue(val any) []byte {
 	em, err := client.CborEncodingOptions().EncMode()
 	if err != nil {
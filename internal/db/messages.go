@@ -25,8 +25,8 @@ func (db *db) handleMessages(ctx context.Context, sub *event.Subscription) {
 	docIDQueue := newMergeQueue()
 	schemaRootQueue := newMergeQueue()
 
-	// This is used to ensure we only trigger loadAndPublishP2PCollections and loadAndPublishReplicators
-	// once per db instanciation.
+	// This is used to ensure we only trigger loadAndPublishPeerAccess, loadAndPublishP2PCollections
+	// and loadAndPublishReplicators once per db instanciation.
 	loadOnce := sync.Once{}
 	for {
 		select {
@@ -83,11 +83,18 @@ func (db *db) handleMessages(ctx context.Context, sub *event.Subscription) {
 				}()
 			case event.PeerInfo:
 				db.peerInfo.Store(evt.Info)
-				// Load and publish P2P collections and replicators once per db instance start.
+				// Load and publish peer access, P2P collections and replicators once per db instance start.
 				// A Go routine is used to ensure the message handler is not blocked by these potentially
 				// long running operations.
 				go loadOnce.Do(func() {
-					err := db.loadAndPublishP2PCollections(ctx)
+					// The access of peers is loaded first so that it applies to the blocks pushed to
+					// the P2P collections and replicators once they are loaded.
+					err := db.loadAndPublishPeerAccess(ctx)
+					if err != nil {
+						log.ErrorContextE(ctx, "Failed to load peer access", err)
+					}
+
+					err = db.loadAndPublishP2PCollections(ctx)
 					if err != nil {
 						log.ErrorContextE(ctx, "Failed to load P2P collections", err)
 					}
@@ -99,10 +106,15 @@ func (db *db) handleMessages(ctx context.Context, sub *event.Subscription) {
 				})
 			case event.ReplicatorFailure:
 				// ReplicatorFailure is a notification that a replicator has failed to replicate a document.
+				db.recordReplicatorPush(evt.PeerID.String(), evt.Err)
 				err := db.handleReplicatorFailure(ctx, evt.PeerID.String(), evt.DocID)
 				if err != nil {
 					log.ErrorContextE(ctx, "Failed to handle replicator failure", err)
 				}
+			case event.ReplicatorSuccess:
+				db.recordReplicatorPush(evt.PeerID.String(), nil)
+			case event.PushRejected:
+				db.handlePushRejected(evt)
 			}
 		}
 	}
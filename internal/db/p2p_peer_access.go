@@ -0,0 +1,201 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/event"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+func (db *db) SetPeerAccess(ctx context.Context, params client.PeerAccessParams) error {
+	if params.Access != client.PeerAccessAllow && params.Access != client.PeerAccessDeny {
+		return NewErrInvalidPeerAccess(params.Access)
+	}
+	for _, id := range params.PeerIDs {
+		if _, err := peer.Decode(id); err != nil {
+			return err
+		}
+	}
+
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	for _, id := range params.PeerIDs {
+		access, err := json.Marshal(client.PeerAccess{
+			PeerID: id,
+			Access: params.Access,
+		})
+		if err != nil {
+			return err
+		}
+		err = txn.Peerstore().Put(ctx, keys.NewPeerAccessKey(id).ToDS(), access)
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.commitAndPublishPeerAccess(ctx, txn)
+}
+
+func (db *db) DeletePeerAccess(ctx context.Context, peerIDs []string) error {
+	for _, id := range peerIDs {
+		if _, err := peer.Decode(id); err != nil {
+			return err
+		}
+	}
+
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	for _, id := range peerIDs {
+		err = txn.Peerstore().Delete(ctx, keys.NewPeerAccessKey(id).ToDS())
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.commitAndPublishPeerAccess(ctx, txn)
+}
+
+func (db *db) GetAllPeerAccess(ctx context.Context) ([]client.PeerAccess, error) {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	stored, err := getAllPeerAccess(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.rejectedPushesMu.Lock()
+	defer db.rejectedPushesMu.Unlock()
+
+	access := []client.PeerAccess{}
+	for _, peerAccess := range stored {
+		peerAccess.RejectedPushes = db.rejectedPushes[peerAccess.PeerID]
+		access = append(access, peerAccess)
+	}
+	// Peers without access may still have had their pushed blocks rejected, either because
+	// other peers are allowed or because their blocks were not authorized.
+	for id, count := range db.rejectedPushes {
+		if _, ok := stored[id]; !ok {
+			access = append(access, client.PeerAccess{
+				PeerID:         id,
+				RejectedPushes: count,
+			})
+		}
+	}
+	sort.Slice(access, func(i, j int) bool {
+		return access[i].PeerID < access[j].PeerID
+	})
+	return access, nil
+}
+
+// getAllPeerAccess returns the persisted access of peers, by peer ID.
+func getAllPeerAccess(ctx context.Context, txn datastore.Txn) (map[string]client.PeerAccess, error) {
+	results, err := txn.Peerstore().Query(ctx, query.Query{
+		Prefix: keys.NewPeerAccessKey("").ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeQueryResults(results)
+
+	access := make(map[string]client.PeerAccess)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		var peerAccess client.PeerAccess
+		if err := json.Unmarshal(result.Value, &peerAccess); err != nil {
+			return nil, err
+		}
+		access[peerAccess.PeerID] = peerAccess
+	}
+	return access, nil
+}
+
+// commitAndPublishPeerAccess commits the given transaction and publishes the persisted access
+// of peers once it is committed.
+func (db *db) commitAndPublishPeerAccess(ctx context.Context, txn datastore.Txn) error {
+	stored, err := getAllPeerAccess(ctx, txn)
+	if err != nil {
+		return err
+	}
+	evt, err := newPeerAccessEvent(stored)
+	if err != nil {
+		return err
+	}
+	txn.OnSuccess(func() {
+		db.events.Publish(event.NewMessage(event.PeerAccessName, evt))
+	})
+	return txn.Commit(ctx)
+}
+
+func newPeerAccessEvent(stored map[string]client.PeerAccess) (event.PeerAccess, error) {
+	evt := event.PeerAccess{}
+	for id, peerAccess := range stored {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			return event.PeerAccess{}, err
+		}
+		switch peerAccess.Access {
+		case client.PeerAccessAllow:
+			evt.Allowed = append(evt.Allowed, pid)
+		case client.PeerAccessDeny:
+			evt.Denied = append(evt.Denied, pid)
+		}
+	}
+	return evt, nil
+}
+
+func (db *db) loadAndPublishPeerAccess(ctx context.Context) error {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	stored, err := getAllPeerAccess(ctx, txn)
+	if err != nil {
+		return err
+	}
+	evt, err := newPeerAccessEvent(stored)
+	if err != nil {
+		return err
+	}
+	db.events.Publish(event.NewMessage(event.PeerAccessName, evt))
+	return nil
+}
+
+// handlePushRejected counts the blocks pushed by peers that were rejected.
+func (db *db) handlePushRejected(evt event.PushRejected) {
+	db.rejectedPushesMu.Lock()
+	defer db.rejectedPushesMu.Unlock()
+	db.rejectedPushes[evt.FromPeer.String()]++
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/event"
+)
+
+const (
+	testPeerID1 = "12D3KooWB8Na2fKhdGtej5GjoVhmBBYFvqXiqFCSkR7fJFWHUbNr"
+	testPeerID2 = "12D3KooWCXAHChBd7SoFKV4tiqBo2jHKjzZuZqX4MgGtEkKDAbdq"
+)
+
+func TestSetPeerAccess_WithInvalidAccess_ShouldError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{testPeerID1},
+		Access:  "invalid",
+	})
+	require.ErrorIs(t, err, ErrInvalidPeerAccess)
+}
+
+func TestSetPeerAccess_WithInvalidPeerID_ShouldError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{"invalid"},
+		Access:  client.PeerAccessDeny,
+	})
+	require.Error(t, err)
+}
+
+func TestSetPeerAccess_ShouldPersistAndPublish(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	sub, err := db.events.Subscribe(event.PeerAccessName)
+	require.NoError(t, err)
+	defer db.events.Unsubscribe(sub)
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{testPeerID1, testPeerID2},
+		Access:  client.PeerAccessAllow,
+	})
+	require.NoError(t, err)
+	<-sub.Message()
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{testPeerID2},
+		Access:  client.PeerAccessDeny,
+	})
+	require.NoError(t, err)
+
+	msg := <-sub.Message()
+	evt := msg.Data.(event.PeerAccess)
+	require.Equal(t, []peer.ID{mustDecodePeerID(t, testPeerID1)}, evt.Allowed)
+	require.Equal(t, []peer.ID{mustDecodePeerID(t, testPeerID2)}, evt.Denied)
+
+	access, err := db.GetAllPeerAccess(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []client.PeerAccess{
+		{PeerID: testPeerID1, Access: client.PeerAccessAllow},
+		{PeerID: testPeerID2, Access: client.PeerAccessDeny},
+	}, access)
+}
+
+func TestDeletePeerAccess_ShouldDeleteAndPublish(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{testPeerID1, testPeerID2},
+		Access:  client.PeerAccessDeny,
+	})
+	require.NoError(t, err)
+
+	sub, err := db.events.Subscribe(event.PeerAccessName)
+	require.NoError(t, err)
+	defer db.events.Unsubscribe(sub)
+
+	err = db.DeletePeerAccess(ctx, []string{testPeerID1})
+	require.NoError(t, err)
+
+	msg := <-sub.Message()
+	evt := msg.Data.(event.PeerAccess)
+	require.Empty(t, evt.Allowed)
+	require.Equal(t, []peer.ID{mustDecodePeerID(t, testPeerID2)}, evt.Denied)
+
+	access, err := db.GetAllPeerAccess(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []client.PeerAccess{
+		{PeerID: testPeerID2, Access: client.PeerAccessDeny},
+	}, access)
+}
+
+func TestGetAllPeerAccess_WithRejectedPushes_ShouldCount(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = db.SetPeerAccess(ctx, client.PeerAccessParams{
+		PeerIDs: []string{testPeerID1},
+		Access:  client.PeerAccessDeny,
+	})
+	require.NoError(t, err)
+
+	pid1 := mustDecodePeerID(t, testPeerID1)
+	pid2 := mustDecodePeerID(t, testPeerID2)
+	db.events.Publish(event.NewMessage(event.PushRejectedName, event.PushRejected{FromPeer: pid1}))
+	db.events.Publish(event.NewMessage(event.PushRejectedName, event.PushRejected{FromPeer: pid1}))
+	db.events.Publish(event.NewMessage(event.PushRejectedName, event.PushRejected{FromPeer: pid2}))
+
+	expected := []client.PeerAccess{
+		{PeerID: testPeerID1, Access: client.PeerAccessDeny, RejectedPushes: 2},
+		{PeerID: testPeerID2, RejectedPushes: 1},
+	}
+	require.Eventually(t, func() bool {
+		access, err := db.GetAllPeerAccess(ctx)
+		require.NoError(t, err)
+		return slices.Equal(expected, access)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func mustDecodePeerID(t *testing.T, id string) peer.ID {
+	pid, err := peer.Decode(id)
+	require.NoError(t, err)
+	return pid
+}
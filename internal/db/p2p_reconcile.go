@@ -0,0 +1,71 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/event"
+)
+
+func (db *db) ReconcileWithPeer(ctx context.Context, params client.ReconcileParams) (client.ReconcileResult, error) {
+	if err := params.Info.ID.Validate(); err != nil {
+		return client.ReconcileResult{}, err
+	}
+
+	peerInfo := db.PeerInfo()
+	if peerInfo.ID == "" {
+		return client.ReconcileResult{}, ErrReconcileP2PDisabled
+	}
+	if params.Info.ID == peerInfo.ID {
+		return client.ReconcileResult{}, ErrSelfTargetForReconcile
+	}
+
+	p2pCollections, err := db.GetAllP2PCollections(ctx)
+	if err != nil {
+		return client.ReconcileResult{}, err
+	}
+	schemaRoots := params.CollectionIDs
+	if len(schemaRoots) == 0 {
+		schemaRoots = p2pCollections
+	}
+	for _, schemaRoot := range schemaRoots {
+		if !slices.Contains(p2pCollections, schemaRoot) {
+			return client.ReconcileResult{}, NewErrReconcileNotP2PCollection(schemaRoot)
+		}
+	}
+	if len(schemaRoots) == 0 {
+		return client.ReconcileResult{}, nil
+	}
+
+	resultChan := make(chan event.ReconcileResult, 1)
+	db.events.Publish(event.NewMessage(event.ReconcileName, event.Reconcile{
+		Info:        params.Info,
+		SchemaRoots: schemaRoots,
+		Result:      resultChan,
+	}))
+
+	select {
+	case <-ctx.Done():
+		return client.ReconcileResult{}, ctx.Err()
+
+	case result := <-resultChan:
+		if result.Err != nil {
+			return client.ReconcileResult{}, result.Err
+		}
+		return client.ReconcileResult{
+			Docs:   result.Docs,
+			Rounds: result.Rounds,
+		}, nil
+	}
+}
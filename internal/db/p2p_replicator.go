@@ -13,6 +13,7 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"time"
 
 	"github.com/fxamacker/cbor/v2"
@@ -111,12 +112,38 @@ func (db *db) SetReplicator(ctx context.Context, rep client.ReplicatorParams) er
 		}
 	}
 
+	colsByName := make(map[string]client.Collection, len(collections))
+	for _, col := range collections {
+		colsByName[col.Name().Value()] = col
+	}
+	for name, filter := range rep.Filters {
+		col, ok := colsByName[name]
+		if !ok {
+			return NewErrInvalidReplicatorFilter(name, ErrReplicatorFilterNotReplicated)
+		}
+		if err := validateReplicatorFilter(col, filter); err != nil {
+			return err
+		}
+	}
+
+	if storedRep.Filters == nil {
+		storedRep.Filters = make(map[string]map[string]any)
+	}
 	addedCols := []client.Collection{}
 	for _, col := range collections {
+		filter := rep.Filters[col.Name().Value()]
 		if _, ok := storedSchemas[col.SchemaRoot()]; !ok {
 			storedSchemas[col.SchemaRoot()] = struct{}{}
 			addedCols = append(addedCols, col)
 			storedRep.Schemas = append(storedRep.Schemas, col.SchemaRoot())
+		} else if !reflect.DeepEqual(storedRep.Filters[col.SchemaRoot()], filter) {
+			// The documents matching the new filter may not have been replicated yet.
+			addedCols = append(addedCols, col)
+		}
+		if len(filter) > 0 {
+			storedRep.Filters[col.SchemaRoot()] = filter
+		} else {
+			delete(storedRep.Filters, col.SchemaRoot())
 		}
 	}
 
@@ -135,16 +162,20 @@ func (db *db) SetReplicator(ctx context.Context, rep client.ReplicatorParams) er
 		db.events.Publish(event.NewMessage(event.ReplicatorName, event.Replicator{
 			Info:    rep.Info,
 			Schemas: storedSchemas,
-			Docs:    db.getDocsHeads(context.Background(), addedCols),
+			Filters: storedRep.Filters,
+			Docs:    db.getDocsHeads(context.Background(), addedCols, storedRep.Filters),
 		}))
 	})
 
 	return txn.Commit(ctx)
 }
 
+// getDocsHeads returns the heads of the documents of the given collections matching the
+// filter of their schema root, if any.
 func (db *db) getDocsHeads(
 	ctx context.Context,
 	cols []client.Collection,
+	filters map[string]map[string]any,
 ) <-chan event.Update {
 	updateChan := make(chan event.Update)
 	go func() {
@@ -171,6 +202,21 @@ func (db *db) getDocsHeads(
 					log.ErrorContextE(ctx, "Key channel error", docIDResult.Err)
 					continue
 				}
+				if filter, ok := filters[col.SchemaRoot()]; ok {
+					matches, err := docMatchesFilter(ctx, col, docIDResult.ID, filter)
+					if err != nil {
+						log.ErrorContextE(
+							ctx,
+							"Failed to match replicator filter",
+							err,
+							corelog.String("DocID", docIDResult.ID.String()),
+							corelog.Any("Collection", col.Name()))
+						continue
+					}
+					if !matches {
+						continue
+					}
+				}
 				docID := keys.DataStoreKeyFromDocID(docIDResult.ID)
 				headset := clock.NewHeadSet(
 					txn.Headstore(),
@@ -268,6 +314,7 @@ func (db *db) DeleteReplicator(ctx context.Context, rep client.ReplicatorParams)
 
 	for _, col := range collections {
 		delete(storedSchemas, col.SchemaRoot())
+		delete(storedRep.Filters, col.SchemaRoot())
 	}
 	// Update the list of schemas for this replicator prior to persisting.
 	storedRep.Schemas = []string{}
@@ -283,7 +330,7 @@ func (db *db) DeleteReplicator(ctx context.Context, rep client.ReplicatorParams)
 			return err
 		}
 	} else {
-		repBytes, err := json.Marshal(rep)
+		repBytes, err := json.Marshal(storedRep)
 		if err != nil {
 			return err
 		}
@@ -297,6 +344,7 @@ func (db *db) DeleteReplicator(ctx context.Context, rep client.ReplicatorParams)
 		db.events.Publish(event.NewMessage(event.ReplicatorName, event.Replicator{
 			Info:    rep.Info,
 			Schemas: storedSchemas,
+			Filters: storedRep.Filters,
 		}))
 	})
 
@@ -325,6 +373,9 @@ func (db *db) GetAllReplicators(ctx context.Context) ([]client.Replicator, error
 		if err = json.Unmarshal(result.Value, &rep); err != nil {
 			return nil, err
 		}
+		if err = db.setReplicatorStatus(ctx, txn, &rep); err != nil {
+			return nil, err
+		}
 		reps = append(reps, rep)
 	}
 	return reps, nil
@@ -344,6 +395,7 @@ func (db *db) loadAndPublishReplicators(ctx context.Context) error {
 		db.events.Publish(event.NewMessage(event.ReplicatorName, event.Replicator{
 			Info:    rep.Info,
 			Schemas: schemaMap,
+			Filters: rep.Filters,
 		}))
 	}
 	return nil
@@ -621,6 +673,7 @@ func (db *db) retryReplicator(ctx context.Context, peerID string) {
 		err = db.retryDoc(ctx, key.DocID)
 		if err != nil {
 			log.ErrorContextE(ctx, "Failed to retry doc", err)
+			db.recordReplicatorPush(peerID, err)
 			err = db.handleCompletedReplicatorRetry(ctx, peerID, false)
 			if err != nil {
 				log.ErrorContextE(ctx, "Failed to handle completed replicator retry", err)
@@ -0,0 +1,152 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/metric"
+)
+
+const (
+	replicatorPendingDocsMetric        = "replicator.pending_docs"
+	replicatorRetryAttemptMetric       = "replicator.retry_attempt"
+	replicatorNextRetryMetric          = "replicator.next_retry"
+	replicatorLastSuccessfulPushMetric = "replicator.last_successful_push"
+
+	peerIDAttribute = "peer_id"
+)
+
+// replicatorPushes holds the outcome of the last pushes to a replicator.
+//
+// It is only held in memory, and is reset when the node restarts.
+type replicatorPushes struct {
+	lastSuccess time.Time
+	lastError   string
+}
+
+// recordReplicatorPush records the outcome of a push to the replicator with the given peer ID.
+//
+// A nil error records a successful push, and clears the error of the previous failed push.
+func (db *db) recordReplicatorPush(peerID string, err error) {
+	db.replicatorPushesMu.Lock()
+	defer db.replicatorPushesMu.Unlock()
+	pushes := db.replicatorPushes[peerID]
+	if err == nil {
+		pushes.lastSuccess = time.Now()
+		pushes.lastError = ""
+	} else {
+		pushes.lastError = err.Error()
+	}
+	db.replicatorPushes[peerID] = pushes
+}
+
+// setReplicatorStatus sets the backlog of documents waiting to be retried and the outcome
+// of the last pushes of the given replicator.
+func (db *db) setReplicatorStatus(ctx context.Context, txn datastore.Txn, rep *client.Replicator) error {
+	peerID := rep.Info.ID.String()
+
+	results, err := txn.Peerstore().Query(ctx, query.Query{
+		Prefix:   keys.NewReplicatorRetryDocIDKey(peerID, "").ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer closeQueryResults(results)
+	rep.PendingDocs = 0
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		rep.PendingDocs++
+	}
+
+	b, err := txn.Peerstore().Get(ctx, keys.NewReplicatorRetryIDKey(peerID).ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		rInfo := retryInfo{}
+		if err := cbor.Unmarshal(b, &rInfo); err != nil {
+			return err
+		}
+		rep.RetryAttempt = rInfo.NumRetries
+		rep.NextRetry = rInfo.NextRetry
+	}
+
+	db.replicatorPushesMu.Lock()
+	pushes := db.replicatorPushes[peerID]
+	db.replicatorPushesMu.Unlock()
+	rep.LastSuccessfulPush = pushes.lastSuccess
+	rep.LastError = pushes.lastError
+	return nil
+}
+
+// registerReplicatorMetrics registers the gauges observing the status of the replicators
+// on the given meter.
+//
+// Times are observed as unix timestamps in seconds, and only if they are set.
+func (db *db) registerReplicatorMetrics(meter *metric.Meter) error {
+	pendingDocs, err := meter.GetAsyncGauge(replicatorPendingDocsMetric, "{document}")
+	if err != nil {
+		return err
+	}
+	retryAttempt, err := meter.GetAsyncGauge(replicatorRetryAttemptMetric, "{attempt}")
+	if err != nil {
+		return err
+	}
+	nextRetry, err := meter.GetAsyncGauge(replicatorNextRetryMetric, "s")
+	if err != nil {
+		return err
+	}
+	lastSuccessfulPush, err := meter.GetAsyncGauge(replicatorLastSuccessfulPushMetric, "s")
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Get().RegisterCallback(
+		func(ctx context.Context, o otelMetric.Observer) error {
+			reps, err := db.GetAllReplicators(ctx)
+			if err != nil {
+				return err
+			}
+			for _, rep := range reps {
+				attributes := otelMetric.WithAttributes(attribute.String(peerIDAttribute, rep.Info.ID.String()))
+				o.ObserveInt64(pendingDocs, int64(rep.PendingDocs), attributes)
+				o.ObserveInt64(retryAttempt, int64(rep.RetryAttempt), attributes)
+				if !rep.NextRetry.IsZero() {
+					o.ObserveInt64(nextRetry, rep.NextRetry.Unix(), attributes)
+				}
+				if !rep.LastSuccessfulPush.IsZero() {
+					o.ObserveInt64(lastSuccessfulPush, rep.LastSuccessfulPush.Unix(), attributes)
+				}
+			}
+			return nil
+		},
+		pendingDocs,
+		retryAttempt,
+		nextRetry,
+		lastSuccessfulPush,
+	)
+	return err
+}
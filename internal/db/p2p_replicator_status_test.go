@@ -0,0 +1,83 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/metric"
+)
+
+// getMetricGauges returns the values of the gauge with the given name, by attribute set.
+func getMetricGauges(t *testing.T, ctx context.Context, meter *metric.Meter, name string) map[attribute.Set]int64 {
+	data, err := meter.Dump(ctx)
+	require.NoError(t, err)
+
+	gauges := map[attribute.Set]int64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			require.True(t, ok)
+			for _, point := range gauge.DataPoints {
+				gauges[point.Attributes] = point.Value
+			}
+		}
+	}
+	return gauges
+}
+
+func TestReplicatorMetrics_ObservesReplicatorStatus(t *testing.T) {
+	ctx := context.Background()
+	meter := metric.NewMeter()
+	meter.Register("defradb")
+	peerID := mustDecodePeerID(t, testPeerID1)
+	db, err := newMemoryDB(ctx, WithMeter(&meter), WithRetryInterval([]time.Duration{time.Hour}))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.ReplicatorParams{
+		Info:        peer.AddrInfo{ID: peerID},
+		Collections: []string{"User"},
+	})
+	require.NoError(t, err)
+
+	err = db.handleReplicatorFailure(ctx, peerID.String(), "bae-1")
+	require.NoError(t, err)
+	db.recordReplicatorPush(peerID.String(), nil)
+
+	attributes := attribute.NewSet(attribute.String(peerIDAttribute, peerID.String()))
+	require.Equal(
+		t,
+		map[attribute.Set]int64{attributes: 1},
+		getMetricGauges(t, ctx, &meter, replicatorPendingDocsMetric),
+	)
+	require.Equal(
+		t,
+		map[attribute.Set]int64{attributes: 0},
+		getMetricGauges(t, ctx, &meter, replicatorRetryAttemptMetric),
+	)
+	require.Contains(t, getMetricGauges(t, ctx, &meter, replicatorNextRetryMetric), attributes)
+	require.Contains(t, getMetricGauges(t, ctx, &meter, replicatorLastSuccessfulPushMetric), attributes)
+}
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 	b58 "github.com/mr-tron/base58/base58"
 	"github.com/stretchr/testify/require"
@@ -168,6 +169,136 @@ func TestSetReplicator_WithValidCollectionWithDoc_ShouldSucceed(t *testing.T) {
 	}
 }
 
+func TestSetReplicator_WithFilterOnNonReplicatedCollection_ShouldError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.AddSchema(ctx, `type User { name: String } type Book { name: String }`)
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.ReplicatorParams{
+		Info:        peer.AddrInfo{ID: "other"},
+		Collections: []string{"User"},
+		Filters: map[string]map[string]any{
+			"Book": {"name": map[string]any{"_eq": "Go"}},
+		},
+	})
+	require.ErrorIs(t, err, ErrReplicatorFilterNotReplicated)
+}
+
+func TestSetReplicator_WithFilterOnUnknownField_ShouldError(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	err = db.SetReplicator(ctx, client.ReplicatorParams{
+		Info:        peer.AddrInfo{ID: "other"},
+		Collections: []string{"User"},
+		Filters: map[string]map[string]any{
+			"User": {"region": map[string]any{"_eq": "eu"}},
+		},
+	})
+	require.ErrorIs(t, err, ErrReplicatorFilterFields)
+}
+
+func TestSetReplicator_WithFilter_ShouldReplicateMatchingDocs(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	sub, err := db.events.Subscribe(event.ReplicatorName)
+	require.NoError(t, err)
+	cols, err := db.AddSchema(ctx, `type User { name: String, region: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, cols[0].Name.Value())
+	require.NoError(t, err)
+	euDoc, err := client.NewDocFromMap(map[string]any{"name": "Alice", "region": "eu"}, col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, euDoc)
+	require.NoError(t, err)
+	usDoc, err := client.NewDocFromMap(map[string]any{"name": "Bob", "region": "us"}, col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, usDoc)
+	require.NoError(t, err)
+
+	filter := map[string]any{"region": map[string]any{"_eq": "eu"}}
+	err = db.SetReplicator(ctx, client.ReplicatorParams{
+		Info:        peer.AddrInfo{ID: mustDecodePeerID(t, testPeerID1)},
+		Collections: []string{"User"},
+		Filters:     map[string]map[string]any{"User": filter},
+	})
+	require.NoError(t, err)
+	msg := <-sub.Message()
+	replicator := msg.Data.(event.Replicator)
+	require.Equal(t, map[string]map[string]any{col.SchemaRoot(): filter}, replicator.Filters)
+	var docIDs []string
+	for docEvt := range replicator.Docs {
+		docIDs = append(docIDs, docEvt.DocID)
+	}
+	require.Equal(t, []string{euDoc.ID().String()}, docIDs)
+
+	reps, err := db.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]any{col.SchemaRoot(): filter}, reps[0].Filters)
+
+	matches, err := NewDocMatcher(db).DocMatchesFilter(ctx, col.SchemaRoot(), usDoc.ID().String(), nil, filter)
+	require.NoError(t, err)
+	require.False(t, matches)
+}
+
+func TestDocMatchesFilter_WithPreviousVersion_ShouldMatchPreviousState(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	cols, err := db.AddSchema(ctx, `type User { name: String, region: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, cols[0].Name.Value())
+	require.NoError(t, err)
+
+	sub, err := db.events.Subscribe(event.UpdateName)
+	require.NoError(t, err)
+	doc, err := client.NewDocFromMap(map[string]any{"name": "Alice", "region": "eu"}, col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+	euVersion := (<-sub.Message()).Data.(event.Update).Cid
+
+	err = doc.Set("region", "us")
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+	usVersion := (<-sub.Message()).Data.(event.Update).Cid
+
+	filter := map[string]any{"region": map[string]any{"_eq": "eu"}}
+	matcher := NewDocMatcher(db)
+
+	matches, err := matcher.DocMatchesFilter(ctx, col.SchemaRoot(), doc.ID().String(), nil, filter)
+	require.NoError(t, err)
+	require.False(t, matches)
+
+	versions := []cid.Cid{usVersion}
+	matches, err = matcher.DocMatchesFilter(ctx, col.SchemaRoot(), doc.ID().String(), versions, filter)
+	require.NoError(t, err)
+	require.False(t, matches)
+
+	versions = []cid.Cid{usVersion, euVersion}
+	matches, err = matcher.DocMatchesFilter(ctx, col.SchemaRoot(), doc.ID().String(), versions, filter)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	// previous versions of deleted documents can still be matched
+	_, err = col.Delete(ctx, doc.ID())
+	require.NoError(t, err)
+	matches, err = matcher.DocMatchesFilter(ctx, col.SchemaRoot(), doc.ID().String(), []cid.Cid{euVersion}, filter)
+	require.NoError(t, err)
+	require.True(t, matches)
+}
+
 func TestDeleteReplicator_WithEmptyPeerInfo_ShouldError(t *testing.T) {
 	ctx := context.Background()
 	db, err := newDefraMemoryDB(ctx)
@@ -0,0 +1,86 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/acp"
+	acpIdentity "github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/db/permission"
+)
+
+// pushAuthorizer is a helper struct that authorizes the identities that created the blocks
+// pushed by peers over the network.
+type pushAuthorizer struct {
+	db  client.DB
+	acp immutable.Option[acp.ACP]
+}
+
+// NewPushAuthorizer creates a new PushAuthorizer.
+func NewPushAuthorizer(db client.DB, acp immutable.Option[acp.ACP]) pushAuthorizer {
+	return pushAuthorizer{
+		db:  db,
+		acp: acp,
+	}
+}
+
+// CanUpdateDoc returns true if the identity with the given DID has write access to the
+// document with the given ID of the collection with the given schema root.
+//
+// Access is unrestricted if ACP is not available, if the collection has no policy, or if
+// the document is public.
+func (a pushAuthorizer) CanUpdateDoc(
+	ctx context.Context,
+	schemaRoot string,
+	docID string,
+	did immutable.Option[string],
+) (bool, error) {
+	if !a.acp.HasValue() {
+		return true, nil
+	}
+
+	ctx, txn, err := ensureContextTxn(ctx, a.db, true)
+	if err != nil {
+		return false, err
+	}
+	defer txn.Discard(ctx)
+
+	cols, err := a.db.GetCollections(
+		ctx,
+		client.CollectionFetchOptions{
+			SchemaRoot: immutable.Some(schemaRoot),
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	if len(cols) == 0 {
+		return false, NewErrCollectionWithSchemaRootNotFound(schemaRoot)
+	}
+
+	identity := immutable.None[acpIdentity.Identity]()
+	if did.HasValue() {
+		identity = immutable.Some(acpIdentity.Identity{DID: did.Value()})
+	}
+
+	return permission.CheckAccessOfDocOnCollectionWithACP(
+		ctx,
+		identity,
+		a.acp.Value(),
+		cols[0],
+		acp.WritePermission,
+		docID,
+	)
+}
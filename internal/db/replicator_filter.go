@@ -0,0 +1,160 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+)
+
+// docMatcher is a helper struct that matches the documents replicated to peers against
+// the filters of the replicators.
+type docMatcher struct {
+	db client.DB
+}
+
+// NewDocMatcher creates a new DocMatcher.
+func NewDocMatcher(db client.DB) docMatcher {
+	return docMatcher{
+		db: db,
+	}
+}
+
+// DocMatchesFilter returns true if the document with the given ID of the collection with
+// the given schema root matches the given filter in any of the given versions.
+//
+// If no versions are given the current state of the document is matched.
+func (m docMatcher) DocMatchesFilter(
+	ctx context.Context,
+	schemaRoot string,
+	docID string,
+	versions []cid.Cid,
+	filter map[string]any,
+) (bool, error) {
+	ctx, txn, err := ensureContextTxn(ctx, m.db, true)
+	if err != nil {
+		return false, err
+	}
+	defer txn.Discard(ctx)
+
+	cols, err := m.db.GetCollections(
+		ctx,
+		client.CollectionFetchOptions{
+			SchemaRoot: immutable.Some(schemaRoot),
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	if len(cols) == 0 {
+		return false, NewErrCollectionWithSchemaRootNotFound(schemaRoot)
+	}
+
+	id, err := client.NewDocIDFromString(docID)
+	if err != nil {
+		return false, err
+	}
+	if len(versions) == 0 {
+		return docMatchesFilter(ctx, cols[0], id, filter)
+	}
+	for _, version := range versions {
+		matches, err := docVersionMatchesFilter(ctx, m.db, cols[0], id, version, filter)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// docVersionMatchesFilter returns true if the given version of the document with the given ID
+// matches the given filter.
+func docVersionMatchesFilter(
+	ctx context.Context,
+	db client.DB,
+	col client.Collection,
+	docID client.DocID,
+	version cid.Cid,
+	filter map[string]any,
+) (bool, error) {
+	name := col.Name().Value()
+	req := fmt.Sprintf(
+		`query($filter: %s%s) { %s(cid: %q, docID: %q, filter: $filter) { %s } }`,
+		name,
+		filterArgSuffix,
+		name,
+		version.String(),
+		docID.String(),
+		request.DocIDFieldName,
+	)
+	res := db.ExecRequest(ctx, req, client.WithVariables(map[string]any{"filter": filter}))
+	if len(res.GQL.Errors) > 0 {
+		return false, res.GQL.Errors[0]
+	}
+	data, ok := res.GQL.Data.(map[string]any)
+	if !ok {
+		return false, nil
+	}
+	docs, ok := data[name].([]map[string]any)
+	return ok && len(docs) > 0, nil
+}
+
+// filterArgSuffix is the suffix of the names of the generated filter input types.
+const filterArgSuffix = "FilterArg"
+
+// docMatchesFilter returns true if the document with the given ID matches the given filter.
+//
+// Deleted documents are matched against their last known values, so that their deletion
+// is replicated to the peers that received them.
+func docMatchesFilter(
+	ctx context.Context,
+	col client.Collection,
+	docID client.DocID,
+	filter map[string]any,
+) (bool, error) {
+	doc, err := col.Get(ctx, docID, true)
+	if err != nil {
+		return false, err
+	}
+	// Documents missing the values targeted by the filter do not match it.
+	passes, _, err := mapper.RunFilterOnValues(request.Filter{Conditions: filter}, getDocValues(doc))
+	return passes, err
+}
+
+// validateReplicatorFilter returns an error if the given filter cannot be evaluated against
+// the documents of the given collection.
+func validateReplicatorFilter(col client.Collection, filter map[string]any) error {
+	if col.Description().IsBranchable {
+		// The blocks of branchable collections link to the blocks of all their documents.
+		return NewErrInvalidReplicatorFilter(col.Name().Value(), ErrReplicatorFilterOnBranchable)
+	}
+
+	values := map[string]any{
+		request.DocIDFieldName: nil,
+	}
+	for _, field := range col.Definition().GetFields() {
+		values[field.Name] = nil
+	}
+	_, ok, err := mapper.RunFilterOnValues(request.Filter{Conditions: filter}, values)
+	if err != nil {
+		return NewErrInvalidReplicatorFilter(col.Name().Value(), err)
+	}
+	if !ok {
+		return NewErrInvalidReplicatorFilter(col.Name().Value(), ErrReplicatorFilterFields)
+	}
+	return nil
+}
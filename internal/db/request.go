@@ -12,53 +12,262 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/sourcenetwork/graphql-go/language/ast"
+	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/acp/identity"
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/internal/planner"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+	"github.com/sourcenetwork/defradb/internal/request/graphql"
 )
 
+// preparedRequest is a request prepared with PrepareRequest.
+//
+// Only the validated AST of the request is kept, the request is still mapped and planned every
+// time it is executed as both depend on its variables and on the collections at that time.
+type preparedRequest struct {
+	request string
+	ast     *ast.Document
+	// schemaVersion is the version of the schema the AST was validated against.
+	schemaVersion uint64
+}
+
+// mappedRequest is a query that has been parsed and mapped against the collections, so that
+// it is only planned when it is executed again.
+type mappedRequest struct {
+	request   *request.Request
+	operation *mapper.Operation
+	// schemaVersion is the version of the schema the request was mapped with.
+	schemaVersion uint64
+}
+
 // execRequest executes a request against the database.
 func (db *db) execRequest(ctx context.Context, request string, options *client.GQLOptions) *client.RequestResult {
-	res := &client.RequestResult{}
-	ast, err := db.parser.BuildRequestAST(request)
-	if err != nil {
-		res.GQL.Errors = append(res.GQL.Errors, err)
+	start := time.Now()
+	ast, errors := db.parser.PrepareRequest(request)
+	if len(errors) > 0 {
+		res := &client.RequestResult{}
+		res.GQL.Errors = append(res.GQL.Errors, errors...)
 		return res
 	}
+	return db.execAST(ctx, request, ast, start, options)
+}
+
+// execAST executes a request whose AST has been validated against the current schema.
+func (db *db) execAST(
+	ctx context.Context,
+	request string,
+	ast *ast.Document,
+	start time.Time,
+	options *client.GQLOptions,
+) *client.RequestResult {
+	res := &client.RequestResult{}
 	if db.parser.IsIntrospection(ast) {
 		return db.parser.ExecuteIntrospection(request)
 	}
 
-	parsedRequest, errors := db.parser.Parse(ast, options)
-	if len(errors) > 0 {
-		res.GQL.Errors = append(res.GQL.Errors, errors...)
-		return res
+	txn := mustGetContextTxn(ctx)
+	// the version is read first, so that the request is mapped again if the schema
+	// changes whilst it is being mapped.
+	schemaVersion := db.parser.SchemaVersion()
+	cacheKey, cacheable := db.mappedRequestKey(txn, request, options)
+	mapped, cached := db.getMappedRequest(cacheKey, cacheable, schemaVersion)
+	if !cached {
+		parsedRequest, errors := db.parser.ParsePrepared(ast, options)
+		if len(errors) > 0 {
+			res.GQL.Errors = append(res.GQL.Errors, errors...)
+			return res
+		}
+
+		pub, err := db.handleSubscription(ctx, parsedRequest)
+		if err != nil {
+			res.GQL.Errors = append(res.GQL.Errors, err)
+			return res
+		}
+
+		if pub != nil {
+			res.Subscription = pub
+			return res
+		}
+		mapped = mappedRequest{request: parsedRequest, schemaVersion: schemaVersion}
 	}
 
-	pub, err := db.handleSubscription(ctx, parsedRequest)
-	if err != nil {
-		res.GQL.Errors = append(res.GQL.Errors, err)
-		return res
+	// the limits of the node are ceilings that requests may only lower
+	maxDuration := db.maxRequestDuration
+	if options.MaxDuration > 0 && (maxDuration <= 0 || options.MaxDuration < maxDuration) {
+		maxDuration = options.MaxDuration
+	}
+	if maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, maxDuration, client.ErrRequestTimeout)
+		defer cancel()
 	}
 
-	if pub != nil {
-		res.Subscription = pub
-		return res
+	maxScannedDocs := db.maxRequestScannedDocs
+	if options.MaxScannedDocs > 0 && (maxScannedDocs == 0 || options.MaxScannedDocs < maxScannedDocs) {
+		maxScannedDocs = options.MaxScannedDocs
 	}
 
-	txn := mustGetContextTxn(ctx)
-	planner := planner.New(ctx, identity.FromContext(ctx), db.acp, db, txn)
+	planner := db.newPlanner(ctx, txn, planner.WithMaxScannedDocs(maxScannedDocs))
+
+	if !cached {
+		operation, err := planner.MapRequest(mapped.request)
+		if err != nil {
+			db.recordRequest(ctx, request, start, planner)
+			res.GQL.Errors = append(res.GQL.Errors, requestContextError(ctx, err))
+			return res
+		}
+		mapped.operation = operation
+		// mutations are not cached, as their inputs are consumed when they are executed
+		if cacheable && len(mapped.request.Mutations) == 0 {
+			db.mappedRequests.Add(cacheKey, mapped)
+		}
+	}
 
-	results, err := planner.RunRequest(ctx, parsedRequest)
+	results, err := planner.RunMappedRequest(ctx, mapped.request, mapped.operation)
+	db.recordRequest(ctx, request, start, planner)
 	if err != nil {
-		res.GQL.Errors = append(res.GQL.Errors, err)
+		res.GQL.Errors = append(res.GQL.Errors, requestContextError(ctx, err))
+		return res
 	}
 	res.GQL.Data = results
 	return res
 }
 
+// mappedRequestKey returns the key of the given request in the cache of mapped requests, and
+// false if the request can not be cached.
+//
+// Requests executed within explicit transactions are not cached, as the collections of the
+// transaction may differ from the ones of the current schema.
+func (db *db) mappedRequestKey(txn datastore.Txn, request string, options *client.GQLOptions) (string, bool) {
+	if db.mappedRequests == nil {
+		return "", false
+	}
+	if _, ok := txn.(*explicitTxn); ok {
+		return "", false
+	}
+	key, err := json.Marshal(struct {
+		Request       string
+		OperationName string
+		Variables     map[string]any
+	}{
+		Request:       graphql.NormalizeRequest(request),
+		OperationName: options.OperationName,
+		Variables:     options.Variables,
+	})
+	if err != nil {
+		// the variables can not be encoded, the request is mapped every time it is executed
+		return "", false
+	}
+	return string(key), true
+}
+
+// getMappedRequest returns the cached mapped request with the given key, if it was mapped with
+// the given version of the schema.
+func (db *db) getMappedRequest(key string, cacheable bool, schemaVersion uint64) (mappedRequest, bool) {
+	if !cacheable {
+		return mappedRequest{}, false
+	}
+	mapped, ok := db.mappedRequests.Get(key)
+	if !ok || mapped.schemaVersion != schemaVersion {
+		return mappedRequest{}, false
+	}
+	return mapped, true
+}
+
+// requestContextError returns the error of a request that failed after its context was
+// canceled, or the given error if it was not.
+//
+// Errors caused by the cancellation are replaced by an [client.ErrRequestTimeout] or an
+// [client.ErrRequestCanceled] error, as the errors of the context itself are not meaningful to users.
+func requestContextError(ctx context.Context, err error) error {
+	if ctx.Err() == nil {
+		return err
+	}
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, client.ErrRequestTimeout), errors.Is(cause, context.DeadlineExceeded):
+		return client.ErrRequestTimeout
+	default:
+		return client.ErrRequestCanceled
+	}
+}
+
+// prepareRequest parses and validates the given request, and stores it under an ID derived
+// from the normalized request.
+//
+// The least recently used prepared requests are evicted once the maximum number of prepared
+// requests is reached.
+func (db *db) prepareRequest(request string) (string, error) {
+	hash := sha256.Sum256([]byte(graphql.NormalizeRequest(request)))
+	id := hex.EncodeToString(hash[:])
+	if _, err := db.storePreparedRequest(id, request); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// storePreparedRequest validates the given request against the current schema, and stores
+// it under the given ID.
+func (db *db) storePreparedRequest(id string, request string) (preparedRequest, error) {
+	// the version is read first, so that the request is prepared again if the schema
+	// changes whilst it is being validated.
+	schemaVersion := db.parser.SchemaVersion()
+	ast, errs := db.parser.PrepareRequest(request)
+	if len(errs) > 0 {
+		return preparedRequest{}, errors.Join(errs...)
+	}
+	prepared := preparedRequest{
+		request:       request,
+		ast:           ast,
+		schemaVersion: schemaVersion,
+	}
+	db.preparedRequests.Add(id, prepared)
+	return prepared, nil
+}
+
+// getPreparedRequest returns the prepared request with the given ID, validating it again
+// if the schema changed since it was prepared.
+func (db *db) getPreparedRequest(id string) (preparedRequest, error) {
+	prepared, ok := db.preparedRequests.Get(id)
+	if !ok {
+		return preparedRequest{}, client.NewErrPreparedRequestNotFound(id)
+	}
+	if prepared.schemaVersion == db.parser.SchemaVersion() {
+		return prepared, nil
+	}
+	return db.storePreparedRequest(id, prepared.request)
+}
+
 // ExecIntrospection executes an introspection request against the database.
 func (db *db) ExecIntrospection(request string) *client.RequestResult {
 	return db.parser.ExecuteIntrospection(request)
 }
+
+// newPlanner returns a new planner for a request executed within the given transaction.
+func (db *db) newPlanner(ctx context.Context, txn datastore.Txn, opts ...planner.Option) *planner.Planner {
+	opts = append(
+		opts,
+		planner.WithMemoryBudget(db.queryMemoryBudget),
+		planner.WithStatistics(func(name string) (immutable.Option[client.CollectionStatistics], error) {
+			return db.getPlanningStatistics(SetContextTxn(ctx, txn), name)
+		}),
+	)
+	return planner.New(
+		ctx,
+		identity.FromContext(ctx),
+		db.acp,
+		db,
+		txn,
+		opts...,
+	)
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corelog"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/sourcenetwork/defradb/acp/identity"
+	"github.com/sourcenetwork/defradb/internal/metric"
+	"github.com/sourcenetwork/defradb/internal/planner"
+)
+
+// slowRequestLog is the logger of the slow request log, so that it can be configured
+// separately from the other logs of the database.
+var slowRequestLog = corelog.NewLogger("slowrequest")
+
+const (
+	requestDurationMetric     = "request.duration"
+	requestSlowMetric         = "request.slow"
+	requestDocFetchesMetric   = "request.doc_fetches"
+	requestFieldFetchesMetric = "request.field_fetches"
+	requestIndexFetchesMetric = "request.index_fetches"
+
+	collectionAttribute = "collection"
+	indexAttribute      = "index"
+)
+
+// requestMetrics holds the instruments recording the execution of requests.
+//
+// Durations are recorded once per request, and fetches are recorded by the collection
+// and index they were scanned from.
+type requestMetrics struct {
+	duration     otelMetric.Int64Histogram
+	slow         otelMetric.Int64Counter
+	docFetches   otelMetric.Int64Counter
+	fieldFetches otelMetric.Int64Counter
+	indexFetches otelMetric.Int64Counter
+}
+
+func newRequestMetrics(meter *metric.Meter) (*requestMetrics, error) {
+	duration, err := meter.GetSyncHistogram(requestDurationMetric, "ms")
+	if err != nil {
+		return nil, err
+	}
+	slow, err := meter.GetSyncCounter(requestSlowMetric, "{request}")
+	if err != nil {
+		return nil, err
+	}
+	docFetches, err := meter.GetSyncCounter(requestDocFetchesMetric, "{document}")
+	if err != nil {
+		return nil, err
+	}
+	fieldFetches, err := meter.GetSyncCounter(requestFieldFetchesMetric, "{field}")
+	if err != nil {
+		return nil, err
+	}
+	indexFetches, err := meter.GetSyncCounter(requestIndexFetchesMetric, "{entry}")
+	if err != nil {
+		return nil, err
+	}
+	return &requestMetrics{
+		duration:     duration,
+		slow:         slow,
+		docFetches:   docFetches,
+		fieldFetches: fieldFetches,
+		indexFetches: indexFetches,
+	}, nil
+}
+
+// record records the execution of a request that took the given duration.
+func (m *requestMetrics) record(
+	ctx context.Context,
+	duration time.Duration,
+	scans []planner.ScanInfo,
+	slow bool,
+) {
+	for _, scan := range scans {
+		scanAttributes := otelMetric.WithAttributes(
+			attribute.String(collectionAttribute, scan.Collection),
+			attribute.String(indexAttribute, scan.Index),
+		)
+		m.docFetches.Add(ctx, int64(scan.DocsFetched), scanAttributes)
+		m.fieldFetches.Add(ctx, int64(scan.FieldsFetched), scanAttributes)
+		m.indexFetches.Add(ctx, int64(scan.IndexesFetched), scanAttributes)
+	}
+	m.duration.Record(ctx, duration.Milliseconds())
+	if slow {
+		m.slow.Add(ctx, 1)
+	}
+}
+
+// recordRequest records the execution of the given request, started at the given time, to the
+// request metrics and to the slow request log if it took longer than the slow request threshold.
+func (db *db) recordRequest(ctx context.Context, request string, start time.Time, p *planner.Planner) {
+	if db.metrics == nil && db.slowRequestThreshold <= 0 {
+		return
+	}
+	duration := time.Since(start)
+	slow := db.slowRequestThreshold > 0 && duration >= db.slowRequestThreshold
+	scans := p.ScanExecInfo()
+
+	if db.metrics != nil {
+		db.metrics.record(ctx, duration, scans, slow)
+	}
+	if !slow {
+		return
+	}
+	var did string
+	if ident := identity.FromContext(ctx); ident.HasValue() {
+		did = ident.Value().DID
+	}
+	slowRequestLog.InfoContext(
+		ctx,
+		"Slow request",
+		corelog.String("Request", request),
+		corelog.String("Identity", did),
+		corelog.Duration("Duration", duration),
+		corelog.Any("Scans", scans),
+	)
+}
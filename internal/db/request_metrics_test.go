@@ -0,0 +1,198 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/metric"
+)
+
+// createRequestMetricsTestDocs adds an indexed User collection with a couple of documents to the given db.
+func createRequestMetricsTestDocs(t *testing.T, ctx context.Context, db *db) {
+	_, err := db.AddSchema(ctx, userSchema)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	_, err = col.CreateIndex(ctx, client.IndexDescription{
+		Name:   "User_name",
+		Fields: []client.IndexedFieldDescription{{Name: "name"}},
+	})
+	require.NoError(t, err)
+
+	for _, doc := range []string{`{"name": "John", "age": 30}`, `{"name": "Islam", "age": 33}`} {
+		doc, err := client.NewDocFromJSON([]byte(doc), col.Definition())
+		require.NoError(t, err)
+		err = col.Create(ctx, doc)
+		require.NoError(t, err)
+	}
+}
+
+// getMetricSums returns the sums of the counter with the given name, by attribute set.
+func getMetricSums(t *testing.T, ctx context.Context, meter *metric.Meter, name string) map[attribute.Set]int64 {
+	data, err := meter.Dump(ctx)
+	require.NoError(t, err)
+
+	sums := map[attribute.Set]int64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, point := range sum.DataPoints {
+				sums[point.Attributes] = point.Value
+			}
+		}
+	}
+	return sums
+}
+
+func TestRequestMetrics_RecordsFetchesByCollectionAndIndex(t *testing.T) {
+	ctx := context.Background()
+	meter := metric.NewMeter()
+	meter.Register("defradb")
+	db, err := newMemoryDB(ctx, WithMeter(&meter))
+	require.NoError(t, err)
+	defer db.Close()
+	createRequestMetricsTestDocs(t, ctx, db)
+
+	result := db.ExecRequest(ctx, `query {
+		User(filter: {name: {_eq: "John"}}) {
+			name
+		}
+	}`)
+	require.Len(t, result.GQL.Errors, 0)
+
+	result = db.ExecRequest(ctx, `query {
+		User {
+			age
+		}
+	}`)
+	require.Len(t, result.GQL.Errors, 0)
+
+	assert.Equal(t, map[attribute.Set]int64{
+		attribute.NewSet(
+			attribute.String(collectionAttribute, "User"),
+			attribute.String(indexAttribute, "User_name"),
+		): 1,
+		attribute.NewSet(
+			attribute.String(collectionAttribute, "User"),
+			attribute.String(indexAttribute, ""),
+		): 2,
+	}, getMetricSums(t, ctx, &meter, requestDocFetchesMetric))
+
+	// Requests are not slow unless a threshold is set.
+	assert.Empty(t, getMetricSums(t, ctx, &meter, requestSlowMetric))
+}
+
+func TestRequestMetrics_WithSlowRequestThreshold_RecordsSlowRequests(t *testing.T) {
+	ctx := context.Background()
+	meter := metric.NewMeter()
+	meter.Register("defradb")
+	db, err := newMemoryDB(ctx, WithMeter(&meter), WithSlowRequestThreshold(time.Nanosecond))
+	require.NoError(t, err)
+	defer db.Close()
+	createRequestMetricsTestDocs(t, ctx, db)
+
+	result := db.ExecRequest(ctx, `query {
+		User {
+			name
+		}
+	}`)
+	require.Len(t, result.GQL.Errors, 0)
+
+	assert.Equal(t, map[attribute.Set]int64{
+		attribute.NewSet(): 1,
+	}, getMetricSums(t, ctx, &meter, requestSlowMetric))
+}
+
+func TestRequestMetrics_WithManyScans_RecordsDurationOncePerRequest(t *testing.T) {
+	ctx := context.Background()
+	meter := metric.NewMeter()
+	meter.Register("defradb")
+	db, err := newMemoryDB(ctx, WithMeter(&meter))
+	require.NoError(t, err)
+	defer db.Close()
+	createRequestMetricsTestDocs(t, ctx, db)
+
+	result := db.ExecRequest(ctx, `query {
+		johns: User(filter: {name: {_eq: "John"}}) {
+			name
+		}
+		all: User {
+			age
+		}
+	}`)
+	require.Len(t, result.GQL.Errors, 0)
+
+	data, err := meter.Dump(ctx)
+	require.NoError(t, err)
+
+	var counts []uint64
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != requestDurationMetric {
+				continue
+			}
+			histogram, ok := m.Data.(metricdata.Histogram[int64])
+			require.True(t, ok)
+			for _, point := range histogram.DataPoints {
+				counts = append(counts, point.Count)
+			}
+		}
+	}
+	assert.Equal(t, []uint64{1}, counts)
+}
+
+func TestScanExecInfo_WithIndex_HasIndexName(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	createRequestMetricsTestDocs(t, ctx, db)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	ctx = SetContextTxn(ctx, txn)
+
+	ast, errs := db.parser.PrepareRequest(`query {
+		User(filter: {name: {_eq: "Islam"}}) {
+			age
+		}
+	}`)
+	require.Len(t, errs, 0)
+
+	parsed, errs := db.parser.ParsePrepared(ast, &client.GQLOptions{})
+	require.Len(t, errs, 0)
+
+	p := db.newPlanner(ctx, txn)
+	_, err = p.RunRequest(ctx, parsed)
+	require.NoError(t, err)
+
+	scans := p.ScanExecInfo()
+	require.Len(t, scans, 1)
+	assert.Equal(t, "User", scans[0].Collection)
+	assert.Equal(t, "User_name", scans[0].Index)
+	assert.Equal(t, uint64(1), scans[0].DocsFetched)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore/memory"
+)
+
+func TestPrepareRequest_WithMaxPreparedRequests_ShouldEvictLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDB(ctx, memory.NewDatastore(ctx), acp.NoACP, nil, WithMaxPreparedRequests(2))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String, age: Int }`)
+	require.NoError(t, err)
+
+	nameID, err := db.PrepareRequest(ctx, `query { User { name } }`)
+	require.NoError(t, err)
+	ageID, err := db.PrepareRequest(ctx, `query { User { age } }`)
+	require.NoError(t, err)
+
+	// executing the first request makes the second one the least recently used
+	res := db.ExecPreparedRequest(ctx, nameID)
+	require.Empty(t, res.GQL.Errors)
+
+	_, err = db.PrepareRequest(ctx, `query { User { _docID } }`)
+	require.NoError(t, err)
+	require.Equal(t, 2, db.preparedRequests.Len())
+
+	res = db.ExecPreparedRequest(ctx, ageID)
+	require.Len(t, res.GQL.Errors, 1)
+	require.ErrorIs(t, res.GQL.Errors[0], client.ErrPreparedRequestNotFound)
+
+	res = db.ExecPreparedRequest(ctx, nameID)
+	require.Empty(t, res.GQL.Errors)
+}
+
+func TestExecPreparedRequest_ShouldReuseValidatedAST(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	id, err := db.PrepareRequest(ctx, `query { User { name } }`)
+	require.NoError(t, err)
+	prepared, ok := db.preparedRequests.Get(id)
+	require.True(t, ok)
+
+	res := db.ExecPreparedRequest(ctx, id)
+	require.Empty(t, res.GQL.Errors)
+	executed, ok := db.preparedRequests.Get(id)
+	require.True(t, ok)
+	require.Same(t, prepared.ast, executed.ast)
+
+	// the request is validated again once the schema changes
+	_, err = db.AddSchema(ctx, `type Book { title: String }`)
+	require.NoError(t, err)
+	res = db.ExecPreparedRequest(ctx, id)
+	require.Empty(t, res.GQL.Errors)
+	executed, ok = db.preparedRequests.Get(id)
+	require.True(t, ok)
+	require.NotSame(t, prepared.ast, executed.ast)
+	require.Equal(t, db.parser.SchemaVersion(), executed.schemaVersion)
+}
@@ -0,0 +1,750 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/db/base"
+	"github.com/sourcenetwork/defradb/internal/hll"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+const (
+	// statsFlushThreshold is the number of changes to the index statistics after which
+	// they are written to the systemstore.
+	statsFlushThreshold = 100
+	// statsFoldThreshold is the number of stored changes to the document counts after which
+	// they are folded into the stored counts.
+	statsFoldThreshold = 100
+	// staleSketchDivisor bounds the number of values removed from an index since its sketch
+	// was built, as a fraction of the estimate of the sketch, past which the sketch is rebuilt.
+	staleSketchDivisor = 2
+)
+
+// statistics maintains the number of documents of each collection and the estimated number
+// of distinct values of each index.
+//
+// The changes to the document counts are written by the transactions making them, under keys
+// unique to each change so that concurrent transactions never conflict on them, and are folded
+// into the stored counts in the background. The sketches of the values of the indexes are kept
+// in memory and written to the systemstore every [statsFlushThreshold] changes and when the
+// database is closed.
+//
+// Values removed from an index cannot be removed from its sketch, so the sketch is rebuilt
+// once too many values have been removed since it was built.
+//
+// Statistics that are not known, for example because the database was created before they
+// were maintained, are rebuilt in the background the first time they are read.
+type statistics struct {
+	mu sync.Mutex
+
+	// ctx is the context of the database, it is canceled when the database is closed.
+	ctx   context.Context
+	store datastore.DSReaderWriter
+
+	// docCounts contains the number of documents of each collection by collection root ID.
+	docCounts map[uint32]int64
+	// sketches contains the sketch of the values of each index.
+	sketches map[keys.IndexStatsKey]*indexSketch
+
+	dirtySketches map[keys.IndexStatsKey]struct{}
+	// changes is the number of changes to the sketches since they were last written.
+	changes int
+
+	// lastDeltaID is the ID of the last change written to the document counts.
+	lastDeltaID atomic.Uint64
+	// deltas is the number of changes to the document counts since they were last folded.
+	deltas int
+
+	// rebuilds contains the rebuilds running in the background, by name.
+	rebuilds map[string]struct{}
+	wg       sync.WaitGroup
+}
+
+// indexSketch is the sketch of the values of an index.
+type indexSketch struct {
+	sketch *hll.Sketch
+	// removals is the number of values removed from the index since the sketch was built.
+	removals uint64
+}
+
+// isStale returns true if too many values were removed from the index for the sketch to
+// be a good estimate.
+func (s *indexSketch) isStale() bool {
+	return s.removals > 0 && s.removals >= s.sketch.Estimate()/staleSketchDivisor
+}
+
+// loadStatistics returns the statistics stored in the given systemstore.
+func loadStatistics(ctx context.Context, store datastore.DSReaderWriter) (*statistics, error) {
+	s := &statistics{
+		ctx:           ctx,
+		store:         store,
+		docCounts:     make(map[uint32]int64),
+		sketches:      make(map[keys.IndexStatsKey]*indexSketch),
+		dirtySketches: make(map[keys.IndexStatsKey]struct{}),
+		rebuilds:      make(map[string]struct{}),
+	}
+
+	docCounts, lastDeltaID, err := readDocumentCounts(ctx, store, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.docCounts = docCounts
+	s.lastDeltaID.Store(lastDeltaID)
+
+	sketchResults, err := store.Query(ctx, query.Query{Prefix: keys.NewIndexStatsKey(0, 0).ToString()})
+	if err != nil {
+		return nil, err
+	}
+	for res := range sketchResults.Next() {
+		if res.Error != nil {
+			_ = sketchResults.Close()
+			return nil, res.Error
+		}
+		key, err := keys.NewIndexStatsKeyFromString(res.Key)
+		if err != nil {
+			_ = sketchResults.Close()
+			return nil, err
+		}
+		sketch := &hll.Sketch{}
+		if err := sketch.UnmarshalBinary(res.Value); err != nil {
+			_ = sketchResults.Close()
+			return nil, NewErrInvalidStoredStatistics(res.Key)
+		}
+		s.sketches[key] = &indexSketch{sketch: sketch}
+	}
+	return s, sketchResults.Close()
+}
+
+// readDocumentCounts returns the stored document counts, with their stored changes applied,
+// of the collection with the given root ID, or of all collections if it is zero.
+//
+// Only the counts that were stored are returned, the changes to the others are ignored.
+// The highest ID of the stored changes is also returned.
+func readDocumentCounts(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	collectionRootID uint32,
+) (map[uint32]int64, uint64, error) {
+	counts := make(map[uint32]int64)
+	if collectionRootID != 0 {
+		// the count of a single collection is not matched by a prefix query, as it has no child keys
+		key := keys.NewCollectionStatsKey(collectionRootID)
+		value, err := store.Get(ctx, key.ToDS())
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return nil, 0, err
+		}
+		if err == nil {
+			count, n := binary.Varint(value)
+			if n <= 0 {
+				return nil, 0, NewErrInvalidStoredStatistics(key.ToString())
+			}
+			counts[collectionRootID] = count
+		}
+	} else {
+		countResults, err := store.Query(ctx, query.Query{Prefix: keys.NewCollectionStatsKey(0).ToString()})
+		if err != nil {
+			return nil, 0, err
+		}
+		for res := range countResults.Next() {
+			if res.Error != nil {
+				_ = countResults.Close()
+				return nil, 0, res.Error
+			}
+			key, err := keys.NewCollectionStatsKeyFromString(res.Key)
+			if err != nil {
+				_ = countResults.Close()
+				return nil, 0, err
+			}
+			count, n := binary.Varint(res.Value)
+			if n <= 0 {
+				_ = countResults.Close()
+				return nil, 0, NewErrInvalidStoredStatistics(res.Key)
+			}
+			counts[key.CollectionRootID] = count
+		}
+		if err := countResults.Close(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var lastDeltaID uint64
+	err := iterateDocumentCountDeltas(ctx, store, collectionRootID, func(key keys.CollectionStatsDeltaKey, delta int64) {
+		lastDeltaID = max(lastDeltaID, key.DeltaID)
+		if count, ok := counts[key.CollectionRootID]; ok {
+			counts[key.CollectionRootID] = count + delta
+		}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	for rootID, count := range counts {
+		counts[rootID] = max(count, 0)
+	}
+	return counts, lastDeltaID, nil
+}
+
+// iterateDocumentCountDeltas calls the given function with each stored change to the document
+// count of the collection with the given root ID, or of all collections if it is zero.
+func iterateDocumentCountDeltas(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	collectionRootID uint32,
+	fn func(key keys.CollectionStatsDeltaKey, delta int64),
+) error {
+	results, err := store.Query(ctx, query.Query{
+		Prefix: keys.NewCollectionStatsDeltaKey(collectionRootID, 0).ToString(),
+	})
+	if err != nil {
+		return err
+	}
+	for res := range results.Next() {
+		if res.Error != nil {
+			_ = results.Close()
+			return res.Error
+		}
+		key, err := keys.NewCollectionStatsDeltaKeyFromString(res.Key)
+		if err != nil {
+			_ = results.Close()
+			return err
+		}
+		delta, n := binary.Varint(res.Value)
+		if n <= 0 {
+			_ = results.Close()
+			return NewErrInvalidStoredStatistics(res.Key)
+		}
+		fn(key, delta)
+	}
+	return results.Close()
+}
+
+// initDocumentCount stores a zero document count for the new collection with the given root ID
+// within the given transaction.
+func (s *statistics) initDocumentCount(ctx context.Context, txn datastore.Txn, collectionRootID uint32) error {
+	err := txn.Systemstore().Put(ctx, keys.NewCollectionStatsKey(collectionRootID).ToDS(), binary.AppendVarint(nil, 0))
+	if err != nil {
+		return err
+	}
+	txn.OnSuccess(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.docCounts[collectionRootID] = 0
+	})
+	return nil
+}
+
+// addDocuments stores the change of the given number, which may be negative, of documents of
+// the collection with the given root ID within the given transaction.
+//
+// The change is applied to the document count once the transaction succeeds.
+func (db *db) addDocuments(ctx context.Context, txn datastore.Txn, collectionRootID uint32, delta int64) error {
+	s := db.stats
+	key := keys.NewCollectionStatsDeltaKey(collectionRootID, s.lastDeltaID.Add(1))
+	err := txn.Systemstore().Put(ctx, key.ToDS(), binary.AppendVarint(nil, delta))
+	if err != nil {
+		return err
+	}
+	txn.OnSuccess(func() {
+		s.mu.Lock()
+		if count, ok := s.docCounts[collectionRootID]; ok {
+			s.docCounts[collectionRootID] = max(count+delta, 0)
+		}
+		s.deltas++
+		fold := s.deltas >= statsFoldThreshold
+		if fold {
+			s.deltas = 0
+		}
+		s.mu.Unlock()
+
+		if fold {
+			db.rebuildStatsInBackground("fold", db.foldDocumentCounts)
+		}
+	})
+	return nil
+}
+
+// foldDocumentCounts applies the stored changes to the stored document counts, and removes them.
+//
+// The changes to the counts that are not stored are kept until the counts are rebuilt.
+func (db *db) foldDocumentCounts(ctx context.Context) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	counts, _, err := readDocumentCounts(ctx, txn.Systemstore(), 0)
+	if err != nil {
+		return err
+	}
+	var deltaKeys []keys.CollectionStatsDeltaKey
+	err = iterateDocumentCountDeltas(ctx, txn.Systemstore(), 0, func(key keys.CollectionStatsDeltaKey, _ int64) {
+		if _, ok := counts[key.CollectionRootID]; ok {
+			deltaKeys = append(deltaKeys, key)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if len(deltaKeys) == 0 {
+		return nil
+	}
+	for rootID, count := range counts {
+		err := txn.Systemstore().Put(ctx, keys.NewCollectionStatsKey(rootID).ToDS(), binary.AppendVarint(nil, count))
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range deltaKeys {
+		if err := txn.Systemstore().Delete(ctx, key.ToDS()); err != nil {
+			return err
+		}
+	}
+	return txn.Commit(ctx)
+}
+
+// rebuildDocumentCount counts the stored documents of the collection with the given root ID,
+// and stores the count in place of its stored changes.
+func (db *db) rebuildDocumentCount(ctx context.Context, collectionRootID uint32) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	count, err := countStoredDocuments(ctx, txn, collectionRootID)
+	if err != nil {
+		return err
+	}
+	// The changes made by the transactions committed after this one started are not counted,
+	// so only the ones it can see are replaced by the count.
+	var deltaKeys []keys.CollectionStatsDeltaKey
+	err = iterateDocumentCountDeltas(ctx, txn.Systemstore(), collectionRootID,
+		func(key keys.CollectionStatsDeltaKey, _ int64) {
+			deltaKeys = append(deltaKeys, key)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, key := range deltaKeys {
+		if err := txn.Systemstore().Delete(ctx, key.ToDS()); err != nil {
+			return err
+		}
+	}
+	value := binary.AppendVarint(nil, int64(count))
+	if err := txn.Systemstore().Put(ctx, keys.NewCollectionStatsKey(collectionRootID).ToDS(), value); err != nil {
+		return err
+	}
+	if err := txn.Commit(ctx); err != nil {
+		return err
+	}
+
+	// The changes committed in the meantime were not applied to the unknown count,
+	// so the count is read back along with them.
+	counts, _, err := readDocumentCounts(ctx, db.multistore.Systemstore(), collectionRootID)
+	if err != nil {
+		return err
+	}
+	if count, ok := counts[collectionRootID]; ok {
+		db.stats.setDocumentCount(collectionRootID, count)
+	}
+	return nil
+}
+
+// countStoredDocuments returns the number of documents of the collection with the given root ID
+// that are not deleted.
+func countStoredDocuments(ctx context.Context, txn datastore.Txn, collectionRootID uint32) (uint64, error) {
+	prefix := keys.PrimaryDataStoreKey{CollectionRootID: collectionRootID}
+	results, err := txn.Datastore().Query(ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for res := range results.Next() {
+		if res.Error != nil {
+			_ = results.Close()
+			return 0, res.Error
+		}
+		if !bytes.Equal(res.Value, []byte{base.DeletedObjectMarker}) {
+			count++
+		}
+	}
+	return count, results.Close()
+}
+
+// addIndexValue adds the given encoded value to the sketch of the index with the given key if it is known.
+func (s *statistics) addIndexValue(key keys.IndexStatsKey, value []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sketch, ok := s.sketches[key]
+	if !ok {
+		return
+	}
+	sketch.sketch.Insert(value)
+	s.dirtySketches[key] = struct{}{}
+	s.changed()
+}
+
+// removeIndexValue records the removal of a value from the index with the given key.
+//
+// It returns true if the sketch of the index became stale.
+func (s *statistics) removeIndexValue(key keys.IndexStatsKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sketch, ok := s.sketches[key]
+	if !ok {
+		return false
+	}
+	wasStale := sketch.isStale()
+	sketch.removals++
+	return !wasStale && sketch.isStale()
+}
+
+// initIndex sets an empty sketch for the new index with the given key.
+//
+// It must be called before any value is added to the index.
+func (s *statistics) initIndex(key keys.IndexStatsKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sketches[key] = &indexSketch{sketch: hll.New()}
+	s.dirtySketches[key] = struct{}{}
+	s.changed()
+}
+
+// removeIndex forgets the sketch of the index with the given key.
+func (s *statistics) removeIndex(key keys.IndexStatsKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sketches, key)
+	delete(s.dirtySketches, key)
+}
+
+// changed records a change to the sketches and writes them once there are enough changes.
+//
+// The caller must hold the lock.
+func (s *statistics) changed() {
+	s.changes++
+	if s.changes < statsFlushThreshold {
+		return
+	}
+	if err := s.flushLocked(context.Background()); err != nil {
+		log.ErrorE("Failed to store statistics", err)
+	}
+}
+
+// flush writes the changed sketches to the systemstore.
+func (s *statistics) flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flushLocked(ctx)
+}
+
+func (s *statistics) flushLocked(ctx context.Context) error {
+	for key := range s.dirtySketches {
+		value, err := s.sketches[key].sketch.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := s.store.Put(ctx, key.ToDS(), value); err != nil {
+			return err
+		}
+		delete(s.dirtySketches, key)
+	}
+	s.changes = 0
+	return nil
+}
+
+// getDocumentCount returns the document count of the collection with the given root ID
+// if it is known.
+func (s *statistics) getDocumentCount(collectionRootID uint32) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, ok := s.docCounts[collectionRootID]
+	return uint64(count), ok
+}
+
+// setDocumentCount sets the document count of the collection with the given root ID.
+func (s *statistics) setDocumentCount(collectionRootID uint32, count int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.docCounts[collectionRootID] = count
+}
+
+// getDistinctKeys returns the estimated number of distinct values of the index with the
+// given key if it is known, and whether its sketch is stale.
+func (s *statistics) getDistinctKeys(key keys.IndexStatsKey) (distinct uint64, isStale bool, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sketch, ok := s.sketches[key]
+	if !ok {
+		return 0, false, false
+	}
+	return sketch.sketch.Estimate(), sketch.isStale(), true
+}
+
+// setSketch sets the sketch of the index with the given key, unless the index was dropped
+// in the meantime.
+func (s *statistics) setSketch(key keys.IndexStatsKey, sketch *hll.Sketch, isNew bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sketches[key]; !ok && !isNew {
+		return
+	}
+	s.sketches[key] = &indexSketch{sketch: sketch}
+	s.dirtySketches[key] = struct{}{}
+	s.changed()
+}
+
+// rebuildStatsInBackground runs the given rebuild of the statistics with the given name in the
+// background, unless it is already running.
+func (db *db) rebuildStatsInBackground(name string, rebuild func(context.Context) error) {
+	s := db.stats
+	s.mu.Lock()
+	if _, ok := s.rebuilds[name]; ok || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.rebuilds[name] = struct{}{}
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer s.wg.Done()
+		err := rebuild(s.ctx)
+		if err != nil && s.ctx.Err() == nil {
+			log.ErrorE("Failed to rebuild statistics", err)
+		}
+		s.mu.Lock()
+		delete(s.rebuilds, name)
+		s.mu.Unlock()
+	}()
+}
+
+// close waits for the rebuilds running in the background, and writes the changed sketches.
+//
+// The context of the database must be canceled beforehand.
+func (s *statistics) close(ctx context.Context) error {
+	s.wg.Wait()
+	return s.flush(ctx)
+}
+
+// indexStatsValue returns the encoded value of the given index key that is counted in the
+// sketch of the index, which excludes the document ID stored in some index keys.
+func indexStatsValue(key *keys.IndexDataStoreKey, fieldCount int) []byte {
+	valueKey := *key
+	if len(valueKey.Fields) > fieldCount {
+		valueKey.Fields = valueKey.Fields[:fieldCount]
+	}
+	return valueKey.Bytes()
+}
+
+// buildIndexSketch builds the sketch of the given index from its stored entries.
+func buildIndexSketch(
+	ctx context.Context,
+	txn datastore.Txn,
+	col *collection,
+	desc client.IndexDescription,
+) (*hll.Sketch, error) {
+	fields := make([]client.FieldDefinition, len(desc.Fields))
+	for i := range desc.Fields {
+		field, ok := col.Definition().GetFieldByName(desc.Fields[i].Name)
+		if !ok {
+			return nil, client.NewErrFieldNotExist(desc.Fields[i].Name)
+		}
+		fields[i] = field
+	}
+
+	prefix := keys.NewIndexDataStoreKey(col.ID(), desc.ID, nil)
+	results, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sketch := hll.New()
+	for res := range results.Next() {
+		if res.Error != nil {
+			_ = results.Close()
+			return nil, res.Error
+		}
+		key, err := keys.DecodeIndexDataStoreKey([]byte(res.Key), &desc, fields)
+		if err != nil {
+			_ = results.Close()
+			return nil, err
+		}
+		sketch.Insert(indexStatsValue(&key, len(desc.Fields)))
+	}
+	return sketch, results.Close()
+}
+
+// rebuildIndexSketch builds the sketch of the given index from its stored entries, in place
+// of its current sketch.
+func (db *db) rebuildIndexSketch(ctx context.Context, col *collection, desc client.IndexDescription) error {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	sketch, err := buildIndexSketch(ctx, txn, col, desc)
+	if err != nil {
+		return err
+	}
+	db.stats.setSketch(keys.NewIndexStatsKey(col.ID(), desc.ID), sketch, false)
+	return nil
+}
+
+// rebuildIndexSketchInBackground rebuilds the sketch of the given index in the background.
+func (db *db) rebuildIndexSketchInBackground(col *collection, desc client.IndexDescription) {
+	name := fmt.Sprintf("index/%d/%d", col.ID(), desc.ID)
+	db.rebuildStatsInBackground(name, func(ctx context.Context) error {
+		return db.rebuildIndexSketch(ctx, col, desc)
+	})
+}
+
+// getPlanningStatistics returns the statistics of the active collection with the given name
+// that are known, to plan requests with.
+//
+// The statistics that are not known, or are stale, are rebuilt in the background so that
+// planning never has to read the stored documents or index entries.
+func (db *db) getPlanningStatistics(
+	ctx context.Context,
+	name string,
+) (immutable.Option[client.CollectionStatistics], error) {
+	col, err := db.getCollectionByName(ctx, name)
+	if err != nil {
+		return immutable.None[client.CollectionStatistics](), err
+	}
+	// collections returned by the db are always of the internal type
+	internalCol := col.(*collection)
+
+	rootID := col.Description().RootID
+	docCount, ok := db.stats.getDocumentCount(rootID)
+	if !ok {
+		db.rebuildStatsInBackground(fmt.Sprintf("collection/%d", rootID), func(ctx context.Context) error {
+			return db.rebuildDocumentCount(ctx, rootID)
+		})
+		return immutable.None[client.CollectionStatistics](), nil
+	}
+
+	result := client.CollectionStatistics{
+		CollectionName: name,
+		DocumentCount:  docCount,
+		Indexes:        []client.IndexStatistics{},
+	}
+	for _, index := range col.Description().Indexes {
+		distinct, isStale, ok := db.stats.getDistinctKeys(keys.NewIndexStatsKey(col.ID(), index.ID))
+		if !ok || isStale {
+			db.rebuildIndexSketchInBackground(internalCol, index)
+		}
+		if !ok {
+			continue
+		}
+		result.Indexes = append(result.Indexes, client.IndexStatistics{
+			IndexName:    index.Name,
+			DistinctKeys: distinct,
+		})
+	}
+	return immutable.Some(result), nil
+}
+
+// getCollectionStatistics returns the statistics of the active collection with the given name.
+//
+// The statistics that are not known, or are stale, are rebuilt before being returned.
+//
+// The documents of collections with a policy are counted by reading the documents the identity
+// may read, and the statistics of their indexes are not returned as they would reveal the
+// values of documents the identity may not read.
+func (db *db) getCollectionStatistics(ctx context.Context, name string) (client.CollectionStatistics, error) {
+	col, err := db.getCollectionByName(ctx, name)
+	if err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	// collections returned by the db are always of the internal type
+	internalCol := col.(*collection)
+
+	result := client.CollectionStatistics{
+		CollectionName: name,
+		Indexes:        []client.IndexStatistics{},
+	}
+	if col.Description().Policy.HasValue() {
+		result.DocumentCount, err = db.countReadableDocuments(ctx, name)
+		return result, err
+	}
+
+	rootID := col.Description().RootID
+	docCount, ok := db.stats.getDocumentCount(rootID)
+	if !ok {
+		if err := db.rebuildDocumentCount(ctx, rootID); err != nil {
+			return client.CollectionStatistics{}, err
+		}
+		docCount, _ = db.stats.getDocumentCount(rootID)
+	}
+	result.DocumentCount = docCount
+
+	for _, index := range col.Description().Indexes {
+		statsKey := keys.NewIndexStatsKey(col.ID(), index.ID)
+		distinct, isStale, ok := db.stats.getDistinctKeys(statsKey)
+		if !ok || isStale {
+			sketch, err := buildIndexSketch(ctx, mustGetContextTxn(ctx), internalCol, index)
+			if err != nil {
+				return client.CollectionStatistics{}, err
+			}
+			db.stats.setSketch(statsKey, sketch, !ok)
+			distinct = sketch.Estimate()
+		}
+		result.Indexes = append(result.Indexes, client.IndexStatistics{
+			IndexName:    index.Name,
+			DistinctKeys: distinct,
+		})
+	}
+	return result, nil
+}
+
+// countReadableDocuments returns the number of documents of the collection with the given name
+// that the identity of the context may read.
+func (db *db) countReadableDocuments(ctx context.Context, name string) (uint64, error) {
+	res := db.execRequest(ctx, fmt.Sprintf(`query { _count(%s: {}) }`, name), &client.GQLOptions{})
+	if len(res.GQL.Errors) > 0 {
+		return 0, res.GQL.Errors[0]
+	}
+	data, ok := res.GQL.Data.(map[string]any)
+	if !ok {
+		return 0, nil
+	}
+	count, _ := data[request.CountFieldName].(int)
+	return uint64(count), nil
+}
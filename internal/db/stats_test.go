@@ -0,0 +1,99 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/internal/keys"
+)
+
+func TestDocumentCount_WithCommittedAndDiscardedWrites_ShouldOnlyStoreCommittedChanges(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	rootID := col.Description().RootID
+
+	for _, name := range []string{"John", "Islam"} {
+		doc, err := client.NewDocFromJSON([]byte(`{"name": "`+name+`"}`), col.Definition())
+		require.NoError(t, err)
+		require.NoError(t, col.Create(ctx, doc))
+	}
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred"}`), col.Definition())
+	require.NoError(t, err)
+	require.NoError(t, col.Create(SetContextTxn(ctx, txn), doc))
+	txn.Discard(ctx)
+
+	count, ok := db.stats.getDocumentCount(rootID)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), count)
+
+	// the changes are stored by the transactions making them, so they are read back
+	// without the statistics having been written
+	stored, err := loadStatistics(ctx, db.multistore.Systemstore())
+	require.NoError(t, err)
+	count, ok = stored.getDocumentCount(rootID)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), count)
+}
+
+func TestPlanningStatistics_WithUnknownDocumentCount_ShouldRebuildInBackground(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDefraMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	rootID := col.Description().RootID
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John"}`), col.Definition())
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+
+	// forget the count, as if the collection was created before counts were maintained
+	err = db.multistore.Systemstore().Delete(ctx, keys.NewCollectionStatsKey(rootID).ToDS())
+	require.NoError(t, err)
+	db.stats.mu.Lock()
+	delete(db.stats.docCounts, rootID)
+	db.stats.mu.Unlock()
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	txnCtx := SetContextTxn(ctx, txn)
+
+	stats, err := db.getPlanningStatistics(txnCtx, "User")
+	require.NoError(t, err)
+	require.False(t, stats.HasValue())
+
+	db.stats.wg.Wait()
+
+	stats, err = db.getPlanningStatistics(txnCtx, "User")
+	require.NoError(t, err)
+	require.True(t, stats.HasValue())
+	require.Equal(t, uint64(1), stats.Value().DocumentCount)
+}
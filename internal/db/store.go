@@ -12,6 +12,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/lens-vm/lens/host-go/config/model"
 
@@ -22,6 +23,18 @@ import (
 
 // ExecRequest executes a request against the database.
 func (db *db) ExecRequest(ctx context.Context, request string, opts ...client.RequestOption) *client.RequestResult {
+	return db.execInTxn(ctx, opts, func(ctx context.Context, options *client.GQLOptions) *client.RequestResult {
+		return db.execRequest(ctx, request, options)
+	})
+}
+
+// execInTxn executes a request with the given options within the transaction of the context,
+// or within a new transaction committed once the request succeeded.
+func (db *db) execInTxn(
+	ctx context.Context,
+	opts []client.RequestOption,
+	exec func(context.Context, *client.GQLOptions) *client.RequestResult,
+) *client.RequestResult {
 	ctx, txn, err := ensureContextTxn(ctx, db, false)
 	if err != nil {
 		res := &client.RequestResult{}
@@ -35,7 +48,7 @@ func (db *db) ExecRequest(ctx context.Context, request string, opts ...client.Re
 		o(options)
 	}
 
-	res := db.execRequest(ctx, request, options)
+	res := exec(ctx, options)
 	if len(res.GQL.Errors) > 0 {
 		return res
 	}
@@ -48,6 +61,29 @@ func (db *db) ExecRequest(ctx context.Context, request string, opts ...client.Re
 	return res
 }
 
+// PrepareRequest parses and validates a request, returning the ID of the prepared request.
+func (db *db) PrepareRequest(ctx context.Context, request string) (string, error) {
+	return db.prepareRequest(request)
+}
+
+// ExecPreparedRequest executes a prepared request against the database.
+func (db *db) ExecPreparedRequest(
+	ctx context.Context,
+	id string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	return db.execInTxn(ctx, opts, func(ctx context.Context, options *client.GQLOptions) *client.RequestResult {
+		start := time.Now()
+		prepared, err := db.getPreparedRequest(id)
+		if err != nil {
+			res := &client.RequestResult{}
+			res.GQL.Errors = append(res.GQL.Errors, err)
+			return res
+		}
+		return db.execAST(ctx, prepared.request, prepared.ast, start, options)
+	})
+}
+
 // GetCollectionByName returns an existing collection within the database.
 func (db *db) GetCollectionByName(ctx context.Context, name string) (client.Collection, error) {
 	ctx, txn, err := ensureContextTxn(ctx, db, true)
@@ -115,6 +151,20 @@ func (db *db) GetAllIndexes(
 	return db.getAllIndexDescriptions(ctx)
 }
 
+// GetCollectionStatistics returns the estimated statistics of the active collection with the given name.
+func (db *db) GetCollectionStatistics(
+	ctx context.Context,
+	collectionName string,
+) (client.CollectionStatistics, error) {
+	ctx, txn, err := ensureContextTxn(ctx, db, true)
+	if err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	defer txn.Discard(ctx)
+
+	return db.getCollectionStatistics(ctx, collectionName)
+}
+
 // AddSchema takes the provided GQL schema in SDL format, and applies it to the database,
 // creating the necessary collections, request types, etc.
 //
@@ -262,24 +312,16 @@ func (db *db) RefreshViews(ctx context.Context, opts client.CollectionFetchOptio
 	return nil
 }
 
-// BasicImport imports a json dataset.
+// BasicImport imports a dataset exported by BasicExport.
 // filepath must be accessible to the node.
+//
+// Documents are imported in batches, each committed in its own transaction
+// unless an explicit transaction is used.
 func (db *db) BasicImport(ctx context.Context, filepath string) error {
-	ctx, txn, err := ensureContextTxn(ctx, db, false)
-	if err != nil {
-		return err
-	}
-	defer txn.Discard(ctx)
-
-	err = db.basicImport(ctx, filepath)
-	if err != nil {
-		return err
-	}
-
-	return txn.Commit(ctx)
+	return db.basicImport(ctx, filepath)
 }
 
-// BasicExport exports the current data or subset of data to file in json format.
+// BasicExport exports the current data or subset of data to file in the configured format.
 func (db *db) BasicExport(ctx context.Context, config *client.BackupConfig) error {
 	ctx, txn, err := ensureContextTxn(ctx, db, true)
 	if err != nil {
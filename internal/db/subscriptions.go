@@ -13,16 +13,18 @@ package db
 import (
 	"context"
 
-	"github.com/sourcenetwork/defradb/acp/identity"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/event"
-	"github.com/sourcenetwork/defradb/internal/planner"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 )
 
 // handleSubscription checks for a subscription within the given request and
 // starts a new go routine that will return all subscription results on the returned
 // channel. If a subscription does not exist on the given request nil will be returned.
+//
+// Changes to documents that cannot pass the subscription filter are discarded before
+// any transaction is created.
 func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-chan client.GQLResult, error) {
 	if len(r.Subscription) == 0 || len(r.Subscription[0].Selections) == 0 {
 		return nil, nil // This is not a subscription request and we have nothing to do here
@@ -32,7 +34,7 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 	if !ok {
 		return nil, client.NewErrUnexpectedType[request.ObjectSubscription]("SubscriptionSelection", selections)
 	}
-	sub, err := db.events.Subscribe(event.UpdateName)
+	sub, err := db.events.Subscribe(event.DocChangeName)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +47,7 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 
 		// listen for events and send to the result channel
 		for {
-			var evt event.Update
+			var evt event.DocChange
 			select {
 			case <-ctx.Done():
 				return // context cancelled
@@ -53,12 +55,16 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 				if !ok {
 					return // channel closed
 				}
-				evt, ok = val.Data.(event.Update)
+				evt, ok = val.Data.(event.DocChange)
 				if !ok {
 					continue // invalid event value
 				}
 			}
 
+			if !subscriptionMayMatch(ctx, subRequest, evt) {
+				continue
+			}
+
 			txn, err := db.NewTxn(ctx, false)
 			if err != nil {
 				log.ErrorContext(ctx, err.Error())
@@ -67,7 +73,7 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 
 			ctx := SetContextTxn(ctx, txn)
 
-			p := planner.New(ctx, identity.FromContext(ctx), db.acp, db, txn)
+			p := db.newPlanner(ctx, txn)
 			s := subRequest.ToSelect(evt.DocID, evt.Cid.String())
 
 			result, err := p.RunSelection(ctx, s)
@@ -78,6 +84,8 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 			res := client.GQLResult{}
 			if err != nil {
 				res.Errors = append(res.Errors, err)
+			} else {
+				setChangeFields(subRequest, evt, result)
 			}
 			res.Data = result
 
@@ -93,3 +101,110 @@ func (db *db) handleSubscription(ctx context.Context, r *request.Request) (<-cha
 
 	return resCh, nil
 }
+
+// subscriptionMayMatch returns false if the given document change cannot yield a result
+// for the given subscription.
+//
+// The subscription filter is evaluated against the values held by the change, if the filter
+// targets anything that the change does not hold it is left to the selection.
+func subscriptionMayMatch(ctx context.Context, subRequest *request.ObjectSubscription, evt event.DocChange) bool {
+	if evt.CollectionName != subRequest.Collection {
+		return false
+	}
+	if evt.Kind == event.DocDeleted && !subRequest.ShowDeleted {
+		return false
+	}
+	if !subRequest.Filter.HasValue() {
+		return true
+	}
+
+	passes, ok, err := mapper.RunFilterOnValues(subRequest.Filter.Value(), evt.Values)
+	if err != nil {
+		// let the selection surface the error to the subscriber
+		log.ErrorContext(ctx, err.Error())
+		return true
+	}
+	return !ok || passes
+}
+
+// setChangeFields sets the requested change kind and changed values of the given change
+// on the given subscription result.
+func setChangeFields(subRequest *request.ObjectSubscription, evt event.DocChange, result map[string]any) {
+	var kindKeys, changesKeys []string
+	for _, field := range subRequest.Fields {
+		f, ok := field.(*request.Field)
+		if !ok {
+			continue
+		}
+		switch f.Name {
+		case request.KindFieldName:
+			kindKeys = append(kindKeys, getResultKey(f))
+		case request.ChangesFieldName:
+			changesKeys = append(changesKeys, getResultKey(f))
+		}
+	}
+	if len(kindKeys) == 0 && len(changesKeys) == 0 {
+		return
+	}
+
+	changes := getDocChanges(evt)
+	for _, value := range result {
+		docs, ok := value.([]map[string]any)
+		if !ok {
+			continue
+		}
+		for _, doc := range docs {
+			for _, key := range kindKeys {
+				doc[key] = string(evt.Kind)
+			}
+			for _, key := range changesKeys {
+				doc[key] = changes
+			}
+		}
+	}
+}
+
+// getDocChanges returns the previous and current values of the fields changed by the
+// given document change, keyed by field name.
+func getDocChanges(evt event.DocChange) map[string]any {
+	changes := map[string]any{}
+	switch evt.Kind {
+	case event.DocCreated:
+		for name, value := range evt.Values {
+			if name == request.DocIDFieldName {
+				continue
+			}
+			changes[name] = map[string]any{
+				request.PreviousValueName: nil,
+				request.CurrentValueName:  value,
+			}
+		}
+
+	case event.DocUpdated:
+		for name, previous := range evt.Previous {
+			changes[name] = map[string]any{
+				request.PreviousValueName: previous,
+				request.CurrentValueName:  evt.Values[name],
+			}
+		}
+
+	case event.DocDeleted:
+		for name, value := range evt.Values {
+			if name == request.DocIDFieldName {
+				continue
+			}
+			changes[name] = map[string]any{
+				request.PreviousValueName: value,
+				request.CurrentValueName:  nil,
+			}
+		}
+	}
+	return changes
+}
+
+func getResultKey(field *request.Field) string {
+	if field.Alias.HasValue() {
+		return field.Alias.Value()
+	}
+	return field.Name
+}
@@ -20,13 +20,11 @@ import (
 	"github.com/lens-vm/lens/host-go/config/model"
 	"github.com/sourcenetwork/immutable"
 
-	"github.com/sourcenetwork/defradb/acp/identity"
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/internal/core"
 	"github.com/sourcenetwork/defradb/internal/db/description"
 	"github.com/sourcenetwork/defradb/internal/keys"
-	"github.com/sourcenetwork/defradb/internal/planner"
 )
 
 func (db *db) addView(
@@ -147,7 +145,7 @@ func (db *db) getViews(ctx context.Context, opts client.CollectionFetchOptions)
 func (db *db) buildViewCache(ctx context.Context, col client.CollectionDefinition) (err error) {
 	txn := mustGetContextTxn(ctx)
 
-	p := planner.New(ctx, identity.FromContext(ctx), db.acp, db, txn)
+	p := db.newPlanner(ctx, txn)
 
 	// temporarily disable the cache in order to query without using it
 	col.Description.IsMaterialized = false
@@ -0,0 +1,23 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hll
+
+import (
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errInvalidSketchEncoding string = "invalid sketch encoding"
+)
+
+var (
+	ErrInvalidSketchEncoding = errors.New(errInvalidSketchEncoding)
+)
@@ -0,0 +1,106 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+/*
+Package hll provides a HyperLogLog sketch used to estimate the number of distinct
+values in a multiset using a small, fixed amount of memory.
+*/
+package hll
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// precision is the number of bits of the hash used to select a register.
+	//
+	// With 2^12 registers the standard error of the estimate is about 1.6%.
+	precision = 12
+	// registerCount is the number of registers of a sketch.
+	registerCount = 1 << precision
+	// encodingVersion is the version of the binary encoding of a sketch.
+	encodingVersion byte = 1
+)
+
+// Sketch estimates the number of distinct values inserted into it.
+//
+// Values can't be removed from a sketch, so the estimate never decreases.
+type Sketch struct {
+	registers []uint8
+}
+
+// New returns a new empty sketch.
+func New() *Sketch {
+	return &Sketch{registers: make([]uint8, registerCount)}
+}
+
+// Insert adds the given value to the sketch.
+func (s *Sketch) Insert(value []byte) {
+	s.InsertHash(xxhash.Sum64(value))
+}
+
+// InsertHash adds the value with the given 64 bit hash to the sketch.
+func (s *Sketch) InsertHash(hash uint64) {
+	index := hash >> (64 - precision)
+	// the sentinel bit bounds the rank if all the remaining bits are zero
+	rank := uint8(bits.LeadingZeros64(hash<<precision|1<<(precision-1))) + 1
+	if rank > s.registers[index] {
+		s.registers[index] = rank
+	}
+}
+
+// Merge adds all the values of the other sketch to this one.
+func (s *Sketch) Merge(other *Sketch) {
+	for i, rank := range other.registers {
+		if rank > s.registers[i] {
+			s.registers[i] = rank
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct values inserted into the sketch.
+func (s *Sketch) Estimate() uint64 {
+	m := float64(registerCount)
+	sum := 0.0
+	zeros := 0
+	for _, rank := range s.registers {
+		sum += 1 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is much more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// MarshalBinary returns the binary encoding of the sketch.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(s.registers)+1)
+	data = append(data, encodingVersion)
+	return append(data, s.registers...), nil
+}
+
+// UnmarshalBinary replaces the sketch with the one of the given binary encoding.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) != registerCount+1 || data[0] != encodingVersion {
+		return ErrInvalidSketchEncoding
+	}
+	s.registers = make([]uint8, registerCount)
+	copy(s.registers, data[1:])
+	return nil
+}
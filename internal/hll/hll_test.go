@@ -0,0 +1,69 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hll
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertValues(s *Sketch, from, to int) {
+	for i := from; i < to; i++ {
+		s.Insert([]byte(fmt.Sprintf("value-%d", i)))
+	}
+}
+
+func TestSketch_IfEmpty_ShouldEstimateZero(t *testing.T) {
+	assert.Equal(t, uint64(0), New().Estimate())
+}
+
+func TestSketch_WithSmallCardinality_ShouldBeExact(t *testing.T) {
+	s := New()
+	insertValues(s, 0, 10)
+	insertValues(s, 0, 10)
+	assert.Equal(t, uint64(10), s.Estimate())
+}
+
+func TestSketch_WithLargeCardinality_ShouldBeWithinErrorBound(t *testing.T) {
+	for _, count := range []int{1_000, 10_000, 100_000} {
+		s := New()
+		insertValues(s, 0, count)
+		assert.InEpsilon(t, count, s.Estimate(), 0.05, "count: %d", count)
+	}
+}
+
+func TestSketch_Merge_ShouldEstimateUnion(t *testing.T) {
+	a := New()
+	insertValues(a, 0, 6_000)
+	b := New()
+	insertValues(b, 4_000, 10_000)
+	a.Merge(b)
+	assert.InEpsilon(t, 10_000, a.Estimate(), 0.05)
+}
+
+func TestSketch_MarshalBinary_ShouldRoundTrip(t *testing.T) {
+	s := New()
+	insertValues(s, 0, 5_000)
+	data, err := s.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := &Sketch{}
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, s.Estimate(), decoded.Estimate())
+}
+
+func TestSketch_UnmarshalBinary_IfInvalid_ShouldError(t *testing.T) {
+	err := (&Sketch{}).UnmarshalBinary([]byte{encodingVersion, 1, 2})
+	assert.ErrorIs(t, err, ErrInvalidSketchEncoding)
+}
@@ -29,6 +29,10 @@ const (
 	PriorityKey = InstanceType("p")
 	// DeletedKey is a type that represents a deleted document.
 	DeletedKey = InstanceType("d")
+	// SetStateKey is a type that represents the internal state of a set CRDT instance.
+	SetStateKey = InstanceType("s")
+	// TextStateKey is a type that represents the internal state of a text CRDT instance.
+	TextStateKey = InstanceType("t")
 
 	DATASTORE_DOC_VERSION_FIELD_ID = "v"
 )
@@ -86,6 +90,18 @@ func (k DataStoreKey) WithDeletedFlag() DataStoreKey {
 	return newKey
 }
 
+func (k DataStoreKey) WithSetStateFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = SetStateKey
+	return newKey
+}
+
+func (k DataStoreKey) WithTextStateFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = TextStateKey
+	return newKey
+}
+
 func (k DataStoreKey) WithCollectionRoot(colRoot uint32) DataStoreKey {
 	newKey := k
 	newKey.CollectionRootID = colRoot
@@ -67,6 +67,12 @@ func (k *IndexDataStoreKey) ToString() string {
 	return string(k.Bytes())
 }
 
+// PrefixEndBytes returns the byte representation of the key that sorts precisely
+// behind all the keys prefixed by this key.
+func (k *IndexDataStoreKey) PrefixEndBytes() []byte {
+	return bytesPrefixEnd(k.Bytes())
+}
+
 // Equal returns true if the two keys are equal
 func (k *IndexDataStoreKey) Equal(other IndexDataStoreKey) bool {
 	if k.CollectionID != other.CollectionID || k.IndexID != other.IndexID {
@@ -392,3 +392,54 @@ func TestIndexDataStoreKey_IsEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestNewIndexStatsKey_IfNoIndexID_ReturnCollectionPrefix(t *testing.T) {
+	key := NewIndexStatsKey(1, 0)
+	assert.Equal(t, "/stats/index/1", key.ToString())
+}
+
+func TestNewIndexStatsKeyFromString_IfFullKeyString_ReturnKey(t *testing.T) {
+	key, err := NewIndexStatsKeyFromString("/stats/index/1/2")
+	assert.NoError(t, err)
+	assert.Equal(t, NewIndexStatsKey(1, 2), key)
+}
+
+func TestNewIndexStatsKeyFromString_IfInvalidString_ReturnError(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"/stats/index",
+		"/stats/index/1",
+		"/stats/index/1/2/3",
+		"/stats/collection/1/2",
+	} {
+		_, err := NewIndexStatsKeyFromString(key)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	}
+}
+
+func TestNewCollectionStatsKeyFromString_IfFullKeyString_ReturnKey(t *testing.T) {
+	key, err := NewCollectionStatsKeyFromString("/stats/collection/3")
+	assert.NoError(t, err)
+	assert.Equal(t, NewCollectionStatsKey(3), key)
+	assert.Equal(t, "/stats/collection/3", key.ToString())
+}
+
+func TestNewCollectionStatsDeltaKeyFromString_IfFullKeyString_ReturnKey(t *testing.T) {
+	key, err := NewCollectionStatsDeltaKeyFromString("/stats/delta/3/12")
+	assert.NoError(t, err)
+	assert.Equal(t, NewCollectionStatsDeltaKey(3, 12), key)
+	assert.Equal(t, "/stats/delta/3/12", key.ToString())
+}
+
+func TestNewCollectionStatsDeltaKeyFromString_IfInvalidString_ReturnError(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"/stats/delta",
+		"/stats/delta/3",
+		"/stats/delta/3/12/1",
+		"/stats/index/3/12",
+	} {
+		_, err := NewCollectionStatsDeltaKeyFromString(key)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keys
+
+import ds "github.com/ipfs/go-datastore"
+
+type PeerAccessKey struct {
+	PeerID string
+}
+
+var _ Key = (*PeerAccessKey)(nil)
+
+func NewPeerAccessKey(id string) PeerAccessKey {
+	return PeerAccessKey{PeerID: id}
+}
+
+func (k PeerAccessKey) ToString() string {
+	result := PEER_ACCESS
+
+	if k.PeerID != "" {
+		result = result + "/" + k.PeerID
+	}
+
+	return result
+}
+
+func (k PeerAccessKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k PeerAccessKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
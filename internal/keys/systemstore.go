@@ -23,4 +23,8 @@ const (
 	COLLECTION_SEQ            = "/seq/collection"
 	INDEX_ID_SEQ              = "/seq/index"
 	FIELD_ID_SEQ              = "/seq/field"
+	BACKUP_IMPORT             = "/backup/import"
+	COLLECTION_STATS          = "/stats/collection"
+	COLLECTION_STATS_DELTA    = "/stats/delta"
+	INDEX_STATS               = "/stats/index"
 )
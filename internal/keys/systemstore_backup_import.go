@@ -0,0 +1,44 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keys
+
+import ds "github.com/ipfs/go-datastore"
+
+// BackupImportKey is used to key the progress of the import of a backup file.
+type BackupImportKey struct {
+	// ImportID identifies the imported file.
+	ImportID string
+}
+
+var _ Key = (*BackupImportKey)(nil)
+
+// NewBackupImportKey returns a new BackupImportKey for the import with the given id.
+func NewBackupImportKey(importID string) BackupImportKey {
+	return BackupImportKey{ImportID: importID}
+}
+
+func (k BackupImportKey) ToString() string {
+	result := BACKUP_IMPORT
+
+	if k.ImportID != "" {
+		result = result + "/" + k.ImportID
+	}
+
+	return result
+}
+
+func (k BackupImportKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k BackupImportKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
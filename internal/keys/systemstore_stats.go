@@ -0,0 +1,189 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keys
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// CollectionStatsKey points to the stored statistics of the documents of a collection.
+//
+// It is stored in the format `/stats/collection/[CollectionRootID]`.
+type CollectionStatsKey struct {
+	// CollectionRootID is the root id of the collection that the statistics are of.
+	CollectionRootID uint32
+}
+
+var _ Key = (*CollectionStatsKey)(nil)
+
+// NewCollectionStatsKey returns a new CollectionStatsKey for the collection with the given root id.
+func NewCollectionStatsKey(collectionRootID uint32) CollectionStatsKey {
+	return CollectionStatsKey{CollectionRootID: collectionRootID}
+}
+
+// NewCollectionStatsKeyFromString creates a new [CollectionStatsKey].
+//
+// It expects the key to be in the format `/stats/collection/[CollectionRootID]`.
+func NewCollectionStatsKeyFromString(key string) (CollectionStatsKey, error) {
+	keyArr := strings.Split(key, "/")
+	if len(keyArr) != 4 || "/"+keyArr[1]+"/"+keyArr[2] != COLLECTION_STATS {
+		return CollectionStatsKey{}, ErrInvalidKey
+	}
+	rootID, err := strconv.ParseUint(keyArr[3], 10, 32)
+	if err != nil {
+		return CollectionStatsKey{}, err
+	}
+	return CollectionStatsKey{CollectionRootID: uint32(rootID)}, nil
+}
+
+func (k CollectionStatsKey) ToString() string {
+	result := COLLECTION_STATS
+
+	if k.CollectionRootID != 0 {
+		result = fmt.Sprintf("%s/%d", result, k.CollectionRootID)
+	}
+
+	return result
+}
+
+func (k CollectionStatsKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k CollectionStatsKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// CollectionStatsDeltaKey points to a change to the number of documents of a collection,
+// written by the transaction changing it.
+//
+// It is stored in the format `/stats/delta/[CollectionRootID]/[DeltaID]`.
+type CollectionStatsDeltaKey struct {
+	// CollectionRootID is the root id of the collection that the change is of.
+	CollectionRootID uint32
+	// DeltaID is the id of the change, unique amongst the stored changes.
+	DeltaID uint64
+}
+
+var _ Key = (*CollectionStatsDeltaKey)(nil)
+
+// NewCollectionStatsDeltaKey returns a new CollectionStatsDeltaKey for the change with the given id
+// to the collection with the given root id.
+func NewCollectionStatsDeltaKey(collectionRootID uint32, deltaID uint64) CollectionStatsDeltaKey {
+	return CollectionStatsDeltaKey{CollectionRootID: collectionRootID, DeltaID: deltaID}
+}
+
+// NewCollectionStatsDeltaKeyFromString creates a new [CollectionStatsDeltaKey].
+//
+// It expects the key to be in the format `/stats/delta/[CollectionRootID]/[DeltaID]`.
+func NewCollectionStatsDeltaKeyFromString(key string) (CollectionStatsDeltaKey, error) {
+	keyArr := strings.Split(key, "/")
+	if len(keyArr) != 5 || "/"+keyArr[1]+"/"+keyArr[2] != COLLECTION_STATS_DELTA {
+		return CollectionStatsDeltaKey{}, ErrInvalidKey
+	}
+	rootID, err := strconv.ParseUint(keyArr[3], 10, 32)
+	if err != nil {
+		return CollectionStatsDeltaKey{}, err
+	}
+	deltaID, err := strconv.ParseUint(keyArr[4], 10, 64)
+	if err != nil {
+		return CollectionStatsDeltaKey{}, err
+	}
+	return CollectionStatsDeltaKey{CollectionRootID: uint32(rootID), DeltaID: deltaID}, nil
+}
+
+// ToString returns the string representation of the key.
+//
+// If the CollectionRootID is empty, the rest is ignored.
+func (k CollectionStatsDeltaKey) ToString() string {
+	result := COLLECTION_STATS_DELTA
+
+	if k.CollectionRootID != 0 {
+		result = fmt.Sprintf("%s/%d", result, k.CollectionRootID)
+		if k.DeltaID != 0 {
+			result = fmt.Sprintf("%s/%d", result, k.DeltaID)
+		}
+	}
+
+	return result
+}
+
+func (k CollectionStatsDeltaKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k CollectionStatsDeltaKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// IndexStatsKey points to the stored statistics of the values of an index.
+//
+// It is stored in the format `/stats/index/[CollectionID]/[IndexID]`.
+type IndexStatsKey struct {
+	// CollectionID is the id of the collection that the index is on.
+	CollectionID uint32
+	// IndexID is the id of the index.
+	IndexID uint32
+}
+
+var _ Key = (*IndexStatsKey)(nil)
+
+// NewIndexStatsKey returns a new IndexStatsKey for the given index of the given collection.
+func NewIndexStatsKey(collectionID, indexID uint32) IndexStatsKey {
+	return IndexStatsKey{CollectionID: collectionID, IndexID: indexID}
+}
+
+// NewIndexStatsKeyFromString creates a new [IndexStatsKey].
+//
+// It expects the key to be in the format `/stats/index/[CollectionID]/[IndexID]`.
+func NewIndexStatsKeyFromString(key string) (IndexStatsKey, error) {
+	keyArr := strings.Split(key, "/")
+	if len(keyArr) != 5 || "/"+keyArr[1]+"/"+keyArr[2] != INDEX_STATS {
+		return IndexStatsKey{}, ErrInvalidKey
+	}
+	colID, err := strconv.ParseUint(keyArr[3], 10, 32)
+	if err != nil {
+		return IndexStatsKey{}, err
+	}
+	indexID, err := strconv.ParseUint(keyArr[4], 10, 32)
+	if err != nil {
+		return IndexStatsKey{}, err
+	}
+	return IndexStatsKey{CollectionID: uint32(colID), IndexID: uint32(indexID)}, nil
+}
+
+// ToString returns the string representation of the key.
+//
+// If the CollectionID is empty, the rest is ignored.
+func (k IndexStatsKey) ToString() string {
+	result := INDEX_STATS
+
+	if k.CollectionID != 0 {
+		result = fmt.Sprintf("%s/%d", result, k.CollectionID)
+		if k.IndexID != 0 {
+			result = fmt.Sprintf("%s/%d", result, k.IndexID)
+		}
+	}
+
+	return result
+}
+
+func (k IndexStatsKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k IndexStatsKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
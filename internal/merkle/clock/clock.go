@@ -104,6 +104,11 @@ func (mc *MerkleClock) AddDelta(
 	delta.SetPriority(height)
 	block := coreblock.New(delta, links, heads...)
 
+	timestamp := coreblock.TimestampFromContext(ctx)
+	if timestamp.HasValue() {
+		block.SetTimestamp(timestamp.Value())
+	}
+
 	fieldName := immutable.None[string]()
 	if block.Delta.GetFieldName() != "" {
 		fieldName = immutable.Some(block.Delta.GetFieldName())
@@ -122,6 +127,14 @@ func (mc *MerkleClock) AddDelta(
 		dagBlock.Encryption = &encLink
 	}
 
+	signer := coreblock.SignerFromContext(ctx)
+	if signer.HasValue() {
+		err = dagBlock.Sign(signer.Value().PrivateKey)
+		if err != nil {
+			return cidlink.Link{}, nil, NewErrSigningBlock(err)
+		}
+	}
+
 	link, err := mc.putBlock(ctx, dagBlock)
 	if err != nil {
 		return cidlink.Link{}, nil, err
@@ -218,7 +231,12 @@ func encryptBlock(
 		return nil, err
 	}
 	clonedCRDT.SetData(bytes)
-	return &coreblock.Block{Delta: clonedCRDT, Heads: block.Heads, Links: block.Links}, nil
+	return &coreblock.Block{
+		Delta:     clonedCRDT,
+		Heads:     block.Heads,
+		Links:     block.Links,
+		Timestamp: block.Timestamp,
+	}, nil
 }
 
 // ProcessBlock merges the delta CRDT and updates the state accordingly.
@@ -27,6 +27,7 @@ const (
 	errCouldNotFindBlock      = "error checking for known block "
 	errFailedToGetNextQResult = "failed to get next query result"
 	errCouldNotGetEncKey      = "could not get encryption key"
+	errSigningBlock           = "error signing block"
 )
 
 var (
@@ -41,6 +42,7 @@ var (
 	ErrFailedToGetNextQResult = errors.New(errFailedToGetNextQResult)
 	ErrDecodingHeight         = errors.New("error decoding height")
 	ErrCouldNotGetEncKey      = errors.New(errCouldNotGetEncKey)
+	ErrSigningBlock           = errors.New(errSigningBlock)
 )
 
 func NewErrCreatingBlock(inner error) error {
@@ -51,6 +53,10 @@ func NewErrWritingBlock(inner error) error {
 	return errors.Wrap(errWritingBlock, inner)
 }
 
+func NewErrSigningBlock(inner error) error {
+	return errors.Wrap(errSigningBlock, inner)
+}
+
 func NewErrGettingHeads(inner error) error {
 	return errors.Wrap(errGettingHeads, inner)
 }
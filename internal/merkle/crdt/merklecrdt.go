@@ -70,6 +70,21 @@ func FieldLevelCRDTWithStore(
 			cType == client.PN_COUNTER,
 			kind.(client.ScalarKind),
 		), nil
+	case client.G_SET, client.OR_SET:
+		return NewMerkleSet(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+			cType == client.OR_SET,
+		), nil
+	case client.TEXT:
+		return NewMerkleText(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+		), nil
 	}
 	return nil, client.NewErrUnknownCRDT(cType)
 }
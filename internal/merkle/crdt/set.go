@@ -0,0 +1,64 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+
+	"github.com/sourcenetwork/defradb/internal/core/crdt"
+	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/merkle/clock"
+)
+
+// MerkleSet is a MerkleCRDT implementation of the Set using MerkleClocks.
+type MerkleSet struct {
+	clock *clock.MerkleClock
+	reg   crdt.Set
+}
+
+var _ FieldLevelMerkleCRDT = (*MerkleSet)(nil)
+
+// NewMerkleSet creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a Set CRDT.
+func NewMerkleSet(
+	store Stores,
+	schemaVersionKey keys.CollectionSchemaVersionKey,
+	key keys.DataStoreKey,
+	fieldName string,
+	allowRemove bool,
+) *MerkleSet {
+	register := crdt.NewSet(store.Datastore(), schemaVersionKey, key, fieldName, allowRemove)
+	clk := clock.NewMerkleClock(store.Headstore(), store.Blockstore(), store.Encstore(), key.ToHeadStoreKey(), register)
+
+	return &MerkleSet{
+		clock: clk,
+		reg:   register,
+	}
+}
+
+func (m *MerkleSet) Clock() *clock.MerkleClock {
+	return m.clock
+}
+
+// Save the difference between the current elements of the Set and the given value to the DAG.
+func (m *MerkleSet) Save(ctx context.Context, data *DocField) (cidlink.Link, []byte, error) {
+	bytes, err := data.FieldValue.Bytes()
+	if err != nil {
+		return cidlink.Link{}, nil, err
+	}
+	delta, err := m.reg.Update(ctx, bytes)
+	if err != nil {
+		return cidlink.Link{}, nil, err
+	}
+	return m.clock.AddDelta(ctx, delta)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+
+	"github.com/sourcenetwork/defradb/internal/core/crdt"
+	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/merkle/clock"
+)
+
+// MerkleText is a MerkleCRDT implementation of the Text using MerkleClocks.
+type MerkleText struct {
+	clock *clock.MerkleClock
+	reg   crdt.Text
+}
+
+var _ FieldLevelMerkleCRDT = (*MerkleText)(nil)
+
+// NewMerkleText creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a Text CRDT.
+func NewMerkleText(
+	store Stores,
+	schemaVersionKey keys.CollectionSchemaVersionKey,
+	key keys.DataStoreKey,
+	fieldName string,
+) *MerkleText {
+	register := crdt.NewText(store.Datastore(), schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(store.Headstore(), store.Blockstore(), store.Encstore(), key.ToHeadStoreKey(), register)
+
+	return &MerkleText{
+		clock: clk,
+		reg:   register,
+	}
+}
+
+func (m *MerkleText) Clock() *clock.MerkleClock {
+	return m.clock
+}
+
+// Save the edits of the given value to the DAG.
+//
+// If the value was produced by patching the saved text, the patches are saved as edits at
+// their positions, otherwise the current Text is replaced with the given value.
+func (m *MerkleText) Save(ctx context.Context, data *DocField) (cidlink.Link, []byte, error) {
+	if patches := data.FieldValue.TextPatches(); patches != nil {
+		delta, err := m.reg.Patch(ctx, patches)
+		if err != nil {
+			return cidlink.Link{}, nil, err
+		}
+		return m.clock.AddDelta(ctx, delta)
+	}
+
+	bytes, err := data.FieldValue.Bytes()
+	if err != nil {
+		return cidlink.Link{}, nil, err
+	}
+	delta, err := m.reg.Update(ctx, bytes)
+	if err != nil {
+		return cidlink.Link{}, nil, err
+	}
+	return m.clock.AddDelta(ctx, delta)
+}
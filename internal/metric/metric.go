@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/metric"
 	otelMetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -59,6 +60,21 @@ func (m *Meter) Register(name string) {
 	m.meter = m.provider.Meter(name)
 }
 
+// RegisterWithExporter gives a name to the metric and initializes the provider, periodically
+// exporting the gathered data with the given exporter.
+//
+// The remaining data is exported when the meter is closed.
+func (m *Meter) RegisterWithExporter(name string, exporter otelMetric.Exporter) {
+	m.provider = m.newManualProvider(otelMetric.WithReader(otelMetric.NewPeriodicReader(exporter)))
+	m.meter = m.provider.Meter(name)
+}
+
+// NewOTLPExporter returns an exporter pushing the metrics to the OTLP over HTTP endpoint
+// with the given URL, such as `http://localhost:4318/v1/metrics`.
+func NewOTLPExporter(ctx context.Context, endpointURL string) (otelMetric.Exporter, error) {
+	return otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(endpointURL))
+}
+
 // Dump is responsible to read the metrics and output all the gathered data.
 func (m *Meter) Dump(ctx context.Context) (*metricdata.ResourceMetrics, error) {
 	out := &metricdata.ResourceMetrics{}
@@ -95,6 +111,20 @@ func (m *Meter) GetSyncCounter(
 	)
 }
 
+// GetAsyncGauge returns a new gauge with the given name and unit.
+//
+// The gauge is observed by the callbacks registered with it on the meter, whenever the
+// metrics are read.
+func (m *Meter) GetAsyncGauge(
+	name string,
+	unit string,
+) (metric.Int64ObservableGauge, error) {
+	return m.meter.Int64ObservableGauge(
+		name,
+		metric.WithUnit(unit),
+	)
+}
+
 // DumpScopeMetricsString returns a string representation of the metrics.
 func (m *Meter) DumpScopeMetricsString(ctx context.Context) (string, error) {
 	out := &metricdata.ResourceMetrics{}
@@ -115,10 +145,9 @@ func (m *Meter) Get() metric.Meter {
 	return m.meter
 }
 
-func (m *Meter) newManualProvider() *otelMetric.MeterProvider {
+func (m *Meter) newManualProvider(opts ...otelMetric.Option) *otelMetric.MeterProvider {
 	// Register a manual reader.
 	m.reader = otelMetric.NewManualReader()
-	return otelMetric.NewMeterProvider(
-		otelMetric.WithReader(m.reader),
-	)
+	opts = append(opts, otelMetric.WithReader(m.reader))
+	return otelMetric.NewMeterProvider(opts...)
 }
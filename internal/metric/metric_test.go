@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
@@ -119,6 +120,51 @@ func TestMetricSyncCounter(t *testing.T) {
 	}
 }
 
+func TestMetricAsyncGauge(t *testing.T) {
+	meter := NewMeter()
+	meter.Register("GaugeOnly")
+	stuffGauge, err := meter.GetAsyncGauge(
+		"stuffLevel",
+		"1",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = meter.Get().RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			o.ObserveInt64(stuffGauge, 7)
+			return nil
+		},
+		stuffGauge,
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	data, err := meter.Dump(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 1, len(data.ScopeMetrics))
+	assert.Equal(t, "GaugeOnly", data.ScopeMetrics[0].Scope.Name)
+	assert.Equal(t, 1, len(data.ScopeMetrics[0].Metrics))
+	assert.Equal(t, "stuffLevel", data.ScopeMetrics[0].Metrics[0].Name)
+
+	firstMetricData := data.ScopeMetrics[0].Metrics[0].Data
+	gaugeData, isGauge := firstMetricData.(metricdata.Gauge[int64])
+	if !isGauge {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(gaugeData.DataPoints))
+	assert.Equal(t, int64(7), gaugeData.DataPoints[0].Value)
+
+	if meter.Close(ctx) != nil {
+		t.Error(err)
+	}
+}
+
 func TestMetricWithCounterAndHistogramIntrumentOnOneMeter(t *testing.T) {
 	meter := NewMeter()
 
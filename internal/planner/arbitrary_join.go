@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/container"
 	"github.com/sourcenetwork/defradb/internal/keys"
 	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 )
@@ -178,10 +179,12 @@ func join(
 	sources []*dataSource,
 	keyFields []mapper.Field,
 	mapping *core.DocumentMapping,
+	spiller *groupSpiller,
 ) (*orderedMap, error) {
 	result := orderedMap{
 		values:       []core.Doc{},
 		indexesByKey: map[string]int{},
+		budget:       spiller.budget,
 	}
 
 	childIndexes := make([]int, len(sources))
@@ -208,6 +211,13 @@ func join(
 					return nil, err
 				}
 			}
+
+			if result.overBudget {
+				err = spiller.spill(&result)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
@@ -228,12 +238,57 @@ func generateKey(doc core.Doc, keyFields []mapper.Field) string {
 type orderedMap struct {
 	values       []core.Doc
 	indexesByKey map[string]int
+
+	// The keys, the positions of the first document and whether the parent has been merged in,
+	// of each value.  These are needed to merge the values back together if they are spilled.
+	keys      []string
+	seqs      []int64
+	hasParent []bool
+	nextSeq   int64
+
+	budget   *container.MemoryBudget
+	reserved int64
+	// overBudget is true if the memory budget has been exceeded since the map was last reset.
+	overBudget bool
+}
+
+// reserve reserves the memory held by the given document from the budget.
+func (m *orderedMap) reserve(doc core.Doc) {
+	size := container.DocSize(doc)
+	m.reserved += size
+	if !m.budget.Reserve(size) {
+		m.overBudget = true
+	}
+}
+
+// add adds a new value under the given key.
+func (m *orderedMap) add(key string, value core.Doc, hasParent bool) {
+	m.indexesByKey[key] = len(m.values)
+	m.values = append(m.values, value)
+	m.keys = append(m.keys, key)
+	m.seqs = append(m.seqs, m.nextSeq)
+	m.hasParent = append(m.hasParent, hasParent)
+	m.nextSeq++
+}
+
+// reset removes all the values from the map and releases their memory.
+func (m *orderedMap) reset() {
+	m.values = []core.Doc{}
+	m.indexesByKey = map[string]int{}
+	m.keys = nil
+	m.seqs = nil
+	m.hasParent = nil
+	m.overBudget = false
+	m.budget.Release(m.reserved)
+	m.reserved = 0
 }
 
 func (m *orderedMap) mergeParent(key string, childIndexes []int, value core.Doc) {
+	m.reserve(value)
 	index, exists := m.indexesByKey[key]
 	if exists {
 		existingValue := m.values[index]
+		m.hasParent[index] = true
 
 		// copy every value from the child, apart from the child-indexes
 	propertyLoop:
@@ -262,21 +317,16 @@ func (m *orderedMap) mergeParent(key string, childIndexes []int, value core.Doc)
 		value.Fields[childAddress] = []core.Doc{}
 	}
 
-	index = len(m.values)
-	m.values = append(m.values, value)
-	m.indexesByKey[key] = index
+	m.add(key, value, true)
 }
 
 func (m *orderedMap) appendChild(key string, childIndex int, value core.Doc, mapping *core.DocumentMapping) {
+	m.reserve(value)
 	index, exists := m.indexesByKey[key]
 	var parent core.Doc
 	if !exists {
-		index = len(m.values)
-
 		parent = mapping.NewDoc()
-		m.values = append(m.values, parent)
-
-		m.indexesByKey[key] = index
+		m.add(key, parent, false)
 	} else {
 		parent = m.values[index]
 	}
@@ -177,6 +177,10 @@ func (n *dagScanNode) Explain(explainType request.ExplainType) (map[string]any,
 func (n *dagScanNode) Next() (bool, error) {
 	n.execInfo.iterations++
 
+	if err := n.planner.checkContext(); err != nil {
+		return false, err
+	}
+
 	var currentCid *cid.Cid
 	store := n.planner.txn.Blockstore()
 
@@ -342,6 +346,18 @@ func (n *dagScanNode) dagBlockToNodeDoc(block *coreblock.Block) (core.Doc, error
 		n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.DeltaFieldName, nil)
 	}
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.HeightFieldName, int64(prio))
+
+	signer, err := block.SignerDID()
+	if err != nil {
+		return core.Doc{}, err
+	}
+	if signer.HasValue() {
+		n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.SignerFieldName, signer.Value())
+	}
+	timestamp := block.GetTimestamp()
+	if timestamp.HasValue() {
+		n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.TimestampFieldName, timestamp.Value())
+	}
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.FieldNameFieldName, fieldName)
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.FieldIDFieldName, fieldID)
 
@@ -42,6 +42,7 @@ var (
 	_ explainablePlanNode = (*maxNode)(nil)
 	_ explainablePlanNode = (*minNode)(nil)
 	_ explainablePlanNode = (*orderNode)(nil)
+	_ explainablePlanNode = (*paginationNode)(nil)
 	_ explainablePlanNode = (*scanNode)(nil)
 	_ explainablePlanNode = (*selectNode)(nil)
 	_ explainablePlanNode = (*selectTopNode)(nil)
@@ -65,6 +66,10 @@ const (
 	joinSubTypeLabel    = "subType"
 	limitLabel          = "limit"
 	offsetLabel         = "offset"
+	firstLabel          = "first"
+	afterLabel          = "after"
+	lastLabel           = "last"
+	beforeLabel         = "before"
 	sourcesLabel        = "sources"
 	prefixesLabel       = "prefixes"
 )
@@ -412,6 +417,7 @@ func (p *Planner) executeAndExplainRequest(
 	executeExplain["executionSuccess"] = executionSuccess
 	executeExplain["planExecutions"] = planExecutions
 	executeExplain["sizeOfResult"] = len(docs)
+	executeExplain["memoryBudget"] = p.memory.Limit()
 
 	return map[string]any{
 		request.ExplainLabel: executeExplain,
@@ -0,0 +1,167 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package filter
+
+import (
+	"strings"
+
+	"github.com/sourcenetwork/defradb/internal/connor"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+)
+
+// Implies returns true if every document matching the given conditions is guaranteed
+// to also match the implied conditions.
+//
+// Both arguments are expected in their external (field name keyed) form. The check is
+// conservative: it may return false for conditions that do in fact imply each other,
+// but it never returns true for conditions that don't.
+func Implies(conditions, implied map[string]any) bool {
+	fieldConds := make(map[string][]map[string]any)
+	collectConjuncts(conditions, fieldConds)
+	return implies(fieldConds, implied)
+}
+
+// collectConjuncts collects the field conditions that all have to hold for the
+// given conditions to match, following nested `_and` operators.
+func collectConjuncts(conditions map[string]any, fieldConds map[string][]map[string]any) {
+	for key, cond := range conditions {
+		if key == connor.AndOp {
+			conds, _ := cond.([]any)
+			for _, c := range conds {
+				if condMap, ok := c.(map[string]any); ok {
+					collectConjuncts(condMap, fieldConds)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if condMap, ok := cond.(map[string]any); ok {
+			fieldConds[key] = append(fieldConds[key], condMap)
+		}
+	}
+}
+
+func implies(fieldConds map[string][]map[string]any, implied map[string]any) bool {
+	for key, cond := range implied {
+		switch key {
+		case connor.AndOp:
+			conds, ok := cond.([]any)
+			if !ok {
+				return false
+			}
+			for _, c := range conds {
+				condMap, ok := c.(map[string]any)
+				if !ok || !implies(fieldConds, condMap) {
+					return false
+				}
+			}
+			continue
+
+		case connor.OrOp:
+			conds, ok := cond.([]any)
+			if !ok {
+				return false
+			}
+			isImplied := false
+			for _, c := range conds {
+				condMap, ok := c.(map[string]any)
+				if ok && implies(fieldConds, condMap) {
+					isImplied = true
+					break
+				}
+			}
+			if !isImplied {
+				return false
+			}
+			continue
+		}
+
+		if strings.HasPrefix(key, "_") {
+			return false
+		}
+		condMap, ok := cond.(map[string]any)
+		if !ok || len(condMap) == 0 {
+			return false
+		}
+		for op, value := range condMap {
+			if !isFieldConditionImplied(fieldConds[key], op, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isFieldConditionImplied returns true if any of the given conditions of a field
+// implies the field condition with the given operator and value.
+func isFieldConditionImplied(conds []map[string]any, impliedOp string, impliedValue any) bool {
+	if !connor.IsOpSimple(impliedOp) {
+		return false
+	}
+	for _, cond := range conds {
+		for op, value := range cond {
+			if isOpImplied(op, value, impliedOp, impliedValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isOpImplied returns true if the field condition `op: value` implies the field
+// condition `impliedOp: impliedValue`.
+func isOpImplied(op string, value any, impliedOp string, impliedValue any) bool {
+	switch op {
+	case connor.EqualOp:
+		return match(impliedOp, impliedValue, value)
+
+	case connor.InOp:
+		values, ok := value.([]any)
+		if !ok || len(values) == 0 {
+			return false
+		}
+		for _, v := range values {
+			if !match(impliedOp, impliedValue, v) {
+				return false
+			}
+		}
+		return true
+
+	case connor.GreaterOp, connor.GreaterOrEqualOp:
+		if impliedOp != connor.GreaterOp && impliedOp != connor.GreaterOrEqualOp {
+			break
+		}
+		// a value strictly greater than the bound satisfies any lower bound up to it
+		if op == connor.GreaterOp {
+			return match(connor.GreaterOrEqualOp, impliedValue, value)
+		}
+		return match(impliedOp, impliedValue, value)
+
+	case connor.LesserOp, connor.LesserOrEqualOp:
+		if impliedOp != connor.LesserOp && impliedOp != connor.LesserOrEqualOp {
+			break
+		}
+		// a value strictly lesser than the bound satisfies any upper bound down to it
+		if op == connor.LesserOp {
+			return match(connor.LesserOrEqualOp, impliedValue, value)
+		}
+		return match(impliedOp, impliedValue, value)
+	}
+	return op == impliedOp && match(connor.EqualOp, impliedValue, value)
+}
+
+// match returns true if the given data matches the condition `op: value`.
+func match(op string, value any, data any) bool {
+	isMatch, err := connor.Match(map[connor.FilterKey]any{&mapper.Operator{Operation: op}: value}, data)
+	return err == nil && isMatch
+}
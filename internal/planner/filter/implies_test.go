@@ -0,0 +1,204 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplies(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions map[string]any
+		implied    map[string]any
+		isImplied  bool
+	}{
+		{
+			name:       "same condition",
+			conditions: m("status", m("_eq", "active")),
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  true,
+		},
+		{
+			name:       "different value",
+			conditions: m("status", m("_eq", "archived")),
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  false,
+		},
+		{
+			name:       "no condition on field",
+			conditions: m("name", m("_eq", "John")),
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  false,
+		},
+		{
+			name:       "nil conditions",
+			conditions: nil,
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  false,
+		},
+		{
+			name: "condition among other fields",
+			conditions: map[string]any{
+				"name":   m("_eq", "John"),
+				"status": m("_eq", "active"),
+			},
+			implied:   m("status", m("_eq", "active")),
+			isImplied: true,
+		},
+		{
+			name: "condition within _and",
+			conditions: r("_and",
+				m("name", m("_eq", "John")),
+				r("_and", m("status", m("_eq", "active"))),
+			),
+			implied:   m("status", m("_eq", "active")),
+			isImplied: true,
+		},
+		{
+			name: "condition within _or",
+			conditions: r("_or",
+				m("status", m("_eq", "active")),
+				m("name", m("_eq", "John")),
+			),
+			implied:   m("status", m("_eq", "active")),
+			isImplied: false,
+		},
+		{
+			name:       "condition within _not",
+			conditions: m("_not", m("status", m("_eq", "active"))),
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  false,
+		},
+		{
+			name:       "_eq implies _in",
+			conditions: m("status", m("_eq", "active")),
+			implied:    m("status", m("_in", []any{"active", "pending"})),
+			isImplied:  true,
+		},
+		{
+			name:       "_in implies _in with more values",
+			conditions: m("status", m("_in", []any{"active"})),
+			implied:    m("status", m("_in", []any{"active", "pending"})),
+			isImplied:  true,
+		},
+		{
+			name:       "_in does not imply _eq",
+			conditions: m("status", m("_in", []any{"active", "pending"})),
+			implied:    m("status", m("_eq", "active")),
+			isImplied:  false,
+		},
+		{
+			name:       "_eq implies _ne of other value",
+			conditions: m("status", m("_eq", "active")),
+			implied:    m("status", m("_ne", "archived")),
+			isImplied:  true,
+		},
+		{
+			name:       "_eq on bool",
+			conditions: m("deleted", m("_eq", false)),
+			implied:    m("deleted", m("_eq", false)),
+			isImplied:  true,
+		},
+		{
+			name:       "_eq implies range",
+			conditions: m("age", m("_eq", 30)),
+			implied:    m("age", m("_gt", float64(18))),
+			isImplied:  true,
+		},
+		{
+			name:       "narrower _gt implies _gt",
+			conditions: m("age", m("_gt", 30)),
+			implied:    m("age", m("_gt", float64(18))),
+			isImplied:  true,
+		},
+		{
+			name:       "_gt implies _ge of same value",
+			conditions: m("age", m("_gt", 18)),
+			implied:    m("age", m("_ge", float64(18))),
+			isImplied:  true,
+		},
+		{
+			name:       "_ge does not imply _gt of same value",
+			conditions: m("age", m("_ge", 18)),
+			implied:    m("age", m("_gt", float64(18))),
+			isImplied:  false,
+		},
+		{
+			name:       "wider _ge does not imply _ge",
+			conditions: m("age", m("_ge", 10)),
+			implied:    m("age", m("_ge", float64(18))),
+			isImplied:  false,
+		},
+		{
+			name:       "narrower _lt implies _le",
+			conditions: m("age", m("_lt", 10)),
+			implied:    m("age", m("_le", float64(18))),
+			isImplied:  true,
+		},
+		{
+			name:       "_lt does not imply _gt",
+			conditions: m("age", m("_lt", 10)),
+			implied:    m("age", m("_gt", float64(5))),
+			isImplied:  false,
+		},
+		{
+			name: "range implied by separate conditions",
+			conditions: r("_and",
+				m("age", m("_gt", 20)),
+				m("age", m("_lt", 30)),
+			),
+			implied:   m("age", map[string]any{"_ge": float64(20), "_le": float64(40)}),
+			isImplied: true,
+		},
+		{
+			name:       "implied _and",
+			conditions: map[string]any{"status": m("_eq", "active"), "age": m("_gt", 30)},
+			implied: r("_and",
+				m("status", m("_eq", "active")),
+				m("age", m("_gt", float64(18))),
+			),
+			isImplied: true,
+		},
+		{
+			name:       "implied _and with one condition not implied",
+			conditions: m("status", m("_eq", "active")),
+			implied: r("_and",
+				m("status", m("_eq", "active")),
+				m("age", m("_gt", float64(18))),
+			),
+			isImplied: false,
+		},
+		{
+			name:       "implied _or",
+			conditions: m("status", m("_eq", "pending")),
+			implied: r("_or",
+				m("status", m("_eq", "active")),
+				m("status", m("_eq", "pending")),
+			),
+			isImplied: true,
+		},
+		{
+			name:       "implied _not",
+			conditions: m("status", m("_eq", "active")),
+			implied:    m("_not", m("status", m("_eq", "archived"))),
+			isImplied:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.isImplied, Implies(test.conditions, test.implied))
+		})
+	}
+}
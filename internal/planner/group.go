@@ -35,9 +35,17 @@ type groupNode struct {
 	// The data sources that this node will draw data from.
 	dataSources []*dataSource
 
+	// built is true once the documents of the data sources have been grouped.
+	built        bool
 	values       []core.Doc
 	currentIndex int
 
+	// reserved is the memory reserved from the budget of the request by the groups held in values.
+	reserved int64
+
+	// spiller holds the groups written to disk, if the memory budget of the request was exceeded.
+	spiller *groupSpiller
+
 	execInfo groupExecInfo
 }
 
@@ -56,6 +64,12 @@ type groupExecInfo struct {
 
 	// Total number of child selections hidden after offset and limit.
 	hiddenAfterLimit uint64
+
+	// Total number of runs of groups spilled to disk.
+	spilledRuns uint64
+
+	// Total number of groups, and parts of groups, spilled to disk.
+	spilledDocs uint64
 }
 
 // Creates a new group node.
@@ -104,6 +118,10 @@ func (n *groupNode) Kind() string {
 func (n *groupNode) Init() error {
 	// We need to make sure state is cleared down on Init,
 	// this function may be called multiple times per instance (for example during a join)
+	if err := n.releaseGroups(); err != nil {
+		return err
+	}
+	n.built = false
 	n.values = nil
 	n.currentValue = core.Doc{}
 	n.currentIndex = 0
@@ -141,7 +159,20 @@ func (n *groupNode) Close() error {
 			return err
 		}
 	}
-	return nil
+	return n.releaseGroups()
+}
+
+// releaseGroups releases the groups held by the node, removing any spilled to disk.
+func (n *groupNode) releaseGroups() error {
+	n.p.memory.Release(n.reserved)
+	n.reserved = 0
+	n.values = nil
+	if n.spiller == nil {
+		return nil
+	}
+	err := n.spiller.close()
+	n.spiller = nil
+	return err
 }
 
 func (n *groupNode) Source() planNode { return n.dataSources[0].Source() }
@@ -149,56 +180,94 @@ func (n *groupNode) Source() planNode { return n.dataSources[0].Source() }
 func (n *groupNode) Next() (bool, error) {
 	n.execInfo.iterations++
 
-	if n.values == nil {
-		values, err := join(n.dataSources, n.groupByFields, n.documentMapping)
+	if !n.built {
+		err := n.build()
 		if err != nil {
 			return false, err
 		}
+	}
+
+	if n.spiller.hasSpilled() {
+		group, hasNext, err := n.spiller.next()
+		if err != nil || !hasNext {
+			return false, err
+		}
+		n.currentValue = n.prepareGroup(group)
+		return true, nil
+	}
+
+	if n.currentIndex < len(n.values) {
+		n.currentValue = n.prepareGroup(n.values[n.currentIndex])
+		n.currentIndex++
+		return true, nil
+	}
+
+	return false, nil
+}
 
+// build groups the documents of the data sources.
+func (n *groupNode) build() error {
+	childIndexes := make([]int, len(n.dataSources))
+	for i, source := range n.dataSources {
+		childIndexes[i] = source.childIndex
+	}
+	n.spiller = newGroupSpiller(n.p.memory, childIndexes)
+
+	values, err := join(n.dataSources, n.groupByFields, n.documentMapping, n.spiller)
+	if err != nil {
+		return err
+	}
+
+	if n.spiller.hasSpilled() {
+		err = n.spiller.finish(values)
+		if err != nil {
+			return err
+		}
+	} else {
 		n.values = values.values
+		n.reserved = values.reserved
+	}
+	n.execInfo.spilledRuns += n.spiller.spilledRuns()
+	n.execInfo.spilledDocs += n.spiller.spilledDocs()
+	n.built = true
+	return nil
+}
 
-		for _, group := range n.values {
-			n.execInfo.groups++
+// prepareGroup sets the child selections of the given group that have not been
+// yielded by any data source to empty, and hides those outside of their limit.
+func (n *groupNode) prepareGroup(group core.Doc) core.Doc {
+	n.execInfo.groups++
 
-			for _, childSelect := range n.childSelects {
-				n.execInfo.childSelections++
+	for _, childSelect := range n.childSelects {
+		n.execInfo.childSelections++
 
-				subSelect := group.Fields[childSelect.Index]
-				if subSelect == nil {
-					// If the sub-select is nil we need to set it to an empty array and continue
-					group.Fields[childSelect.Index] = []core.Doc{}
-					continue
-				}
+		subSelect := group.Fields[childSelect.Index]
+		if subSelect == nil {
+			// If the sub-select is nil we need to set it to an empty array and continue
+			group.Fields[childSelect.Index] = []core.Doc{}
+			continue
+		}
 
-				childDocs := subSelect.([]core.Doc)
-				if childSelect.Limit != nil {
-					l := uint64(len(childDocs))
+		childDocs := subSelect.([]core.Doc)
+		if childSelect.Limit != nil {
+			l := uint64(len(childDocs))
 
-					// We must hide all child documents before the offset
-					for i := uint64(0); i < childSelect.Limit.Offset && i < l; i++ {
-						childDocs[i].Hidden = true
+			// We must hide all child documents before the offset
+			for i := uint64(0); i < childSelect.Limit.Offset && i < l; i++ {
+				childDocs[i].Hidden = true
 
-						n.execInfo.hiddenBeforeOffset++
-					}
+				n.execInfo.hiddenBeforeOffset++
+			}
 
-					// We must hide all child documents after the offset plus limit
-					for i := childSelect.Limit.Limit + childSelect.Limit.Offset; i < l; i++ {
-						childDocs[i].Hidden = true
+			// We must hide all child documents after the offset plus limit
+			for i := childSelect.Limit.Limit + childSelect.Limit.Offset; i < l; i++ {
+				childDocs[i].Hidden = true
 
-						n.execInfo.hiddenAfterLimit++
-					}
-				}
+				n.execInfo.hiddenAfterLimit++
 			}
 		}
 	}
-
-	if n.currentIndex < len(n.values) {
-		n.currentValue = n.values[n.currentIndex]
-		n.currentIndex++
-		return true, nil
-	}
-
-	return false, nil
+	return group
 }
 
 func (n *groupNode) simpleExplain() (map[string]any, error) {
@@ -310,6 +379,8 @@ func (n *groupNode) excuteExplain() map[string]any {
 		"hiddenBeforeOffset":    n.execInfo.hiddenBeforeOffset,
 		"hiddenAfterLimit":      n.execInfo.hiddenAfterLimit,
 		"hiddenChildSelections": n.execInfo.hiddenBeforeOffset + n.execInfo.hiddenAfterLimit,
+		"spilledRuns":           n.execInfo.spilledRuns,
+		"spilledDocs":           n.execInfo.spilledDocs,
 	}
 }
 
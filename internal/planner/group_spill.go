@@ -0,0 +1,204 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/container"
+)
+
+// The fields of the documents wrapping the partial groups written to disk.
+const (
+	partialKeyField = iota
+	partialSeqField
+	partialHasParentField
+	partialGroupField
+)
+
+// The fields of the documents wrapping the merged groups written to disk.
+const (
+	mergedSeqField = iota
+	mergedGroupField
+)
+
+// groupSpiller writes the groups being built by a groupNode to disk once the memory budget
+// of the request is exceeded, and merges them back once all the documents have been grouped.
+//
+// Groups are spilled whilst they are still being built, so the same group may be spilled
+// more than once. The spilled parts of each group are first sorted by group key so that
+// they can be merged together, and the merged groups are then sorted by the position of
+// their first document so that they are yielded in the same order as if nothing was spilled.
+type groupSpiller struct {
+	budget       *container.MemoryBudget
+	childIndexes []int
+
+	// partials holds the partial groups, ordered by group key.
+	partials *container.Sorter
+	// groups holds the merged groups, ordered by the position of their first document.
+	groups *container.Sorter
+	// spilled is true if the groups of the map have been moved to the partials at least once.
+	spilled bool
+}
+
+func newGroupSpiller(budget *container.MemoryBudget, childIndexes []int) *groupSpiller {
+	return &groupSpiller{
+		budget:       budget,
+		childIndexes: childIndexes,
+		partials: container.NewSorter(budget, func(a, b core.Doc) int {
+			return strings.Compare(a.Fields[partialKeyField].(string), b.Fields[partialKeyField].(string))
+		}),
+		groups: container.NewSorter(budget, func(a, b core.Doc) int {
+			return cmp.Compare(a.Fields[mergedSeqField].(int64), b.Fields[mergedSeqField].(int64))
+		}),
+	}
+}
+
+// hasSpilled returns true if any group has been moved out of the map to be spilled.
+func (s *groupSpiller) hasSpilled() bool {
+	return s.spilled
+}
+
+// spill moves all the groups held by the given map to the partial groups, which are written
+// to disk once they hold enough of the memory budget, and clears the map.
+func (s *groupSpiller) spill(m *orderedMap) error {
+	s.spilled = true
+	return s.addPartials(m)
+}
+
+// finish merges the groups held by the given map with the spilled ones, after
+// which the merged groups can be read with next.
+func (s *groupSpiller) finish(m *orderedMap) error {
+	if err := s.addPartials(m); err != nil {
+		return err
+	}
+	if err := s.partials.Finish(); err != nil {
+		return err
+	}
+
+	var current []core.Doc
+	for {
+		hasNext, err := s.partials.Next()
+		if err != nil {
+			return err
+		}
+		if !hasNext {
+			break
+		}
+		partial := s.partials.Value()
+		if len(current) > 0 &&
+			current[0].Fields[partialKeyField] != partial.Fields[partialKeyField] {
+			if err := s.groups.Add(s.merge(current)); err != nil {
+				return err
+			}
+			current = current[:0]
+		}
+		current = append(current, partial)
+	}
+	if len(current) > 0 {
+		if err := s.groups.Add(s.merge(current)); err != nil {
+			return err
+		}
+	}
+	return s.groups.Finish()
+}
+
+// next moves to the next merged group, returning false once all have been read.
+func (s *groupSpiller) next() (core.Doc, bool, error) {
+	hasNext, err := s.groups.Next()
+	if err != nil || !hasNext {
+		return core.Doc{}, false, err
+	}
+	return s.groups.Value().Fields[mergedGroupField].(core.Doc), true, nil
+}
+
+// spilledRuns returns the number of runs written to disk.
+func (s *groupSpiller) spilledRuns() uint64 {
+	return uint64(s.partials.SpilledRuns() + s.groups.SpilledRuns())
+}
+
+// spilledDocs returns the number of partial and merged groups written to disk.
+func (s *groupSpiller) spilledDocs() uint64 {
+	return s.partials.SpilledDocs() + s.groups.SpilledDocs()
+}
+
+// close releases the groups held in memory and removes the spilled ones from disk.
+func (s *groupSpiller) close() error {
+	if err := s.partials.Close(); err != nil {
+		return err
+	}
+	return s.groups.Close()
+}
+
+// addPartials adds the groups held by the given map to the partial groups, and clears the map.
+//
+// The memory reserved by the map is released first, so that the partial groups are only
+// spilled if they hold the memory themselves.
+func (s *groupSpiller) addPartials(m *orderedMap) error {
+	values, keys, seqs, hasParent := m.values, m.keys, m.seqs, m.hasParent
+	m.reset()
+	for i, value := range values {
+		partial := core.Doc{
+			Fields: core.DocFields{keys[i], seqs[i], hasParent[i], value},
+		}
+		if err := s.partials.Add(partial); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge merges the given partial groups of the same key, in the order they were spilled,
+// into a single group wrapped with the position of its first document.
+func (s *groupSpiller) merge(partials []core.Doc) core.Doc {
+	// the parent fields are taken from the last partial group the parent was merged into,
+	// as each merge of the parent overwrites them.
+	base := 0
+	seq := partials[0].Fields[partialSeqField].(int64)
+	for i, partial := range partials {
+		if partial.Fields[partialHasParentField].(bool) {
+			base = i
+		}
+		seq = min(seq, partial.Fields[partialSeqField].(int64))
+	}
+
+	group := partials[base].Fields[partialGroupField].(core.Doc)
+	for _, childIndex := range s.childIndexes {
+		var children []core.Doc
+		found := false
+		for _, partial := range partials {
+			partialGroup := partial.Fields[partialGroupField].(core.Doc)
+			if childIndex >= len(partialGroup.Fields) || partialGroup.Fields[childIndex] == nil {
+				continue
+			}
+			children = append(children, partialGroup.Fields[childIndex].([]core.Doc)...)
+			found = true
+		}
+		if !found {
+			continue
+		}
+		if childIndex >= len(group.Fields) {
+			newFields := make(core.DocFields, childIndex+1)
+			copy(newFields, group.Fields)
+			group.Fields = newFields
+		}
+		if children == nil {
+			children = []core.Doc{}
+		}
+		group.Fields[childIndex] = children
+	}
+
+	return core.Doc{
+		Fields: core.DocFields{seq, group},
+	}
+}
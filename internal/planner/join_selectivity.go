@@ -0,0 +1,334 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/internal/connor"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/planner/filter"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+)
+
+// The costs below are rough estimates of the number of documents fetched to serve a
+// condition through an index, used when the collection has no statistics.
+const (
+	// uniqueIndexValueCost is the estimated number of documents fetched per value
+	// looked up in a unique index.
+	uniqueIndexValueCost = 1
+	// indexValueCost is the estimated number of documents fetched per value
+	// looked up in a non-unique index.
+	indexValueCost = 10
+	// indexRangeCost is the estimated number of documents fetched for a range of
+	// values scanned in an index.
+	indexRangeCost = 100
+	// relationFanOut is the estimated number of documents related to a single document.
+	relationFanOut = 10
+)
+
+// relationConditions returns the conditions that the objects related through the relation
+// field at the given index must match for the given conditions to match.
+//
+// Conditions within an _and are all collected, while an _or is only collected if every one
+// of its elements has conditions on the relation. Conditions within a _not are never collected.
+func relationConditions(conditions map[connor.FilterKey]any, relIndex int) map[connor.FilterKey]any {
+	result := map[connor.FilterKey]any{}
+	for key, cond := range conditions {
+		switch k := key.(type) {
+		case *mapper.PropertyIndex:
+			if relConds, ok := cond.(map[connor.FilterKey]any); ok && k.Index == relIndex {
+				addConjuncts(result, relConds)
+			}
+
+		case *mapper.Operator:
+			items, _ := cond.([]any)
+			switch k.Operation {
+			case request.FilterOpAnd:
+				for _, item := range items {
+					if itemConds, ok := item.(map[connor.FilterKey]any); ok {
+						addConjuncts(result, relationConditions(itemConds, relIndex))
+					}
+				}
+
+			case request.FilterOpOr:
+				disjuncts := make([]any, 0, len(items))
+				for _, item := range items {
+					itemConds, ok := item.(map[connor.FilterKey]any)
+					if !ok {
+						disjuncts = nil
+						break
+					}
+					relConds := relationConditions(itemConds, relIndex)
+					if len(relConds) == 0 {
+						// this element may match without the relation, so the _or implies nothing
+						disjuncts = nil
+						break
+					}
+					disjuncts = append(disjuncts, relConds)
+				}
+				if len(disjuncts) == 1 {
+					addConjuncts(result, disjuncts[0].(map[connor.FilterKey]any))
+				} else if len(disjuncts) > 1 {
+					result[&mapper.Operator{Operation: request.FilterOpOr}] = disjuncts
+				}
+			}
+		}
+	}
+	return rewriteOrAsIn(result)
+}
+
+// addConjuncts adds the given conditions to the target, flattening any _and on the way.
+func addConjuncts(target, conditions map[connor.FilterKey]any) {
+	for key, cond := range conditions {
+		if op, ok := key.(*mapper.Operator); ok && op.Operation == request.FilterOpAnd {
+			items, _ := cond.([]any)
+			for _, item := range items {
+				if itemConds, ok := item.(map[connor.FilterKey]any); ok {
+					addConjuncts(target, itemConds)
+				}
+			}
+			continue
+		}
+		target[key] = cond
+	}
+}
+
+// rewriteOrAsIn replaces every _or of _eq and _in conditions on a single field with one _in
+// condition on that field, so that it can be served by an index on the field.
+//
+// Eg. {_or: [{name: {_eq: "A"}}, {name: {_in: ["B", "C"]}}]} becomes {name: {_in: ["A", "B", "C"]}}
+func rewriteOrAsIn(conditions map[connor.FilterKey]any) map[connor.FilterKey]any {
+	for key, cond := range conditions {
+		op, ok := key.(*mapper.Operator)
+		if !ok || op.Operation != request.FilterOpOr {
+			continue
+		}
+		items, _ := cond.([]any)
+		prop, values, ok := orAsIn(items)
+		if !ok {
+			continue
+		}
+		delete(conditions, key)
+		conditions[prop] = map[connor.FilterKey]any{
+			&mapper.Operator{Operation: connor.InOp}: values,
+		}
+	}
+	return conditions
+}
+
+// orAsIn returns the field and the values of the _in condition equivalent to the
+// given elements of an _or, if there is one.
+func orAsIn(items []any) (*mapper.PropertyIndex, []any, bool) {
+	var prop *mapper.PropertyIndex
+	var values []any
+	for _, item := range items {
+		itemConds, ok := item.(map[connor.FilterKey]any)
+		if !ok || len(itemConds) != 1 {
+			return nil, nil, false
+		}
+		for key, cond := range itemConds {
+			itemProp, ok := key.(*mapper.PropertyIndex)
+			if !ok || (prop != nil && itemProp.Index != prop.Index) {
+				return nil, nil, false
+			}
+			prop = itemProp
+
+			opConds, ok := cond.(map[connor.FilterKey]any)
+			if !ok || len(opConds) != 1 {
+				return nil, nil, false
+			}
+			for opKey, value := range opConds {
+				op, ok := opKey.(*mapper.Operator)
+				if !ok {
+					return nil, nil, false
+				}
+				switch op.Operation {
+				case connor.EqualOp:
+					if value == nil {
+						return nil, nil, false
+					}
+					values = append(values, value)
+				case connor.InOp:
+					inValues, ok := value.([]any)
+					if !ok {
+						return nil, nil, false
+					}
+					values = append(values, inValues...)
+				default:
+					return nil, nil, false
+				}
+			}
+		}
+	}
+	if prop == nil {
+		return nil, nil, false
+	}
+	return &mapper.PropertyIndex{Index: prop.Index}, values, true
+}
+
+// estimateFilterCost returns the estimated number of documents of the given collection that need
+// to be fetched to find the documents matching the given conditions, either through an index on
+// one of its fields or through the related objects of one of its relations.
+//
+// It returns false if the conditions can't be served by any index.
+func (p *Planner) estimateFilterCost(
+	def client.CollectionDefinition,
+	mapping *core.DocumentMapping,
+	conditions map[connor.FilterKey]any,
+) (uint64, bool, error) {
+	cost, index, err := p.estimateIndexCost(def, mapping, conditions)
+	if err != nil {
+		return 0, false, err
+	}
+	found := index.HasValue()
+
+	for relIndex, prop := range filter.ExtractProperties(conditions) {
+		if !prop.IsRelation() || relIndex >= len(mapping.ChildMappings) || mapping.ChildMappings[relIndex] == nil {
+			continue
+		}
+		name, ok := mapping.TryToFindNameFromIndex(relIndex)
+		if !ok {
+			continue
+		}
+		fieldDef, ok := def.GetFieldByName(name)
+		if !ok || !fieldDef.Kind.IsObject() {
+			continue
+		}
+		relDef, ok, err := client.GetDefinitionFromStore(p.ctx, p.db, def, fieldDef.Kind)
+		if err != nil {
+			return 0, false, err
+		}
+		if !ok {
+			continue
+		}
+		relCost, ok, err := p.estimateFilterCost(
+			relDef,
+			mapping.ChildMappings[relIndex],
+			relationConditions(conditions, relIndex),
+		)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok && (!found || relCost*relationFanOut < cost) {
+			cost = relCost * relationFanOut
+			found = true
+		}
+	}
+	return cost, found, nil
+}
+
+// estimateIndexCost returns the estimated number of documents of the given collection fetched
+// through the cheapest index that serves one of the top-level field conditions, along with the index.
+func (p *Planner) estimateIndexCost(
+	def client.CollectionDefinition,
+	mapping *core.DocumentMapping,
+	conditions map[connor.FilterKey]any,
+) (uint64, immutable.Option[client.IndexDescription], error) {
+	fieldConds := make(map[string]map[connor.FilterKey]any)
+	for key, cond := range conditions {
+		prop, ok := key.(*mapper.PropertyIndex)
+		if !ok {
+			continue
+		}
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		if name, ok := mapping.TryToFindNameFromIndex(prop.Index); ok {
+			fieldConds[name] = condMap
+		}
+	}
+
+	if len(fieldConds) == 0 {
+		return 0, immutable.None[client.IndexDescription](), nil
+	}
+
+	stats, err := p.getStatistics(def)
+	if err != nil {
+		return 0, immutable.None[client.IndexDescription](), err
+	}
+
+	f := &mapper.Filter{Conditions: conditions}
+	f.ExternalConditions = f.ToMap(mapping)
+
+	var cost uint64
+	var result immutable.Option[client.IndexDescription]
+	// fields are visited in schema order so that the same index is chosen every time
+	for _, field := range def.Schema.Fields {
+		condMap, ok := fieldConds[field.Name]
+		if !ok {
+			continue
+		}
+		for _, index := range def.Description.GetIndexesOnField(field.Name) {
+			if len(index.Fields[0].JSONPath) > 0 || !isIndexUsable(index, f) {
+				continue
+			}
+			indexCost, ok := estimateIndexedFieldCost(index, condMap, stats)
+			if ok && (!result.HasValue() || indexCost < cost) {
+				cost = indexCost
+				result = immutable.Some(index)
+			}
+		}
+	}
+	return cost, result, nil
+}
+
+// estimateIndexedFieldCost returns the estimated number of documents fetched through the given
+// index to serve the given conditions on its first field.
+func estimateIndexedFieldCost(
+	index client.IndexDescription,
+	conditions map[connor.FilterKey]any,
+	stats immutable.Option[client.CollectionStatistics],
+) (uint64, bool) {
+	valueCost := indexValueRows(index, stats)
+
+	var cost uint64
+	found := false
+	for key, value := range conditions {
+		op, ok := key.(*mapper.Operator)
+		if !ok {
+			continue
+		}
+		var opCost uint64
+		switch op.Operation {
+		case connor.EqualOp:
+			opCost = valueCost
+		case connor.InOp:
+			values, ok := value.([]any)
+			if !ok {
+				continue
+			}
+			opCost = valueCost * uint64(len(values))
+		case connor.GreaterOp, connor.GreaterOrEqualOp, connor.LesserOp, connor.LesserOrEqualOp:
+			opCost = indexRangeRows(stats)
+		default:
+			continue
+		}
+		if !found || opCost < cost {
+			cost = opCost
+			found = true
+		}
+	}
+	return cost, found
+}
+
+// estimateScanCost returns the estimated number of documents fetched by the given scan
+// if it serves its filter through an index.
+func (p *Planner) estimateScanCost(scan *scanNode) (uint64, bool, error) {
+	if scan == nil || !scan.index.HasValue() || scan.indexFilter == nil {
+		return 0, false, nil
+	}
+	cost, index, err := p.estimateIndexCost(scan.col.Definition(), scan.documentMapping, scan.indexFilter.Conditions)
+	return cost, index.HasValue(), err
+}
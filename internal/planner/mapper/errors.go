@@ -16,6 +16,8 @@ const (
 	errInvalidFieldToGroupBy string = "invalid field value to groupBy"
 	errTypeNotFound          string = "type not found"
 	errFieldOrAliasNotFound  string = "field or alias not found"
+	errInvalidCursor         string = "invalid cursor"
+	errInvalidPageInfoTarget string = "page info must be of a sibling collection or relation list selection"
 )
 
 var (
@@ -38,3 +40,15 @@ func NewErrTypeNotFound(name string) error {
 func NewErrFieldOrAliasNotFound(name string) error {
 	return errors.New(errFieldOrAliasNotFound, errors.NewKV("Name", name))
 }
+
+func NewErrInvalidCursor(cursor string, innerErr ...error) error {
+	kvs := []errors.KV{errors.NewKV("Cursor", cursor)}
+	if len(innerErr) > 0 {
+		kvs = append(kvs, errors.NewKV("InnerErr", innerErr[0]))
+	}
+	return errors.New(errInvalidCursor, kvs...)
+}
+
+func NewErrInvalidPageInfoTarget(of string) error {
+	return errors.New(errInvalidPageInfoTarget, errors.NewKV("Of", of))
+}
@@ -80,11 +80,29 @@ func ToOperation(
 			operation.Mutations = append(operation.Mutations, m)
 			operation.addSelection(i, t.Field, m.Select)
 
+		case *request.PageInfo:
+			operation.PageInfos = append(operation.PageInfos, toPageInfo(i, t, operation.DocumentMapping))
+
 		default:
 			return nil, ErrInvalidSelect
 		}
 	}
 
+	selects := make([]Requestable, 0, len(operation.Selects)+len(operation.PageInfos))
+	for _, s := range operation.Selects {
+		selects = append(selects, s)
+	}
+	for _, pageInfo := range operation.PageInfos {
+		selects = append(selects, pageInfo)
+	}
+	err := resolvePageInfoTargets(operationRequest.Selections, operation.DocumentMapping, selects, func(s *Select) bool {
+		_, isAggregate := request.Aggregates[s.Name]
+		return !isAggregate
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return operation, nil
 }
 
@@ -130,11 +148,28 @@ func toSelect(
 		return nil, err
 	}
 
+	var pagination *Pagination
+	if rootSelectType == ObjectSelection {
+		// Needs to be done before resolving the filter, as cursors may add seek conditions to it
+		pagination, selectRequest, err = toPagination(ctx, store, selectRequest, definition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	fields, aggregates, err := getRequestables(ctx, rootSelectType, selectRequest, mapping, collectionName, store)
 	if err != nil {
 		return nil, err
 	}
 
+	err = resolvePageInfoTargets(selectRequest.Fields, mapping, fields, func(s *Select) bool {
+		field, ok := definition.GetFieldByName(s.Name)
+		return ok && field.Kind.IsObject() && field.Kind.IsArray()
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Needs to be done before resolving aggregates, else filter conversion may fail there
 	filterDependencies, err := resolveFilterDependencies(
 		ctx, store, rootSelectType, collectionName, selectRequest.Filter, mapping, fields)
@@ -216,8 +251,10 @@ func toSelect(
 		Targetable:      targetable,
 		DocumentMapping: mapping,
 		Cid:             selectRequest.CID,
+		AsOf:            selectRequest.AsOf,
 		CollectionName:  collectionName,
 		Fields:          fields,
+		Pagination:      pagination,
 	}, nil
 }
 
@@ -789,6 +826,9 @@ func getRequestables(
 			})
 
 			mapping.Add(index, f.Name)
+		case *request.PageInfo:
+			index := mapping.GetNextIndex()
+			fields = append(fields, toPageInfo(index, f, mapping))
 		case *request.Aggregate:
 			index := mapping.GetNextIndex()
 			aggregateRequest, err := getAggregateRequests(index, f)
@@ -945,6 +985,9 @@ func getTopLevelInfo(
 		mapping.SetTypeName(collectionName)
 
 		mapping.Add(mapping.GetNextIndex(), request.DeletedFieldName)
+		mapping.Add(mapping.GetNextIndex(), request.CursorFieldName)
+		mapping.Add(mapping.GetNextIndex(), request.KindFieldName)
+		mapping.Add(mapping.GetNextIndex(), request.ChangesFieldName)
 
 		return mapping, definition, nil
 	}
@@ -1297,12 +1340,15 @@ func toMutation(
 		return nil, err
 	}
 	return &Mutation{
-		Select:        *underlyingSelect,
-		Type:          MutationType(mutationRequest.Type),
-		CreateInput:   mutationRequest.CreateInput,
-		UpdateInput:   mutationRequest.UpdateInput,
-		Encrypt:       mutationRequest.Encrypt,
-		EncryptFields: mutationRequest.EncryptFields,
+		Select:         *underlyingSelect,
+		Type:           MutationType(mutationRequest.Type),
+		CreateInput:    mutationRequest.CreateInput,
+		UpdateInput:    mutationRequest.UpdateInput,
+		AddInput:       mutationRequest.AddInput,
+		RemoveInput:    mutationRequest.RemoveInput,
+		TextPatchInput: mutationRequest.TextPatchInput,
+		Encrypt:        mutationRequest.Encrypt,
+		EncryptFields:  mutationRequest.EncryptFields,
 	}, nil
 }
 
@@ -1386,8 +1432,9 @@ func toFilterKeyValue(
 			Operation: sourceKey,
 		}
 		// if the operator is simple (not compound) then
-		// it does not require further expansion
-		if connor.IsOpSimple(sourceKey) {
+		// it does not require further expansion, the same goes for
+		// invalid JSON paths that could not be expanded
+		if connor.IsOpSimple(sourceKey) || sourceKey == request.FilterOpPath {
 			return returnKey, sourceClause
 		}
 	} else {
@@ -1413,6 +1460,11 @@ func toFilterMap(
 	sourceClause map[string]any,
 	mapping *core.DocumentMapping,
 ) map[connor.FilterKey]any {
+	if _, isOp := sourceKey.(*Operator); !isOp {
+		if path, ok := request.FilterPath(sourceClause[request.FilterOpPath]); ok {
+			return toPathFilterMap(path, sourceClause)
+		}
+	}
 	innerMapClause := make(map[connor.FilterKey]any)
 	for innerSourceKey, innerSourceValue := range sourceClause {
 		var innerMapping *core.DocumentMapping
@@ -1441,6 +1493,22 @@ func toFilterMap(
 	return innerMapClause
 }
 
+// toPathFilterMap converts a JSON path filter clause into the equivalent clause of nested
+// object properties, so that `{_path: ["a", "b"], _eq: 5}` becomes `{a: {b: {_eq: 5}}}`.
+func toPathFilterMap(path []string, sourceClause map[string]any) map[connor.FilterKey]any {
+	innerSourceClause := make(map[string]any, len(sourceClause)-1)
+	for key, value := range sourceClause {
+		if key != request.FilterOpPath {
+			innerSourceClause[key] = value
+		}
+	}
+	result := toFilterMap(&ObjectProperty{}, innerSourceClause, nil)
+	for i := len(path) - 1; i >= 0; i-- {
+		result = map[connor.FilterKey]any{&ObjectProperty{Name: path[i]}: result}
+	}
+	return result
+}
+
 func toFilterList(sourceClause []any, mapping *core.DocumentMapping) []any {
 	returnClauses := make([]any, len(sourceClause))
 	for i, innerSourceClause := range sourceClause {
@@ -1560,6 +1628,73 @@ func RunFilter(doc any, filter *Filter) (bool, error) {
 	return connor.Match(filter.Conditions, doc)
 }
 
+// RunFilterOnValues runs the given request filter against the given field values, keyed
+// by field name.
+//
+// The filter may only be evaluated if it exclusively targets the given values, if it targets
+// anything else, for example a related object, false will be returned for `ok`.
+func RunFilterOnValues(source request.Filter, values map[string]any) (passes bool, ok bool, err error) {
+	if !targetsOnlyValues(source.Conditions, values) {
+		return false, false, nil
+	}
+
+	mapping := core.NewDocumentMapping()
+	doc := core.Doc{}
+	for name, value := range values {
+		mapping.Add(len(doc.Fields), name)
+		doc.Fields = append(doc.Fields, value)
+	}
+
+	passes, err = RunFilter(doc, ToFilter(source, mapping))
+	if err != nil {
+		return false, false, err
+	}
+	return passes, true, nil
+}
+
+// targetsOnlyValues returns true if the given conditions only target properties present
+// within the given values.
+func targetsOnlyValues(conditions map[string]any, values map[string]any) bool {
+	for key, clause := range conditions {
+		switch key {
+		case request.FilterOpAnd, request.FilterOpOr:
+			innerClauses, ok := clause.([]any)
+			if !ok {
+				return false
+			}
+			for _, innerClause := range innerClauses {
+				innerConditions, ok := innerClause.(map[string]any)
+				if !ok || !targetsOnlyValues(innerConditions, values) {
+					return false
+				}
+			}
+
+		case request.FilterOpNot:
+			innerConditions, ok := clause.(map[string]any)
+			if !ok || !targetsOnlyValues(innerConditions, values) {
+				return false
+			}
+
+		default:
+			if _, ok := values[key]; !ok {
+				return false
+			}
+			// Only operators may be applied to the value, anything else targets a
+			// property of the value.
+			fieldConditions, ok := clause.(map[string]any)
+			if !ok {
+				return false
+			}
+			for op := range fieldConditions {
+				if !strings.HasPrefix(op, "_") {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
 // equal compares the given Targetables and returns true if they can be considered equal.
 func (s Targetable) equal(other Targetable) bool {
 	if s.Index != other.Index &&
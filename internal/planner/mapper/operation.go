@@ -31,6 +31,9 @@ type Operation struct {
 
 	// CommitSelects is the list of commit selections in the operation.
 	CommitSelects []*CommitSelect
+
+	// PageInfos is the list of page infos of selections in the operation.
+	PageInfos []*PageInfo
 }
 
 // addSelection adds a new selection to the operation's document mapping.
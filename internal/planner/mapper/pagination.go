@@ -0,0 +1,474 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package mapper
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/encoding"
+)
+
+// Pagination represents the cursor-based pagination of a [Select].
+type Pagination struct {
+	// First caps the number of results to the given number, taken from the start
+	// of the results.
+	First immutable.Option[uint64]
+
+	// Last caps the number of results to the given number, taken from the end
+	// of the results.
+	Last immutable.Option[uint64]
+
+	// After restricts the results to those positioned after the given cursor.
+	After immutable.Option[Cursor]
+
+	// Before restricts the results to those positioned before the given cursor.
+	Before immutable.Option[Cursor]
+}
+
+// Cursor marks the position of a document within an ordered set of results.
+type Cursor struct {
+	// Values contains the values of the fields the results are ordered by, in the
+	// order of the order conditions.
+	Values []any
+
+	// DocID is the ID of the document, it is used to break ties between documents
+	// with equal order values.
+	DocID string
+}
+
+// Encode returns the opaque string representation of this cursor.
+//
+// The order values are encoded using the same order-preserving encoding as index keys,
+// this way the types of the values survive the round trip.
+func (c Cursor) Encode() (string, error) {
+	var b []byte
+	for _, value := range c.Values {
+		if value == nil {
+			b = encoding.EncodeNullAscending(b)
+			continue
+		}
+		normalValue, err := client.NewNormalValue(value)
+		if err != nil {
+			return "", err
+		}
+		b = encoding.EncodeFieldValue(b, normalValue, false)
+	}
+	b = encoding.EncodeStringAscending(b, c.DocID)
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes the given string, as returned by [Cursor.Encode], into a [Cursor].
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, NewErrInvalidCursor(s, err)
+	}
+
+	var values []any
+	for len(b) > 0 {
+		if encoding.PeekType(b) == encoding.Null {
+			b, _ = encoding.DecodeIfNull(b)
+			values = append(values, nil)
+			continue
+		}
+		var value client.NormalValue
+		b, value, err = encoding.DecodeFieldValue(b, false, client.FieldKind_NILLABLE_STRING)
+		if err != nil {
+			return Cursor{}, NewErrInvalidCursor(s, err)
+		}
+		values = append(values, value.Unwrap())
+	}
+
+	if len(values) == 0 {
+		return Cursor{}, NewErrInvalidCursor(s)
+	}
+	docID, ok := values[len(values)-1].(string)
+	if !ok {
+		return Cursor{}, NewErrInvalidCursor(s)
+	}
+
+	return Cursor{
+		Values: values[:len(values)-1],
+		DocID:  docID,
+	}, nil
+}
+
+// toPagination converts the pagination arguments of the given request into a [Pagination].
+//
+// It returns nil if the request neither paginates nor selects the cursor of its documents.  A select
+// that the page info is requested of is paginated regardless, see [resolvePageInfoTargets].
+//
+// If a cursor is provided, a condition seeking to the cursor position is added to the
+// filter of the returned request so that the scan (and index, if any) may skip the documents
+// before it.  The given request is left untouched, a copy is returned if the filter changed.
+func toPagination(
+	ctx context.Context,
+	store client.Store,
+	selectRequest *request.Select,
+	definition client.CollectionDefinition,
+) (*Pagination, *request.Select, error) {
+	if !selectRequest.HasPaging() && !selectsPaginationFields(selectRequest) {
+		return nil, selectRequest, nil
+	}
+
+	var orderConditions []request.OrderCondition
+	if selectRequest.OrderBy.HasValue() {
+		orderConditions = selectRequest.OrderBy.Value().Conditions
+	}
+
+	pagination := &Pagination{
+		First: selectRequest.First,
+		Last:  selectRequest.Last,
+	}
+
+	filter := selectRequest.Filter
+	hasSeekCondition := false
+
+	if selectRequest.After.HasValue() {
+		cursor, err := decodeCursorFor(ctx, store, selectRequest.After.Value(), definition, orderConditions)
+		if err != nil {
+			return nil, nil, err
+		}
+		pagination.After = immutable.Some(cursor)
+		var added bool
+		filter, added = withCursorSeekCondition(filter, definition, orderConditions, cursor, true)
+		hasSeekCondition = hasSeekCondition || added
+	}
+
+	if selectRequest.Before.HasValue() {
+		cursor, err := decodeCursorFor(ctx, store, selectRequest.Before.Value(), definition, orderConditions)
+		if err != nil {
+			return nil, nil, err
+		}
+		pagination.Before = immutable.Some(cursor)
+		var added bool
+		filter, added = withCursorSeekCondition(filter, definition, orderConditions, cursor, false)
+		hasSeekCondition = hasSeekCondition || added
+	}
+
+	if hasSeekCondition {
+		seekRequest := *selectRequest
+		seekRequest.Filter = filter
+		selectRequest = &seekRequest
+	}
+	return pagination, selectRequest, nil
+}
+
+// decodeCursorFor decodes the given cursor and ensures it is usable with the given ordering.
+//
+// The values of the cursor must be of the types of the fields they are ordered by.  The types
+// of fields that are not resolvable from the collection, such as aliased aggregates, are
+// checked against the documents by the pagination plan node.
+func decodeCursorFor(
+	ctx context.Context,
+	store client.Store,
+	s string,
+	definition client.CollectionDefinition,
+	orderConditions []request.OrderCondition,
+) (Cursor, error) {
+	cursor, err := DecodeCursor(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if len(cursor.Values) != len(orderConditions) {
+		return Cursor{}, NewErrInvalidCursor(s)
+	}
+	for i, condition := range orderConditions {
+		kind, ok, err := getOrderFieldKind(ctx, store, definition, condition.Fields)
+		if err != nil {
+			return Cursor{}, err
+		}
+		if ok && !isCursorValueOfKind(cursor.Values[i], kind) {
+			return Cursor{}, NewErrInvalidCursor(s)
+		}
+	}
+	return cursor, nil
+}
+
+// getOrderFieldKind returns the kind of the field at the given order path, and true if the
+// field could be found.
+func getOrderFieldKind(
+	ctx context.Context,
+	store client.Store,
+	definition client.CollectionDefinition,
+	path []string,
+) (client.FieldKind, bool, error) {
+	for i, name := range path {
+		if name == request.DocIDFieldName && i == len(path)-1 {
+			return client.FieldKind_DocID, true, nil
+		}
+		field, ok := definition.GetFieldByName(name)
+		if !ok {
+			return nil, false, nil
+		}
+		if i == len(path)-1 {
+			return field.Kind, true, nil
+		}
+		if !field.Kind.IsObject() {
+			return nil, false, nil
+		}
+		var found bool
+		var err error
+		definition, found, err = client.GetDefinitionFromStore(ctx, store, definition, field.Kind)
+		if err != nil || !found {
+			return nil, false, err
+		}
+	}
+	return nil, false, nil
+}
+
+// isCursorValueOfKind returns true if the given value, as decoded from a cursor, may be a value
+// of a field of the given kind.
+func isCursorValueOfKind(value any, kind client.FieldKind) bool {
+	if value == nil {
+		return true
+	}
+	switch kind {
+	case client.FieldKind_NILLABLE_BOOL, client.FieldKind_NILLABLE_INT:
+		// bools are encoded as integers within cursors
+		_, ok := value.(int64)
+		return ok
+	case client.FieldKind_NILLABLE_FLOAT:
+		switch value.(type) {
+		case int64, float64:
+			return true
+		default:
+			return false
+		}
+	case client.FieldKind_DocID, client.FieldKind_NILLABLE_STRING, client.FieldKind_NILLABLE_BLOB:
+		_, ok := value.(string)
+		return ok
+	case client.FieldKind_NILLABLE_DATETIME:
+		_, ok := value.(time.Time)
+		return ok
+	default:
+		return true
+	}
+}
+
+// selectsPaginationFields returns true if the cursor of the documents has been requested.
+func selectsPaginationFields(selectRequest *request.Select) bool {
+	for _, field := range selectRequest.Fields {
+		if f, ok := field.(*request.Field); ok && f.Name == request.CursorFieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// withCursorSeekCondition returns a copy of the given filter with an added condition on the
+// primary order field that excludes (most of) the documents positioned on the wrong side of
+// the cursor, and true if the condition was added.
+//
+// The condition is only a coarse seek that allows the scan to use an index, the exact cursor
+// position is enforced by the pagination plan node.  Documents with the same value as the cursor
+// are only excluded if the value is unique to the document of the cursor.  If a safe condition
+// cannot be built the given filter is returned.
+func withCursorSeekCondition(
+	filter immutable.Option[request.Filter],
+	definition client.CollectionDefinition,
+	orderConditions []request.OrderCondition,
+	cursor Cursor,
+	isAfter bool,
+) (immutable.Option[request.Filter], bool) {
+	if len(orderConditions) == 0 {
+		// Results without any order conditions are ordered by document ID, which the
+		// scan cannot seek to.
+		return filter, false
+	}
+
+	condition := orderConditions[0]
+	if len(condition.Fields) != 1 || cursor.Values[0] == nil {
+		return filter, false
+	}
+	// Nil values sort first, so only the side of the cursor that excludes nil values
+	// may be expressed as a simple comparison.
+	if isAfter != (condition.Direction == request.ASC) {
+		return filter, false
+	}
+	field, ok := definition.GetFieldByName(condition.Fields[0])
+	if !ok || !isSeekableKind(field.Kind) {
+		return filter, false
+	}
+	fieldName := field.Name
+	value := cursor.Values[0]
+	op := request.FilterOpGreaterOrEqual
+	if isUniqueField(definition, fieldName) {
+		op = request.FilterOpGreater
+	}
+
+	var conditions map[string]any
+	if filter.HasValue() {
+		conditions = make(map[string]any, len(filter.Value().Conditions)+1)
+		for key, existing := range filter.Value().Conditions {
+			conditions[key] = existing
+		}
+	} else {
+		conditions = map[string]any{}
+	}
+
+	switch existing := conditions[fieldName].(type) {
+	case nil:
+		conditions[fieldName] = map[string]any{op: value}
+	case map[string]any:
+		if _, hasOp := existing[op]; hasOp {
+			return filter, false
+		}
+		fieldConditions := make(map[string]any, len(existing)+1)
+		for key, existingValue := range existing {
+			fieldConditions[key] = existingValue
+		}
+		fieldConditions[op] = value
+		conditions[fieldName] = fieldConditions
+	default:
+		return filter, false
+	}
+
+	return immutable.Some(request.Filter{Conditions: conditions}), true
+}
+
+// isUniqueField returns true if the values of the field with the given name are unique to
+// their document.
+func isUniqueField(definition client.CollectionDefinition, fieldName string) bool {
+	for _, index := range definition.Description.Indexes {
+		if index.Unique && len(index.Fields) == 1 && index.Fields[0].Name == fieldName &&
+			len(index.Fields[0].JSONPath) == 0 && len(index.Filter) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func isSeekableKind(kind client.FieldKind) bool {
+	switch kind {
+	case client.FieldKind_NILLABLE_INT,
+		client.FieldKind_NILLABLE_FLOAT,
+		client.FieldKind_NILLABLE_DATETIME:
+		return true
+	default:
+		return false
+	}
+}
+
+// PageInfo represents the page info of a sibling [Select].
+type PageInfo struct {
+	Field
+
+	// Target is the index of the select the page info describes.
+	Target int
+}
+
+func (p *PageInfo) CloneTo(index int) Requestable {
+	return &PageInfo{
+		Field:  *p.Field.cloneTo(index),
+		Target: p.Target,
+	}
+}
+
+// toPageInfo maps the given page info request onto the given index of the host mapping.
+//
+// Page info is not a relation, it is computed by the pagination plan node of its target, so it
+// is mapped as a plain field with a child mapping describing the page info properties.  The target
+// is resolved by [resolvePageInfoTargets] once all the siblings have been mapped.
+func toPageInfo(index int, pageInfoRequest *request.PageInfo, mapping *core.DocumentMapping) *PageInfo {
+	childMapping := core.NewDocumentMapping()
+	for i, name := range request.PageInfoFields {
+		childMapping.Add(i, name)
+	}
+	childMapping.SetTypeName(request.PageInfoTypeName)
+
+	for _, f := range pageInfoRequest.Fields {
+		childMapping.RenderKeys = append(childMapping.RenderKeys, core.RenderKey{
+			Index: childMapping.FirstIndexOfName(f.Name),
+			Key:   getRenderKey(f),
+		})
+	}
+
+	mapping.SetChildAt(index, childMapping)
+	mapping.Add(index, pageInfoRequest.Name)
+	mapping.RenderKeys = append(mapping.RenderKeys, core.RenderKey{
+		Index: index,
+		Key:   getRenderKey(&pageInfoRequest.Field),
+	})
+
+	return &PageInfo{
+		Field: Field{
+			Index: index,
+			Name:  pageInfoRequest.Name,
+		},
+	}
+}
+
+// resolvePageInfoTargets sets the targets of the page infos requested by the given requests
+// to the sibling selects rendered under the keys given by their 'of' arguments, and ensures the
+// targets are paginated.
+//
+// The isPaginable function returns true if the given select yields a set of documents that may
+// be paginated.
+func resolvePageInfoTargets(
+	requests []request.Selection,
+	mapping *core.DocumentMapping,
+	siblings []Requestable,
+	isPaginable func(*Select) bool,
+) error {
+	for _, field := range requests {
+		pageInfoRequest, ok := field.(*request.PageInfo)
+		if !ok {
+			continue
+		}
+		index, _ := renderedIndexOf(mapping, getRenderKey(&pageInfoRequest.Field))
+		targetIndex, ok := renderedIndexOf(mapping, pageInfoRequest.Of)
+		if !ok {
+			return NewErrInvalidPageInfoTarget(pageInfoRequest.Of)
+		}
+
+		var pageInfo *PageInfo
+		var target *Select
+		for _, sibling := range siblings {
+			switch s := sibling.(type) {
+			case *PageInfo:
+				if s.Index == index {
+					pageInfo = s
+				}
+			case *Select:
+				if s.Index == targetIndex && isPaginable(s) {
+					target = s
+				}
+			}
+		}
+		if pageInfo == nil || target == nil {
+			return NewErrInvalidPageInfoTarget(pageInfoRequest.Of)
+		}
+
+		if target.Pagination == nil {
+			target.Pagination = &Pagination{}
+		}
+		pageInfo.Target = target.Index
+	}
+	return nil
+}
+
+// renderedIndexOf returns the index rendered under the given key.
+func renderedIndexOf(mapping *core.DocumentMapping, key string) (int, bool) {
+	for _, renderKey := range mapping.RenderKeys {
+		if renderKey.Key == key {
+			return renderKey.Index, true
+		}
+	}
+	return 0, false
+}
@@ -13,6 +13,7 @@ package mapper
 import (
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/internal/core"
 )
 
@@ -30,6 +31,10 @@ type Select struct {
 	// A commit identifier that can be specified to request data at a given time.
 	Cid immutable.Option[string]
 
+	// A point in the history of the documents that can be specified to request data
+	// as it was at that point.
+	AsOf immutable.Option[request.AsOf]
+
 	// The name of the collection that this Select selects data from.
 	CollectionName string
 
@@ -39,6 +44,10 @@ type Select struct {
 	// Selects.
 	Fields []Requestable
 
+	// Pagination holds the cursor-based pagination of this Select, it is nil if
+	// the Select is neither paginated nor selects any of the pagination fields.
+	Pagination *Pagination
+
 	// SkipResolve is a flag that indicates that the fields in this Select don't need to be resolved,
 	// i.e. it's value doesn't need to be fetched and provided to the user.
 	// It is used to avoid resolving related objects if they are used only in a filter and not requested in a response.
@@ -62,8 +71,10 @@ func (s *Select) cloneTo(index int) *Select {
 		Targetable:      *s.Targetable.cloneTo(index),
 		DocumentMapping: s.DocumentMapping,
 		Cid:             s.Cid,
+		AsOf:            s.AsOf,
 		CollectionName:  s.CollectionName,
 		Fields:          s.Fields,
+		Pagination:      s.Pagination,
 	}
 }
 
@@ -73,12 +73,14 @@ type ObjectProperty struct {
 }
 
 func (k *ObjectProperty) PropertyAndOperator(data any, defaultOp string) (any, string, error) {
-	if data == nil {
-		return nil, defaultOp, nil
+	if _, ok := data.(core.Doc); ok {
+		return nil, defaultOp, NewErrFieldOrAliasNotFound(k.Name)
 	}
+	// Values that are not objects, such as JSON scalars, have no properties
+	// and are treated the same as objects without the property.
 	docMap, ok := data.(map[string]any)
 	if !ok {
-		return nil, defaultOp, NewErrFieldOrAliasNotFound(k.Name)
+		return nil, defaultOp, nil
 	}
 	return docMap[k.Name], defaultOp, nil
 }
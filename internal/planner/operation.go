@@ -25,8 +25,9 @@ type operationNode struct {
 	documentIterator
 	docMapper
 
-	children map[int]planNode
-	isDone   bool
+	children  map[int]planNode
+	pageInfos []*mapper.PageInfo
+	isDone    bool
 }
 
 func (n *operationNode) Prefixes(prefixes []keys.Walkable) {
@@ -118,6 +119,17 @@ func (n *operationNode) Next() (bool, error) {
 		}
 	}
 
+	// The page infos may only be set once their targets have been consumed.
+	for _, pageInfo := range n.pageInfos {
+		target, ok := n.children[pageInfo.Target].(*selectTopNode)
+		if !ok || target.pagination == nil {
+			return false, ErrMissingChildValue
+		}
+		n.currentValue.Fields[pageInfo.Index] = target.pagination.pageInfo(
+			n.documentMapping.ChildMappings[pageInfo.Index],
+		)
+	}
+
 	n.isDone = true
 	return true, nil
 }
@@ -164,5 +176,6 @@ func (p *Planner) Operation(operation *mapper.Operation) (*operationNode, error)
 	return &operationNode{
 		docMapper: docMapper{operation.DocumentMapping},
 		children:  children,
+		pageInfos: operation.PageInfos,
 	}, nil
 }
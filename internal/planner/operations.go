@@ -22,6 +22,7 @@ var (
 	_ planNode = (*minNode)(nil)
 	_ planNode = (*multiScanNode)(nil)
 	_ planNode = (*orderNode)(nil)
+	_ planNode = (*paginationNode)(nil)
 	_ planNode = (*parallelNode)(nil)
 	_ planNode = (*pipeNode)(nil)
 	_ planNode = (*scanNode)(nil)
@@ -34,7 +35,6 @@ var (
 	_ planNode = (*typeJoinOne)(nil)
 	_ planNode = (*updateNode)(nil)
 	_ planNode = (*upsertNode)(nil)
-	_ planNode = (*valuesNode)(nil)
 	_ planNode = (*viewNode)(nil)
 	_ planNode = (*lensNode)(nil)
 
@@ -11,9 +11,13 @@
 package planner
 
 import (
+	"container/heap"
+	"slices"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/container"
 	"github.com/sourcenetwork/defradb/internal/keys"
 	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 )
@@ -31,12 +35,10 @@ type orderingStrategy interface {
 	valueIterator
 	// Add a document to the strategy node.
 	// copies data if its needed.
-	// Ideally stores inside a valuesNode
-	// rowContainer buffer.
 	Add(core.Doc) error
 	// Finish finalizes and applies the actual
 	// ordering mechanism to all the stored data.
-	Finish()
+	Finish() error
 }
 
 // order the results
@@ -48,6 +50,14 @@ type orderNode struct {
 
 	ordering []mapper.OrderCondition
 
+	// limit is the maximum number of documents that may be yielded, if greater than zero.
+	// Only these documents need to be retained whilst sorting.
+	limit uint64
+
+	// isSourceOrdered is true if the plan already yields the documents in the
+	// requested order, in which case they are passed through without sorting.
+	isSourceOrdered bool
+
 	// simplified planNode interface
 	// used for iterating through
 	// an already sorted plan
@@ -68,6 +78,12 @@ type orderNode struct {
 type orderExecInfo struct {
 	// Total number of times orderNode was executed.
 	iterations uint64
+
+	// Total number of sorted runs spilled to disk.
+	spilledRuns uint64
+
+	// Total number of documents spilled to disk.
+	spilledDocs uint64
 }
 
 // OrderBy creates a new orderNode which returns the underlying
@@ -93,8 +109,15 @@ func (n *orderNode) Kind() string {
 
 func (n *orderNode) Init() error {
 	// reset stateful data
+	if n.orderStrategy != nil {
+		// release the documents, and any spilled runs, of the previous execution
+		if err := n.orderStrategy.Close(); err != nil {
+			return err
+		}
+	}
 	n.needSort = true
 	n.orderStrategy = nil
+	n.valueIter = nil
 	return n.plan.Init()
 }
 func (n *orderNode) Start() error { return n.plan.Start() }
@@ -102,6 +125,9 @@ func (n *orderNode) Start() error { return n.plan.Start() }
 func (n *orderNode) Prefixes(prefixes []keys.Walkable) { n.plan.Prefixes(prefixes) }
 
 func (n *orderNode) Value() core.Doc {
+	if n.isSourceOrdered {
+		return n.plan.Value()
+	}
 	return n.valueIter.Value()
 }
 
@@ -150,7 +176,9 @@ func (n *orderNode) Explain(explainType request.ExplainType) (map[string]any, er
 
 	case request.ExecuteExplain:
 		return map[string]any{
-			"iterations": n.execInfo.iterations,
+			"iterations":  n.execInfo.iterations,
+			"spilledRuns": n.execInfo.spilledRuns,
+			"spilledDocs": n.execInfo.spilledDocs,
 		}, nil
 
 	default:
@@ -161,11 +189,18 @@ func (n *orderNode) Explain(explainType request.ExplainType) (map[string]any, er
 func (n *orderNode) Next() (bool, error) {
 	n.execInfo.iterations++
 
+	if n.isSourceOrdered {
+		return n.plan.Next()
+	}
+
 	for n.needSort {
 		// make sure our orderStrategy is initialized
 		if n.orderStrategy == nil {
-			v := n.p.newContainerValuesNode(n.ordering)
-			n.orderStrategy = newAllSortStrategy(v)
+			if n.limit > 0 {
+				n.orderStrategy = newTopNSortStrategy(n.ordering, n.limit)
+			} else {
+				n.orderStrategy = newAllSortStrategy(n.p.memory, n.ordering)
+			}
 		}
 
 		// consume data (from plan) (Next / Values())
@@ -174,7 +209,13 @@ func (n *orderNode) Next() (bool, error) {
 			return false, err
 		}
 		if !next {
-			n.orderStrategy.Finish()
+			if err := n.orderStrategy.Finish(); err != nil {
+				return false, err
+			}
+			if s, ok := n.orderStrategy.(*allSortStrategy); ok {
+				n.execInfo.spilledRuns += uint64(s.sorter.SpilledRuns())
+				n.execInfo.spilledDocs += s.sorter.SpilledDocs()
+			}
 			n.valueIter = n.orderStrategy
 			n.needSort = false
 			break
@@ -212,41 +253,156 @@ func (n *orderNode) Close() error {
 func (n *orderNode) Source() planNode { return n.plan }
 
 // allSortStrategy is the simplest sort strategy available.
-// it consumes all the data into the underlying valueNode
-// document container, then sorts it. Its designed for an
-// unknown number of records.
+// it consumes all the data into the underlying document
+// sorter, then sorts it. Its designed for an unknown
+// number of records, which are spilled to disk if they
+// exceed the memory budget of the request.
 type allSortStrategy struct {
-	valueNode *valuesNode
+	sorter *container.Sorter
 }
 
-func newAllSortStrategy(v *valuesNode) *allSortStrategy {
+func newAllSortStrategy(budget *container.MemoryBudget, ordering []mapper.OrderCondition) *allSortStrategy {
 	return &allSortStrategy{
-		valueNode: v,
+		sorter: container.NewSorter(budget, func(a, b core.Doc) int {
+			return compareDocs(ordering, a, b)
+		}),
 	}
 }
 
-// Add adds a new document to underlying valueNode
+// Add adds a new document to the underlying sorter
 func (s *allSortStrategy) Add(doc core.Doc) error {
-	s.valueNode.docs.AddDoc(doc)
-	return nil
+	return s.sorter.Add(doc)
 }
 
-// Finish finalizes and sorts the underling valueNode
-func (s *allSortStrategy) Finish() {
-	s.valueNode.SortAll()
+// Finish finalizes and sorts the underlying sorter
+func (s *allSortStrategy) Finish() error {
+	return s.sorter.Finish()
 }
 
-// Next gets the next doc ready from the underling valueNode
+// Next gets the next doc ready from the underlying sorter
 func (s *allSortStrategy) Next() (bool, error) {
-	return s.valueNode.Next()
+	return s.sorter.Next()
 }
 
-// Values returns the values of the next doc from the underliny valueNode
+// Values returns the values of the next doc from the underlying sorter
 func (s *allSortStrategy) Value() core.Doc {
-	return s.valueNode.Value()
+	return s.sorter.Value()
 }
 
-// Close closes the underling valueNode
+// Close closes the underlying sorter, removing any spilled runs
 func (s *allSortStrategy) Close() error {
-	return s.valueNode.Close()
+	return s.sorter.Close()
+}
+
+// topNSortStrategy is the sort strategy used when only a known number of
+// documents may be yielded. It retains only the best documents seen so far
+// in a bounded heap, so that the memory used doesn't grow with the number
+// of records.
+type topNSortStrategy struct {
+	entries  topNHeap
+	seq      uint64
+	docIndex int
+}
+
+func newTopNSortStrategy(ordering []mapper.OrderCondition, limit uint64) *topNSortStrategy {
+	return &topNSortStrategy{
+		entries: topNHeap{
+			ordering: ordering,
+			bound:    limit,
+		},
+		docIndex: -1,
+	}
+}
+
+// Add retains a copy of the document if it is one of the best documents seen so far.
+func (s *topNSortStrategy) Add(doc core.Doc) error {
+	s.entries.add(doc, s.seq)
+	s.seq++
+	return nil
+}
+
+// Finish sorts the retained documents from best to worst.
+func (s *topNSortStrategy) Finish() error {
+	slices.SortFunc(s.entries.entries, func(a, b topNEntry) int {
+		if s.entries.worse(&a, &b) {
+			return 1
+		}
+		if s.entries.worse(&b, &a) {
+			return -1
+		}
+		return 0
+	})
+	return nil
+}
+
+// Next moves to the next retained document.
+func (s *topNSortStrategy) Next() (bool, error) {
+	if s.docIndex >= len(s.entries.entries)-1 {
+		return false, nil
+	}
+	s.docIndex++
+	return true, nil
+}
+
+// Value returns the current retained document.
+func (s *topNSortStrategy) Value() core.Doc {
+	return s.entries.entries[s.docIndex].doc
+}
+
+// Close releases the retained documents.
+func (s *topNSortStrategy) Close() error {
+	s.entries.entries = nil
+	return nil
+}
+
+// topNEntry is a document retained by the topNSortStrategy.
+type topNEntry struct {
+	doc core.Doc
+	// seq is the position of the document in the source, used to keep the sort stable.
+	seq uint64
+}
+
+// topNHeap holds at most bound entries, with the worst one at its root so that
+// it can be dropped when a better document is added.
+type topNHeap struct {
+	entries  []topNEntry
+	ordering []mapper.OrderCondition
+	bound    uint64
+}
+
+var _ heap.Interface = (*topNHeap)(nil)
+
+func (h *topNHeap) Len() int           { return len(h.entries) }
+func (h *topNHeap) Less(i, j int) bool { return h.worse(&h.entries[i], &h.entries[j]) }
+func (h *topNHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *topNHeap) Push(x any)         { h.entries = append(h.entries, x.(topNEntry)) }
+
+func (h *topNHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// worse returns true if a must be ordered after b.
+//
+// Documents that are equal by the ordering conditions keep the order of the source.
+func (h *topNHeap) worse(a, b *topNEntry) bool {
+	if c := compareDocs(h.ordering, a.doc, b.doc); c != 0 {
+		return c > 0
+	}
+	return a.seq > b.seq
+}
+
+// add adds a copy of the given document to the heap, dropping the worst entry if it is full.
+func (h *topNHeap) add(doc core.Doc, seq uint64) {
+	if uint64(len(h.entries)) < h.bound {
+		heap.Push(h, topNEntry{doc: doc.Clone(), seq: seq})
+		return
+	}
+	entry := topNEntry{doc: doc, seq: seq}
+	if h.worse(&h.entries[0], &entry) {
+		entry.doc = doc.Clone()
+		h.entries[0] = entry
+		heap.Fix(h, 0)
+	}
 }
@@ -0,0 +1,448 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"container/heap"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/base"
+	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+)
+
+// paginationNode applies cursor-based pagination to the results of its source.
+//
+// The node takes over the ordering of the results from the orderNode.  Documents are ordered
+// by the requested order conditions with ties broken by document ID, so that every document
+// has a stable and unique position that a cursor may point to.  Only the documents that may
+// make it into the page are retained whilst consuming the source, and the source is only
+// consumed up to the end of the page if it already yields the documents in that order.
+type paginationNode struct {
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	ordering   []mapper.OrderCondition
+	pagination mapper.Pagination
+
+	// page contains the documents of the requested page, in order.
+	page []paginationEntry
+	// pageIndex is the index of the current document within the page.
+	pageIndex int
+	// needPage is true if the page has not yet been built from the source.
+	needPage bool
+
+	// isSourceOrdered is true if the plan already yields the documents in the order of
+	// their cursors.
+	isSourceOrdered bool
+
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     string
+	endCursor       string
+
+	execInfo paginationExecInfo
+}
+
+type paginationExecInfo struct {
+	// Total number of times paginationNode was executed.
+	iterations uint64
+
+	// Total number of documents yielded by the source that were positioned outside of the page.
+	docsSkipped uint64
+}
+
+// paginationEntry is a document along with its cursor.
+type paginationEntry struct {
+	doc    core.Doc
+	cursor mapper.Cursor
+}
+
+// Pagination creates a new paginationNode from the given select, it returns nil if the
+// select is neither paginated nor selects any of the pagination fields.
+func (p *Planner) Pagination(parsed *mapper.Select, orderBy *mapper.OrderBy) *paginationNode {
+	if parsed.Pagination == nil {
+		return nil
+	}
+
+	var ordering []mapper.OrderCondition
+	if orderBy != nil {
+		ordering = orderBy.Conditions
+	}
+
+	return &paginationNode{
+		p:          p,
+		ordering:   ordering,
+		pagination: *parsed.Pagination,
+		needPage:   true,
+		docMapper:  docMapper{parsed.DocumentMapping},
+	}
+}
+
+func (n *paginationNode) Kind() string {
+	return "paginationNode"
+}
+
+func (n *paginationNode) Init() error {
+	// reset stateful data
+	n.page = nil
+	n.pageIndex = -1
+	n.needPage = true
+	n.hasNextPage = false
+	n.hasPreviousPage = false
+	n.startCursor = ""
+	n.endCursor = ""
+	return n.plan.Init()
+}
+
+func (n *paginationNode) Start() error                      { return n.plan.Start() }
+func (n *paginationNode) Prefixes(prefixes []keys.Walkable) { n.plan.Prefixes(prefixes) }
+func (n *paginationNode) Close() error                      { return n.plan.Close() }
+func (n *paginationNode) Source() planNode                  { return n.plan }
+
+func (n *paginationNode) Value() core.Doc {
+	return n.page[n.pageIndex].doc
+}
+
+func (n *paginationNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.needPage {
+		err := n.buildPage()
+		if err != nil {
+			return false, err
+		}
+		n.needPage = false
+		n.pageIndex = -1
+	}
+
+	if n.pageIndex >= len(n.page)-1 {
+		return false, nil
+	}
+	n.pageIndex++
+	return true, nil
+}
+
+// buildPage consumes the source and retains the documents of the requested page.
+func (n *paginationNode) buildPage() error {
+	entries := &paginationHeap{}
+	switch {
+	case n.pagination.First.HasValue():
+		// retain the documents closest to the start, dropping the greatest
+		entries.bound = int(n.pagination.First.Value())
+		entries.isBounded = true
+		entries.worse = func(a, b *paginationEntry) bool { return n.compare(a.cursor, b.cursor) > 0 }
+	case n.pagination.Last.HasValue():
+		// retain the documents closest to the end, dropping the smallest
+		entries.bound = int(n.pagination.Last.Value())
+		entries.isBounded = true
+		entries.worse = func(a, b *paginationEntry) bool { return n.compare(a.cursor, b.cursor) < 0 }
+	}
+
+	for {
+		next, err := n.plan.Next()
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+
+		doc := n.plan.Value()
+		cursor := n.cursorOf(doc)
+
+		if n.pagination.After.HasValue() {
+			result, err := n.compareWithCursor(cursor, n.pagination.After.Value())
+			if err != nil {
+				return err
+			}
+			if result <= 0 {
+				n.hasPreviousPage = true
+				n.execInfo.docsSkipped++
+				continue
+			}
+		}
+		if n.pagination.Before.HasValue() {
+			result, err := n.compareWithCursor(cursor, n.pagination.Before.Value())
+			if err != nil {
+				return err
+			}
+			if result >= 0 {
+				n.hasNextPage = true
+				n.execInfo.docsSkipped++
+				if n.isSourceOrdered {
+					// all the remaining documents are positioned after the cursor
+					break
+				}
+				continue
+			}
+		}
+
+		if entries.add(paginationEntry{doc: doc.Clone(), cursor: cursor}) {
+			n.execInfo.docsSkipped++
+			if n.pagination.First.HasValue() {
+				n.hasNextPage = true
+				if n.isSourceOrdered {
+					// the page is full and all the remaining documents are positioned after it
+					break
+				}
+			} else {
+				n.hasPreviousPage = true
+			}
+		}
+	}
+
+	page := entries.entries
+	sort.Slice(page, func(i, j int) bool {
+		return n.compare(page[i].cursor, page[j].cursor) < 0
+	})
+
+	// If both first and last have been provided, last is applied to the first results.
+	if n.pagination.First.HasValue() && n.pagination.Last.HasValue() {
+		last := int(n.pagination.Last.Value())
+		if len(page) > last {
+			n.execInfo.docsSkipped += uint64(len(page) - last)
+			n.hasPreviousPage = true
+			page = page[len(page)-last:]
+		}
+	}
+
+	return n.setPaginationFields(page)
+}
+
+// setPaginationFields sets the cursor fields of the given page, and stores it on the node.
+func (n *paginationNode) setPaginationFields(page []paginationEntry) error {
+	for i := range page {
+		cursor, err := page[i].cursor.Encode()
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			n.startCursor = cursor
+		}
+		if i == len(page)-1 {
+			n.endCursor = cursor
+		}
+		n.documentMapping.TrySetFirstOfName(&page[i].doc, request.CursorFieldName, cursor)
+	}
+
+	n.page = page
+	return nil
+}
+
+// pageInfo returns the page info of the page built by the node, mapped using the given page
+// info mapping.
+//
+// It must only be called once the source has been consumed.
+func (n *paginationNode) pageInfo(mapping *core.DocumentMapping) core.Doc {
+	pageInfo := mapping.NewDoc()
+	mapping.SetFirstOfName(&pageInfo, request.HasNextPageFieldName, n.hasNextPage)
+	mapping.SetFirstOfName(&pageInfo, request.HasPreviousPageFieldName, n.hasPreviousPage)
+	if len(n.page) > 0 {
+		mapping.SetFirstOfName(&pageInfo, request.StartCursorFieldName, n.startCursor)
+		mapping.SetFirstOfName(&pageInfo, request.EndCursorFieldName, n.endCursor)
+	}
+	return pageInfo
+}
+
+// cursorOf returns the cursor marking the position of the given document.
+func (n *paginationNode) cursorOf(doc core.Doc) mapper.Cursor {
+	values := make([]any, len(n.ordering))
+	for i, order := range n.ordering {
+		values[i] = getDocProp(doc, order.FieldIndexes)
+	}
+	return mapper.Cursor{
+		Values: values,
+		DocID:  doc.GetID(),
+	}
+}
+
+// compare returns -1 if the cursor a is positioned before b, 1 if it is positioned after it,
+// and 0 if both mark the same position.
+func (n *paginationNode) compare(a, b mapper.Cursor) int {
+	for i, order := range n.ordering {
+		result := compareCursorValues(a.Values[i], b.Values[i])
+		if order.Direction == mapper.DESC {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return strings.Compare(a.DocID, b.DocID)
+}
+
+// compareWithCursor compares the cursor of a document with a cursor given by the request.
+//
+// It returns an error if the values of the given cursor are not comparable with those of the
+// document, which happens if the cursor was tampered with or was returned for another ordering.
+func (n *paginationNode) compareWithCursor(a, b mapper.Cursor) (int, error) {
+	for i := range n.ordering {
+		if !areCursorValuesComparable(a.Values[i], b.Values[i]) {
+			cursor, err := b.Encode()
+			if err != nil {
+				return 0, err
+			}
+			return 0, mapper.NewErrInvalidCursor(cursor)
+		}
+	}
+	return n.compare(a, b), nil
+}
+
+// areCursorValuesComparable returns true if the given order values are of comparable types.
+func areCursorValuesComparable(a, b any) bool {
+	a, b = normalizeCursorValue(a), normalizeCursorValue(b)
+	if a == nil || b == nil {
+		return true
+	}
+	switch a.(type) {
+	case int64, float64:
+		switch b.(type) {
+		case int64, float64:
+			return true
+		}
+	}
+	return reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// compareCursorValues compares the given order values, values decoded from a cursor may be
+// of a different (but compatible) type to those held by a document.
+func compareCursorValues(a, b any) int {
+	a, b = normalizeCursorValue(a), normalizeCursorValue(b)
+	switch av := a.(type) {
+	case int64:
+		if _, ok := b.(float64); ok {
+			a = float64(av)
+		}
+	case float64:
+		if bv, ok := b.(int64); ok {
+			b = float64(bv)
+		}
+	}
+	return base.Compare(a, b)
+}
+
+func normalizeCursorValue(v any) any {
+	switch tv := v.(type) {
+	case bool:
+		// bools are encoded as integers within cursors
+		if tv {
+			return int64(1)
+		}
+		return int64(0)
+	case int:
+		return int64(tv)
+	case float32:
+		return float64(tv)
+	default:
+		return v
+	}
+}
+
+func (n *paginationNode) simpleExplain() (map[string]any, error) {
+	simpleExplainMap := map[string]any{
+		firstLabel:  nil,
+		afterLabel:  nil,
+		lastLabel:   nil,
+		beforeLabel: nil,
+	}
+
+	if n.pagination.First.HasValue() {
+		simpleExplainMap[firstLabel] = n.pagination.First.Value()
+	}
+	if n.pagination.Last.HasValue() {
+		simpleExplainMap[lastLabel] = n.pagination.Last.Value()
+	}
+	if n.pagination.After.HasValue() {
+		cursor, err := n.pagination.After.Value().Encode()
+		if err != nil {
+			return nil, err
+		}
+		simpleExplainMap[afterLabel] = cursor
+	}
+	if n.pagination.Before.HasValue() {
+		cursor, err := n.pagination.Before.Value().Encode()
+		if err != nil {
+			return nil, err
+		}
+		simpleExplainMap[beforeLabel] = cursor
+	}
+
+	return simpleExplainMap, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *paginationNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations":  n.execInfo.iterations,
+			"docsSkipped": n.execInfo.docsSkipped,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+// paginationHeap holds documents whilst the page is being built.
+//
+// If bounded, it holds at most bound entries, dropping the worst one when full.
+type paginationHeap struct {
+	entries   []paginationEntry
+	bound     int
+	isBounded bool
+	// worse returns true if a should be dropped before b.
+	worse func(a, b *paginationEntry) bool
+}
+
+var _ heap.Interface = (*paginationHeap)(nil)
+
+func (h *paginationHeap) Len() int           { return len(h.entries) }
+func (h *paginationHeap) Less(i, j int) bool { return h.worse(&h.entries[i], &h.entries[j]) }
+func (h *paginationHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *paginationHeap) Push(x any)         { h.entries = append(h.entries, x.(paginationEntry)) }
+
+func (h *paginationHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// add adds the given entry to the heap, returning true if an entry had to be dropped.
+func (h *paginationHeap) add(entry paginationEntry) bool {
+	if !h.isBounded {
+		h.entries = append(h.entries, entry)
+		return false
+	}
+	if h.bound <= 0 {
+		return true
+	}
+	if len(h.entries) < h.bound {
+		heap.Push(h, entry)
+		return false
+	}
+	if h.worse(&h.entries[0], &entry) {
+		h.entries[0] = entry
+		heap.Fix(h, 0)
+	}
+	return true
+}
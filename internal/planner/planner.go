@@ -22,6 +22,7 @@ import (
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/internal/connor"
 	"github.com/sourcenetwork/defradb/internal/core"
+	"github.com/sourcenetwork/defradb/internal/db/container"
 	"github.com/sourcenetwork/defradb/internal/keys"
 	"github.com/sourcenetwork/defradb/internal/planner/filter"
 	"github.com/sourcenetwork/defradb/internal/planner/mapper"
@@ -92,6 +93,23 @@ type Planner struct {
 	acp      immutable.Option[acp.ACP]
 	db       client.Store
 
+	// statistics returns the statistics of the collection with the given name that are known,
+	// nil if the planner has no statistics to plan with.
+	statistics StatisticsFunc
+	// stats contains the statistics of the collections read so far, by collection name.
+	stats map[string]immutable.Option[client.CollectionStatistics]
+
+	// memory is the budget shared by the nodes of the request that hold documents in memory.
+	memory *container.MemoryBudget
+
+	// maxScannedDocs is the maximum number of documents the request may scan, zero if unlimited.
+	maxScannedDocs uint64
+	// scannedDocs is the number of documents scanned so far by the request.
+	scannedDocs uint64
+
+	// scans contains all the scan nodes planned for the request.
+	scans []*scanNode
+
 	ctx context.Context
 }
 
@@ -101,14 +119,90 @@ func New(
 	acp immutable.Option[acp.ACP],
 	db client.Store,
 	txn datastore.Txn,
+	opts ...Option,
 ) *Planner {
-	return &Planner{
+	p := &Planner{
 		txn:      txn,
 		identity: identity,
 		acp:      acp,
 		db:       db,
 		ctx:      ctx,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Option is a function that sets a config value on the planner.
+type Option func(*Planner)
+
+// WithMemoryBudget sets the maximum number of bytes the documents held in memory by the
+// nodes of a request may take, past which they are spilled to disk.
+//
+// A limit of zero or less disables spilling.
+func WithMemoryBudget(limit int64) Option {
+	return func(p *Planner) {
+		p.memory = container.NewMemoryBudget(limit)
+	}
+}
+
+// WithMaxScannedDocs sets the maximum number of documents a request may scan, past which
+// it fails with a [client.ErrMaxScannedDocsExceeded] error.
+//
+// A count of zero disables the limit.
+func WithMaxScannedDocs(count uint64) Option {
+	return func(p *Planner) {
+		p.maxScannedDocs = count
+	}
+}
+
+// StatisticsFunc returns the statistics of the collection with the given name, if they are known.
+//
+// It must return promptly, as it is called whilst planning requests.
+type StatisticsFunc func(collectionName string) (immutable.Option[client.CollectionStatistics], error)
+
+// WithStatistics sets the function returning the statistics the planner chooses between
+// the indexes and scans of collections with.
+func WithStatistics(fn StatisticsFunc) Option {
+	return func(p *Planner) {
+		p.statistics = fn
+	}
+}
+
+// addScannedDocs records the given number of scanned documents, and returns an error if the
+// request has now scanned more documents than it may.
+func (p *Planner) addScannedDocs(count uint64) error {
+	p.scannedDocs += count
+	if p.maxScannedDocs > 0 && p.scannedDocs > p.maxScannedDocs {
+		return client.NewErrMaxScannedDocsExceeded(p.maxScannedDocs)
+	}
+	return nil
+}
+
+// ScanExecInfo returns the information about the execution of the scan nodes that were
+// executed by the request, in the order they were planned.
+func (p *Planner) ScanExecInfo() []ScanInfo {
+	infos := []ScanInfo{}
+	for _, scan := range p.scans {
+		// Nodes that were replaced while optimizing the plan are never executed.
+		if scan.execInfo.iterations == 0 {
+			continue
+		}
+		infos = append(infos, scan.scanInfo())
+	}
+	return infos
+}
+
+// checkContext returns the cause of the cancellation of the request context, if it has been canceled.
+//
+// It is called between the Next() calls of the nodes reading from the store, so that
+// canceled requests stop as soon as possible.
+func (p *Planner) checkContext() error {
+	if p.ctx.Err() != nil {
+		return context.Cause(p.ctx)
+	}
+	return nil
 }
 
 func (p *Planner) newObjectMutationPlan(stmt *mapper.Mutation) (planNode, error) {
@@ -207,6 +301,12 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 		return err
 	}
 
+	// child selects are fetched per parent document, so only a root select can be
+	// yielded in the order of an index.
+	if parentPlan == nil {
+		p.tryOrderByIndex(plan)
+	}
+
 	// wire up source to plan
 	plan.planNode = plan.selectNode
 
@@ -221,8 +321,11 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 
 	p.expandAggregatePlans(plan)
 
-	// if order
-	if plan.order != nil {
+	// if paginated, the pagination node also takes care of the ordering
+	if plan.pagination != nil {
+		plan.pagination.plan = plan.planNode
+		plan.planNode = plan.pagination
+	} else if plan.order != nil {
 		plan.order.plan = plan.planNode
 		plan.planNode = plan.order
 	}
@@ -231,6 +334,11 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 		p.expandLimitPlan(plan, parentPlan)
 	}
 
+	// if ordered and limited, only the documents that may be yielded need to be retained
+	if plan.order != nil && plan.pagination == nil && plan.limit != nil && plan.limit.limit > 0 {
+		plan.order.limit = plan.limit.limit + plan.limit.offset
+	}
+
 	return nil
 }
 
@@ -269,64 +377,221 @@ func (p *Planner) expandTypeIndexJoinPlan(plan *typeIndexJoin, parentPlan *selec
 	return client.NewErrUnhandledType("join plan", plan.joinPlan)
 }
 
-func findFilteredByRelationFields(
-	conditions map[connor.FilterKey]any,
-	mapping *core.DocumentMapping,
-) map[string]int {
-	filterProperties := filter.ExtractProperties(conditions)
-	filteredSubFields := make(map[string]int)
-	for _, prop := range filterProperties {
-		if childMapping := mapping.ChildMappings[prop.Index]; childMapping != nil {
-			if !prop.IsRelation() {
-				continue
-			}
-			for _, subProp := range prop.Fields {
-				for fieldName, indices := range childMapping.IndexesByName {
-					if indices[0] == subProp.Index {
-						filteredSubFields[fieldName] = subProp.Index
-					}
-				}
-			}
-		}
-	}
-	return filteredSubFields
-}
-
+// tryOptimizeJoinDirection inverts the direction of the given join if the related objects
+// matching the filter of the parent can be found through indexes with fewer fetches than
+// the parent documents themselves.
+//
+// If the conditions on the related objects can be served by an index of the related
+// collection, the scan of the child side is driven by that index. Otherwise, for example
+// if the conditions are on objects that are further related, a separate plan yielding
+// the matching related objects drives the join, which in turn may invert its own joins.
 func (p *Planner) tryOptimizeJoinDirection(node *invertibleTypeJoin, parentPlan *selectTopNode) error {
 	if !node.childSide.relFieldDef.HasValue() {
 		// If the relation is one sided we cannot invert the join, so return early
 		return nil
 	}
 
-	filteredSubFields := findFilteredByRelationFields(
+	childConds := relationConditions(
 		parentPlan.selectNode.filter.Conditions,
-		node.documentMapping,
+		node.parentSide.relFieldMapIndex.Value(),
 	)
+	if len(childConds) == 0 {
+		return nil
+	}
+
 	slct := node.childSide.plan.(*selectTopNode).selectNode
-	desc := slct.collection.Description()
-	for subFieldName, subFieldInd := range filteredSubFields {
-		indexes := desc.GetIndexesOnField(subFieldName)
-		if len(indexes) > 0 && !filter.IsComplex(parentPlan.selectNode.filter) {
-			subInd := node.documentMapping.FirstIndexOfName(node.parentSide.relFieldDef.Value().Name)
-			relatedField := mapper.Field{Name: node.parentSide.relFieldDef.Value().Name, Index: subInd}
-			fieldFilter := filter.UnwrapRelation(filter.CopyField(
-				parentPlan.selectNode.filter,
-				relatedField,
-				mapper.Field{Name: subFieldName, Index: subFieldInd},
-			), relatedField)
-			// At the moment we just take the first index, but later we want to run some kind of analysis to
-			// determine which index is best to use. https://github.com/sourcenetwork/defradb/issues/2680
-			err := node.invertJoinDirectionWithIndex(fieldFilter, indexes[0])
-			if err != nil {
-				return err
-			}
-			break
+	if slct.selectReq.GroupBy != nil {
+		return nil
+	}
+	childDef := slct.collection.Definition()
+	childCost, ok, err := p.estimateFilterCost(childDef, slct.documentMapping, childConds)
+	if err != nil || !ok {
+		return err
+	}
+	// the parent is only driven by the related objects if they are more selective
+	// than the conditions of the parent served by its own index.
+	parentScan := getScanNode(node.parentSide.plan)
+	parentCost, ok, err := p.estimateScanCost(parentScan)
+	if err != nil {
+		return err
+	}
+	if ok && parentCost <= childCost {
+		return nil
+	}
+	if !ok && parentScan != nil {
+		// without an index all the parents are scanned, which is cheaper than driving
+		// them from the related objects if most of them are estimated to match.
+		stats, err := p.getStatistics(parentScan.col.Definition())
+		if err != nil {
+			return err
+		}
+		if isScanCheaper(stats, childCost) {
+			return nil
+		}
+	}
+
+	childConds = filter.Copy(childConds)
+	indexCost, index, err := p.estimateIndexCost(childDef, slct.documentMapping, childConds)
+	if err != nil {
+		return err
+	}
+	if index.HasValue() && indexCost == childCost {
+		var fieldFilter *mapper.Filter
+		for _, field := range index.Value().Fields {
+			fieldFilter = filter.Merge(fieldFilter, filter.CopyField(
+				&mapper.Filter{Conditions: childConds},
+				mapper.Field{Name: field.Name, Index: slct.documentMapping.FirstIndexOfName(field.Name)},
+			))
 		}
+		err := node.invertJoinDirectionWithIndex(fieldFilter, index.Value())
+		if err != nil {
+			return err
+		}
+	} else {
+		driver, err := p.makeJoinDriver(slct.selectReq, childConds, parentPlan)
+		if err != nil {
+			return err
+		}
+		node.invertJoinDirectionWithDriver(driver)
 	}
+	parentPlan.selectNode.hasInvertedJoin = true
 
 	return nil
 }
 
+// makeJoinDriver returns an expanded plan that yields the objects selected by the given child
+// select that match the given conditions, so that it can drive an inverted join.
+func (p *Planner) makeJoinDriver(
+	childReq *mapper.Select,
+	conditions map[connor.FilterKey]any,
+	parentPlan *selectTopNode,
+) (planNode, error) {
+	driverReq := copySelect(childReq)
+	driverReq.Filter = &mapper.Filter{Conditions: conditions}
+	driverReq.Filter.ExternalConditions = driverReq.Filter.ToMap(driverReq.DocumentMapping)
+	// the driver yields every matching object, regardless of what is requested of the children.
+	driverReq.DocIDs = immutable.None[[]string]()
+	driverReq.Limit = nil
+	driverReq.OrderBy = nil
+	driverReq.Pagination = nil
+
+	driver, err := p.Select(driverReq)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expandPlan(driver, parentPlan); err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// copySelect returns a copy of the given select with copies of the filters of the select and
+// its child selects, as planning a select modifies its filter.
+func copySelect(slct *mapper.Select) *mapper.Select {
+	result := *slct
+	if slct.Filter != nil {
+		result.Filter = &mapper.Filter{
+			Conditions:         filter.Copy(slct.Filter.Conditions),
+			ExternalConditions: slct.Filter.ExternalConditions,
+		}
+	}
+	result.Fields = make([]mapper.Requestable, len(slct.Fields))
+	for i, field := range slct.Fields {
+		if childSelect, ok := field.(*mapper.Select); ok {
+			result.Fields[i] = copySelect(childSelect)
+		} else {
+			result.Fields[i] = field
+		}
+	}
+	return &result
+}
+
+// copyOperation returns a copy of the given operation with copies of the filters of its selects,
+// as planning an operation modifies them.
+func copyOperation(operation *mapper.Operation) *mapper.Operation {
+	result := *operation
+	result.Selects = make([]*mapper.Select, len(operation.Selects))
+	for i, slct := range operation.Selects {
+		result.Selects[i] = copySelect(slct)
+	}
+	result.Mutations = make([]*mapper.Mutation, len(operation.Mutations))
+	for i, mutation := range operation.Mutations {
+		mutationCopy := *mutation
+		mutationCopy.Select = *copySelect(&mutation.Select)
+		result.Mutations[i] = &mutationCopy
+	}
+	result.CommitSelects = make([]*mapper.CommitSelect, len(operation.CommitSelects))
+	for i, commitSelect := range operation.CommitSelects {
+		commitSelectCopy := *commitSelect
+		commitSelectCopy.Select = *copySelect(&commitSelect.Select)
+		result.CommitSelects[i] = &commitSelectCopy
+	}
+	return &result
+}
+
+// tryOrderByIndex lets the scan of the given select yield the documents in the requested
+// order if it is covered by an index, so that they don't need to be sorted in memory.
+//
+// Paginated documents are ordered with ties broken by document ID, so the order conditions
+// must cover all the fields of the index, whose entries are ordered by document ID last.
+// Without any order conditions, they are ordered by document ID as yielded by a plain scan.
+func (p *Planner) tryOrderByIndex(plan *selectTopNode) {
+	if plan.group != nil || plan.selectNode.hasInvertedJoin {
+		return
+	}
+	var ordering []mapper.OrderCondition
+	switch {
+	case plan.pagination != nil:
+		ordering = plan.pagination.ordering
+	case plan.order != nil:
+		ordering = plan.order.ordering
+	default:
+		return
+	}
+	scan, ok := plan.selectNode.origSource.(*scanNode)
+	if !ok || len(scan.prefixes) > 0 {
+		return
+	}
+	selectReq := plan.selectNode.selectReq
+	if selectReq.Cid.HasValue() || selectReq.AsOf.HasValue() || selectReq.DocIDs.HasValue() || selectReq.ShowDeleted {
+		return
+	}
+
+	setSourceOrdered := func(isSourceOrdered bool) {
+		if plan.pagination != nil {
+			plan.pagination.isSourceOrdered = isSourceOrdered
+		} else {
+			plan.order.isSourceOrdered = isSourceOrdered
+		}
+	}
+	isMatchingOrder := func(index client.IndexDescription) bool {
+		if plan.pagination != nil && len(ordering) != len(index.Fields) {
+			return false
+		}
+		return isIndexMatchingOrder(scan, index, ordering)
+	}
+
+	if scan.index.HasValue() {
+		// the documents are already fetched through an index
+		index := scan.index.Value()
+		setSourceOrdered(isMatchingOrder(index) && isIndexOrderKept(scan, index, scan.indexFilter))
+		return
+	}
+	if plan.pagination != nil && len(ordering) == 0 {
+		// the documents of a plain scan are yielded by document ID
+		setSourceOrdered(true)
+		return
+	}
+
+	index := findIndexByOrder(scan, ordering)
+	if !index.HasValue() || !isMatchingOrder(index.Value()) {
+		return
+	}
+	scan.orderedByIndex = true
+	scan.initFetcher(immutable.None[string](), index)
+	setSourceOrdered(isIndexOrderKept(scan, index.Value(), scan.indexFilter))
+}
+
 // expandTypeJoin does a plan graph expansion and other optimizations on invertibleTypeJoin.
 func (p *Planner) expandTypeJoin(node *invertibleTypeJoin, parentPlan *selectTopNode) error {
 	if parentPlan.selectNode.filter == nil {
@@ -338,7 +603,9 @@ func (p *Planner) expandTypeJoin(node *invertibleTypeJoin, parentPlan *selectTop
 		return err
 	}
 
-	return p.expandPlan(node.childSide.plan, parentPlan)
+	// a plan driving the join is already expanded, so only the plan fetching
+	// the related documents still needs to be.
+	return p.expandPlan(node.childSide.getRelatedPlan(), parentPlan)
 }
 
 func (p *Planner) expandGroupNodePlan(topNodeSelect *selectTopNode) error {
@@ -492,6 +759,9 @@ func (p *Planner) executeRequest(
 		copy := docMap.ToMap(planNode.Value())
 		docs = append(docs, copy)
 
+		if err := p.checkContext(); err != nil {
+			return nil, err
+		}
 		hasNext, err = planNode.Next()
 		if err != nil {
 			return nil, err
@@ -518,7 +788,23 @@ func (p *Planner) RunRequest(
 	ctx context.Context,
 	req *request.Request,
 ) (map[string]any, error) {
-	planNode, err := p.MakePlan(req)
+	operation, err := p.MapRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.RunMappedRequest(ctx, req, operation)
+}
+
+// RunMappedRequest runs the given request, mapped with [Planner.MapRequest], and then returns the result(s).
+//
+// The mapped operation is not modified, so that the request can be run any number of times without
+// being mapped again.
+func (p *Planner) RunMappedRequest(
+	ctx context.Context,
+	req *request.Request,
+	operation *mapper.Operation,
+) (map[string]any, error) {
+	planNode, err := p.makeOperationPlan(copyOperation(operation))
 	if err != nil {
 		return nil, err
 	}
@@ -587,6 +873,15 @@ func (p *Planner) MakeSelectionPlan(selection *request.Select) (planNode, error)
 //
 // @TODO {defradb/issues/368}: Test this exported function.
 func (p *Planner) MakePlan(req *request.Request) (planNode, error) {
+	m, err := p.MapRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.makeOperationPlan(m)
+}
+
+// MapRequest maps the operation of the given request against the collections of the database.
+func (p *Planner) MapRequest(req *request.Request) (*mapper.Operation, error) {
 	// TODO handle multiple operation statements
 	// https://github.com/sourcenetwork/defradb/issues/1395
 	var operation *request.OperationDefinition
@@ -597,10 +892,11 @@ func (p *Planner) MakePlan(req *request.Request) (planNode, error) {
 	} else {
 		return nil, ErrMissingQueryOrMutation
 	}
-	m, err := mapper.ToOperation(p.ctx, p.db, operation)
-	if err != nil {
-		return nil, err
-	}
+	return mapper.ToOperation(p.ctx, p.db, operation)
+}
+
+// makeOperationPlan makes the plan of the given mapped operation.
+func (p *Planner) makeOperationPlan(m *mapper.Operation) (planNode, error) {
 	planNode, err := p.Operation(m)
 	if err != nil {
 		return nil, err
@@ -34,6 +34,22 @@ type scanExecInfo struct {
 	fetches fetcher.ExecInfo
 }
 
+// ScanInfo contains information about the execution of a node scanning the documents of a collection.
+type ScanInfo struct {
+	// Collection is the name of the scanned collection.
+	Collection string `json:"collection"`
+	// Index is the name of the index the documents were fetched with, empty if none was used.
+	Index string `json:"index,omitempty"`
+	// Iterations is the number of times the scan was issued.
+	Iterations uint64 `json:"iterations"`
+	// DocsFetched is the number of documents fetched.
+	DocsFetched uint64 `json:"docFetches"`
+	// FieldsFetched is the number of fields fetched.
+	FieldsFetched uint64 `json:"fieldFetches"`
+	// IndexesFetched is the number of index entries fetched.
+	IndexesFetched uint64 `json:"indexFetches"`
+}
+
 // scans an index for records
 type scanNode struct {
 	documentIterator
@@ -52,6 +68,14 @@ type scanNode struct {
 	filter *mapper.Filter
 	slct   *mapper.Select
 
+	// index is the index the documents are fetched with, if any.
+	index immutable.Option[client.IndexDescription]
+	// indexFilter is the part of the filter that is matched against the index.
+	indexFilter *mapper.Filter
+	// orderedByIndex is true if the documents are fetched through the index to yield
+	// them in its order, even if none of the indexed fields are filtered.
+	orderedByIndex bool
+
 	fetcher fetcher.Fetcher
 
 	execInfo scanExecInfo
@@ -159,8 +183,14 @@ func (scan *scanNode) initFetcher(
 	index immutable.Option[client.IndexDescription],
 ) {
 	var f fetcher.Fetcher
+	scan.index = immutable.None[client.IndexDescription]()
+	scan.indexFilter = nil
 	if cid.HasValue() {
 		f = new(fetcher.VersionedFetcher)
+	} else if scan.slct.AsOf.HasValue() {
+		// Indexes hold the current state of the documents, so they can't be used to
+		// fetch documents as they were at a previous point.
+		f = fetcher.NewAsOfFetcher(scan.slct.AsOf.Value())
 	} else {
 		f = new(fetcher.DocumentFetcher)
 
@@ -173,8 +203,10 @@ func (scan *scanNode) initFetcher(
 				indexField := mapper.Field{Index: typeIndex, Name: fieldName}
 				fd, _ := scan.col.Definition().Schema.GetFieldByName(fieldName)
 				// if the field is an array, we need to copy it instead of moving so that the
-				// top select node can do final filter check on the whole array of the document
-				if fd.Kind.IsArray() {
+				// top select node can do final filter check on the whole array of the document.
+				// Full-text indexes store only the words of a field, so the same applies to them
+				// and to JSON path indexes that store only the values at the path.
+				if fd.Kind.IsArray() || index.Value().Type == client.IndexTypeFullText || len(field.JSONPath) > 0 {
 					fieldsToCopy = append(fieldsToCopy, indexField)
 				} else {
 					fieldsToMove = append(fieldsToMove, indexField)
@@ -185,8 +217,10 @@ func (scan *scanNode) initFetcher(
 			for i := range fieldsToCopy {
 				indexFilter = filter.Merge(indexFilter, filter.CopyField(scan.filter, fieldsToCopy[i]))
 			}
-			if indexFilter != nil {
+			if indexFilter != nil || scan.orderedByIndex {
 				f = fetcher.NewIndexFetcher(f, index.Value(), indexFilter)
+				scan.index = index
+				scan.indexFilter = indexFilter
 			}
 		}
 
@@ -225,12 +259,20 @@ func (n *scanNode) Next() (bool, error) {
 		return false, nil
 	}
 
+	if err := n.p.checkContext(); err != nil {
+		return false, err
+	}
+
 	doc, execInfo, err := n.fetcher.FetchNext(n.p.ctx)
 	if err != nil {
 		return false, err
 	}
 	n.execInfo.fetches.Add(execInfo)
 
+	if err := n.p.addScannedDocs(execInfo.DocsFetched); err != nil {
+		return false, err
+	}
+
 	if doc == nil {
 		return false, nil
 	}
@@ -288,13 +330,53 @@ func (n *scanNode) simpleExplain() (map[string]any, error) {
 	return simpleExplainMap, nil
 }
 
-func (n *scanNode) executeExplain() map[string]any {
+func (n *scanNode) executeExplain() (map[string]any, error) {
+	estimatedRows, err := n.estimateRows()
+	if err != nil {
+		return nil, err
+	}
 	return map[string]any{
-		"iterations":   n.execInfo.iterations,
-		"docFetches":   n.execInfo.fetches.DocsFetched,
-		"fieldFetches": n.execInfo.fetches.FieldsFetched,
-		"indexFetches": n.execInfo.fetches.IndexesFetched,
+		"iterations":    n.execInfo.iterations,
+		"docFetches":    n.execInfo.fetches.DocsFetched,
+		"estimatedRows": estimatedRows,
+		"fieldFetches":  n.execInfo.fetches.FieldsFetched,
+		"indexFetches":  n.execInfo.fetches.IndexesFetched,
+	}, nil
+}
+
+// scanInfo returns the information about the execution of this node.
+func (n *scanNode) scanInfo() ScanInfo {
+	info := ScanInfo{
+		Collection:     n.col.Name().Value(),
+		Iterations:     n.execInfo.iterations,
+		DocsFetched:    n.execInfo.fetches.DocsFetched,
+		FieldsFetched:  n.execInfo.fetches.FieldsFetched,
+		IndexesFetched: n.execInfo.fetches.IndexesFetched,
+	}
+	if n.index.HasValue() {
+		info.Index = n.index.Value().Name
+	}
+	return info
+}
+
+// estimateRows returns the number of documents that the planner estimates a single
+// execution of this node to fetch, to be compared with the documents actually fetched.
+func (n *scanNode) estimateRows() (uint64, error) {
+	if n.slct != nil && n.slct.Cid.HasValue() {
+		return 1, nil
+	}
+	if n.slct != nil && n.slct.DocIDs.HasValue() {
+		return uint64(len(n.slct.DocIDs.Value())), nil
+	}
+	cost, ok, err := n.p.estimateScanCost(n)
+	if err != nil || ok {
+		return cost, err
+	}
+	stats, err := n.p.getStatistics(n.col.Definition())
+	if err != nil || !stats.HasValue() {
+		return 0, err
 	}
+	return stats.Value().DocumentCount, nil
 }
 
 // Explain method returns a map containing all attributes of this node that
@@ -305,7 +387,7 @@ func (n *scanNode) Explain(explainType request.ExplainType) (map[string]any, err
 		return n.simpleExplain()
 
 	case request.ExecuteExplain:
-		return n.executeExplain(), nil
+		return n.executeExplain()
 
 	default:
 		return nil, ErrUnknownExplainRequestType
@@ -330,6 +412,7 @@ func (p *Planner) Scan(
 	if err != nil {
 		return nil, err
 	}
+	p.scans = append(p.scans, scan)
 	return scan, nil
 }
 
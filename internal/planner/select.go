@@ -16,9 +16,11 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/internal/connor"
 	"github.com/sourcenetwork/defradb/internal/core"
 	"github.com/sourcenetwork/defradb/internal/db/base"
 	"github.com/sourcenetwork/defradb/internal/keys"
+	"github.com/sourcenetwork/defradb/internal/planner/filter"
 	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 )
 
@@ -51,6 +53,7 @@ type selectTopNode struct {
 
 	group      *groupNode
 	order      *orderNode
+	pagination *paginationNode
 	limit      *limitNode
 	aggregates []aggregateNode
 
@@ -116,6 +119,10 @@ type selectNode struct {
 
 	docIDs immutable.Option[[]string]
 
+	// hasInvertedJoin is true if a type join of this select is driven by the related
+	// documents, in which case the documents of this select are fetched one by one.
+	hasInvertedJoin bool
+
 	selectReq    *mapper.Select
 	groupSelects []*mapper.Select
 
@@ -295,29 +302,76 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 	}
 
 	if isScanNode {
-		origScan.initFetcher(n.selectReq.Cid, findIndexByFilteringField(origScan))
+		index, err := n.planner.findIndexByFilteringField(origScan)
+		if err != nil {
+			return nil, err
+		}
+		origScan.initFetcher(n.selectReq.Cid, index)
 	}
 
 	return aggregates, nil
 }
 
-func findIndexByFilteringField(scanNode *scanNode) immutable.Option[client.IndexDescription] {
+// findIndexByFilteringField returns the index to fetch the documents of the given scan with
+// to serve its filter, if any.
+//
+// Of the indexes that serve the filter, the one estimated to fetch the fewest documents is chosen,
+// unless scanning the whole collection is estimated to be cheaper.
+func (p *Planner) findIndexByFilteringField(scanNode *scanNode) (immutable.Option[client.IndexDescription], error) {
 	if scanNode.filter == nil {
-		return immutable.None[client.IndexDescription]()
+		return immutable.None[client.IndexDescription](), nil
 	}
 	colDesc := scanNode.col.Description()
 
+	// full-text indexes are preferred as a _search condition is usually very selective
+	// and the index yields documents ordered by relevance.
+	for _, field := range scanNode.col.Schema().Fields {
+		cond, isFiltered := scanNode.filter.ExternalConditions[field.Name]
+		if !isFiltered {
+			continue
+		}
+		condMap, ok := cond.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, isSearch := condMap[request.FilterOpSearch]; !isSearch {
+			continue
+		}
+		for _, index := range colDesc.GetFullTextIndexesOnField(field.Name) {
+			if isIndexUsable(index, scanNode.filter) {
+				return immutable.Some(index), nil
+			}
+		}
+	}
+
+	def := scanNode.col.Definition()
+	cost, index, err := p.estimateIndexCost(def, scanNode.documentMapping, scanNode.filter.Conditions)
+	if err != nil {
+		return immutable.None[client.IndexDescription](), err
+	}
+	if index.HasValue() {
+		stats, err := p.getStatistics(def)
+		if err != nil {
+			return immutable.None[client.IndexDescription](), err
+		}
+		if isScanCheaper(stats, cost) {
+			return immutable.None[client.IndexDescription](), nil
+		}
+		return index, nil
+	}
+
+	// the cost of the remaining conditions can't be estimated, so the first index serving them is used.
 	for _, field := range scanNode.col.Schema().Fields {
 		if _, isFiltered := scanNode.filter.ExternalConditions[field.Name]; !isFiltered {
 			continue
 		}
-		indexes := colDesc.GetIndexesOnField(field.Name)
-		if len(indexes) > 0 {
-			// we return the first found index. We will optimize it later.
-			return immutable.Some(indexes[0])
+		for _, index := range colDesc.GetIndexesOnField(field.Name) {
+			if isIndexUsable(index, scanNode.filter) && isJSONPathFiltered(scanNode, index) {
+				return immutable.Some(index), nil
+			}
 		}
 	}
-	return immutable.None[client.IndexDescription]()
+	return immutable.None[client.IndexDescription](), nil
 }
 
 func findIndexByFieldName(col client.Collection, fieldName string) immutable.Option[client.IndexDescription] {
@@ -325,16 +379,147 @@ func findIndexByFieldName(col client.Collection, fieldName string) immutable.Opt
 		if field.Name != fieldName {
 			continue
 		}
-		indexes := col.Description().GetIndexesOnField(field.Name)
-		if len(indexes) > 0 {
+		for _, index := range col.Description().GetIndexesOnField(field.Name) {
 			// At the moment we just take the first index, but later we want to run some kind of analysis to
 			// determine which index is best to use. https://github.com/sourcenetwork/defradb/issues/2680
-			return immutable.Some(indexes[0])
+			if isIndexUsable(index, nil) {
+				return immutable.Some(index)
+			}
+		}
+	}
+	return immutable.None[client.IndexDescription]()
+}
+
+// isIndexUsable returns true if the given index contains all the documents matching the filter.
+//
+// Partial indexes are only usable if the filter implies the filter of the index.
+func isIndexUsable(index client.IndexDescription, f *mapper.Filter) bool {
+	if len(index.Filter) == 0 {
+		return true
+	}
+	return f != nil && filter.Implies(f.ExternalConditions, index.Filter)
+}
+
+// isJSONPathFiltered returns true if the first field of the given index has no JSON path or
+// if the filter of the scan has a condition that the index can serve on the value at the path.
+func isJSONPathFiltered(scanNode *scanNode, index client.IndexDescription) bool {
+	path := index.Fields[0].JSONPath
+	if len(path) == 0 {
+		return true
+	}
+	fieldIndex := scanNode.documentMapping.FirstIndexOfName(index.Fields[0].Name)
+	var cond any
+	for key, c := range scanNode.filter.Conditions {
+		if prop, ok := key.(*mapper.PropertyIndex); ok && prop.Index == fieldIndex {
+			cond = c
+			break
+		}
+	}
+	for _, name := range path {
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok {
+			return false
+		}
+		cond = nil
+		for key, c := range condMap {
+			if prop, ok := key.(*mapper.ObjectProperty); ok && prop.Name == name {
+				cond = c
+				break
+			}
+		}
+	}
+	condMap, ok := cond.(map[connor.FilterKey]any)
+	if !ok {
+		return false
+	}
+	for key := range condMap {
+		op, ok := key.(*mapper.Operator)
+		if !ok {
+			continue
+		}
+		switch op.Operation {
+		case connor.EqualOp, connor.InOp, connor.GreaterOp, connor.GreaterOrEqualOp,
+			connor.LesserOp, connor.LesserOrEqualOp, connor.AnyOp:
+			return true
+		}
+	}
+	return false
+}
+
+// findIndexByOrder returns an index that yields the documents of the scan in the given order, if any.
+//
+// The order conditions must match the leading fields of the index, including their direction.
+func findIndexByOrder(scanNode *scanNode, ordering []mapper.OrderCondition) immutable.Option[client.IndexDescription] {
+	if len(ordering) == 0 {
+		return immutable.None[client.IndexDescription]()
+	}
+	for _, index := range scanNode.col.Description().Indexes {
+		if isIndexMatchingOrder(scanNode, index, ordering) && isIndexUsable(index, scanNode.filter) {
+			return immutable.Some(index)
 		}
 	}
 	return immutable.None[client.IndexDescription]()
 }
 
+// isIndexMatchingOrder returns true if the leading fields of the index match the given order conditions.
+//
+// Only conditions on scalar fields of the scanned collection can be matched.
+func isIndexMatchingOrder(
+	scanNode *scanNode,
+	index client.IndexDescription,
+	ordering []mapper.OrderCondition,
+) bool {
+	if index.Type != client.IndexTypeValue || len(ordering) == 0 || len(ordering) > len(index.Fields) {
+		return false
+	}
+	for i, cond := range ordering {
+		if len(cond.FieldIndexes) != 1 {
+			return false
+		}
+		fieldName, found := scanNode.documentMapping.TryToFindNameFromIndex(cond.FieldIndexes[0])
+		// values at a JSON path are not in the order of the field
+		if !found || fieldName != index.Fields[i].Name || len(index.Fields[i].JSONPath) > 0 {
+			return false
+		}
+		field, ok := scanNode.col.Definition().GetFieldByName(fieldName)
+		if !ok || field.Kind.IsArray() || field.Kind.IsObject() {
+			return false
+		}
+		if index.Fields[i].Descending != (cond.Direction == mapper.DESC) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIndexOrderKept returns true if fetching the documents through the index with the given
+// index filter yields them in the order of the index.
+//
+// Documents filtered by an `_in` condition on the first field of the index are fetched in the
+// order of the condition values, and an empty condition makes the index unusable.
+func isIndexOrderKept(scanNode *scanNode, index client.IndexDescription, indexFilter *mapper.Filter) bool {
+	if indexFilter == nil {
+		return true
+	}
+	fieldIndex := scanNode.documentMapping.FirstIndexOfName(index.Fields[0].Name)
+	for key, cond := range indexFilter.Conditions {
+		prop, ok := key.(*mapper.PropertyIndex)
+		if !ok || prop.Index != fieldIndex {
+			continue
+		}
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok || len(condMap) == 0 {
+			return false
+		}
+		for opKey := range condMap {
+			if op, ok := opKey.(*mapper.Operator); ok && op.Operation == request.FilterOpIn {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, error) {
 	aggregates := []aggregateNode{}
 	// loop over the sub type
@@ -484,6 +669,7 @@ func (p *Planner) SelectFromSource(
 		selectNode: s,
 		limit:      limitPlan,
 		order:      orderPlan,
+		pagination: p.Pagination(selectReq, orderBy),
 		group:      groupPlan,
 		aggregates: aggregates,
 		docMapper:  docMapper{selectReq.DocumentMapping},
@@ -528,6 +714,7 @@ func (p *Planner) Select(selectReq *mapper.Select) (planNode, error) {
 		selectNode: s,
 		limit:      limitPlan,
 		order:      orderPlan,
+		pagination: p.Pagination(selectReq, orderBy),
 		group:      groupPlan,
 		aggregates: aggregates,
 		docMapper:  docMapper{selectReq.DocumentMapping},
@@ -0,0 +1,113 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"math"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	// minScanDocCount is the number of documents a collection must have for a full scan of it
+	// to be preferred over an index that is estimated to match too many of its documents.
+	//
+	// Below it, every index is used as fetching through it is cheap regardless.
+	minScanDocCount = 100
+	// maxIndexRowsDivisor bounds the estimated number of documents fetched through an index
+	// for the index to be preferred over a full scan, as a fraction of the documents of the collection.
+	//
+	// Fetching through an index costs more per document than a scan, so an index is only used
+	// if it is estimated to match at most half of the documents.
+	maxIndexRowsDivisor = 2
+	// rangeRowsDivisor is the fraction of the documents of a collection that a range of
+	// values is estimated to match.
+	rangeRowsDivisor = 3
+)
+
+// getStatistics returns the statistics of the given collection, if they are known.
+//
+// The statistics are read once per planner, so all the plans of a request are made
+// with the same statistics.
+func (p *Planner) getStatistics(
+	def client.CollectionDefinition,
+) (immutable.Option[client.CollectionStatistics], error) {
+	if p.statistics == nil || !def.Description.Name.HasValue() {
+		return immutable.None[client.CollectionStatistics](), nil
+	}
+	name := def.Description.Name.Value()
+	if stats, ok := p.stats[name]; ok {
+		return stats, nil
+	}
+	stats, err := p.statistics(name)
+	if err != nil {
+		return immutable.None[client.CollectionStatistics](), err
+	}
+	if p.stats == nil {
+		p.stats = make(map[string]immutable.Option[client.CollectionStatistics])
+	}
+	p.stats[name] = stats
+	return stats, nil
+}
+
+// indexValueRows returns the estimated number of documents fetched per value looked up
+// in the given index.
+func indexValueRows(
+	index client.IndexDescription,
+	stats immutable.Option[client.CollectionStatistics],
+) uint64 {
+	if index.Unique && len(index.Fields) == 1 {
+		return uniqueIndexValueCost
+	}
+	if !stats.HasValue() {
+		return indexValueCost
+	}
+	var distinct uint64
+	found := false
+	for _, indexStats := range stats.Value().Indexes {
+		if indexStats.IndexName == index.Name {
+			distinct = indexStats.DistinctKeys
+			found = true
+			break
+		}
+	}
+	if !found {
+		return indexValueCost
+	}
+	if distinct == 0 {
+		// the index is empty
+		return 1
+	}
+	// the distinct keys are the combinations of the values of all the indexed fields, while only
+	// the first field is looked up, so the values of the fields are assumed to be independent.
+	fieldDistinct := math.Pow(float64(distinct), 1/float64(len(index.Fields)))
+	return max(uint64(math.Ceil(float64(stats.Value().DocumentCount)/fieldDistinct)), 1)
+}
+
+// indexRangeRows returns the estimated number of documents fetched for a range of values
+// scanned in an index.
+func indexRangeRows(stats immutable.Option[client.CollectionStatistics]) uint64 {
+	if !stats.HasValue() {
+		return indexRangeCost
+	}
+	return max(stats.Value().DocumentCount/rangeRowsDivisor, 1)
+}
+
+// isScanCheaper returns true if scanning all the documents of the collection with the given
+// statistics is estimated to be cheaper than fetching the given number of documents through an index.
+func isScanCheaper(stats immutable.Option[client.CollectionStatistics], indexRows uint64) bool {
+	if !stats.HasValue() || stats.Value().DocumentCount < minScanDocCount {
+		return false
+	}
+	return indexRows > stats.Value().DocumentCount/maxIndexRowsDivisor
+}
@@ -323,6 +323,17 @@ func (p *Planner) newInvertableTypeJoin(
 		}
 	}
 
+	var pageInfos []*mapper.PageInfo
+	var childPagination *paginationNode
+	if top, ok := subSelectPlan.(*selectTopNode); ok && top.pagination != nil {
+		childPagination = top.pagination
+		for _, field := range parent.selectReq.Fields {
+			if pageInfo, ok := field.(*mapper.PageInfo); ok && pageInfo.Target == subSelect.Index {
+				pageInfos = append(pageInfos, pageInfo)
+			}
+		}
+	}
+
 	subCol, err := p.db.GetCollectionByName(p.ctx, subSelect.CollectionName)
 	if err != nil {
 		return invertibleTypeJoin{}, err
@@ -373,15 +384,23 @@ func (p *Planner) newInvertableTypeJoin(
 	}
 
 	return invertibleTypeJoin{
-		docMapper:  docMapper{parent.documentMapping},
-		parentSide: parentSide,
-		childSide:  childSide,
-		skipChild:  skipChild,
+		docMapper:       docMapper{parent.documentMapping},
+		parentSide:      parentSide,
+		childSide:       childSide,
+		skipChild:       skipChild,
+		pageInfos:       pageInfos,
+		childPagination: childPagination,
 	}, nil
 }
 
 type joinSide struct {
 	plan planNode
+	// relatedPlan, if set, is used instead of plan to fetch the documents of this side that
+	// are related to a document of the other side.
+	//
+	// It is set if the join is driven by a plan that yields only the documents of this side
+	// that may match the filter of the parent, while all the related documents need to be yielded.
+	relatedPlan planNode
 	// The field definition of the relation-object field on this side of the relation.
 	//
 	// This will always have a value on the primary side, but it may not have a value on
@@ -394,6 +413,15 @@ type joinSide struct {
 	isParent           bool
 }
 
+// getRelatedPlan returns the plan to fetch the documents of this side that are related
+// to a document of the other side.
+func (s *joinSide) getRelatedPlan() planNode {
+	if s.relatedPlan != nil {
+		return s.relatedPlan
+	}
+	return s.plan
+}
+
 func (s *joinSide) isPrimary() bool {
 	return s.relFieldDef.HasValue() && s.relFieldDef.Value().IsPrimaryRelation
 }
@@ -470,6 +498,12 @@ type invertibleTypeJoin struct {
 	parentSide joinSide
 	childSide  joinSide
 
+	// pageInfos contains the page infos of the child documents requested on the parent.
+	pageInfos []*mapper.PageInfo
+	// childPagination is the pagination node of the child plan, it is set if the child
+	// documents are paginated.
+	childPagination *paginationNode
+
 	secondaryFetchLimit uint
 
 	// docsToYield contains documents read and ready to be yielded by this node.
@@ -485,6 +519,11 @@ func (join *invertibleTypeJoin) Init() error {
 	if err := join.childSide.plan.Init(); err != nil {
 		return err
 	}
+	if join.childSide.relatedPlan != nil {
+		if err := join.childSide.relatedPlan.Init(); err != nil {
+			return err
+		}
+	}
 	return join.parentSide.plan.Init()
 }
 
@@ -492,6 +531,11 @@ func (join *invertibleTypeJoin) Start() error {
 	if err := join.childSide.plan.Start(); err != nil {
 		return err
 	}
+	if join.childSide.relatedPlan != nil {
+		if err := join.childSide.relatedPlan.Start(); err != nil {
+			return err
+		}
+	}
 	return join.parentSide.plan.Start()
 }
 
@@ -499,6 +543,11 @@ func (join *invertibleTypeJoin) Close() error {
 	if err := join.parentSide.plan.Close(); err != nil {
 		return err
 	}
+	if join.childSide.relatedPlan != nil {
+		if err := join.childSide.relatedPlan.Close(); err != nil {
+			return err
+		}
+	}
 
 	return join.childSide.plan.Close()
 }
@@ -537,7 +586,7 @@ func (r *primaryObjectsRetriever) retrievePrimaryDocsReferencingSecondaryDoc() e
 		return client.NewErrFieldNotExist(r.primarySide.relFieldDef.Value().Name + request.RelatedObjectID)
 	}
 
-	r.primaryScan = getScanNode(r.primarySide.plan)
+	r.primaryScan = getScanNode(r.primarySide.getRelatedPlan())
 
 	r.relIDFieldDef = relIDFieldDef
 
@@ -553,7 +602,7 @@ func (r *primaryObjectsRetriever) retrievePrimaryDocsReferencingSecondaryDoc() e
 }
 
 func (r *primaryObjectsRetriever) collectDocs(numDocs int) ([]core.Doc, error) {
-	p := r.primarySide.plan
+	p := r.primarySide.getRelatedPlan()
 	// If the primary side is a multiScanNode, we need to get the source node, as we are the only
 	// consumer (one, not multiple) of it.
 	if multiScan, ok := p.(*multiScanNode); ok {
@@ -685,6 +734,7 @@ func (join *invertibleTypeJoin) Next() (bool, error) {
 		if join.parentSide.isPrimary() {
 			join.docsToYield = append(join.docsToYield, primaryDocs...)
 		} else {
+			join.setPageInfos(secondaryDoc)
 			join.docsToYield = append(join.docsToYield, secondaryDoc)
 		}
 
@@ -746,6 +796,7 @@ func (join *invertibleTypeJoin) nextJoinedSecondaryDoc() (bool, error) {
 			if err != nil {
 				return false, err
 			}
+			join.setPageInfos(secondaryDoc)
 		}
 		secondaryDoc.Fields[join.parentSide.relFieldMapIndex.Value()] = primaryDocs
 
@@ -758,6 +809,15 @@ func (join *invertibleTypeJoin) nextJoinedSecondaryDoc() (bool, error) {
 	return true, nil
 }
 
+// setPageInfos sets the page infos of the child documents on the given parent document, it
+// must be called once the child documents of the parent have been fetched.
+func (join *invertibleTypeJoin) setPageInfos(parentDoc core.Doc) {
+	for _, pageInfo := range join.pageInfos {
+		mapping := join.documentMapping.ChildMappings[pageInfo.Index]
+		parentDoc.Fields[pageInfo.Index] = join.childPagination.pageInfo(mapping)
+	}
+}
+
 func (join *invertibleTypeJoin) Value() core.Doc {
 	if len(join.docsToYield) == 0 {
 		return core.Doc{}
@@ -781,6 +841,21 @@ func (join *invertibleTypeJoin) invertJoinDirectionWithIndex(
 	return nil
 }
 
+// invertJoinDirectionWithDriver inverts the join so that it is driven by the given plan,
+// which yields the documents of the child side that may match the filter of the parent.
+//
+// The original plan of the child side is kept to fetch all the documents related to a parent.
+func (join *invertibleTypeJoin) invertJoinDirectionWithDriver(driver planNode) {
+	if s := getScanNode(driver); s != nil {
+		s.tryAddFieldWithName(join.childSide.relFieldDef.Value().Name + request.RelatedObjectID)
+	}
+	join.childSide.relatedPlan = join.childSide.plan
+	join.childSide.plan = driver
+
+	join.childSide.isFirst = join.parentSide.isFirst
+	join.parentSide.isFirst = !join.parentSide.isFirst
+}
+
 func addFilterOnIDField(scan *scanNode, propIndex int, val any) {
 	if scan == nil {
 		return
@@ -32,6 +32,15 @@ type updateNode struct {
 	// input map of fields and values
 	input map[string]any
 
+	// addInput is a map of set fields and the elements to add to them
+	addInput map[string]any
+
+	// removeInput is a map of set fields and the elements to remove from them
+	removeInput map[string]any
+
+	// textPatchInput is a map of text fields and the patches to apply to them
+	textPatchInput map[string]any
+
 	isUpdating bool
 
 	results planNode
@@ -76,6 +85,12 @@ func (n *updateNode) Next() (bool, error) {
 					return false, err
 				}
 			}
+			if err := applySetInputs(doc, n.addInput, n.removeInput); err != nil {
+				return false, err
+			}
+			if err := applyTextPatches(doc, n.textPatchInput); err != nil {
+				return false, err
+			}
 			err = n.collection.Update(n.p.ctx, doc)
 			if err != nil {
 				return false, err
@@ -137,6 +152,19 @@ func (n *updateNode) simpleExplain() (map[string]any, error) {
 	// Add the attribute that represents the patch to update with.
 	simpleExplainMap[inputLabel] = n.input
 
+	// Add the set elements to add and remove, if any were requested.
+	if len(n.addInput) > 0 {
+		simpleExplainMap[request.AddInput] = n.addInput
+	}
+	if len(n.removeInput) > 0 {
+		simpleExplainMap[request.RemoveInput] = n.removeInput
+	}
+
+	// Add the text patches, if any were requested.
+	if len(n.textPatchInput) > 0 {
+		simpleExplainMap[request.TextPatchInput] = n.textPatchInput
+	}
+
 	return simpleExplainMap, nil
 }
 
@@ -160,12 +188,15 @@ func (n *updateNode) Explain(explainType request.ExplainType) (map[string]any, e
 
 func (p *Planner) UpdateDocs(parsed *mapper.Mutation) (planNode, error) {
 	update := &updateNode{
-		p:          p,
-		filter:     parsed.Filter,
-		docIDs:     parsed.DocIDs.Value(),
-		input:      parsed.UpdateInput,
-		isUpdating: true,
-		docMapper:  docMapper{parsed.DocumentMapping},
+		p:              p,
+		filter:         parsed.Filter,
+		docIDs:         parsed.DocIDs.Value(),
+		input:          parsed.UpdateInput,
+		addInput:       parsed.AddInput,
+		removeInput:    parsed.RemoveInput,
+		textPatchInput: parsed.TextPatchInput,
+		isUpdating:     true,
+		docMapper:      docMapper{parsed.DocumentMapping},
 	}
 
 	// get collection
@@ -184,3 +215,120 @@ func (p *Planner) UpdateDocs(parsed *mapper.Mutation) (planNode, error) {
 
 	return update, nil
 }
+
+// applySetInputs adds the given elements to, and removes the given elements from, the
+// set fields of the document.
+//
+// Elements are compared using their CBOR encoding, if an element is both added and
+// removed it will be removed.
+func applySetInputs(doc *client.Document, addInput, removeInput map[string]any) error {
+	if len(addInput) == 0 && len(removeInput) == 0 {
+		return nil
+	}
+	em, err := client.CborEncodingOptions().EncMode()
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]struct{}, len(addInput)+len(removeInput))
+	for field := range addInput {
+		fields[field] = struct{}{}
+	}
+	for field := range removeInput {
+		fields[field] = struct{}{}
+	}
+
+	for field := range fields {
+		removed := make(map[string]struct{})
+		for _, element := range toSetElements(removeInput[field]) {
+			key, err := em.Marshal(element)
+			if err != nil {
+				return err
+			}
+			removed[string(key)] = struct{}{}
+		}
+
+		current, err := getSetElements(doc, field)
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[string]struct{})
+		elements := []any{}
+		for _, element := range append(current, toSetElements(addInput[field])...) {
+			key, err := em.Marshal(element)
+			if err != nil {
+				return err
+			}
+			if _, ok := removed[string(key)]; ok {
+				continue
+			}
+			if _, ok := seen[string(key)]; ok {
+				continue
+			}
+			seen[string(key)] = struct{}{}
+			elements = append(elements, element)
+		}
+
+		err = doc.Set(field, elements)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSetElements returns the current elements of the given set field.
+func getSetElements(doc *client.Document, field string) ([]any, error) {
+	val, err := doc.TryGetValue(field)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	if val.NormalValue().IsNil() {
+		return nil, nil
+	}
+	normalElements, err := client.ToArrayOfNormalValues(val.NormalValue())
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]any, len(normalElements))
+	for i, element := range normalElements {
+		elements[i] = element.Unwrap()
+	}
+	return elements, nil
+}
+
+// toSetElements returns the given set input value as a slice of elements.
+func toSetElements(value any) []any {
+	elements, _ := value.([]any)
+	return elements
+}
+
+// applyTextPatches applies the given positional patches to the text fields of the document.
+func applyTextPatches(doc *client.Document, textPatchInput map[string]any) error {
+	for field, value := range textPatchInput {
+		patchInputs, _ := value.([]any)
+		if len(patchInputs) == 0 {
+			continue
+		}
+
+		patches := make([]client.TextPatch, len(patchInputs))
+		for i, patch := range patchInputs {
+			patchMap, _ := patch.(map[string]any)
+			index, _ := patchMap[request.TextPatchIndex].(int32)
+			deleteCount, _ := patchMap[request.TextPatchDelete].(int32)
+			insert, _ := patchMap[request.TextPatchInsert].(string)
+			patches[i] = client.TextPatch{
+				Index:  int(index),
+				Delete: int(deleteCount),
+				Insert: insert,
+			}
+		}
+
+		err := doc.PatchText(field, patches)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package graphql
+
+import (
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/sourcenetwork/graphql-go/language/ast"
+
+	"github.com/sourcenetwork/defradb/internal/request/graphql/schema"
+)
+
+// DefaultRequestCacheSize is the default number of validated requests held by the cache of the parser.
+const DefaultRequestCacheSize = 1000
+
+// cachedRequest is a request that has been built and validated against a schema.
+type cachedRequest struct {
+	// schema is the schema the request was validated against.
+	schema *schema.SchemaManager
+	ast    *ast.Document
+}
+
+// requestCache holds the validated ASTs of the most recently executed requests, by normalized request.
+//
+// A nil cache holds nothing.
+type requestCache struct {
+	requests *lru.Cache[string, cachedRequest]
+}
+
+func newRequestCache(size int) (*requestCache, error) {
+	if size <= 0 {
+		return nil, nil
+	}
+	requests, err := lru.New[string, cachedRequest](size)
+	if err != nil {
+		return nil, err
+	}
+	return &requestCache{requests: requests}, nil
+}
+
+// get returns the cached AST of the given normalized request, if it was validated against the given schema.
+func (c *requestCache) get(request string, schema *schema.SchemaManager) (*ast.Document, bool) {
+	if c == nil {
+		return nil, false
+	}
+	cached, ok := c.requests.Get(request)
+	if !ok || cached.schema != schema {
+		return nil, false
+	}
+	return cached.ast, true
+}
+
+// add caches the AST of the given normalized request, validated against the given schema.
+func (c *requestCache) add(request string, schema *schema.SchemaManager, doc *ast.Document) {
+	if c == nil {
+		return
+	}
+	c.requests.Add(request, cachedRequest{schema: schema, ast: doc})
+}
+
+// purge removes all the cached requests.
+func (c *requestCache) purge() {
+	if c == nil {
+		return
+	}
+	c.requests.Purge()
+}
+
+// NormalizeRequest returns the given request without its comments and insignificant whitespace,
+// so that requests only differing by their formatting share the same cached AST.
+func NormalizeRequest(request string) string {
+	var builder strings.Builder
+	builder.Grow(len(request))
+
+	var last byte
+	pendingSpace := false
+	write := func(token string) {
+		// whitespace is only significant between two names or numbers, and it
+		// is kept around strings so that they are not merged into block strings.
+		if pendingSpace && last != 0 &&
+			(isNameByte(last) && isNameByte(token[0]) || last == '"' || token[0] == '"') {
+			builder.WriteByte(' ')
+		}
+		pendingSpace = false
+		builder.WriteString(token)
+		last = token[len(token)-1]
+	}
+
+	for i := 0; i < len(request); {
+		c := request[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			pendingSpace = true
+			i++
+
+		case c == '#':
+			for i < len(request) && request[i] != '\n' && request[i] != '\r' {
+				i++
+			}
+			pendingSpace = true
+
+		case c == '"':
+			end := stringEnd(request, i)
+			write(request[i:end])
+			i = end
+
+		default:
+			write(request[i : i+1])
+			i++
+		}
+	}
+	return builder.String()
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// stringEnd returns the position following the end of the string, or block string, starting
+// at the given position. Unterminated strings end with the request.
+func stringEnd(request string, start int) int {
+	if strings.HasPrefix(request[start:], `"""`) {
+		for i := start + 3; i < len(request); i++ {
+			switch {
+			case strings.HasPrefix(request[i:], `\"""`):
+				i += 3
+			case strings.HasPrefix(request[i:], `"""`):
+				return i + 3
+			}
+		}
+		return len(request)
+	}
+	for i := start + 1; i < len(request); i++ {
+		switch request[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(request)
+}
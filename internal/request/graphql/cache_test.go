@@ -0,0 +1,53 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRequest(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+	}{
+		{
+			"query {\n\tUsers {\n\t\tName\n\t}\n}",
+			"query{Users{Name}}",
+		},
+		{
+			"query { Users(limit: 1, offset: 2) { Name, Age } } # users",
+			"query{Users(limit:1 offset:2){Name Age}}",
+		},
+		{
+			`query { Users(filter: {Name: {_eq: "Alice  # not a comment"}}) { Name } }`,
+			`query{Users(filter:{Name:{_eq: "Alice  # not a comment"}}){Name}}`,
+		},
+		{
+			`query { Users(filter: {Name: {_eq: "a \" b"}}) { Name } }`,
+			`query{Users(filter:{Name:{_eq: "a \" b"}}){Name}}`,
+		},
+		{
+			"query { Users(filter: {Name: {_eq: \"\"\"a\n  \"b\"\n\"\"\"}}) { Name } }",
+			"query{Users(filter:{Name:{_eq: \"\"\"a\n  \"b\"\n\"\"\"}}){Name}}",
+		},
+		{
+			"query { Users { ... UserFields } }",
+			"query{Users{...UserFields}}",
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expect, NormalizeRequest(c.input))
+	}
+}
@@ -12,6 +12,7 @@ package graphql
 
 import (
 	"context"
+	"sync/atomic"
 
 	gql "github.com/sourcenetwork/graphql-go"
 	"github.com/sourcenetwork/graphql-go/language/ast"
@@ -31,16 +32,30 @@ var _ core.Parser = (*parser)(nil)
 
 type parser struct {
 	schemaManager *schema.SchemaManager
+
+	// requests holds the validated ASTs of the most recently prepared requests.
+	requests *requestCache
+
+	// schemaVersion is incremented every time the schema is set.
+	schemaVersion atomic.Uint64
 }
 
-func NewParser() (*parser, error) {
+// NewParser returns a new parser, caching the validated ASTs of up to the given number of
+// prepared requests. A cache size of zero or less disables the cache.
+func NewParser(cacheSize int) (*parser, error) {
 	schemaManager, err := schema.NewSchemaManager()
 	if err != nil {
 		return nil, err
 	}
 
+	requests, err := newRequestCache(cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &parser{
 		schemaManager: schemaManager,
+		requests:      requests,
 	}
 
 	return p, nil
@@ -83,20 +98,58 @@ func (p *parser) ExecuteIntrospection(request string) *client.RequestResult {
 	return res
 }
 
+func (p *parser) PrepareRequest(request string) (*ast.Document, []error) {
+	schemaManager := p.schemaManager
+	normalized := NormalizeRequest(request)
+	if doc, ok := p.requests.get(normalized, schemaManager); ok {
+		return doc, nil
+	}
+
+	doc, err := p.BuildRequestAST(request)
+	if err != nil {
+		return nil, []error{err}
+	}
+	// introspection requests are validated when they are executed.
+	if !defrap.IsIntrospectionQuery(*schemaManager.Schema(), doc) {
+		if errs := validate(schemaManager.Schema(), doc); len(errs) > 0 {
+			return nil, errs
+		}
+	}
+
+	p.requests.add(normalized, schemaManager, doc)
+	return doc, nil
+}
+
 func (p *parser) Parse(ast *ast.Document, options *client.GQLOptions) (*request.Request, []error) {
 	schema := p.schemaManager.Schema()
-	validationResult := gql.ValidateDocument(schema, ast, nil)
-	if !validationResult.IsValid {
-		errors := make([]error, len(validationResult.Errors))
-		for i, err := range validationResult.Errors {
-			errors[i] = err
-		}
-		return nil, errors
+	if errs := validate(schema, ast); len(errs) > 0 {
+		return nil, errs
 	}
 
 	return defrap.ParseRequest(*schema, ast, options)
 }
 
+func (p *parser) ParsePrepared(ast *ast.Document, options *client.GQLOptions) (*request.Request, []error) {
+	return defrap.ParseRequest(*p.schemaManager.Schema(), ast, options)
+}
+
+func (p *parser) SchemaVersion() uint64 {
+	return p.schemaVersion.Load()
+}
+
+// validate validates the given request AST against the given schema.
+func validate(schema *gql.Schema, ast *ast.Document) []error {
+	validationResult := gql.ValidateDocument(schema, ast, nil)
+	if validationResult.IsValid {
+		return nil
+	}
+	errors := make([]error, len(validationResult.Errors))
+	for i, err := range validationResult.Errors {
+		errors[i] = err
+	}
+	return errors
+}
+
 func (p *parser) ParseSDL(sdl string) ([]client.CollectionDefinition, error) {
 	return p.schemaManager.ParseSDL(sdl)
 }
@@ -115,6 +168,9 @@ func (p *parser) SetSchema(ctx context.Context, txn datastore.Txn, collections [
 	txn.OnSuccess(
 		func() {
 			p.schemaManager = schemaManager
+			p.schemaVersion.Add(1)
+			// the cached requests were validated against the previous schema.
+			p.requests.purge()
 		},
 	)
 	return err
@@ -26,4 +26,5 @@ var (
 	ErrUnknownGQLOperation            = errors.New("unknown GraphQL operation type")
 	ErrInvalidFilterConditions        = errors.New("invalid filter condition type, expected map")
 	ErrMultipleOrderFieldsDefined     = errors.New("each order argument can only define one field")
+	ErrNegativeAsOfHeight             = errors.New("asOf height cannot be negative")
 )
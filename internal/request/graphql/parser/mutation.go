@@ -181,6 +181,21 @@ func parseUpdateMutationArgs(mut *request.ObjectMutation, args map[string]any) {
 				mut.UpdateInput = v
 			}
 
+		case request.AddInput:
+			if v, ok := value.(map[string]any); ok {
+				mut.AddInput = v
+			}
+
+		case request.RemoveInput:
+			if v, ok := value.(map[string]any); ok {
+				mut.RemoveInput = v
+			}
+
+		case request.TextPatchInput:
+			if v, ok := value.(map[string]any); ok {
+				mut.TextPatchInput = v
+			}
+
 		case request.DocIDArgName:
 			v, ok := value.([]any)
 			if !ok {
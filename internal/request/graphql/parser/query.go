@@ -11,6 +11,8 @@
 package parser
 
 import (
+	"time"
+
 	gql "github.com/sourcenetwork/graphql-go"
 	"github.com/sourcenetwork/graphql-go/language/ast"
 	"github.com/sourcenetwork/immutable"
@@ -35,6 +37,8 @@ func parseQueryOperationDefinition(
 				}
 
 				parsedSelection = parsed
+			} else if field.Name.Value == request.PageInfoFieldName {
+				parsedSelection = parsePageInfo(exe, exe.Schema.QueryType(), field)
 			} else if _, isAggregate := request.Aggregates[field.Name.Value]; isAggregate {
 				parsed, err := parseAggregate(exe, exe.Schema.QueryType(), field)
 				if err != nil {
@@ -125,6 +129,15 @@ func parseSelect(
 				slct.CID = immutable.Some(v)
 			}
 
+		case request.AsOfClause: // parse point in time query field
+			if v, ok := value.(map[string]any); ok {
+				asOf, err := parseAsOf(v)
+				if err != nil {
+					return nil, err
+				}
+				slct.AsOf = immutable.Some(asOf)
+			}
+
 		case request.LimitClause: // parse limit/offset
 			if v, ok := value.(int32); ok {
 				slct.Limit = immutable.Some(uint64(v))
@@ -135,6 +148,26 @@ func parseSelect(
 				slct.Offset = immutable.Some(uint64(v))
 			}
 
+		case request.FirstClause: // parse cursor pagination
+			if v, ok := value.(int32); ok {
+				slct.First = immutable.Some(uint64(v))
+			}
+
+		case request.AfterClause: // parse cursor pagination
+			if v, ok := value.(string); ok {
+				slct.After = immutable.Some(v)
+			}
+
+		case request.LastClause: // parse cursor pagination
+			if v, ok := value.(int32); ok {
+				slct.Last = immutable.Some(uint64(v))
+			}
+
+		case request.BeforeClause: // parse cursor pagination
+			if v, ok := value.(string); ok {
+				slct.Before = immutable.Some(v)
+			}
+
 		case request.OrderClause: // parse order by
 			v, ok := value.([]any)
 			if !ok {
@@ -187,6 +220,52 @@ func parseSelect(
 	return slct, err
 }
 
+// parseAsOf parses the point in history given to the asOf argument.
+func parseAsOf(arguments map[string]any) (request.AsOf, error) {
+	var asOf request.AsOf
+	if v, ok := arguments[request.AsOfHeight].(int32); ok {
+		if v < 0 {
+			return request.AsOf{}, ErrNegativeAsOfHeight
+		}
+		asOf.Height = immutable.Some(uint64(v))
+	}
+	if v, ok := arguments[request.AsOfTime].(time.Time); ok {
+		asOf.Time = immutable.Some(v)
+	}
+	return asOf, nil
+}
+
+// parsePageInfo parses a page info selection, the selection it describes is resolved
+// against its siblings by the mapper.
+func parsePageInfo(
+	exe *gql.ExecutionContext,
+	parent *gql.Object,
+	field *ast.Field,
+) *request.PageInfo {
+	pageInfo := &request.PageInfo{
+		Field: request.Field{
+			Name:  field.Name.Value,
+			Alias: getFieldAlias(field),
+		},
+	}
+
+	fieldDef := gql.GetFieldDef(exe.Schema, parent, field.Name.Value)
+	arguments := gql.GetArgumentValues(fieldDef.Args, field.Arguments, exe.VariableValues)
+	if v, ok := arguments[request.OfClause].(string); ok {
+		pageInfo.Of = v
+	}
+
+	if field.SelectionSet == nil {
+		return pageInfo
+	}
+	for _, selection := range field.SelectionSet.Selections {
+		if node, ok := selection.(*ast.Field); ok {
+			pageInfo.Fields = append(pageInfo.Fields, parseField(node))
+		}
+	}
+	return pageInfo
+}
+
 func parseAggregate(
 	exe *gql.ExecutionContext,
 	parent *gql.Object,
@@ -207,6 +207,8 @@ func parseSelectFields(
 					return nil, err
 				}
 				selection = s
+			} else if node.Name.Value == request.PageInfoFieldName {
+				selection = parsePageInfo(exe, parent, node)
 			} else if node.SelectionSet == nil { // regular field
 				selection = parseField(node)
 			} else { // sub type with extra fields
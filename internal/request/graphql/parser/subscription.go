@@ -59,6 +59,10 @@ func parseSubscription(exe *gql.ExecutionContext, field *ast.Field) (*request.Ob
 		sub.Filter = immutable.Some(request.Filter{Conditions: v})
 	}
 
+	if v, ok := arguments[request.ShowDeleted].(bool); ok {
+		sub.ShowDeleted = v
+	}
+
 	// parse field selections
 	fieldObject, err := typeFromFieldDef(fieldDef)
 	if err != nil {
@@ -282,6 +282,9 @@ func IsValidIndexName(name string) bool {
 func indexFromAST(directive *ast.Directive, fieldDef *ast.FieldDefinition) (client.IndexDescription, error) {
 	var name string
 	var unique bool
+	var indexType client.IndexType
+	var filter map[string]any
+	var path []string
 
 	var direction *ast.EnumValue
 	var includes *ast.ListValue
@@ -319,6 +322,37 @@ func indexFromAST(directive *ast.Directive, fieldDef *ast.FieldDefinition) (clie
 			}
 			unique = uniqueVal.Value
 
+		case types.IndexDirectivePropType:
+			typeVal, ok := arg.Value.(*ast.EnumValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			switch typeVal.Value {
+			case types.IndexTypeValue:
+				indexType = client.IndexTypeValue
+			case types.IndexTypeFullText:
+				indexType = client.IndexTypeFullText
+			default:
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+
+		case types.IndexDirectivePropFilter:
+			filterVal, ok := types.JSONScalarType().ParseLiteral(arg.Value, nil).(map[string]any)
+			if !ok || len(filterVal) == 0 {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			filter = filterVal
+
+		case types.IndexDirectivePropPath:
+			if fieldDef == nil {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			pathVal, err := jsonPathFromAST(arg.Value)
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			path = pathVal
+
 		default:
 			return client.IndexDescription{}, ErrIndexWithUnknownArg
 		}
@@ -345,7 +379,8 @@ func indexFromAST(directive *ast.Directive, fieldDef *ast.FieldDefinition) (clie
 	// implicitly add it as the first entry
 	if !containsField && fieldDef != nil {
 		field := client.IndexedFieldDescription{
-			Name: fieldDef.Name.Value,
+			Name:     fieldDef.Name.Value,
+			JSONPath: path,
 		}
 		if direction != nil {
 			field.Descending = direction.Value == types.FieldOrderDESC
@@ -361,6 +396,8 @@ func indexFromAST(directive *ast.Directive, fieldDef *ast.FieldDefinition) (clie
 		Name:   name,
 		Fields: fields,
 		Unique: unique,
+		Type:   indexType,
+		Filter: filter,
 	}, nil
 }
 
@@ -372,6 +409,7 @@ func indexFieldFromAST(value ast.Value, defaultDirection *ast.EnumValue) (client
 
 	var name string
 	var direction *ast.EnumValue
+	var path []string
 
 	for _, field := range argTypeObject.Fields {
 		switch field.Name.Value {
@@ -389,6 +427,13 @@ func indexFieldFromAST(value ast.Value, defaultDirection *ast.EnumValue) (client
 			}
 			direction = directionVal
 
+		case types.IncludesPropPath:
+			pathVal, err := jsonPathFromAST(field.Value)
+			if err != nil {
+				return client.IndexedFieldDescription{}, err
+			}
+			path = pathVal
+
 		default:
 			return client.IndexedFieldDescription{}, ErrIndexWithUnknownArg
 		}
@@ -406,9 +451,27 @@ func indexFieldFromAST(value ast.Value, defaultDirection *ast.EnumValue) (client
 	return client.IndexedFieldDescription{
 		Name:       name,
 		Descending: descending,
+		JSONPath:   path,
 	}, nil
 }
 
+// jsonPathFromAST returns the path within a JSON field from the given list of strings.
+func jsonPathFromAST(value ast.Value) ([]string, error) {
+	listVal, ok := value.(*ast.ListValue)
+	if !ok || len(listVal.Values) == 0 {
+		return nil, ErrIndexWithInvalidArg
+	}
+	path := make([]string, len(listVal.Values))
+	for i, elem := range listVal.Values {
+		strVal, ok := elem.(*ast.StringValue)
+		if !ok {
+			return nil, ErrIndexWithInvalidArg
+		}
+		path[i] = strVal.Value
+	}
+	return path, nil
+}
+
 func defaultFromAST(
 	field *ast.FieldDefinition,
 	directive *ast.Directive,
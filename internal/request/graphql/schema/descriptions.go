@@ -95,6 +95,11 @@ An optional filter for this select, only documents matching the given criteria
 	aggregateFilterArgDescription string = `
 An optional filter for this aggregate, only documents matching the given criteria
  will be aggregated.
+`
+	asOfArgDescription string = `
+An optional value that specifies a point in the history of the documents. If given,
+ each document will be returned at the state it was in at that point, and documents
+ that did not exist at that point will not be returned.
 `
 	showDeletedArgDescription string = `
 An optional value that specifies as to whether deleted documents may be
@@ -124,6 +129,19 @@ An optional docID value that will limit the update to the document with
 An optional set of docID values that will limit the update to documents
  with a matching docID. If no matching documents are found, the operation will
  succeed, but no documents will be updated.
+`
+	updateAddArgDescription string = `
+An optional set of elements to add to the set fields of the documents. Concurrent
+ additions made on other nodes will be merged instead of overwritten.
+`
+	updateRemoveArgDescription string = `
+An optional set of elements to remove from the observed-remove set fields of the
+ documents. Only the additions of an element that have been observed will be removed.
+`
+	updatePatchArgDescription string = `
+An optional set of positional patches to apply to the text fields of the documents.
+ Patches are applied in order, and concurrent patches made on other nodes will be merged
+ instead of overwritten.
 `
 	updateFilterArgDescription string = `
 An optional filter for this update that will limit the update to the documents
@@ -166,6 +184,18 @@ Indicates as to whether or not this document has been deleted.
 `
 	versionFieldDescription string = `
 Returns the head commit for this document.
+`
+	cursorFieldDescription string = `
+An opaque cursor marking the position of this document within the results,
+ it may be provided to the 'after' and 'before' arguments to page through
+ the results.
+`
+	kindFieldDescription string = `
+The kind of change made to this document, only set on subscription results.
+`
+	changesFieldDescription string = `
+The previous and current values of the fields changed on this document, keyed by
+ field name. Only set on subscription results.
 `
 
 	encryptArgDescription string = `
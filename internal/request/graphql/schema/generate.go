@@ -30,6 +30,8 @@ import (
 const (
 	filterInputNameSuffix    = "FilterArg"
 	mutationInputNameSuffix  = "MutationInputArg"
+	setMutationInputSuffix   = "SetMutationInputArg"
+	textPatchInputSuffix     = "TextPatchInputArg"
 	mutationInputsNameSuffix = "MutationInputsArg"
 )
 
@@ -400,6 +402,10 @@ func (g *Generator) createExpandedFieldList(
 			),
 			request.LimitClause:  schemaTypes.NewArgConfig(gql.Int, schemaTypes.LimitArgDescription),
 			request.OffsetClause: schemaTypes.NewArgConfig(gql.Int, schemaTypes.OffsetArgDescription),
+			request.FirstClause:  schemaTypes.NewArgConfig(gql.Int, schemaTypes.FirstArgDescription),
+			request.AfterClause:  schemaTypes.NewArgConfig(gql.String, schemaTypes.AfterArgDescription),
+			request.LastClause:   schemaTypes.NewArgConfig(gql.Int, schemaTypes.LastArgDescription),
+			request.BeforeClause: schemaTypes.NewArgConfig(gql.String, schemaTypes.BeforeArgDescription),
 		},
 	}
 
@@ -514,6 +520,29 @@ func (g *Generator) buildTypes(
 					Description: deletedFieldDescription,
 					Type:        gql.Boolean,
 				}
+
+				// add _cursor field
+				fields[request.CursorFieldName] = &gql.Field{
+					Description: cursorFieldDescription,
+					Type:        gql.String,
+				}
+
+				// add _pageInfo field
+				fields[request.PageInfoFieldName] = schemaTypes.QueryPageInfo(
+					g.manager.schema.TypeMap()[request.PageInfoTypeName].(*gql.Object),
+				)
+
+				// add _kind field
+				fields[request.KindFieldName] = &gql.Field{
+					Description: kindFieldDescription,
+					Type:        g.manager.schema.TypeMap()[request.DocChangeKindTypeName],
+				}
+
+				// add _changes field
+				fields[request.ChangesFieldName] = &gql.Field{
+					Description: changesFieldDescription,
+					Type:        schemaTypes.JSONScalarType(),
+				}
 			}
 
 			return fields, nil
@@ -605,11 +634,100 @@ func (g *Generator) buildMutationInputTypes(collections []client.CollectionDefin
 
 		mutationObj := gql.NewInputObject(mutationObjConf)
 		g.manager.schema.TypeMap()[mutationObj.Name()] = mutationObj
+
+		setMutationObj, err := g.buildSetMutationInputType(collection)
+		if err != nil {
+			return err
+		}
+		if setMutationObj != nil {
+			g.manager.schema.TypeMap()[setMutationObj.Name()] = setMutationObj
+		}
+
+		textPatchObj, err := g.buildTextPatchInputType(collection)
+		if err != nil {
+			return err
+		}
+		if textPatchObj != nil {
+			g.manager.schema.TypeMap()[textPatchObj.Name()] = textPatchObj
+		}
 	}
 
 	return nil
 }
 
+// buildSetMutationInputType creates the input object type used to add elements to, and
+// remove elements from, the set fields of the given collection.
+//
+// If the collection has no set fields nil is returned.
+func (g *Generator) buildSetMutationInputType(collection client.CollectionDefinition) (*gql.InputObject, error) {
+	fields := make(gql.InputObjectConfigFieldMap)
+	for _, field := range collection.GetFields() {
+		if field.Typ != client.G_SET && field.Typ != client.OR_SET {
+			continue
+		}
+		ttype, ok := fieldKindToGQLType[field.Kind]
+		if !ok {
+			return nil, NewErrTypeNotFound(fmt.Sprint(field.Kind))
+		}
+		fields[field.Name] = &gql.InputObjectFieldConfig{
+			Type: ttype,
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	setMutationInputName := collection.Description.Name.Value() + setMutationInputSuffix
+	if _, ok := g.manager.schema.TypeMap()[setMutationInputName]; ok {
+		return nil, NewErrMutationInputTypeAlreadyExist(setMutationInputName)
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   setMutationInputName,
+		Fields: fields,
+	}), nil
+}
+
+// buildTextPatchInputType creates the input object type used to patch the text fields
+// of the given collection.
+//
+// If the collection has no text fields nil is returned.
+func (g *Generator) buildTextPatchInputType(collection client.CollectionDefinition) (*gql.InputObject, error) {
+	var textFields []string
+	for _, field := range collection.GetFields() {
+		if field.Typ == client.TEXT {
+			textFields = append(textFields, field.Name)
+		}
+	}
+	if len(textFields) == 0 {
+		return nil, nil
+	}
+
+	textPatchInputName := collection.Description.Name.Value() + textPatchInputSuffix
+	if _, ok := g.manager.schema.TypeMap()[textPatchInputName]; ok {
+		return nil, NewErrMutationInputTypeAlreadyExist(textPatchInputName)
+	}
+
+	// The patch object is shared by all collections, so it is only created once.
+	textPatch, ok := g.manager.schema.TypeMap()[schemaTypes.TextPatchObjectName]
+	if !ok {
+		textPatch = schemaTypes.TextPatchInputObject()
+		g.manager.schema.TypeMap()[textPatch.Name()] = textPatch
+	}
+
+	fields := make(gql.InputObjectConfigFieldMap, len(textFields))
+	for _, name := range textFields {
+		fields[name] = &gql.InputObjectFieldConfig{
+			Type: gql.NewList(gql.NewNonNull(textPatch)),
+		}
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   textPatchInputName,
+		Fields: fields,
+	}), nil
+}
+
 func (g *Generator) genAggregateFields() error {
 	topLevelCountInputs := map[string]*gql.InputObject{}
 	topLevelNumericAggInputs := map[string]*gql.InputObject{}
@@ -1124,6 +1242,19 @@ func (g *Generator) GenerateMutationInputForGQLType(obj *gql.Object) ([]*gql.Fie
 		},
 	}
 
+	// Only collections with set fields can add or remove elements.
+	setMutationInput, ok := g.manager.schema.TypeMap()[genTypeName(obj, setMutationInputSuffix)]
+	if ok {
+		update.Args[request.AddInput] = schemaTypes.NewArgConfig(setMutationInput, updateAddArgDescription)
+		update.Args[request.RemoveInput] = schemaTypes.NewArgConfig(setMutationInput, updateRemoveArgDescription)
+	}
+
+	// Only collections with text fields can be patched.
+	textPatchInput, ok := g.manager.schema.TypeMap()[genTypeName(obj, textPatchInputSuffix)]
+	if ok {
+		update.Args[request.TextPatchInput] = schemaTypes.NewArgConfig(textPatchInput, updatePatchArgDescription)
+	}
+
 	delete := &gql.Field{
 		Name:        "delete_" + obj.Name(),
 		Description: deleteDocumentsDescription,
@@ -1338,6 +1469,8 @@ func (g *Generator) genTypeQueryableFieldList(
 	g.manager.schema.TypeMap()[config.groupBy.Name()] = config.groupBy
 	g.manager.schema.TypeMap()[config.order.Name()] = config.order
 
+	asOf := g.manager.schema.TypeMap()[request.AsOfTypeName]
+
 	field := &gql.Field{
 		Name:        name,
 		Description: obj.Description(),
@@ -1345,6 +1478,7 @@ func (g *Generator) genTypeQueryableFieldList(
 		Args: gql.FieldConfigArgument{
 			request.DocIDArgName: schemaTypes.NewArgConfig(gql.NewList(gql.NewNonNull(gql.String)), docIDsArgDescription),
 			"cid":                schemaTypes.NewArgConfig(gql.String, cidArgDescription),
+			request.AsOfClause:   schemaTypes.NewArgConfig(asOf, asOfArgDescription),
 			"filter":             schemaTypes.NewArgConfig(config.filter, selectFilterArgDescription),
 			"groupBy": schemaTypes.NewArgConfig(
 				gql.NewList(gql.NewNonNull(config.groupBy)),
@@ -1354,6 +1488,10 @@ func (g *Generator) genTypeQueryableFieldList(
 			request.ShowDeleted:  schemaTypes.NewArgConfig(gql.Boolean, showDeletedArgDescription),
 			request.LimitClause:  schemaTypes.NewArgConfig(gql.Int, schemaTypes.LimitArgDescription),
 			request.OffsetClause: schemaTypes.NewArgConfig(gql.Int, schemaTypes.OffsetArgDescription),
+			request.FirstClause:  schemaTypes.NewArgConfig(gql.Int, schemaTypes.FirstArgDescription),
+			request.AfterClause:  schemaTypes.NewArgConfig(gql.String, schemaTypes.AfterArgDescription),
+			request.LastClause:   schemaTypes.NewArgConfig(gql.Int, schemaTypes.LastArgDescription),
+			request.BeforeClause: schemaTypes.NewArgConfig(gql.String, schemaTypes.BeforeArgDescription),
 		},
 	}
 
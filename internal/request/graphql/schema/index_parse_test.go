@@ -114,6 +114,32 @@ func TestParseIndexOnStruct(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "Index with filter",
+			sdl:         `type user @index(includes: [{field: "name"}], filter: {status: {_eq: "active"}}) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name"},
+					},
+					Filter: map[string]any{
+						"status": map[string]any{"_eq": "active"},
+					},
+				},
+			},
+		},
+		{
+			description: "Index with JSON path",
+			sdl:         `type user @index(includes: [{field: "meta", path: ["address", "city"]}, {field: "name"}]) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "meta", JSONPath: []string{"address", "city"}},
+						{Name: "name"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -173,6 +199,21 @@ func TestParseInvalidIndexOnStruct(t *testing.T) {
 			sdl:         `type user @index(includes: [1]) {}`,
 			expectedErr: `Argument "includes" has invalid value [1]`,
 		},
+		{
+			description: "invalid 'filter' value type (not an object)",
+			sdl:         `type user @index(includes: [{field: "name"}], filter: "active") {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "'path' argument on struct",
+			sdl:         `type user @index(includes: [{field: "meta"}], path: ["a"]) {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "empty JSON path",
+			sdl:         `type user @index(includes: [{field: "meta", path: []}]) {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
 	}
 
 	for _, test := range cases {
@@ -319,6 +360,64 @@ func TestParseIndexOnField(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "full-text field index",
+			sdl: `type user {
+				bio: String @index(type: FULLTEXT)
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "bio"},
+					},
+					Type: client.IndexTypeFullText,
+				},
+			},
+		},
+		{
+			description: "field index with filter",
+			sdl: `type user {
+				name: String @index(unique: true, filter: {deleted: {_eq: false}})
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name"},
+					},
+					Unique: true,
+					Filter: map[string]any{
+						"deleted": map[string]any{"_eq": false},
+					},
+				},
+			},
+		},
+		{
+			description: "field index with JSON path",
+			sdl: `type user {
+				meta: JSON @index(path: ["address", "city"])
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "meta", JSONPath: []string{"address", "city"}},
+					},
+				},
+			},
+		},
+		{
+			description: "explicit value field index",
+			sdl: `type user {
+				name: String @index(type: VALUE)
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name"},
+					},
+					Type: client.IndexTypeValue,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -377,6 +476,13 @@ func TestParseInvalidIndexOnField(t *testing.T) {
 			}`,
 			expectedErr: `Argument "unique" has invalid value "true"`,
 		},
+		{
+			description: "invalid 'type' value",
+			sdl: `type user {
+				name: String @index(type: HASH) 
+			}`,
+			expectedErr: `Argument "type" has invalid value HASH`,
+		},
 	}
 
 	for _, test := range cases {
@@ -25,30 +25,43 @@ func defaultSchema() (gql.Schema, error) {
 	commitLinkObject := types.CommitLinkObject()
 	commitObject := types.CommitObject(commitLinkObject)
 	commitsOrderArg := types.CommitsOrderArg(orderEnum)
+	pageInfoObject := types.PageInfoObject()
+	asOfInput := types.AsOfInputObject()
+	docChangeKindEnum := types.DocChangeKindEnum()
 
 	indexFieldInput := types.IndexFieldInputObject(orderEnum)
+	indexTypeEnum := types.IndexTypeEnum()
 
 	return gql.NewSchema(gql.SchemaConfig{
 		Types: defaultTypes(
 			commitObject,
 			commitLinkObject,
 			commitsOrderArg,
+			pageInfoObject,
+			asOfInput,
+			docChangeKindEnum,
 			orderEnum,
 			crdtEnum,
 			explainEnum,
 			indexFieldInput,
+			indexTypeEnum,
 		),
-		Query:        defaultQueryType(commitObject, commitsOrderArg),
+		Query:        defaultQueryType(commitObject, commitsOrderArg, pageInfoObject),
 		Mutation:     defaultMutationType(),
-		Directives:   defaultDirectivesType(crdtEnum, explainEnum, orderEnum, indexFieldInput),
+		Directives:   defaultDirectivesType(crdtEnum, explainEnum, orderEnum, indexTypeEnum, indexFieldInput),
 		Subscription: defaultSubscriptionType(),
 	})
 }
 
 // @todo: Use a better default Query type
-func defaultQueryType(commitObject *gql.Object, commitsOrderArg *gql.InputObject) *gql.Object {
+func defaultQueryType(
+	commitObject *gql.Object,
+	commitsOrderArg *gql.InputObject,
+	pageInfoObject *gql.Object,
+) *gql.Object {
 	queryCommits := types.QueryCommits(commitObject, commitsOrderArg)
 	queryLatestCommits := types.QueryLatestCommits(commitObject)
+	queryPageInfo := types.QueryPageInfo(pageInfoObject)
 
 	return gql.NewObject(gql.ObjectConfig{
 		Name: "Query",
@@ -61,6 +74,7 @@ func defaultQueryType(commitObject *gql.Object, commitsOrderArg *gql.InputObject
 			// database API queries
 			queryCommits.Name:       queryCommits,
 			queryLatestCommits.Name: queryLatestCommits,
+			queryPageInfo.Name:      queryPageInfo,
 		},
 	})
 }
@@ -94,6 +108,7 @@ func defaultDirectivesType(
 	crdtEnum *gql.Enum,
 	explainEnum *gql.Enum,
 	orderEnum *gql.Enum,
+	indexTypeEnum *gql.Enum,
 	indexFieldInput *gql.InputObject,
 ) []*gql.Directive {
 	return []*gql.Directive{
@@ -101,7 +116,7 @@ func defaultDirectivesType(
 		types.DefaultDirective(),
 		types.ExplainDirective(explainEnum),
 		types.PolicyDirective(),
-		types.IndexDirective(orderEnum, indexFieldInput),
+		types.IndexDirective(orderEnum, indexTypeEnum, indexFieldInput),
 		types.PrimaryDirective(),
 		types.RelationDirective(),
 		types.MaterializedDirective(),
@@ -127,10 +142,14 @@ func defaultTypes(
 	commitObject *gql.Object,
 	commitLinkObject *gql.Object,
 	commitsOrderArg *gql.InputObject,
+	pageInfoObject *gql.Object,
+	asOfInput *gql.InputObject,
+	docChangeKindEnum *gql.Enum,
 	orderEnum *gql.Enum,
 	crdtEnum *gql.Enum,
 	explainEnum *gql.Enum,
 	indexFieldInput *gql.InputObject,
+	indexTypeEnum *gql.Enum,
 ) []gql.Type {
 	blobScalarType := types.BlobScalarType()
 	jsonScalarType := types.JSONScalarType()
@@ -199,9 +218,14 @@ func defaultTypes(
 		commitLinkObject,
 		commitObject,
 
+		pageInfoObject,
+		asOfInput,
+		docChangeKindEnum,
+
 		crdtEnum,
 		explainEnum,
 
 		indexFieldInput,
+		indexTypeEnum,
 	}
 }
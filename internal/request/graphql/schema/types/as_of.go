@@ -0,0 +1,40 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	gql "github.com/sourcenetwork/graphql-go"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+// AsOfInputObject is the input object used to query documents as of a point in their history.
+//
+//	input AsOf {
+//		height: Int
+//		time: DateTime
+//	}
+func AsOfInputObject() *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        request.AsOfTypeName,
+		Description: asOfDescription,
+		Fields: gql.InputObjectConfigFieldMap{
+			request.AsOfHeight: &gql.InputObjectFieldConfig{
+				Description: asOfHeightFieldDescription,
+				Type:        gql.Int,
+			},
+			request.AsOfTime: &gql.InputObjectFieldConfig{
+				Description: asOfTimeFieldDescription,
+				Type:        gql.DateTime,
+			},
+		},
+	})
+}
@@ -446,6 +446,10 @@ func StringOperatorBlock() *gql.InputObject {
 				Description: nilikeStringOperatorDescription,
 				Type:        gql.String,
 			},
+			"_search": &gql.InputObjectFieldConfig{
+				Description: searchStringOperatorDescription,
+				Type:        gql.String,
+			},
 		},
 	})
 }
@@ -510,6 +514,10 @@ func NotNullStringOperatorBlock() *gql.InputObject {
 				Description: nilikeStringOperatorDescription,
 				Type:        gql.String,
 			},
+			"_search": &gql.InputObjectFieldConfig{
+				Description: searchStringOperatorDescription,
+				Type:        gql.String,
+			},
 		},
 	})
 }
@@ -68,6 +68,14 @@ func CommitObject(commitLinkObject *gql.Object) *gql.Object {
 				Description: commitDeltaFieldDescription,
 				Type:        gql.String,
 			},
+			request.SignerFieldName: &gql.Field{
+				Description: commitSignerFieldDescription,
+				Type:        gql.String,
+			},
+			request.TimestampFieldName: &gql.Field{
+				Description: commitTimestampFieldDescription,
+				Type:        gql.DateTime,
+			},
 			request.LinksFieldName: &gql.Field{
 				Description: commitLinksDescription,
 				Type:        gql.NewList(commitLinkObject),
@@ -135,6 +143,10 @@ func CommitsOrderArg(orderEnum *gql.Enum) *gql.InputObject {
 					Description: commitCollectionIDFieldDescription,
 					Type:        orderEnum,
 				},
+				request.TimestampFieldName: &gql.InputObjectFieldConfig{
+					Description: commitTimestampFieldDescription,
+					Type:        orderEnum,
+				},
 			},
 		},
 	)
@@ -31,6 +31,72 @@ An optional value that caps the number of results to the number provided.
 An optional value that skips the given number of results that would have
  otherwise been returned.  Commonly used alongside the 'limit' argument,
  this argument will still work on its own.
+`
+	FirstArgDescription string = `
+An optional value that caps the number of results to the given number, taken
+ from the start of the results.  May be used alongside the 'after' argument
+ to page forwards through the results.
+`
+	AfterArgDescription string = `
+An optional cursor, as returned by the '_cursor' field, only results positioned
+ after this cursor will be returned.
+`
+	LastArgDescription string = `
+An optional value that caps the number of results to the given number, taken
+ from the end of the results.  May be used alongside the 'before' argument
+ to page backwards through the results.
+`
+	BeforeArgDescription string = `
+An optional cursor, as returned by the '_cursor' field, only results positioned
+ before this cursor will be returned.
+`
+	pageInfoDescription string = `
+PageInfo describes a page of results.
+`
+	pageInfoFieldDescription string = `
+Returns information about the page of results of a sibling selection, it is
+ returned even if the page is empty.
+`
+	pageInfoOfArgDescription string = `
+The alias, or name if it has no alias, of the sibling selection to return the
+ page info of.
+`
+	pageInfoHasNextPageFieldDescription string = `
+Indicates whether more results exist after this page.
+`
+	pageInfoHasPreviousPageFieldDescription string = `
+Indicates whether more results exist before this page.
+`
+	pageInfoStartCursorFieldDescription string = `
+The cursor of the first result in this page.
+`
+	pageInfoEndCursorFieldDescription string = `
+The cursor of the last result in this page.
+`
+	asOfDescription string = `
+AsOf is a point in the history of the documents of a collection. Exactly one of
+ its fields must be given.
+`
+	asOfHeightFieldDescription string = `
+Selects, for each document, the latest commit with a height lower or equal to
+ the given height.
+`
+	asOfTimeFieldDescription string = `
+Selects, for each document, the latest commit created at or before the given time.
+ Commits without a timestamp are considered to have been created before any time.
+`
+	docChangeKindDescription string = `
+DocChangeKind is the kind of change made to a document that a subscription result
+ was yielded for.
+`
+	docCreatedDescription string = `
+The document has been created.
+`
+	docUpdatedDescription string = `
+The document has been updated.
+`
+	docDeletedDescription string = `
+The document has been deleted.
 `
 	commitDescription string = `
 Commit represents an individual commit to a MerkleCRDT, every mutation to a
@@ -92,6 +158,14 @@ The id of the field that this commit was committed against. If this is a composi
 `
 	commitDeltaFieldDescription string = `
 The CBOR encoded representation of the value that is saved as part of this commit.
+`
+	commitSignerFieldDescription string = `
+The DID of the identity that signed this commit. The signature is verified when the
+ commit is received from another node. If the commit is not signed the value will be null.
+`
+	commitTimestampFieldDescription string = `
+The time at which this commit was created. If the node that created the commit did not
+ have block timestamps enabled the value will be null.
 `
 	commitLinkNameFieldDescription string = `
 The Name of the field that this linked commit mutated.
@@ -223,6 +297,11 @@ The case insensitive like operator - if the target value contains the given case
 The case insensitive not-like operator - if the target value does not contain the given case insensitive sub-string
  the check will pass. '%' characters may be used as wildcards, for example '_nlike: "%ritchie"' would match on
  the string 'Quentin Tarantino'.
+`
+	searchStringOperatorDescription string = `
+The search operator - if the target value contains all of the words in the given string the check will
+ pass. Matching is case insensitive and ignores punctuation, for example '_search: "ritchie dennis"' would
+ match on the string 'Dennis M. Ritchie'. If the field has a full-text index, results are ordered by relevance.
 `
 	AndOperatorDescription string = `
 The and operator - all checks within this clause must pass in order for this check to pass.
@@ -261,5 +340,18 @@ Allows the explicit definition of relationship attributes instead of using the s
 `
 	relationDirectiveNameArgDescription string = `
 Explicitly define the name of the relationship instead of using the system generated defaults.
+`
+	textPatchDescription string = `
+A positional edit of a text field. The given number of characters are deleted from the given
+ index, and the given string is then inserted at that index.
+`
+	textPatchIndexDescription string = `
+The index of the character at which the patch is applied, counted in unicode code points.
+`
+	textPatchDeleteDescription string = `
+The number of characters to delete from the index.
+`
+	textPatchInsertDescription string = `
+The string to insert at the index.
 `
 )
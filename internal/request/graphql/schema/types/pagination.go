@@ -0,0 +1,64 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	gql "github.com/sourcenetwork/graphql-go"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+// PageInfoObject describes a page of results.
+//
+//	type PageInfo {
+//		hasNextPage: Boolean
+//		hasPreviousPage: Boolean
+//		startCursor: String
+//		endCursor: String
+//	}
+func PageInfoObject() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name:        request.PageInfoTypeName,
+		Description: pageInfoDescription,
+		Fields: gql.Fields{
+			request.HasNextPageFieldName: &gql.Field{
+				Description: pageInfoHasNextPageFieldDescription,
+				Type:        gql.Boolean,
+			},
+			request.HasPreviousPageFieldName: &gql.Field{
+				Description: pageInfoHasPreviousPageFieldDescription,
+				Type:        gql.Boolean,
+			},
+			request.StartCursorFieldName: &gql.Field{
+				Description: pageInfoStartCursorFieldDescription,
+				Type:        gql.String,
+			},
+			request.EndCursorFieldName: &gql.Field{
+				Description: pageInfoEndCursorFieldDescription,
+				Type:        gql.String,
+			},
+		},
+	})
+}
+
+// QueryPageInfo returns the page info of a sibling selection, given by its alias or name.
+//
+//	_pageInfo(of: String!): PageInfo
+func QueryPageInfo(pageInfoObject *gql.Object) *gql.Field {
+	return &gql.Field{
+		Name:        request.PageInfoFieldName,
+		Description: pageInfoFieldDescription,
+		Type:        pageInfoObject,
+		Args: gql.FieldConfigArgument{
+			request.OfClause: NewArgConfig(gql.NewNonNull(gql.String), pageInfoOfArgDescription),
+		},
+	}
+}
@@ -14,6 +14,8 @@ import (
 	gql "github.com/sourcenetwork/graphql-go"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/event"
 )
 
 const (
@@ -38,9 +40,13 @@ const (
 	IndexDirectivePropUnique    = "unique"
 	IndexDirectivePropDirection = "direction"
 	IndexDirectivePropIncludes  = "includes"
+	IndexDirectivePropType      = "type"
+	IndexDirectivePropFilter    = "filter"
+	IndexDirectivePropPath      = "path"
 
 	IncludesPropField     = "field"
 	IncludesPropDirection = "direction"
+	IncludesPropPath      = "path"
 
 	DefaultDirectiveLabel        = "default"
 	DefaultDirectivePropString   = "string"
@@ -59,6 +65,11 @@ const (
 
 	FieldOrderASC  = "ASC"
 	FieldOrderDESC = "DESC"
+
+	IndexTypeValue    = "VALUE"
+	IndexTypeFullText = "FULLTEXT"
+
+	TextPatchObjectName = "TextPatch"
 )
 
 // OrderingEnum is an enum for the Ordering argument.
@@ -78,6 +89,28 @@ func OrderingEnum() *gql.Enum {
 	})
 }
 
+// DocChangeKindEnum is an enum for the kind of change yielded by a subscription.
+func DocChangeKindEnum() *gql.Enum {
+	return gql.NewEnum(gql.EnumConfig{
+		Name:        request.DocChangeKindTypeName,
+		Description: docChangeKindDescription,
+		Values: gql.EnumValueConfigMap{
+			string(event.DocCreated): &gql.EnumValueConfig{
+				Description: docCreatedDescription,
+				Value:       string(event.DocCreated),
+			},
+			string(event.DocUpdated): &gql.EnumValueConfig{
+				Description: docUpdatedDescription,
+				Value:       string(event.DocUpdated),
+			},
+			string(event.DocDeleted): &gql.EnumValueConfig{
+				Description: docDeletedDescription,
+				Value:       string(event.DocDeleted),
+			},
+		},
+	})
+}
+
 func ExplainEnum() *gql.Enum {
 	return gql.NewEnum(gql.EnumConfig{
 		Name:        "ExplainType",
@@ -184,11 +217,61 @@ func IndexFieldInputObject(orderingEnum *gql.Enum) *gql.InputObject {
 			IncludesPropDirection: &gql.InputObjectFieldConfig{
 				Type: orderingEnum,
 			},
+			IncludesPropPath: &gql.InputObjectFieldConfig{
+				Description: "Sets the path of the indexed value within a JSON field.",
+				Type:        gql.NewList(gql.NewNonNull(gql.String)),
+			},
+		},
+	})
+}
+
+// TextPatchInputObject is the input object used to edit a text field with a positional patch.
+func TextPatchInputObject() *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        TextPatchObjectName,
+		Description: textPatchDescription,
+		Fields: gql.InputObjectConfigFieldMap{
+			request.TextPatchIndex: &gql.InputObjectFieldConfig{
+				Description: textPatchIndexDescription,
+				Type:        gql.NewNonNull(gql.Int),
+			},
+			request.TextPatchDelete: &gql.InputObjectFieldConfig{
+				Description: textPatchDeleteDescription,
+				Type:        gql.Int,
+			},
+			request.TextPatchInsert: &gql.InputObjectFieldConfig{
+				Description: textPatchInsertDescription,
+				Type:        gql.String,
+			},
 		},
 	})
 }
 
-func IndexDirective(orderingEnum *gql.Enum, indexFieldInputObject *gql.InputObject) *gql.Directive {
+// IndexTypeEnum is an enum for the type argument of the @index directive.
+func IndexTypeEnum() *gql.Enum {
+	return gql.NewEnum(gql.EnumConfig{
+		Name:        "IndexType",
+		Description: "One of the possible index types.",
+		Values: gql.EnumValueConfigMap{
+			IndexTypeValue: &gql.EnumValueConfig{
+				Value:       client.IndexTypeValue,
+				Description: "Indexes the whole value of each field. This is the default.",
+			},
+			IndexTypeFullText: &gql.EnumValueConfig{
+				Value: client.IndexTypeFullText,
+				Description: `Indexes the individual words of a single String field.
+	
+	Full-text indexes are used to serve the _search filter operator.`,
+			},
+		},
+	})
+}
+
+func IndexDirective(
+	orderingEnum *gql.Enum,
+	indexTypeEnum *gql.Enum,
+	indexFieldInputObject *gql.InputObject,
+) *gql.Directive {
 	return gql.NewDirective(gql.DirectiveConfig{
 		Name:        IndexDirectiveLabel,
 		Description: "@index is a directive that can be used to create an index on a type or a field.",
@@ -215,6 +298,22 @@ func IndexDirective(orderingEnum *gql.Enum, indexFieldInputObject *gql.InputObje
 	it will be implicitly added as the first entry.`,
 				Type: gql.NewList(indexFieldInputObject),
 			},
+			IndexDirectivePropType: &gql.ArgumentConfig{
+				Description: "Sets the index type. Defaults to VALUE.",
+				Type:        indexTypeEnum,
+			},
+			IndexDirectivePropFilter: &gql.ArgumentConfig{
+				Description: `Makes the index partial, indexing only the documents that match the given filter.
+	
+	The index is used only by the requests whose filter implies it.`,
+				Type: JSONScalarType(),
+			},
+			IndexDirectivePropPath: &gql.ArgumentConfig{
+				Description: `Sets the path of the indexed value within the JSON field the directive is applied to.
+
+	If the path leads to an array, each of its elements is indexed separately.`,
+				Type: gql.NewList(gql.NewNonNull(gql.String)),
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationObject,
@@ -292,6 +391,30 @@ func CRDTEnum() *gql.Enum {
 	will cause the value to roll over to the int64 min value. Incremeting a float and
 	causing it to overflow the float64 max value will act like a no-op.`,
 			},
+			client.G_SET.String(): &gql.EnumValueConfig{
+				Value: client.G_SET,
+				Description: `Grow-only Set.
+	
+	Can only be used on array fields. Elements can be added to the set but never
+	removed. Duplicate elements are ignored and the order of the elements is not kept.`,
+			},
+			client.OR_SET.String(): &gql.EnumValueConfig{
+				Value: client.OR_SET,
+				Description: `Observed-Remove Set.
+	
+	Can only be used on array fields. Elements can be added to and removed from the set.
+	A removal only affects the additions it has observed, so concurrent additions of an
+	element win over its removal. Duplicate elements are ignored and the order of the
+	elements is not kept.`,
+			},
+			client.TEXT.String(): &gql.EnumValueConfig{
+				Value: client.TEXT,
+				Description: `Collaborative Text.
+	
+	Can only be used on String fields. Edits are stored as positional inserts and deletes
+	of characters, so concurrent edits of different parts of the text are all kept instead
+	of overwriting each other.`,
+			},
 		},
 	})
 }
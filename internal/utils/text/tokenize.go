@@ -0,0 +1,51 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits the given text into lower cased word tokens.
+//
+// A token is a maximal run of letters and digits, everything else is treated as a separator.
+// The order of tokens is preserved and duplicates are kept.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// TermFrequencies returns the number of occurrences of every distinct token of the given text.
+func TermFrequencies(s string) map[string]uint64 {
+	tokens := Tokenize(s)
+	result := make(map[string]uint64, len(tokens))
+	for _, token := range tokens {
+		result[token]++
+	}
+	return result
+}
+
+// UniqueTokens returns the distinct tokens of the given text in order of their first occurrence.
+func UniqueTokens(s string) []string {
+	tokens := Tokenize(s)
+	seen := make(map[string]struct{}, len(tokens))
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		result = append(result, token)
+	}
+	return result
+}
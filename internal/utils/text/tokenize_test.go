@@ -0,0 +1,74 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcenetwork/defradb/internal/utils/text"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: []string{},
+		},
+		{
+			name:     "only separators",
+			input:    " ,.!? ",
+			expected: []string{},
+		},
+		{
+			name:     "words are lower cased",
+			input:    "The Quick brown FOX",
+			expected: []string{"the", "quick", "brown", "fox"},
+		},
+		{
+			name:     "punctuation and digits",
+			input:    "Web3, it's 2024!",
+			expected: []string{"web3", "it", "s", "2024"},
+		},
+		{
+			name:     "unicode letters",
+			input:    "Grüße aus Köln",
+			expected: []string{"grüße", "aus", "köln"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := text.Tokenize(tt.input)
+			if len(tt.expected) == 0 {
+				assert.Empty(t, result)
+			} else {
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTermFrequencies(t *testing.T) {
+	result := text.TermFrequencies("the cat and the hat")
+	assert.Equal(t, map[string]uint64{"the": 2, "cat": 1, "and": 1, "hat": 1}, result)
+}
+
+func TestUniqueTokens(t *testing.T) {
+	result := text.UniqueTokens("b a B c a")
+	assert.Equal(t, []string{"b", "a", "c"}, result)
+}
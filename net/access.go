@@ -0,0 +1,197 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"sync"
+
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcenetwork/corelog"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/event"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+)
+
+const (
+	rejectReasonPeerDenied    = "peer is not allowed to push blocks"
+	rejectReasonCreatorDenied = "block creator is not allowed to push blocks"
+	rejectReasonUnauthorized  = "block creator is not authorized to update the document"
+	rejectReasonBadSignature  = "block signature is invalid"
+	rejectReasonMismatchedCID = "block does not match the pushed CID"
+)
+
+// PushAuthorizer authorizes the identities that created the blocks pushed to this node by peers.
+//
+// With a local ACP, the documents created on other nodes are not registered locally,
+// and so are treated as public documents that any identity may update.
+type PushAuthorizer interface {
+	// CanUpdateDoc returns true if the identity with the given DID may update the document
+	// with the given ID of the collection with the given schema root.
+	//
+	// The DID has no value if the block was not signed.
+	CanUpdateDoc(ctx context.Context, schemaRoot string, docID string, did immutable.Option[string]) (bool, error)
+}
+
+// peerAccess controls which peers may push blocks to this node.
+//
+// Denied peers are always rejected. If any peers are allowed, all other peers are rejected.
+//
+// The peers given in the node options are combined with the ones that are updated at runtime.
+type peerAccess struct {
+	mu sync.RWMutex
+
+	staticAllowed map[libpeer.ID]struct{}
+	staticDenied  map[libpeer.ID]struct{}
+
+	allowed map[libpeer.ID]struct{}
+	denied  map[libpeer.ID]struct{}
+}
+
+// newPeerAccess returns a new peer access with the given static lists of peer IDs.
+func newPeerAccess(allowed []string, denied []string) (*peerAccess, error) {
+	staticAllowed, err := decodePeerIDSet(allowed)
+	if err != nil {
+		return nil, err
+	}
+	staticDenied, err := decodePeerIDSet(denied)
+	if err != nil {
+		return nil, err
+	}
+	return &peerAccess{
+		staticAllowed: staticAllowed,
+		staticDenied:  staticDenied,
+		allowed:       make(map[libpeer.ID]struct{}),
+		denied:        make(map[libpeer.ID]struct{}),
+	}, nil
+}
+
+func decodePeerIDSet(ids []string) (map[libpeer.ID]struct{}, error) {
+	set := make(map[libpeer.ID]struct{}, len(ids))
+	for _, id := range ids {
+		pid, err := libpeer.Decode(id)
+		if err != nil {
+			return nil, NewErrInvalidPeerID(err, id)
+		}
+		set[pid] = struct{}{}
+	}
+	return set, nil
+}
+
+// update replaces the peers updated at runtime with the ones of the given event.
+func (a *peerAccess) update(evt event.PeerAccess) {
+	allowed := make(map[libpeer.ID]struct{}, len(evt.Allowed))
+	for _, pid := range evt.Allowed {
+		allowed[pid] = struct{}{}
+	}
+	denied := make(map[libpeer.ID]struct{}, len(evt.Denied))
+	for _, pid := range evt.Denied {
+		denied[pid] = struct{}{}
+	}
+
+	a.mu.Lock()
+	a.allowed = allowed
+	a.denied = denied
+	a.mu.Unlock()
+}
+
+// isAllowed returns true if the given peer may push blocks to this node.
+func (a *peerAccess) isAllowed(pid libpeer.ID) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if _, ok := a.staticDenied[pid]; ok {
+		return false
+	}
+	if _, ok := a.denied[pid]; ok {
+		return false
+	}
+	if len(a.staticAllowed) == 0 && len(a.allowed) == 0 {
+		return true
+	}
+	_, ok := a.staticAllowed[pid]
+	if !ok {
+		_, ok = a.allowed[pid]
+	}
+	return ok
+}
+
+// authorizePeers returns an error if the peer that pushed a block, or the peer that
+// created it, may not push blocks to this node.
+func (s *server) authorizePeers(ctx context.Context, pid libpeer.ID, byPeer libpeer.ID, head docHead) error {
+	if !s.peer.access.isAllowed(pid) {
+		return s.rejectPush(ctx, pid, byPeer, head, rejectReasonPeerDenied)
+	}
+	if byPeer != pid && !s.peer.access.isAllowed(byPeer) {
+		return s.rejectPush(ctx, pid, byPeer, head, rejectReasonCreatorDenied)
+	}
+	return nil
+}
+
+// authorizeBlock returns an error if the signature of the given block is invalid, or if the
+// identity that signed it may not update its document.
+//
+// Blocks are only authorized if an authorizer was enabled in the node options.
+func (s *server) authorizeBlock(
+	ctx context.Context,
+	pid libpeer.ID,
+	byPeer libpeer.ID,
+	head docHead,
+	block *coreblock.Block,
+) error {
+	// The blocks of branchable collections are not documents and can't be authorized.
+	if s.peer.pushAuth == nil || head.DocID == "" {
+		return nil
+	}
+	// The signer is taken from the signature, so it can only be trusted once verified.
+	if err := block.VerifySignature(); err != nil {
+		return s.rejectPush(ctx, pid, byPeer, head, rejectReasonBadSignature)
+	}
+	did, err := block.SignerDID()
+	if err != nil {
+		return err
+	}
+	canUpdate, err := s.peer.pushAuth.CanUpdateDoc(ctx, head.SchemaRoot, head.DocID, did)
+	if err != nil {
+		return err
+	}
+	if !canUpdate {
+		return s.rejectPush(ctx, pid, byPeer, head, rejectReasonUnauthorized)
+	}
+	return nil
+}
+
+// rejectPush logs the rejection of a pushed block, publishes it so that it can be counted,
+// and returns the corresponding error.
+func (s *server) rejectPush(
+	ctx context.Context,
+	pid libpeer.ID,
+	byPeer libpeer.ID,
+	head docHead,
+	reason string,
+) error {
+	log.InfoContext(ctx, "Rejected pushed block",
+		corelog.Any("PeerID", pid.String()),
+		corelog.Any("Creator", byPeer.String()),
+		corelog.Any("DocID", head.DocID),
+		corelog.String("Reason", reason))
+
+	s.peer.bus.Publish(event.NewMessage(event.PushRejectedName, event.PushRejected{
+		FromPeer:   pid,
+		ByPeer:     byPeer,
+		DocID:      head.DocID,
+		SchemaRoot: head.SchemaRoot,
+		Reason:     reason,
+	}))
+
+	return NewErrPushRejected(reason, pid)
+}
@@ -0,0 +1,296 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+	grpcpeer "google.golang.org/grpc/peer"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+)
+
+func newTestPeerID(t *testing.T) libpeer.ID {
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	pid, err := libpeer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestPeerAccessIsAllowed(t *testing.T) {
+	staticPeer := newTestPeerID(t)
+	runtimePeer := newTestPeerID(t)
+	otherPeer := newTestPeerID(t)
+
+	access, err := newPeerAccess(nil, nil)
+	require.NoError(t, err)
+	require.True(t, access.isAllowed(otherPeer))
+
+	access, err = newPeerAccess(nil, []string{staticPeer.String()})
+	require.NoError(t, err)
+	access.update(event.PeerAccess{Denied: []libpeer.ID{runtimePeer}})
+	require.False(t, access.isAllowed(staticPeer))
+	require.False(t, access.isAllowed(runtimePeer))
+	require.True(t, access.isAllowed(otherPeer))
+
+	access, err = newPeerAccess([]string{staticPeer.String()}, nil)
+	require.NoError(t, err)
+	access.update(event.PeerAccess{Allowed: []libpeer.ID{runtimePeer}})
+	require.True(t, access.isAllowed(staticPeer))
+	require.True(t, access.isAllowed(runtimePeer))
+	require.False(t, access.isAllowed(otherPeer))
+
+	// Denied peers are rejected even if they are allowed.
+	access.update(event.PeerAccess{Allowed: []libpeer.ID{runtimePeer}, Denied: []libpeer.ID{staticPeer}})
+	require.False(t, access.isAllowed(staticPeer))
+	require.True(t, access.isAllowed(runtimePeer))
+}
+
+func TestNewPeer_WithInvalidAllowedPeer_Error(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newTestPeer(ctx, t)
+	defer db.Close()
+
+	_, err := NewPeer(
+		ctx,
+		db.Blockstore(),
+		db.Encstore(),
+		db.Events(),
+		WithListenAddresses(randomMultiaddr),
+		WithAllowedPeers("invalid"),
+	)
+	require.ErrorContains(t, err, "invalid peer ID invalid")
+}
+
+// newTestPushLogRequest creates a document and returns the request pushing its head.
+func newTestPushLogRequest(ctx context.Context, t *testing.T, db client.DB, p *Peer) *pushLogRequest {
+	col := createTestUsers(ctx, t, db, 0)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	headCID, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+	b, err := db.Blockstore().AsIPLDStorage().Get(ctx, headCID.KeyString())
+	require.NoError(t, err)
+
+	return &pushLogRequest{
+		DocID:      doc.ID().String(),
+		CID:        headCID.Bytes(),
+		SchemaRoot: col.SchemaRoot(),
+		Creator:    p.PeerID().String(),
+		Block:      b,
+	}
+}
+
+func requirePushRejected(t *testing.T, sub *event.Subscription, reason string) {
+	select {
+	case msg := <-sub.Message():
+		require.Equal(t, reason, msg.Data.(event.PushRejected).Reason)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for rejected push")
+	}
+}
+
+func TestPushLog_WithDeniedPeer_Rejected(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	sub, err := db.Events().Subscribe(event.PushRejectedName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
+	p.access.update(event.PeerAccess{Denied: []libpeer.ID{p.PeerID()}})
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err = p.server.PushLog(ctx, req)
+	require.ErrorIs(t, err, ErrPushRejected)
+	requirePushRejected(t, sub, rejectReasonPeerDenied)
+}
+
+func TestPushLog_WithCreatorNotAllowed_Rejected(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	sub, err := db.Events().Subscribe(event.PushRejectedName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
+	otherPeer := newTestPeerID(t)
+	p.access.update(event.PeerAccess{Allowed: []libpeer.ID{otherPeer}})
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{otherPeer},
+	})
+	_, err = p.server.PushLog(ctx, req)
+	require.ErrorIs(t, err, ErrPushRejected)
+	requirePushRejected(t, sub, rejectReasonCreatorDenied)
+}
+
+type testPushAuthorizer struct {
+	canUpdate bool
+	err       error
+}
+
+func (a testPushAuthorizer) CanUpdateDoc(
+	ctx context.Context,
+	schemaRoot string,
+	docID string,
+	did immutable.Option[string],
+) (bool, error) {
+	return a.canUpdate, a.err
+}
+
+func TestPushLog_WithUnauthorizedCreator_Rejected(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	sub, err := db.Events().Subscribe(event.PushRejectedName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
+	p.pushAuth = testPushAuthorizer{canUpdate: false}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err = p.server.PushLog(ctx, req)
+	require.ErrorIs(t, err, ErrPushRejected)
+	requirePushRejected(t, sub, rejectReasonUnauthorized)
+}
+
+func TestPushLog_WithForgedSignature_Rejected(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	// The block claims to be signed by the owner of the key, with a signature it didn't produce.
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	block, err := coreblock.GetFromBytes(req.Block)
+	require.NoError(t, err)
+	block.Signature = &coreblock.Signature{
+		Type:     coreblock.SignatureTypeES256K,
+		Identity: ownerKey.PubKey().SerializeCompressed(),
+		Value:    []byte("forged"),
+	}
+	req.Block, err = block.Marshal()
+	require.NoError(t, err)
+	link, err := block.GenerateLink()
+	require.NoError(t, err)
+	req.CID = link.Cid.Bytes()
+
+	sub, err := db.Events().Subscribe(event.PushRejectedName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
+	p.pushAuth = testPushAuthorizer{canUpdate: true}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err = p.server.PushLog(ctx, req)
+	require.ErrorIs(t, err, ErrPushRejected)
+	requirePushRejected(t, sub, rejectReasonBadSignature)
+}
+
+func TestPushLog_WithMismatchedCID_Rejected(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	// The pushed block is the one of a field of the document, not the pushed head.
+	block, err := coreblock.GetFromBytes(req.Block)
+	require.NoError(t, err)
+	require.NotEmpty(t, block.Links)
+	req.Block, err = p.blockstore.AsIPLDStorage().Get(ctx, block.Links[0].Cid.KeyString())
+	require.NoError(t, err)
+
+	sub, err := db.Events().Subscribe(event.PushRejectedName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
+	p.pushAuth = testPushAuthorizer{canUpdate: true}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err = p.server.PushLog(ctx, req)
+	require.ErrorIs(t, err, ErrPushRejected)
+	requirePushRejected(t, sub, rejectReasonMismatchedCID)
+}
+
+func TestPushLog_WithAuthorizerError_Error(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	p.pushAuth = testPushAuthorizer{err: errors.New("authorizer error")}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err := p.server.PushLog(ctx, req)
+	require.ErrorContains(t, err, "authorizer error")
+}
+
+func TestPushLog_WithAuthorizedCreator_NoError(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	req := newTestPushLogRequest(ctx, t, db, p)
+
+	p.pushAuth = testPushAuthorizer{canUpdate: true}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	_, err := p.server.PushLog(ctx, req)
+	require.NoError(t, err)
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	cid "github.com/ipfs/go-cid"
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcenetwork/corelog"
+	"google.golang.org/grpc"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+)
+
+// headLogPageSize is the maximum number of heads of a page of the head log.
+const headLogPageSize = 1000
+
+// HeadsRetriever retrieves the heads of the documents known to the node, so that
+// peers that missed updates can compare them with their own and catch up.
+type HeadsRetriever interface {
+	// GetCollectionHeads returns the composite heads of the documents of the collection
+	// with the given schema root, if the collection is shared over the network.
+	GetCollectionHeads(ctx context.Context, schemaRoot string) ([]event.Update, error)
+	// GetDocHeads returns the composite heads of the document with the given ID, if the
+	// document is known to the node.
+	GetDocHeads(ctx context.Context, docID string) ([]event.Update, error)
+}
+
+// docHeadsFromUpdates returns the heads contained in the given updates.
+func docHeadsFromUpdates(updates []event.Update) []docHead {
+	heads := make([]docHead, len(updates))
+	for i, update := range updates {
+		heads[i] = docHead{
+			DocID:      update.DocID,
+			SchemaRoot: update.SchemaRoot,
+			CID:        update.Cid.Bytes(),
+		}
+	}
+	return heads
+}
+
+// compareDocHeads orders the heads of a collection by document ID and CID.
+func compareDocHeads(a, b docHead) int {
+	if c := strings.Compare(a.DocID, b.DocID); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.CID, b.CID)
+}
+
+// subscribedTopics returns the topics this peer is subscribed to, split between the
+// schema roots of collections and the IDs of documents.
+func (s *server) subscribedTopics() (schemaRoots []string, docIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, t := range s.topics {
+		if !t.subscribed {
+			continue
+		}
+		if _, err := client.NewDocIDFromString(name); err == nil {
+			docIDs = append(docIDs, name)
+		} else {
+			schemaRoots = append(schemaRoots, name)
+		}
+	}
+	return schemaRoots, docIDs
+}
+
+// catchUp compares the heads of the given collections and documents with the ones known
+// to the given peer, and pulls the blocks this peer is missing from it.
+//
+// A merge is requested for each of the pulled heads once its DAG is synced.
+func (s *server) catchUp(pid libpeer.ID, schemaRoots []string, docIDs []string) (err error) {
+	if len(schemaRoots) == 0 && len(docIDs) == 0 {
+		return nil
+	}
+	defer func() {
+		if err != nil {
+			err = NewErrCatchUp(err, errors.NewKV("PeerID", pid))
+		}
+	}()
+
+	client, err := s.dial(pid) // grpc dial over P2P stream
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(s.peer.ctx, PullTimeout)
+	defer cancel()
+
+	// The heads of the collections are pulled a page at a time, so that neither the replies
+	// nor the pulled logs grow with the size of the collections.
+	pulled := 0
+	req := &getHeadLogRequest{SchemaRoots: schemaRoots, Limit: headLogPageSize}
+	for len(schemaRoots) > 0 {
+		reply := &getHeadLogReply{}
+		err := client.Invoke(ctx, serviceGetHeadLogName, req, reply)
+		if err != nil {
+			return err
+		}
+		pageHeads, err := s.pullHeads(ctx, client, pid, reply.Heads)
+		if err != nil {
+			return err
+		}
+		pulled += len(pageHeads)
+		if !reply.More || len(reply.Heads) == 0 {
+			break
+		}
+		req.After = &reply.Heads[len(reply.Heads)-1]
+	}
+	if len(docIDs) > 0 {
+		reply := &getDocGraphReply{}
+		err := client.Invoke(ctx, serviceGetDocGraphName, &getDocGraphRequest{DocIDs: docIDs}, reply)
+		if err != nil {
+			return err
+		}
+		docHeads, err := s.pullHeads(ctx, client, pid, reply.Heads)
+		if err != nil {
+			return err
+		}
+		pulled += len(docHeads)
+	}
+	if pulled > 0 {
+		log.InfoContext(ctx, "Caught up with peer",
+			corelog.Any("PeerID", pid.String()),
+			corelog.Any("Heads", pulled))
+	}
+	return nil
+}
+
+// pullHeads pulls the DAGs of the given heads that are missing from the blockstore from
+// the given peer, and returns the heads that were pulled.
+//
+// Nothing is pulled from peers that may not push blocks to this node, and the heads whose
+// blocks were created by identities that may not update their documents are skipped.
+//
+// A merge is requested for each of the pulled heads once its DAG is synced.
+func (s *server) pullHeads(
+	ctx context.Context,
+	client *grpc.ClientConn,
+	pid libpeer.ID,
+	heads []docHead,
+) ([]docHead, error) {
+	// Heads whose blocks are already in the blockstore are either merged or
+	// being merged, so only the others need to be pulled.
+	missing := []docHead{}
+	missingCIDs := []cid.Cid{}
+	seen := make(map[cid.Cid]struct{})
+	for _, head := range heads {
+		headCID, err := cid.Cast(head.CID)
+		if err != nil {
+			return nil, err
+		}
+		// The heads of branchable collections are returned for each of their documents.
+		if _, ok := seen[headCID]; ok {
+			continue
+		}
+		seen[headCID] = struct{}{}
+		has, err := s.peer.blockstore.Has(ctx, headCID)
+		if err != nil {
+			return nil, errors.Wrap(errCheckingForExistingBlock, err)
+		}
+		if !has {
+			missing = append(missing, head)
+			missingCIDs = append(missingCIDs, headCID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	// Pulled blocks are processed as if they were pushed by the peer they are pulled from.
+	for _, head := range missing {
+		if err := s.authorizePeers(ctx, pid, pid, head); err != nil {
+			return nil, err
+		}
+	}
+
+	log.InfoContext(ctx, "Pulling heads from peer",
+		corelog.Any("PeerID", pid.String()),
+		corelog.Any("Heads", len(missing)))
+
+	req := &getLogRequest{}
+	for _, head := range missing {
+		req.CIDs = append(req.CIDs, head.CID)
+	}
+	reply := &getLogReply{}
+	if err := client.Invoke(ctx, serviceGetLogName, req, reply); err != nil {
+		return nil, err
+	}
+	if len(reply.Blocks) != len(missing) {
+		return nil, ErrUnexpectedLogBlock
+	}
+
+	pulled := []docHead{}
+	for i, head := range missing {
+		block, err := coreblock.GetFromBytes(reply.Blocks[i])
+		if err != nil {
+			return nil, err
+		}
+		link, err := block.GenerateLink()
+		if err != nil {
+			return nil, err
+		}
+		if !link.Cid.Equals(missingCIDs[i]) {
+			return nil, ErrUnexpectedLogBlock
+		}
+		err = s.authorizeBlock(ctx, pid, pid, head, block)
+		if errors.Is(err, ErrPushRejected) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = syncDAG(ctx, s.peer.bserv, block)
+		if err != nil {
+			return nil, err
+		}
+
+		s.peer.bus.Publish(event.NewMessage(event.MergeName, event.Merge{
+			DocID:      head.DocID,
+			ByPeer:     pid,
+			FromPeer:   pid,
+			Cid:        missingCIDs[i],
+			SchemaRoot: head.SchemaRoot,
+		}))
+		pulled = append(pulled, head)
+	}
+	return pulled, nil
+}
+
+// catchUpWithConnectedPeers catches up on the given collections and documents with all
+// the peers this peer is connected to.
+func (s *server) catchUpWithConnectedPeers(schemaRoots []string, docIDs []string) {
+	for _, pid := range s.peer.host.Network().Peers() {
+		go func(pid libpeer.ID) {
+			if err := s.catchUp(pid, schemaRoots, docIDs); err != nil && s.peer.ctx.Err() == nil {
+				log.ErrorE("Failed to catch up with peer", err)
+			}
+		}(pid)
+	}
+}
@@ -38,6 +38,13 @@ func (s *server) pushLog(evt event.Update, pid peer.ID) (err error) {
 			s.peer.bus.Publish(event.NewMessage(event.ReplicatorFailureName, event.ReplicatorFailure{
 				DocID:  evt.DocID,
 				PeerID: pid,
+				Err:    err,
+			}))
+		}
+		if err == nil {
+			s.peer.bus.Publish(event.NewMessage(event.ReplicatorSuccessName, event.ReplicatorSuccess{
+				DocID:  evt.DocID,
+				PeerID: pid,
 			}))
 		}
 		// Success is not nil when the pushLog is called from a retry
@@ -86,6 +86,10 @@ func TestPushlogWithInvalidPeerID(t *testing.T) {
 	cid, err := createCID(doc)
 	require.NoError(t, err)
 
+	sub, err := db.Events().Subscribe(event.ReplicatorFailureName)
+	require.NoError(t, err)
+	defer db.Events().Unsubscribe(sub)
+
 	err = p.server.pushLog(event.Update{
 		DocID:      id.String(),
 		Cid:        cid,
@@ -93,6 +97,9 @@ func TestPushlogWithInvalidPeerID(t *testing.T) {
 		Block:      emptyBlock(),
 	}, peer.ID("some-peer-id"))
 	require.Contains(t, err.Error(), "failed to parse peer ID")
+
+	msg := <-sub.Message()
+	require.ErrorContains(t, msg.Data.(event.ReplicatorFailure).Err, "failed to parse peer ID")
 }
 
 func TestPushlog_WithValidPeerID_NoError(t *testing.T) {
@@ -137,6 +144,10 @@ func TestPushlog_WithValidPeerID_NoError(t *testing.T) {
 	b, err := db1.Blockstore().AsIPLDStorage().Get(ctx, headCID.KeyString())
 	require.NoError(t, err)
 
+	sub, err := db1.Events().Subscribe(event.ReplicatorSuccessName)
+	require.NoError(t, err)
+	defer db1.Events().Unsubscribe(sub)
+
 	err = p1.server.pushLog(event.Update{
 		DocID:      doc.ID().String(),
 		Cid:        headCID,
@@ -144,4 +155,10 @@ func TestPushlog_WithValidPeerID_NoError(t *testing.T) {
 		Block:      b,
 	}, p2.PeerInfo().ID)
 	require.NoError(t, err)
+
+	msg := <-sub.Message()
+	require.Equal(t, event.ReplicatorSuccess{
+		PeerID: p2.PeerInfo().ID,
+		DocID:  doc.ID().String(),
+	}, msg.Data)
 }
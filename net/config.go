@@ -25,6 +25,12 @@ type Options struct {
 	GRPCServerOptions []grpc.ServerOption
 	GRPCDialOptions   []grpc.DialOption
 	BootstrapPeers    []string
+	HeadsRetriever    HeadsRetriever
+	AllowedPeers      []string
+	DeniedPeers       []string
+	EnablePushACP     bool
+	PushAuthorizer    PushAuthorizer
+	DocMatcher        DocMatcher
 }
 
 // DefaultOptions returns the default net options.
@@ -72,3 +78,51 @@ func WithBootstrapPeers(peers ...string) NodeOpt {
 		opt.BootstrapPeers = peers
 	}
 }
+
+// WithHeadsRetriever sets the retriever of the document heads shared with peers catching up
+// on missed updates.
+func WithHeadsRetriever(retriever HeadsRetriever) NodeOpt {
+	return func(opt *Options) {
+		opt.HeadsRetriever = retriever
+	}
+}
+
+// WithAllowedPeers sets the IDs of the peers allowed to push blocks to this node.
+//
+// If any peers are allowed, the blocks pushed by all other peers are rejected.
+func WithAllowedPeers(peers ...string) NodeOpt {
+	return func(opt *Options) {
+		opt.AllowedPeers = peers
+	}
+}
+
+// WithDeniedPeers sets the IDs of the peers whose pushed blocks are rejected.
+func WithDeniedPeers(peers ...string) NodeOpt {
+	return func(opt *Options) {
+		opt.DeniedPeers = peers
+	}
+}
+
+// WithEnablePushACP enables checking that the identities that created the blocks pushed
+// by peers may update their documents.
+func WithEnablePushACP(enable bool) NodeOpt {
+	return func(opt *Options) {
+		opt.EnablePushACP = enable
+	}
+}
+
+// WithPushAuthorizer sets the authorizer of the identities that created the blocks pushed
+// by peers, used if checking them is enabled.
+func WithPushAuthorizer(authorizer PushAuthorizer) NodeOpt {
+	return func(opt *Options) {
+		opt.PushAuthorizer = authorizer
+	}
+}
+
+// WithDocMatcher sets the matcher of the replicated documents against the filters of
+// the replicators.
+func WithDocMatcher(matcher DocMatcher) NodeOpt {
+	return func(opt *Options) {
+		opt.DocMatcher = matcher
+	}
+}
@@ -13,6 +13,8 @@ package net
 import (
 	"fmt"
 
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+
 	"github.com/sourcenetwork/defradb/errors"
 )
 
@@ -25,6 +27,11 @@ const (
 	errRequestingEncryptionKeys = "failed to request encryption keys with %v"
 	errTopicAlreadyExist        = "topic with name \"%s\" already exists"
 	errTopicDoesNotExist        = "topic with name \"%s\" does not exists"
+	errGetLog                   = "failed to get log"
+	errCatchUp                  = "failed to catch up with peer"
+	errReconcile                = "failed to reconcile with peer"
+	errInvalidPeerID            = "invalid peer ID %s"
+	errPushRejected             = "pushed block was rejected"
 )
 
 var (
@@ -34,6 +41,11 @@ var (
 	ErrNilDB                    = errors.New("database object can't be nil")
 	ErrNilUpdateChannel         = errors.New("tried to subscribe to update channel, but update channel is nil")
 	ErrCheckingForExistingBlock = errors.New(errCheckingForExistingBlock)
+	ErrMismatchedDocGraphHeads  = errors.New("doc graph heads and blocks have different lengths")
+	ErrUnexpectedLogBlock       = errors.New("received block does not match the requested CID")
+	ErrUnexpectedReconcileRange = errors.New("received reconcile range for a collection that was not requested")
+	ErrReconcileMaxRounds       = errors.New("reconciliation did not converge within the maximum number of rounds")
+	ErrPushRejected             = errors.New(errPushRejected)
 )
 
 func NewErrPushLog(inner error, kv ...errors.KV) error {
@@ -59,3 +71,23 @@ func NewErrTopicAlreadyExist(topic string) error {
 func NewErrTopicDoesNotExist(topic string) error {
 	return errors.New(fmt.Sprintf(errTopicDoesNotExist, topic))
 }
+
+func NewErrGetLog(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errGetLog, inner, kv...)
+}
+
+func NewErrCatchUp(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errCatchUp, inner, kv...)
+}
+
+func NewErrReconcile(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errReconcile, inner, kv...)
+}
+
+func NewErrInvalidPeerID(inner error, id string, kv ...errors.KV) error {
+	return errors.Wrap(fmt.Sprintf(errInvalidPeerID, id), inner, kv...)
+}
+
+func NewErrPushRejected(reason string, pid libpeer.ID) error {
+	return errors.New(errPushRejected, errors.NewKV("Reason", reason), errors.NewKV("PeerID", pid))
+}
@@ -24,24 +24,82 @@ const (
 	serviceGetLogName       = "/" + grpcServiceName + "/GetLog"
 	servicePushLogName      = "/" + grpcServiceName + "/PushLog"
 	serviceGetHeadLogName   = "/" + grpcServiceName + "/GetHeadLog"
+	serviceReconcileName    = "/" + grpcServiceName + "/Reconcile"
 )
 
-type getDocGraphRequest struct{}
+// docHead is a head of the composite DAG of a document, or of a collection if the
+// DocID is empty.
+type docHead struct {
+	DocID      string
+	SchemaRoot string
+	CID        []byte
+}
 
-type getDocGraphReply struct{}
+type getDocGraphRequest struct {
+	DocIDs []string
+}
+
+type getDocGraphReply struct {
+	Heads []docHead
+}
 
-type getHeadLogRequest struct{}
+type getHeadLogRequest struct {
+	SchemaRoots []string
+	// After is the last head of the previous page. The first page is returned if it is nil.
+	After *docHead
+	// Limit is the maximum number of heads of the page, capped at headLogPageSize.
+	Limit int
+}
 
-type getHeadLogReply struct{}
+type getHeadLogReply struct {
+	Heads []docHead
+	// More is true if heads remain past the ones of this page.
+	More bool
+}
 
-type getLogRequest struct{}
+type getLogRequest struct {
+	CIDs [][]byte
+}
 
-type getLogReply struct{}
+type getLogReply struct {
+	Blocks [][]byte
+}
 
-type pushDocGraphRequest struct{}
+type pushDocGraphRequest struct {
+	DocID      string
+	SchemaRoot string
+	Creator    string
+	CIDs       [][]byte
+	Blocks     [][]byte
+}
 
 type pushDocGraphReply struct{}
 
+// reconcileRange is a range of the documents of a collection, ordered by DocID, along
+// with the fingerprint of their heads.
+//
+// The range includes its lower bound and excludes its upper bound, an empty upper bound
+// meaning the range is unbounded.
+type reconcileRange struct {
+	SchemaRoot  string
+	Lower       string
+	Upper       string
+	Fingerprint []byte
+	Count       int
+}
+
+type reconcileRequest struct {
+	// Session identifies the reconciliation the ranges belong to, so that the peer
+	// compares all its rounds with the same snapshot of its documents.
+	Session uint64
+	Ranges  []reconcileRange
+}
+
+type reconcileReply struct {
+	Ranges []reconcileRange
+	Heads  []docHead
+}
+
 type pushLogRequest struct {
 	DocID      string
 	CID        []byte
@@ -63,6 +121,77 @@ type serviceServer interface {
 	PushLog(context.Context, *pushLogRequest) (*pushLogReply, error)
 	// GetHeadLog from this peer
 	GetHeadLog(context.Context, *getHeadLogRequest) (*getHeadLogReply, error)
+	// Reconcile ranges of documents with this peer.
+	Reconcile(context.Context, *reconcileRequest) (*reconcileReply, error)
+}
+
+func getDocGraphHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(getDocGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serviceServer).GetDocGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: serviceGetDocGraphName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(serviceServer).GetDocGraph(ctx, req.(*getDocGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func pushDocGraphHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(pushDocGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serviceServer).PushDocGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: servicePushDocGraphName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(serviceServer).PushDocGraph(ctx, req.(*pushDocGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getLogHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(getLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serviceServer).GetLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: serviceGetLogName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(serviceServer).GetLog(ctx, req.(*getLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func pushLogHandler(
@@ -88,15 +217,81 @@ func pushLogHandler(
 	return interceptor(ctx, in, info, handler)
 }
 
+func getHeadLogHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(getHeadLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serviceServer).GetHeadLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: serviceGetHeadLogName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(serviceServer).GetHeadLog(ctx, req.(*getHeadLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func reconcileHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(reconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serviceServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: serviceReconcileName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(serviceServer).Reconcile(ctx, req.(*reconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func registerServiceServer(s grpc.ServiceRegistrar, srv serviceServer) {
 	desc := &grpc.ServiceDesc{
 		ServiceName: grpcServiceName,
 		HandlerType: (*serviceServer)(nil),
 		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetDocGraph",
+				Handler:    getDocGraphHandler,
+			},
+			{
+				MethodName: "PushDocGraph",
+				Handler:    pushDocGraphHandler,
+			},
+			{
+				MethodName: "GetLog",
+				Handler:    getLogHandler,
+			},
 			{
 				MethodName: "PushLog",
 				Handler:    pushLogHandler,
 			},
+			{
+				MethodName: "GetHeadLog",
+				Handler:    getHeadLogHandler,
+			},
+			{
+				MethodName: "Reconcile",
+				Handler:    reconcileHandler,
+			},
 		},
 		Streams:  []grpc.StreamDesc{},
 		Metadata: "defradb.cbor",
@@ -15,6 +15,7 @@ package net
 import (
 	"context"
 	"io"
+	"maps"
 	"time"
 
 	"github.com/ipfs/boxo/bitswap"
@@ -25,6 +26,7 @@ import (
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
+	libp2pnet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/routing"
 
@@ -61,6 +63,16 @@ type Peer struct {
 	// peer DAG service
 	bserv blockservice.BlockService
 
+	// heads retrieves the document heads shared with peers catching up on missed updates
+	heads HeadsRetriever
+
+	// access controls which peers may push blocks to this node
+	access *peerAccess
+	// pushAuth authorizes the identities that created pushed blocks, nil if disabled
+	pushAuth PushAuthorizer
+	// docMatcher matches the replicated documents against the filters of the replicators
+	docMatcher DocMatcher
+
 	bootCloser io.Closer
 }
 
@@ -90,6 +102,11 @@ func NewPeer(
 		opt(options)
 	}
 
+	access, err := newPeerAccess(options.AllowedPeers, options.DeniedPeers)
+	if err != nil {
+		return nil, err
+	}
+
 	peers := make([]peer.AddrInfo, len(options.BootstrapPeers))
 	for i, p := range options.BootstrapPeers {
 		addr, err := peer.AddrInfoFromString(p)
@@ -124,6 +141,12 @@ func NewPeer(
 		bus:        bus,
 		p2pRPC:     grpc.NewServer(options.GRPCServerOptions...),
 		bserv:      blockservice.New(blockstore, bswap),
+		heads:      options.HeadsRetriever,
+		access:     access,
+		docMatcher: options.DocMatcher,
+	}
+	if options.EnablePushACP {
+		p.pushAuth = options.PushAuthorizer
 	}
 
 	if options.EnablePubSub {
@@ -136,7 +159,13 @@ func NewPeer(
 		if err != nil {
 			return nil, err
 		}
-		p.updateSub, err = p.bus.Subscribe(event.UpdateName, event.P2PTopicName, event.ReplicatorName)
+		p.updateSub, err = p.bus.Subscribe(
+			event.UpdateName,
+			event.P2PTopicName,
+			event.ReplicatorName,
+			event.ReconcileName,
+			event.PeerAccessName,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -149,6 +178,12 @@ func NewPeer(
 		return nil, err
 	}
 
+	if p.ps != nil {
+		h.Network().Notify(&libp2pnet.NotifyBundle{
+			ConnectedF: p.handleConnected,
+		})
+	}
+
 	p2pListener, err := gostream.Listen(h, corenet.Protocol)
 	if err != nil {
 		return nil, err
@@ -247,6 +282,12 @@ func (p *Peer) handleMessageLoop() {
 		case event.Replicator:
 			p.server.updateReplicators(evt)
 
+		case event.Reconcile:
+			go p.handleReconcile(evt)
+
+		case event.PeerAccess:
+			p.access.update(evt)
+
 		default:
 			// ignore other events
 			continue
@@ -298,23 +339,65 @@ func (p *Peer) pushLogToReplicators(lg event.Update) {
 	}
 
 	p.server.mu.Lock()
-	reps, exists := p.server.replicators[lg.SchemaRoot]
+	reps := maps.Clone(p.server.replicators[lg.SchemaRoot])
 	p.server.mu.Unlock()
 
-	if exists {
-		for pid := range reps {
-			go func(peerID peer.ID) {
-				if err := p.server.pushLog(lg, peerID); err != nil {
-					log.ErrorE(
-						"Failed pushing log",
-						err,
-						corelog.String("DocID", lg.DocID),
-						corelog.Any("CID", lg.Cid),
-						corelog.Any("PeerID", peerID))
+	for pid, filter := range reps {
+		go func(peerID peer.ID, filter map[string]any) {
+			matches, err := p.matchesReplicatorFilter(lg, filter)
+			if err != nil {
+				log.ErrorE(
+					"Failed to match replicator filter",
+					err,
+					corelog.String("DocID", lg.DocID),
+					corelog.Any("PeerID", peerID))
+			}
+			if !matches {
+				// Documents not matching the filter are not replicated, and don't need
+				// to be retried.
+				if lg.Success != nil {
+					lg.Success <- err == nil
 				}
-			}(pid)
+				return
+			}
+			if err := p.server.pushLog(lg, peerID); err != nil {
+				log.ErrorE(
+					"Failed pushing log",
+					err,
+					corelog.String("DocID", lg.DocID),
+					corelog.Any("CID", lg.Cid),
+					corelog.Any("PeerID", peerID))
+			}
+		}(pid, filter)
+	}
+}
+
+// handleConnected catches up on the subscribed collections and documents with peers
+// as they get connected, as updates may have been published while we were disconnected.
+func (p *Peer) handleConnected(n libp2pnet.Network, c libp2pnet.Conn) {
+	// Only the first connection to a peer requires catching up.
+	if len(n.ConnsToPeer(c.RemotePeer())) > 1 {
+		return
+	}
+	schemaRoots, docIDs := p.server.subscribedTopics()
+	go func() {
+		err := p.server.catchUp(c.RemotePeer(), schemaRoots, docIDs)
+		if err != nil && p.ctx.Err() == nil {
+			log.ErrorE("Failed to catch up with peer", err)
 		}
+	}()
+}
+
+// handleReconcile reconciles the requested collections with the requested peer, and sends
+// the result of the reconciliation back to the requester.
+func (p *Peer) handleReconcile(evt event.Reconcile) {
+	var result event.ReconcileResult
+	if err := p.Connect(p.ctx, evt.Info); err != nil {
+		result.Err = NewErrReconcile(err, errors.NewKV("PeerID", evt.Info.ID))
+	} else {
+		result.Docs, result.Rounds, result.Err = p.server.reconcile(evt.Info.ID, evt.SchemaRoots)
 	}
+	evt.Result <- result
 }
 
 // Connect initiates a connection to the peer with the given address.
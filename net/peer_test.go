@@ -68,7 +68,7 @@ func newTestPeer(ctx context.Context, t *testing.T) (client.DB, *Peer) {
 	store := memory.NewDatastore(ctx)
 	acpLocal := acp.NewLocalACP()
 	acpLocal.Init(context.Background(), "")
-	db, err := db.NewDB(
+	database, err := db.NewDB(
 		ctx,
 		store,
 		immutable.Some[acp.ACP](acpLocal),
@@ -79,14 +79,15 @@ func newTestPeer(ctx context.Context, t *testing.T) (client.DB, *Peer) {
 
 	n, err := NewPeer(
 		ctx,
-		db.Blockstore(),
-		db.Encstore(),
-		db.Events(),
+		database.Blockstore(),
+		database.Encstore(),
+		database.Events(),
 		WithListenAddresses(randomMultiaddr),
+		WithHeadsRetriever(db.NewHeadsRetriever(database)),
 	)
 	require.NoError(t, err)
 
-	return db, n
+	return database, n
 }
 
 func TestNewPeer_NoError(t *testing.T) {
@@ -0,0 +1,295 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcenetwork/corelog"
+	"google.golang.org/grpc"
+
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
+)
+
+const (
+	// reconcileBranchFactor is the number of ranges a range of documents that differs
+	// between peers is split into.
+	reconcileBranchFactor = 16
+	// reconcileLeafSize is the number of documents under which the heads of a range of
+	// documents that differs between peers are exchanged instead of splitting it.
+	reconcileLeafSize = 16
+	// reconcileMaxRounds is the maximum number of rounds of a reconciliation, which is
+	// only reached by peers replying with ranges that never converge.
+	reconcileMaxRounds = 64
+	// reconcileMaxSessions is the maximum number of reconciliations whose items are kept
+	// by the peer receiving them.
+	reconcileMaxSessions = 64
+	// reconcileSessionTTL is the duration after which the items of a reconciliation are
+	// dropped by the peer receiving it.
+	reconcileSessionTTL = time.Minute
+)
+
+// reconcileSessionKey identifies a reconciliation requested by a peer.
+type reconcileSessionKey struct {
+	pid     libpeer.ID
+	session uint64
+}
+
+// reconcileItem contains the heads of a document, or of a branchable collection if the
+// DocID is empty, along with their hash.
+type reconcileItem struct {
+	docID string
+	heads []docHead
+	hash  [sha256.Size]byte
+}
+
+// reconcileItemsFromUpdates groups the heads contained in the given updates by document,
+// ordered by DocID.
+func reconcileItemsFromUpdates(updates []event.Update) []reconcileItem {
+	heads := make(map[string][]docHead)
+	for _, head := range docHeadsFromUpdates(updates) {
+		heads[head.DocID] = append(heads[head.DocID], head)
+	}
+
+	items := make([]reconcileItem, 0, len(heads))
+	for docID, docHeads := range heads {
+		sort.Slice(docHeads, func(i, j int) bool {
+			return bytes.Compare(docHeads[i].CID, docHeads[j].CID) < 0
+		})
+		hasher := sha256.New()
+		hasher.Write([]byte(docID))
+		for _, head := range docHeads {
+			hasher.Write(head.CID)
+		}
+		item := reconcileItem{
+			docID: docID,
+			heads: docHeads,
+		}
+		copy(item.hash[:], hasher.Sum(nil))
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].docID < items[j].docID
+	})
+	return items
+}
+
+// reconcileItemsInRange returns the items with a DocID within the given bounds.
+func reconcileItemsInRange(items []reconcileItem, lower string, upper string) []reconcileItem {
+	start := sort.Search(len(items), func(i int) bool {
+		return items[i].docID >= lower
+	})
+	end := len(items)
+	if upper != "" {
+		end = sort.Search(len(items), func(i int) bool {
+			return items[i].docID >= upper
+		})
+	}
+	if end < start {
+		return nil
+	}
+	return items[start:end]
+}
+
+// newReconcileRange returns the range with the given bounds, fingerprinting the given items.
+//
+// The fingerprint of a range is the XOR of the hashes of its items, so that it does not
+// depend on how the range was split.
+func newReconcileRange(schemaRoot string, lower string, upper string, items []reconcileItem) reconcileRange {
+	var fingerprint [sha256.Size]byte
+	for _, item := range items {
+		for i := range fingerprint {
+			fingerprint[i] ^= item.hash[i]
+		}
+	}
+	return reconcileRange{
+		SchemaRoot:  schemaRoot,
+		Lower:       lower,
+		Upper:       upper,
+		Fingerprint: fingerprint[:],
+		Count:       len(items),
+	}
+}
+
+// matches returns true if the given range contains the same documents, with the same heads.
+func (r reconcileRange) matches(other reconcileRange) bool {
+	return r.Count == other.Count && bytes.Equal(r.Fingerprint, other.Fingerprint)
+}
+
+// splitReconcileRange splits the given range into ranges containing a similar number
+// of the given items.
+func splitReconcileRange(r reconcileRange, items []reconcileItem) []reconcileRange {
+	size := (len(items) + reconcileBranchFactor - 1) / reconcileBranchFactor
+	ranges := []reconcileRange{}
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		lower := r.Lower
+		if start > 0 {
+			lower = items[start].docID
+		}
+		upper := r.Upper
+		if end < len(items) {
+			upper = items[end].docID
+		}
+		ranges = append(ranges, newReconcileRange(r.SchemaRoot, lower, upper, items[start:end]))
+	}
+	return ranges
+}
+
+// collectionReconcileItems returns the reconcile items of the collection with the given
+// schema root, if it is shared over the network.
+func (s *server) collectionReconcileItems(ctx context.Context, schemaRoot string) ([]reconcileItem, error) {
+	if s.peer.heads == nil {
+		return nil, nil
+	}
+	updates, err := s.peer.heads.GetCollectionHeads(ctx, schemaRoot)
+	if err != nil {
+		return nil, err
+	}
+	return reconcileItemsFromUpdates(updates), nil
+}
+
+// sessionReconcileItems returns the reconcile items of the collection with the given schema root
+// for the given reconciliation requested by the given peer.
+//
+// The items are collected once per reconciliation, so that every round of a reconciliation
+// compares the ranges with the same documents without reading all the heads of the
+// collection again.
+func (s *server) sessionReconcileItems(
+	ctx context.Context,
+	pid libpeer.ID,
+	session uint64,
+	schemaRoot string,
+) ([]reconcileItem, error) {
+	key := reconcileSessionKey{pid: pid, session: session}
+	s.mu.Lock()
+	items, ok := s.reconcileSessions.Get(key)
+	colItems, hasCol := items[schemaRoot]
+	s.mu.Unlock()
+	if ok && hasCol {
+		return colItems, nil
+	}
+
+	colItems, err := s.collectionReconcileItems(ctx, schemaRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, ok = s.reconcileSessions.Get(key)
+	if !ok {
+		items = make(map[string][]reconcileItem)
+	}
+	items[schemaRoot] = colItems
+	s.reconcileSessions.Add(key, items)
+	return colItems, nil
+}
+
+// reconcile finds the documents of the given collections whose heads differ from the ones
+// known to the given peer, and pulls the blocks this peer is missing from it.
+//
+// Reconciliation only pulls, the blocks the given peer is missing are not pushed to it.
+//
+// Ranges of documents whose fingerprints differ are split until they are small enough to
+// exchange their heads, so that the differing documents are found in a number of rounds
+// that is logarithmic to the number of documents.
+//
+// It returns the number of documents that were pulled and the number of rounds needed.
+func (s *server) reconcile(pid libpeer.ID, schemaRoots []string) (docs int, rounds int, err error) {
+	defer func() {
+		if err != nil {
+			err = NewErrReconcile(err, errors.NewKV("PeerID", pid))
+		}
+	}()
+
+	client, err := s.dial(pid) // grpc dial over P2P stream
+	if err != nil {
+		return 0, 0, err
+	}
+
+	items := make(map[string][]reconcileItem)
+	req := &reconcileRequest{Session: rand.Uint64()}
+	for _, schemaRoot := range schemaRoots {
+		items[schemaRoot], err = s.collectionReconcileItems(s.peer.ctx, schemaRoot)
+		if err != nil {
+			return 0, 0, err
+		}
+		req.Ranges = append(req.Ranges, newReconcileRange(schemaRoot, "", "", items[schemaRoot]))
+	}
+
+	pulledDocs := make(map[string]struct{})
+	for len(req.Ranges) > 0 {
+		if rounds == reconcileMaxRounds {
+			return 0, rounds, ErrReconcileMaxRounds
+		}
+		rounds++
+
+		next, err := s.reconcileRound(client, pid, req, items, pulledDocs)
+		if err != nil {
+			return 0, rounds, err
+		}
+		req = next
+	}
+
+	log.InfoContext(s.peer.ctx, "Reconciled with peer",
+		corelog.Any("PeerID", pid.String()),
+		corelog.Any("Docs", len(pulledDocs)),
+		corelog.Any("Rounds", rounds))
+
+	return len(pulledDocs), rounds, nil
+}
+
+// reconcileRound sends the given ranges to the given peer and pulls the heads it replied
+// with, returning the ranges that still differ.
+func (s *server) reconcileRound(
+	client *grpc.ClientConn,
+	pid libpeer.ID,
+	req *reconcileRequest,
+	items map[string][]reconcileItem,
+	pulledDocs map[string]struct{},
+) (*reconcileRequest, error) {
+	ctx, cancel := context.WithTimeout(s.peer.ctx, PullTimeout)
+	defer cancel()
+
+	reply := &reconcileReply{}
+	if err := client.Invoke(ctx, serviceReconcileName, req, reply); err != nil {
+		return nil, err
+	}
+
+	pulled, err := s.pullHeads(ctx, client, pid, reply.Heads)
+	if err != nil {
+		return nil, err
+	}
+	for _, head := range pulled {
+		pulledDocs[head.SchemaRoot+"/"+head.DocID] = struct{}{}
+	}
+
+	next := &reconcileRequest{Session: req.Session}
+	for _, r := range reply.Ranges {
+		colItems, ok := items[r.SchemaRoot]
+		if !ok {
+			return nil, ErrUnexpectedReconcileRange
+		}
+		local := newReconcileRange(r.SchemaRoot, r.Lower, r.Upper, reconcileItemsInRange(colItems, r.Lower, r.Upper))
+		if !local.matches(r) {
+			next.Ranges = append(next.Ranges, local)
+		}
+	}
+	return next, nil
+}
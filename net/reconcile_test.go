@@ -0,0 +1,229 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+	grpcpeer "google.golang.org/grpc/peer"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/event"
+)
+
+func newTestReconcileItems(t *testing.T, count int) []reconcileItem {
+	prefix := cid.Prefix{
+		Version:  1,
+		Codec:    cid.Raw,
+		MhType:   multihash.SHA2_256,
+		MhLength: -1,
+	}
+	updates := make([]event.Update, count)
+	for i := range updates {
+		docID := fmt.Sprintf("doc%03d", i)
+		headCID, err := prefix.Sum([]byte(docID))
+		require.NoError(t, err)
+		updates[i] = event.Update{
+			DocID:      docID,
+			Cid:        headCID,
+			SchemaRoot: "schema",
+		}
+	}
+	return reconcileItemsFromUpdates(updates)
+}
+
+func TestReconcileItemsInRange(t *testing.T) {
+	items := newTestReconcileItems(t, 10)
+
+	require.Len(t, reconcileItemsInRange(items, "", ""), 10)
+	require.Len(t, reconcileItemsInRange(items, "doc002", ""), 8)
+	require.Len(t, reconcileItemsInRange(items, "doc002", "doc005"), 3)
+	require.Len(t, reconcileItemsInRange(items, "doc005", "doc002"), 0)
+}
+
+func TestSplitReconcileRange_CoversRange(t *testing.T) {
+	items := newTestReconcileItems(t, 100)
+	r := newReconcileRange("schema", "", "", items)
+
+	ranges := splitReconcileRange(r, items)
+	require.Len(t, ranges, 15)
+	require.Equal(t, r.Lower, ranges[0].Lower)
+	require.Equal(t, r.Upper, ranges[len(ranges)-1].Upper)
+
+	var fingerprint [32]byte
+	count := 0
+	for i, subRange := range ranges {
+		if i > 0 {
+			require.Equal(t, ranges[i-1].Upper, subRange.Lower)
+		}
+		rangeItems := reconcileItemsInRange(items, subRange.Lower, subRange.Upper)
+		require.True(t, newReconcileRange("schema", subRange.Lower, subRange.Upper, rangeItems).matches(subRange))
+		for j := range fingerprint {
+			fingerprint[j] ^= subRange.Fingerprint[j]
+		}
+		count += subRange.Count
+	}
+	require.Equal(t, r.Count, count)
+	require.Equal(t, r.Fingerprint, fingerprint[:])
+}
+
+func TestReconcileRangeMatches_WithDifferentHeads_False(t *testing.T) {
+	items := newTestReconcileItems(t, 10)
+	otherItems := newTestReconcileItems(t, 10)
+	otherItems[5].hash[0] ^= 1
+
+	r := newReconcileRange("schema", "", "", items)
+	require.True(t, r.matches(newReconcileRange("schema", "", "", items)))
+	require.False(t, r.matches(newReconcileRange("schema", "", "", otherItems)))
+	require.False(t, r.matches(newReconcileRange("schema", "", "", items[1:])))
+}
+
+// createTestUsers creates the User P2P collection and the given number of documents in it.
+func createTestUsers(ctx context.Context, t *testing.T, db client.DB, count int) client.Collection {
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	for i := 0; i < count; i++ {
+		doc, err := client.NewDocFromJSON([]byte(fmt.Sprintf(`{"name": "John", "age": %d}`, i)), col.Definition())
+		require.NoError(t, err)
+		err = col.Create(ctx, doc)
+		require.NoError(t, err)
+	}
+
+	err = db.AddP2PCollections(ctx, []string{col.SchemaRoot()})
+	require.NoError(t, err)
+
+	return col
+}
+
+func TestReconcile_WithSameDocs_EmptyReply(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	col := createTestUsers(ctx, t, db, 20)
+
+	items, err := p.server.collectionReconcileItems(ctx, col.SchemaRoot())
+	require.NoError(t, err)
+	require.Len(t, items, 20)
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	reply, err := p.server.Reconcile(ctx, &reconcileRequest{
+		Ranges: []reconcileRange{newReconcileRange(col.SchemaRoot(), "", "", items)},
+	})
+	require.NoError(t, err)
+	require.Empty(t, reply.Ranges)
+	require.Empty(t, reply.Heads)
+}
+
+func TestReconcile_WithSameSession_ShouldReuseItems(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	col := createTestUsers(ctx, t, db, 20)
+
+	items, err := p.server.collectionReconcileItems(ctx, col.SchemaRoot())
+	require.NoError(t, err)
+	ranges := []reconcileRange{newReconcileRange(col.SchemaRoot(), "", "", items)}
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+	reply, err := p.server.Reconcile(ctx, &reconcileRequest{Session: 1, Ranges: ranges})
+	require.NoError(t, err)
+	require.Empty(t, reply.Ranges)
+	require.Empty(t, reply.Heads)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 100}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	// The rounds of a reconciliation compare the ranges with the documents of its first round.
+	reply, err = p.server.Reconcile(ctx, &reconcileRequest{Session: 1, Ranges: ranges})
+	require.NoError(t, err)
+	require.Empty(t, reply.Ranges)
+	require.Empty(t, reply.Heads)
+
+	reply, err = p.server.Reconcile(ctx, &reconcileRequest{Session: 2, Ranges: ranges})
+	require.NoError(t, err)
+	require.NotEmpty(t, reply.Ranges)
+}
+
+func TestReconcile_WithMissingDocs_PullsDocs(t *testing.T) {
+	ctx := context.Background()
+	db1, p1 := newTestPeer(ctx, t)
+	defer db1.Close()
+	defer p1.Close()
+	db2, p2 := newTestPeer(ctx, t)
+	defer db2.Close()
+	defer p2.Close()
+
+	col := createTestUsers(ctx, t, db1, 40)
+	_, err := db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	sub, err := db2.Events().Subscribe(event.MergeCompleteName)
+	require.NoError(t, err)
+	defer db2.Events().Unsubscribe(sub)
+
+	err = p2.Connect(ctx, p1.PeerInfo())
+	require.NoError(t, err)
+
+	docs, rounds, err := p2.server.reconcile(p1.PeerID(), []string{col.SchemaRoot()})
+	require.NoError(t, err)
+	require.Equal(t, 40, docs)
+	// The documents are split into ranges small enough to exchange their heads in the
+	// first round, and those heads are exchanged in the second round.
+	require.Equal(t, 2, rounds)
+
+	for i := 0; i < 40; i++ {
+		select {
+		case <-sub.Message():
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for merge")
+		}
+	}
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	docIDsChan, err := col2.GetAllDocIDs(ctx)
+	require.NoError(t, err)
+	count := 0
+	for range docIDsChan {
+		count++
+	}
+	require.Equal(t, 40, count)
+
+	docs, _, err = p2.server.reconcile(p1.PeerID(), []string{col.SchemaRoot()})
+	require.NoError(t, err)
+	require.Equal(t, 0, docs)
+}
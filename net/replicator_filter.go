@@ -0,0 +1,77 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/event"
+	coreblock "github.com/sourcenetwork/defradb/internal/core/block"
+)
+
+// DocMatcher matches the documents replicated to peers against the filters of the replicators.
+type DocMatcher interface {
+	// DocMatchesFilter returns true if the document with the given ID of the collection with
+	// the given schema root matches the given filter in any of the given versions.
+	//
+	// If no versions are given the current state of the document is matched.
+	DocMatchesFilter(
+		ctx context.Context,
+		schemaRoot string,
+		docID string,
+		versions []cid.Cid,
+		filter map[string]any,
+	) (bool, error)
+}
+
+// matchesReplicatorFilter returns true if the document of the given update matches the given
+// replicator filter, either before or after the update.
+//
+// Updates taking a document out of the filter are replicated, so that the peer doesn't keep
+// a stale copy of it.
+//
+// Updates are always replicated if the replicator has no filter or if they are not document
+// updates. Filtered updates are never replicated if no DocMatcher is configured.
+func (p *Peer) matchesReplicatorFilter(lg event.Update, filter map[string]any) (bool, error) {
+	if len(filter) == 0 || lg.DocID == "" {
+		return true, nil
+	}
+	if p.docMatcher == nil {
+		return false, nil
+	}
+	return p.docMatcher.DocMatchesFilter(p.ctx, lg.SchemaRoot, lg.DocID, updateVersions(lg), filter)
+}
+
+// updateVersions returns the versions of the document before and after the given update.
+//
+// Deleted documents keep the values they had before being deleted, so only the previous
+// versions are returned for deletes. No versions are returned if the block of the update
+// is not known.
+func updateVersions(lg event.Update) []cid.Cid {
+	if len(lg.Block) == 0 || !lg.Cid.Defined() {
+		return nil
+	}
+	block, err := coreblock.GetFromBytes(lg.Block)
+	if err != nil {
+		return nil
+	}
+	var versions []cid.Cid
+	if client.DocumentStatus(block.Delta.GetStatus()) != client.Deleted {
+		versions = append(versions, lg.Cid)
+	}
+	for _, head := range block.Heads {
+		versions = append(versions, head.Cid)
+	}
+	return versions
+}
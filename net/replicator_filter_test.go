@@ -0,0 +1,112 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/event"
+)
+
+type testDocMatcher struct {
+	matches bool
+}
+
+func (m testDocMatcher) DocMatchesFilter(
+	ctx context.Context,
+	schemaRoot string,
+	docID string,
+	versions []cid.Cid,
+	filter map[string]any,
+) (bool, error) {
+	return m.matches, nil
+}
+
+func TestMatchesReplicatorFilter(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	filter := map[string]any{"name": map[string]any{"_eq": "John"}}
+	docUpdate := event.Update{DocID: "bae-1", SchemaRoot: "root"}
+
+	// Updates are replicated if the replicator has no filter.
+	matches, err := p.matchesReplicatorFilter(docUpdate, nil)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	// Filtered updates can't be matched without a matcher.
+	matches, err = p.matchesReplicatorFilter(docUpdate, filter)
+	require.NoError(t, err)
+	require.False(t, matches)
+
+	// Collection updates are not filtered.
+	matches, err = p.matchesReplicatorFilter(event.Update{SchemaRoot: "root"}, filter)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	p.docMatcher = testDocMatcher{matches: true}
+	matches, err = p.matchesReplicatorFilter(docUpdate, filter)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	p.docMatcher = testDocMatcher{matches: false}
+	matches, err = p.matchesReplicatorFilter(docUpdate, filter)
+	require.NoError(t, err)
+	require.False(t, matches)
+}
+
+func TestUpdateVersions(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	col := createTestUsers(ctx, t, db, 0)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`), col.Definition())
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+	createHead, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+	updateHead, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	_, err = col.Delete(ctx, doc.ID())
+	require.NoError(t, err)
+	deleteHead, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	newUpdate := func(head cid.Cid) event.Update {
+		b, err := db.Blockstore().AsIPLDStorage().Get(ctx, head.KeyString())
+		require.NoError(t, err)
+		return event.Update{DocID: doc.ID().String(), Cid: head, Block: b}
+	}
+
+	// Updates are matched before and after being applied.
+	require.Equal(t, []cid.Cid{createHead}, updateVersions(newUpdate(createHead)))
+	require.Equal(t, []cid.Cid{updateHead, createHead}, updateVersions(newUpdate(updateHead)))
+	// Deletes are only matched before being applied.
+	require.Equal(t, []cid.Cid{updateHead}, updateVersions(newUpdate(deleteHead)))
+	// Updates without blocks are matched against the current state of the document.
+	require.Nil(t, updateVersions(event.Update{DocID: doc.ID().String()}))
+}
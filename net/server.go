@@ -15,9 +15,11 @@ package net
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/fxamacker/cbor/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	cid "github.com/ipfs/go-cid"
 	libpeer "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
@@ -43,11 +45,15 @@ type server struct {
 	opts []grpc.DialOption
 
 	topics map[string]pubsubTopic
-	// replicators is a map from collectionName => peerId
-	replicators map[string]map[libpeer.ID]struct{}
+	// replicators is a map from schemaRoot => peerId => filter of the replicated documents
+	replicators map[string]map[libpeer.ID]map[string]any
 	mu          sync.Mutex
 
 	conns map[libpeer.ID]*grpc.ClientConn
+
+	// reconcileSessions contains the reconcile items of the reconciliations requested by
+	// other peers, by schema root.
+	reconcileSessions *expirable.LRU[reconcileSessionKey, map[string][]reconcileItem]
 }
 
 // pubsubTopic is a wrapper of rpc.Topic to be able to track if the topic has
@@ -64,7 +70,12 @@ func newServer(p *Peer, opts ...grpc.DialOption) (*server, error) {
 		peer:        p,
 		conns:       make(map[libpeer.ID]*grpc.ClientConn),
 		topics:      make(map[string]pubsubTopic),
-		replicators: make(map[string]map[libpeer.ID]struct{}),
+		replicators: make(map[string]map[libpeer.ID]map[string]any),
+		reconcileSessions: expirable.NewLRU[reconcileSessionKey, map[string][]reconcileItem](
+			reconcileMaxSessions,
+			nil,
+			reconcileSessionTTL,
+		),
 	}
 
 	cred := insecure.NewCredentials()
@@ -80,24 +91,71 @@ func newServer(p *Peer, opts ...grpc.DialOption) (*server, error) {
 }
 
 // GetDocGraph receives a get graph request
+//
+// It replies with the heads of the requested documents that are known to this peer.
 func (s *server) GetDocGraph(
 	ctx context.Context,
 	req *getDocGraphRequest,
 ) (*getDocGraphReply, error) {
-	return nil, nil
+	reply := &getDocGraphReply{}
+	if s.peer.heads == nil {
+		return reply, nil
+	}
+	for _, docID := range req.DocIDs {
+		if _, err := client.NewDocIDFromString(docID); err != nil {
+			return nil, err
+		}
+		updates, err := s.peer.heads.GetDocHeads(ctx, docID)
+		if err != nil {
+			return nil, err
+		}
+		reply.Heads = append(reply.Heads, docHeadsFromUpdates(updates)...)
+	}
+	return reply, nil
 }
 
 // PushDocGraph receives a push graph request
+//
+// Each of the pushed heads is processed as if it was received through a push log request.
 func (s *server) PushDocGraph(
 	ctx context.Context,
 	req *pushDocGraphRequest,
 ) (*pushDocGraphReply, error) {
-	return nil, nil
+	if len(req.CIDs) != len(req.Blocks) {
+		return nil, ErrMismatchedDocGraphHeads
+	}
+	for i := range req.CIDs {
+		_, err := s.PushLog(ctx, &pushLogRequest{
+			DocID:      req.DocID,
+			CID:        req.CIDs[i],
+			SchemaRoot: req.SchemaRoot,
+			Creator:    req.Creator,
+			Block:      req.Blocks[i],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pushDocGraphReply{}, nil
 }
 
 // GetLog receives a get log request
+//
+// It replies with the requested blocks, in the order they were requested.
 func (s *server) GetLog(ctx context.Context, req *getLogRequest) (*getLogReply, error) {
-	return nil, nil
+	reply := &getLogReply{}
+	for _, c := range req.CIDs {
+		blockCID, err := cid.Cast(c)
+		if err != nil {
+			return nil, err
+		}
+		block, err := s.peer.blockstore.Get(ctx, blockCID)
+		if err != nil {
+			return nil, NewErrGetLog(err, errors.NewKV("CID", blockCID))
+		}
+		reply.Blocks = append(reply.Blocks, block.RawData())
+	}
+	return reply, nil
 }
 
 // PushLog receives a push log request
@@ -131,6 +189,26 @@ func (s *server) PushLog(ctx context.Context, req *pushLogRequest) (*pushLogRepl
 		corelog.Any("Creator", byPeer.String()),
 		corelog.Any("DocID", req.DocID))
 
+	head := docHead{
+		DocID:      req.DocID,
+		SchemaRoot: req.SchemaRoot,
+		CID:        req.CID,
+	}
+	if err := s.authorizePeers(ctx, pid, byPeer, head); err != nil {
+		return nil, err
+	}
+	// The block is authorized and merged under the pushed CID, so it must be the block it addresses.
+	link, err := block.GenerateLink()
+	if err != nil {
+		return nil, err
+	}
+	if !link.Cid.Equals(headCID) {
+		return nil, s.rejectPush(ctx, pid, byPeer, head, rejectReasonMismatchedCID)
+	}
+	if err := s.authorizeBlock(ctx, pid, byPeer, head, block); err != nil {
+		return nil, err
+	}
+
 	log.InfoContext(ctx, "Starting DAG sync",
 		corelog.Any("PeerID", pid.String()),
 		corelog.Any("DocID", req.DocID))
@@ -165,11 +243,88 @@ func (s *server) PushLog(ctx context.Context, req *pushLogRequest) (*pushLogRepl
 }
 
 // GetHeadLog receives a get head log request
+//
+// It replies with a page of the heads of the documents of the requested collections that
+// are shared by this peer. The heads are ordered by collection, in the order they are
+// requested, and by document ID and CID within a collection.
 func (s *server) GetHeadLog(
 	ctx context.Context,
 	req *getHeadLogRequest,
 ) (*getHeadLogReply, error) {
-	return nil, nil
+	reply := &getHeadLogReply{}
+	if s.peer.heads == nil {
+		return reply, nil
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > headLogPageSize {
+		limit = headLogPageSize
+	}
+
+	schemaRoots := req.SchemaRoots
+	if req.After != nil {
+		i := slices.Index(schemaRoots, req.After.SchemaRoot)
+		if i < 0 {
+			return reply, nil
+		}
+		schemaRoots = schemaRoots[i:]
+	}
+	for _, schemaRoot := range schemaRoots {
+		updates, err := s.peer.heads.GetCollectionHeads(ctx, schemaRoot)
+		if err != nil {
+			return nil, err
+		}
+		heads := docHeadsFromUpdates(updates)
+		slices.SortFunc(heads, compareDocHeads)
+		if req.After != nil && req.After.SchemaRoot == schemaRoot {
+			i, found := slices.BinarySearchFunc(heads, *req.After, compareDocHeads)
+			if found {
+				i++
+			}
+			heads = heads[i:]
+		}
+		if len(reply.Heads)+len(heads) > limit {
+			reply.Heads = append(reply.Heads, heads[:limit-len(reply.Heads)]...)
+			reply.More = true
+			return reply, nil
+		}
+		reply.Heads = append(reply.Heads, heads...)
+	}
+	return reply, nil
+}
+
+// Reconcile receives a reconcile request
+//
+// It compares the requested ranges of documents with the ones of the collections shared
+// by this peer. It replies with the heads of the documents of the small ranges that differ,
+// and splits the large ones into smaller ranges.
+func (s *server) Reconcile(ctx context.Context, req *reconcileRequest) (*reconcileReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &reconcileReply{}
+	for _, r := range req.Ranges {
+		colItems, err := s.sessionReconcileItems(ctx, pid, req.Session, r.SchemaRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		rangeItems := reconcileItemsInRange(colItems, r.Lower, r.Upper)
+		local := newReconcileRange(r.SchemaRoot, r.Lower, r.Upper, rangeItems)
+		switch {
+		case local.matches(r):
+			continue
+
+		case len(rangeItems) <= reconcileLeafSize:
+			for _, item := range rangeItems {
+				reply.Heads = append(reply.Heads, item.heads...)
+			}
+
+		default:
+			reply.Ranges = append(reply.Ranges, splitReconcileRange(local, rangeItems)...)
+		}
+	}
+	return reply, nil
 }
 
 // addPubSubTopic subscribes to a topic on the pubsub network
@@ -370,12 +525,20 @@ func peerIDFromContext(ctx context.Context) (libpeer.ID, error) {
 }
 
 func (s *server) updatePubSubTopics(evt event.P2PTopic) {
+	schemaRoots := []string{}
 	for _, topic := range evt.ToAdd {
 		_, err := s.addPubSubTopic(topic, true, nil)
 		if err != nil {
 			log.ErrorE("Failed to add pubsub topic.", err)
+			continue
+		}
+		if _, err := client.NewDocIDFromString(topic); err != nil {
+			schemaRoots = append(schemaRoots, topic)
 		}
 	}
+	// Updates to newly subscribed collections may have been published before we subscribed
+	// to them, so we catch up on them with the peers we are connected to.
+	s.catchUpWithConnectedPeers(schemaRoots, nil)
 
 	for _, topic := range evt.ToRemove {
 		err := s.removePubSubTopic(topic)
@@ -403,7 +566,7 @@ func (s *server) updateReplicators(evt event.Replicator) {
 	s.mu.Lock()
 	for schema, peers := range s.replicators {
 		if _, hasSchema := evt.Schemas[schema]; hasSchema {
-			s.replicators[schema][evt.Info.ID] = struct{}{}
+			s.replicators[schema][evt.Info.ID] = evt.Filters[schema]
 			delete(evt.Schemas, schema)
 		} else {
 			if _, exists := peers[evt.Info.ID]; exists {
@@ -413,9 +576,9 @@ func (s *server) updateReplicators(evt event.Replicator) {
 	}
 	for schema := range evt.Schemas {
 		if _, exists := s.replicators[schema]; !exists {
-			s.replicators[schema] = make(map[libpeer.ID]struct{})
+			s.replicators[schema] = make(map[libpeer.ID]map[string]any)
 		}
-		s.replicators[schema][evt.Info.ID] = struct{}{}
+		s.replicators[schema][evt.Info.ID] = evt.Filters[schema]
 	}
 	s.mu.Unlock()
 
@@ -13,6 +13,7 @@ package net
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore/query"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
 	"github.com/sourcenetwork/defradb/internal/core"
 	"github.com/sourcenetwork/defradb/internal/keys"
 )
@@ -34,14 +36,57 @@ func TestNewServerSimple(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// createTestUser creates the User collection and a document in it.
+func createTestUser(ctx context.Context, t *testing.T, db client.DB) (client.Collection, *client.Document) {
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`), col.Definition())
+	require.NoError(t, err)
+
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	return col, doc
+}
+
 func TestGetDocGraph(t *testing.T) {
 	ctx := context.Background()
 	db, p := newTestPeer(ctx, t)
 	defer db.Close()
 	defer p.Close()
-	r, err := p.server.GetDocGraph(ctx, &getDocGraphRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+
+	col, doc := createTestUser(ctx, t, db)
+
+	headCID, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	r, err := p.server.GetDocGraph(ctx, &getDocGraphRequest{DocIDs: []string{doc.ID().String()}})
+	require.NoError(t, err)
+	require.Equal(t, []docHead{{
+		DocID:      doc.ID().String(),
+		SchemaRoot: col.SchemaRoot(),
+		CID:        headCID.Bytes(),
+	}}, r.Heads)
+}
+
+func TestGetDocGraph_WithUnknownDoc_NoHeads(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	r, err := p.server.GetDocGraph(ctx, &getDocGraphRequest{
+		DocIDs: []string{"bae-7fca96a2-5f01-5558-a81f-09b47587f26d"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, r.Heads)
 }
 
 func TestPushDocGraph(t *testing.T) {
@@ -49,9 +94,39 @@ func TestPushDocGraph(t *testing.T) {
 	db, p := newTestPeer(ctx, t)
 	defer db.Close()
 	defer p.Close()
-	r, err := p.server.PushDocGraph(ctx, &pushDocGraphRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+
+	col, doc := createTestUser(ctx, t, db)
+
+	headCID, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	b, err := db.Blockstore().AsIPLDStorage().Get(ctx, headCID.KeyString())
+	require.NoError(t, err)
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{p.PeerID()},
+	})
+
+	_, err = p.server.PushDocGraph(ctx, &pushDocGraphRequest{
+		DocID:      doc.ID().String(),
+		SchemaRoot: col.SchemaRoot(),
+		Creator:    p.PeerID().String(),
+		CIDs:       [][]byte{headCID.Bytes()},
+		Blocks:     [][]byte{b},
+	})
+	require.NoError(t, err)
+}
+
+func TestPushDocGraph_WithMismatchedHeads_Error(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	_, err := p.server.PushDocGraph(ctx, &pushDocGraphRequest{
+		CIDs: [][]byte{{}},
+	})
+	require.ErrorIs(t, err, ErrMismatchedDocGraphHeads)
 }
 
 func TestGetLog(t *testing.T) {
@@ -59,9 +134,18 @@ func TestGetLog(t *testing.T) {
 	db, p := newTestPeer(ctx, t)
 	defer db.Close()
 	defer p.Close()
-	r, err := p.server.GetLog(ctx, &getLogRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+
+	_, doc := createTestUser(ctx, t, db)
+
+	headCID, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	b, err := db.Blockstore().AsIPLDStorage().Get(ctx, headCID.KeyString())
+	require.NoError(t, err)
+
+	r, err := p.server.GetLog(ctx, &getLogRequest{CIDs: [][]byte{headCID.Bytes()}})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{b}, r.Blocks)
 }
 
 func TestGetHeadLog(t *testing.T) {
@@ -69,9 +153,94 @@ func TestGetHeadLog(t *testing.T) {
 	db, p := newTestPeer(ctx, t)
 	defer db.Close()
 	defer p.Close()
-	r, err := p.server.GetHeadLog(ctx, &getHeadLogRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+
+	col, doc := createTestUser(ctx, t, db)
+
+	headCID, err := getHead(ctx, db, doc.ID())
+	require.NoError(t, err)
+
+	// The heads of collections that are not shared are not returned.
+	r, err := p.server.GetHeadLog(ctx, &getHeadLogRequest{SchemaRoots: []string{col.SchemaRoot()}})
+	require.NoError(t, err)
+	require.Empty(t, r.Heads)
+
+	err = db.AddP2PCollections(ctx, []string{col.SchemaRoot()})
+	require.NoError(t, err)
+
+	r, err = p.server.GetHeadLog(ctx, &getHeadLogRequest{SchemaRoots: []string{col.SchemaRoot()}})
+	require.NoError(t, err)
+	require.Equal(t, []docHead{{
+		DocID:      doc.ID().String(),
+		SchemaRoot: col.SchemaRoot(),
+		CID:        headCID.Bytes(),
+	}}, r.Heads)
+}
+
+func TestGetHeadLog_WithLimit_ShouldReturnPages(t *testing.T) {
+	ctx := context.Background()
+	db, p := newTestPeer(ctx, t)
+	defer db.Close()
+	defer p.Close()
+
+	col := createTestUsers(ctx, t, db, 5)
+
+	all, err := p.server.GetHeadLog(ctx, &getHeadLogRequest{SchemaRoots: []string{col.SchemaRoot()}})
+	require.NoError(t, err)
+	require.Len(t, all.Heads, 5)
+	require.False(t, all.More)
+
+	var heads []docHead
+	req := &getHeadLogRequest{SchemaRoots: []string{col.SchemaRoot()}, Limit: 2}
+	for {
+		r, err := p.server.GetHeadLog(ctx, req)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(r.Heads), 2)
+		heads = append(heads, r.Heads...)
+		if !r.More {
+			break
+		}
+		req.After = &r.Heads[len(r.Heads)-1]
+	}
+	require.Equal(t, all.Heads, heads)
+}
+
+func TestCatchUp_WithMissingDoc_MergesDoc(t *testing.T) {
+	ctx := context.Background()
+	db1, p1 := newTestPeer(ctx, t)
+	defer db1.Close()
+	defer p1.Close()
+	db2, p2 := newTestPeer(ctx, t)
+	defer db2.Close()
+	defer p2.Close()
+
+	_, doc := createTestUser(ctx, t, db1)
+	_, err := db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	sub, err := db2.Events().Subscribe(event.MergeCompleteName)
+	require.NoError(t, err)
+	defer db2.Events().Unsubscribe(sub)
+
+	err = p2.Connect(ctx, p1.PeerInfo())
+	require.NoError(t, err)
+
+	err = p2.server.catchUp(p1.PeerID(), nil, []string{doc.ID().String()})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-sub.Message():
+		require.Equal(t, doc.ID().String(), msg.Data.(event.MergeComplete).Merge.DocID)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for merge")
+	}
+
+	col, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	_, err = col.Get(ctx, doc.ID(), false)
+	require.NoError(t, err)
 }
 
 func getHead(ctx context.Context, db client.DB, docID client.DocID) (cid.Cid, error) {
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcenetwork/defradb/http"
 	"github.com/sourcenetwork/defradb/internal/db"
 	"github.com/sourcenetwork/defradb/internal/kms"
+	"github.com/sourcenetwork/defradb/internal/metric"
 	"github.com/sourcenetwork/defradb/net"
 )
 
@@ -45,6 +46,7 @@ type Options struct {
 	disableAPI        bool
 	enableDevelopment bool
 	kmsType           immutable.Option[kms.ServiceType]
+	metricsEndpoint   string
 }
 
 // DefaultOptions returns options with default settings.
@@ -82,12 +84,23 @@ func WithEnableDevelopment(enable bool) NodeOpt {
 	}
 }
 
+// WithMetricsEndpoint sets the URL of the OTLP over HTTP endpoint the metrics are exported to,
+// such as `http://localhost:4318/v1/metrics`.
+//
+// Metrics are not recorded if no endpoint is set.
+func WithMetricsEndpoint(endpoint string) NodeOpt {
+	return func(o *Options) {
+		o.metricsEndpoint = endpoint
+	}
+}
+
 // Node is a DefraDB instance with optional sub-systems.
 type Node struct {
 	DB         client.DB
 	Peer       *net.Peer
 	Server     *http.Server
 	kmsService kms.Service
+	meter      *metric.Meter
 
 	options    *Options
 	dbOpts     []db.Option
@@ -147,14 +160,34 @@ func (n *Node) Start(ctx context.Context) error {
 		return err
 	}
 
-	n.DB, err = db.NewDB(ctx, rootstore, acp, lens, n.dbOpts...)
+	dbOpts := n.dbOpts
+	if n.options.metricsEndpoint != "" {
+		exporter, err := metric.NewOTLPExporter(ctx, n.options.metricsEndpoint)
+		if err != nil {
+			return err
+		}
+		meter := metric.NewMeter()
+		meter.RegisterWithExporter("defradb", exporter)
+		n.meter = &meter
+		dbOpts = append([]db.Option{db.WithMeter(n.meter)}, dbOpts...)
+	}
+
+	n.DB, err = db.NewDB(ctx, rootstore, acp, lens, dbOpts...)
 	if err != nil {
 		return err
 	}
 
 	if !n.options.disableP2P {
 		// setup net node
-		n.Peer, err = net.NewPeer(ctx, n.DB.Blockstore(), n.DB.Encstore(), n.DB.Events(), n.netOpts...)
+		netOpts := append(
+			[]net.NodeOpt{
+				net.WithHeadsRetriever(db.NewHeadsRetriever(n.DB)),
+				net.WithPushAuthorizer(db.NewPushAuthorizer(n.DB, acp)),
+				net.WithDocMatcher(db.NewDocMatcher(n.DB)),
+			},
+			n.netOpts...,
+		)
+		n.Peer, err = net.NewPeer(ctx, n.DB.Blockstore(), n.DB.Encstore(), n.DB.Events(), netOpts...)
 		if err != nil {
 			return err
 		}
@@ -220,6 +253,12 @@ func (n *Node) Close(ctx context.Context) error {
 	if n.Peer != nil {
 		n.Peer.Close()
 	}
+	if n.meter != nil {
+		// The meter observes the database, so it is shut down, exporting the remaining
+		// metrics, before the database is closed.
+		err = errors.Join(err, n.meter.Close(ctx))
+		n.meter = nil
+	}
 	if n.DB != nil {
 		n.DB.Close()
 	}
@@ -12,6 +12,8 @@ package node
 
 import (
 	"context"
+	gohttp "net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -38,6 +40,55 @@ func TestWithEnableDevelopment(t *testing.T) {
 	assert.Equal(t, true, options.enableDevelopment)
 }
 
+func TestWithMetricsEndpoint(t *testing.T) {
+	options := &Options{}
+	WithMetricsEndpoint("http://localhost:4318/v1/metrics")(options)
+	assert.Equal(t, "http://localhost:4318/v1/metrics", options.metricsEndpoint)
+}
+
+func TestStartWithMetricsEndpoint_ShouldExportMetricsOnClose(t *testing.T) {
+	ctx := context.Background()
+
+	exported := make(chan string, 1)
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		select {
+		case exported <- r.URL.Path:
+		default:
+		}
+		w.WriteHeader(gohttp.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := []Option{
+		WithDisableAPI(true),
+		WithDisableP2P(true),
+		WithStoreType(MemoryStore),
+		WithMetricsEndpoint(server.URL + "/v1/metrics"),
+	}
+
+	n, err := New(ctx, opts...)
+	require.NoError(t, err)
+
+	err = n.Start(ctx)
+	require.NoError(t, err)
+
+	_, err = n.DB.AddSchema(ctx, "type User { name: String }")
+	require.NoError(t, err)
+
+	result := n.DB.ExecRequest(ctx, "query { User { name } }")
+	require.Len(t, result.GQL.Errors, 0)
+
+	err = n.Close(ctx)
+	require.NoError(t, err)
+
+	select {
+	case path := <-exported:
+		assert.Equal(t, "/v1/metrics", path)
+	default:
+		t.Fatal("expected metrics to be exported")
+	}
+}
+
 func TestPurgeAndRestartWithDevModeDisabled(t *testing.T) {
 	ctx := context.Background()
 
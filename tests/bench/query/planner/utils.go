@@ -110,7 +110,7 @@ func buildParser(
 		return nil, err
 	}
 
-	parser, err := graphql.NewParser()
+	parser, err := graphql.NewParser(graphql.DefaultRequestCacheSize)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +81,13 @@ func (w *Wrapper) PeerInfo() peer.AddrInfo {
 func (w *Wrapper) SetReplicator(ctx context.Context, rep client.ReplicatorParams) error {
 	args := []string{"client", "p2p", "replicator", "set"}
 	args = append(args, "--collection", strings.Join(rep.Collections, ","))
+	if len(rep.Filters) > 0 {
+		filters, err := json.Marshal(rep.Filters)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--filter", string(filters))
+	}
 
 	info, err := json.Marshal(rep.Info)
 	if err != nil {
@@ -150,6 +157,61 @@ func (w *Wrapper) GetAllP2PCollections(ctx context.Context) ([]string, error) {
 	return cols, nil
 }
 
+func (w *Wrapper) ReconcileWithPeer(
+	ctx context.Context,
+	params client.ReconcileParams,
+) (client.ReconcileResult, error) {
+	args := []string{"client", "p2p", "reconcile"}
+	args = append(args, "--collection", strings.Join(params.CollectionIDs, ","))
+
+	info, err := json.Marshal(params.Info)
+	if err != nil {
+		return client.ReconcileResult{}, err
+	}
+	args = append(args, string(info))
+
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return client.ReconcileResult{}, err
+	}
+	var res client.ReconcileResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return client.ReconcileResult{}, err
+	}
+	return res, nil
+}
+
+func (w *Wrapper) SetPeerAccess(ctx context.Context, params client.PeerAccessParams) error {
+	args := []string{"client", "p2p", "access", "set"}
+	args = append(args, "--access", string(params.Access))
+	args = append(args, strings.Join(params.PeerIDs, ","))
+
+	_, err := w.cmd.execute(ctx, args)
+	return err
+}
+
+func (w *Wrapper) DeletePeerAccess(ctx context.Context, peerIDs []string) error {
+	args := []string{"client", "p2p", "access", "delete"}
+	args = append(args, strings.Join(peerIDs, ","))
+
+	_, err := w.cmd.execute(ctx, args)
+	return err
+}
+
+func (w *Wrapper) GetAllPeerAccess(ctx context.Context) ([]client.PeerAccess, error) {
+	args := []string{"client", "p2p", "access", "getall"}
+
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var access []client.PeerAccess
+	if err := json.Unmarshal(data, &access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
 func (w *Wrapper) BasicImport(ctx context.Context, filepath string) error {
 	args := []string{"client", "backup", "import"}
 	args = append(args, filepath)
@@ -393,6 +455,24 @@ func (w *Wrapper) GetAllIndexes(ctx context.Context) (map[client.CollectionName]
 	return indexes, nil
 }
 
+func (w *Wrapper) GetCollectionStatistics(
+	ctx context.Context,
+	collectionName string,
+) (client.CollectionStatistics, error) {
+	args := []string{"client", "collection", "stats"}
+	args = append(args, "--name", collectionName)
+
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	var stats client.CollectionStatistics
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return client.CollectionStatistics{}, err
+	}
+	return stats, nil
+}
+
 func (w *Wrapper) ExecRequest(
 	ctx context.Context,
 	query string,
@@ -400,7 +480,38 @@ func (w *Wrapper) ExecRequest(
 ) *client.RequestResult {
 	args := []string{"client", "query"}
 	args = append(args, query)
+	return w.execRequest(ctx, args, opts...)
+}
+
+func (w *Wrapper) PrepareRequest(ctx context.Context, request string) (string, error) {
+	args := []string{"client", "query", "--prepare"}
+	args = append(args, request)
 
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	var res http.PreparedRequest
+	if err := json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+func (w *Wrapper) ExecPreparedRequest(
+	ctx context.Context,
+	id string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	args := []string{"client", "query", "--prepared", id}
+	return w.execRequest(ctx, args, opts...)
+}
+
+func (w *Wrapper) execRequest(
+	ctx context.Context,
+	args []string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
 	options := &client.GQLOptions{}
 	for _, o := range opts {
 		o(options)
@@ -418,6 +529,12 @@ func (w *Wrapper) ExecRequest(
 		}
 		args = append(args, "--variables", string(enc))
 	}
+	if options.MaxDuration > 0 {
+		args = append(args, "--max-duration", options.MaxDuration.String())
+	}
+	if options.MaxScannedDocs > 0 {
+		args = append(args, "--max-scanned-docs", strconv.FormatUint(options.MaxScannedDocs, 10))
+	}
 
 	stdOut, stdErr, err := w.cmd.executeStream(ctx, args)
 	if err != nil {
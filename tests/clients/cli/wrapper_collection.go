@@ -346,6 +346,13 @@ func (c *Collection) CreateIndex(
 	if indexDesc.Unique {
 		args = append(args, "--unique")
 	}
+	if len(indexDesc.Filter) > 0 {
+		filter, err := json.Marshal(indexDesc.Filter)
+		if err != nil {
+			return index, err
+		}
+		args = append(args, "--filter", string(filter))
+	}
 
 	fields := make([]string, len(indexDesc.Fields))
 	orders := make([]bool, len(indexDesc.Fields))
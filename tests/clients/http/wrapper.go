@@ -87,6 +87,25 @@ func (w *Wrapper) GetAllP2PCollections(ctx context.Context) ([]string, error) {
 	return w.client.GetAllP2PCollections(ctx)
 }
 
+func (w *Wrapper) ReconcileWithPeer(
+	ctx context.Context,
+	params client.ReconcileParams,
+) (client.ReconcileResult, error) {
+	return w.client.ReconcileWithPeer(ctx, params)
+}
+
+func (w *Wrapper) SetPeerAccess(ctx context.Context, params client.PeerAccessParams) error {
+	return w.client.SetPeerAccess(ctx, params)
+}
+
+func (w *Wrapper) DeletePeerAccess(ctx context.Context, peerIDs []string) error {
+	return w.client.DeletePeerAccess(ctx, peerIDs)
+}
+
+func (w *Wrapper) GetAllPeerAccess(ctx context.Context) ([]client.PeerAccess, error) {
+	return w.client.GetAllPeerAccess(ctx)
+}
+
 func (w *Wrapper) BasicImport(ctx context.Context, filepath string) error {
 	return w.client.BasicImport(ctx, filepath)
 }
@@ -205,6 +224,13 @@ func (w *Wrapper) GetAllIndexes(ctx context.Context) (map[client.CollectionName]
 	return w.client.GetAllIndexes(ctx)
 }
 
+func (w *Wrapper) GetCollectionStatistics(
+	ctx context.Context,
+	collectionName string,
+) (client.CollectionStatistics, error) {
+	return w.client.GetCollectionStatistics(ctx, collectionName)
+}
+
 func (w *Wrapper) ExecRequest(
 	ctx context.Context,
 	query string,
@@ -213,6 +239,18 @@ func (w *Wrapper) ExecRequest(
 	return w.client.ExecRequest(ctx, query, opts...)
 }
 
+func (w *Wrapper) PrepareRequest(ctx context.Context, request string) (string, error) {
+	return w.client.PrepareRequest(ctx, request)
+}
+
+func (w *Wrapper) ExecPreparedRequest(
+	ctx context.Context,
+	id string,
+	opts ...client.RequestOption,
+) *client.RequestResult {
+	return w.client.ExecPreparedRequest(ctx, id, opts...)
+}
+
 func (w *Wrapper) NewTxn(ctx context.Context, readOnly bool) (datastore.Txn, error) {
 	client, err := w.client.NewTxn(ctx, readOnly)
 	if err != nil {
@@ -0,0 +1,104 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_acp
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestACP_GetCollectionStatistics_ShouldOnlyCountReadableDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Test acp, collection statistics only count the documents the identity may read",
+
+		Actions: []any{
+			testUtils.AddPolicy{
+
+				Identity: testUtils.ClientIdentity(1),
+
+				Policy: `
+                    name: test
+                    description: a test policy which marks a collection in a database as a resource
+
+                    actor:
+                      name: actor
+
+                    resources:
+                      users:
+                        permissions:
+                          read:
+                            expr: owner + reader
+                          write:
+                            expr: owner
+
+                        relations:
+                          owner:
+                            types:
+                              - actor
+                          reader:
+                            types:
+                              - actor
+                          admin:
+                            manages:
+                              - reader
+                            types:
+                              - actor
+                `,
+
+				ExpectedPolicyID: "94eb195c0e459aa79e02a1986c7e731c5015721c18a373f2b2a0ed140a04b454",
+			},
+
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users @policy(
+						id: "94eb195c0e459aa79e02a1986c7e731c5015721c18a373f2b2a0ed140a04b454",
+						resource: "users"
+					) {
+						name: String @index
+					}
+				`,
+			},
+
+			testUtils.CreateDoc{
+				Doc: `{"name": "Shahzad"}`,
+			},
+
+			testUtils.CreateDoc{
+				Identity: testUtils.ClientIdentity(1),
+
+				Doc: `{"name": "Islam"}`,
+			},
+
+			testUtils.GetCollectionStatistics{
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "Users",
+					DocumentCount:  1,
+					Indexes:        []client.IndexStatistics{},
+				},
+			},
+
+			testUtils.GetCollectionStatistics{
+				Identity: testUtils.ClientIdentity(1),
+
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "Users",
+					DocumentCount:  2,
+					Indexes:        []client.IndexStatistics{},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
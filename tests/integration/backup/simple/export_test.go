@@ -105,3 +105,22 @@ func TestBackupExport_JustUserCollection_NoError(t *testing.T) {
 
 	executeTestCase(t, test)
 }
+
+func TestBackupExport_WithNDJSONFormat_NoError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"name": "John", "age": 30}`,
+			},
+			testUtils.BackupExport{
+				Config: client.BackupConfig{
+					Format: client.BackupFormatNDJSON,
+				},
+				ExpectedContent: `{"collection":"User","document":{"_docID":"bae-7fca96a2-5f01-5558-a81f-09b47587f26d","_docIDNew":"bae-7fca96a2-5f01-5558-a81f-09b47587f26d","age":30,"name":"John"}}` + "\n",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
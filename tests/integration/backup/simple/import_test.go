@@ -11,6 +11,8 @@
 package backup
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
@@ -45,6 +47,41 @@ func TestBackupImport_Simple_NoError(t *testing.T) {
 	executeTestCase(t, test)
 }
 
+func TestBackupImport_WithNDJSONFormat_NoError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.BackupImport{
+				ImportContent: `{"collection":"User","document":{"_docID":"bae-7fca96a2-5f01-5558-a81f-09b47587f26d","_docIDNew":"bae-7fca96a2-5f01-5558-a81f-09b47587f26d","age":30,"name":"John"}}
+{"collection":"User","document":{"_docID":"bae-e933420a-988a-56f8-8952-6c245aebd519","_docIDNew":"bae-e933420a-988a-56f8-8952-6c245aebd519","age":31,"name":"Smith"}}
+`,
+			},
+			testUtils.Request{
+				Request: `
+					query  {
+						User {
+							name
+							age
+						}
+					}`,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{
+							"name": "John",
+							"age":  int64(30),
+						},
+						{
+							"name": "Smith",
+							"age":  int64(31),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
 func TestBackupImport_WithInvalidFilePath_ReturnError(t *testing.T) {
 	test := testUtils.TestCase{
 		Actions: []any{
@@ -214,3 +251,96 @@ func TestBackupImport_WithMultipleNoKeysAndInvalidField_Errors(t *testing.T) {
 
 	executeTestCase(t, test)
 }
+
+// newNDJSONUserRecords returns the given number of NDJSON backup records of distinct users.
+//
+// The number of records is expected to exceed the size of an import batch so that a later
+// failure does not discard the documents imported before it.
+func newNDJSONUserRecords(count int) string {
+	var records strings.Builder
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(&records, `{"collection":"User","document":{"name":"User %d","age":%d}}`+"\n", i, i)
+	}
+	return records.String()
+}
+
+func TestBackupImport_WithInterruptedImport_ResumesAfterLastBatch(t *testing.T) {
+	filepath := t.TempDir() + "/test.ndjson"
+
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.BackupImport{
+				Filepath:      filepath,
+				ImportContent: newNDJSONUserRecords(1001) + `{"collection":"Book","document":{"title":"Game of Thrones"}}`,
+				ExpectedError: "failed to get collection",
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						title: String
+					}
+				`,
+			},
+			// The import resumes after the documents that have already been imported.
+			testUtils.BackupImport{
+				Filepath: filepath,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						_count(User: {})
+						Book {
+							title
+						}
+					}`,
+				Results: map[string]any{
+					"_count": 1001,
+					"Book": []map[string]any{
+						{
+							"title": "Game of Thrones",
+						},
+					},
+				},
+			},
+			// The progress of a completed import is removed so the file is imported from the start.
+			testUtils.BackupImport{
+				Filepath:      filepath,
+				ExpectedError: "a document with the given ID already exists",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestBackupImport_WithInterruptedImportOfChangedFile_ImportsFromStart(t *testing.T) {
+	filepath := t.TempDir() + "/test.ndjson"
+
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.BackupImport{
+				Filepath:      filepath,
+				ImportContent: newNDJSONUserRecords(1001) + `{"collection":"User","document":{"name":"Fred","age":"invalid"}}`,
+				ExpectedError: "failed to create a new doc from map",
+			},
+			// None of the documents of the changed file are skipped.
+			testUtils.BackupImport{
+				Filepath: filepath,
+				ImportContent: `{"collection":"User","document":{"name":"Alice","age":50}}
+{"collection":"User","document":{"name":"Islam","age":60}}
+`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						_count(User: {})
+					}`,
+				Results: map[string]any{
+					"_count": 1002,
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestBackupExportImport_WithNDJSONFormat_NoError(t *testing.T) {
+	executeFormatRoundTripTestCase(t, client.BackupFormatNDJSON)
+}
+
+func TestBackupExportImport_WithCBORFormat_NoError(t *testing.T) {
+	executeFormatRoundTripTestCase(t, client.BackupFormatCBOR)
+}
+
+// executeFormatRoundTripTestCase exports the documents of the first node in the given format
+// and imports them into the second node, relying on the format of the file being detected.
+func executeFormatRoundTripTestCase(t *testing.T, format string) {
+	filepath := t.TempDir() + "/test." + format
+
+	test := testUtils.TestCase{
+		Actions: []any{
+			// Configure 2 nodes for this test, we will export from the first
+			// and import to the second.
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: schemas,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc:    `{"name": "John", "age": 30}`,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc:    `{"name": "Bob", "age": 40}`,
+			},
+			testUtils.BackupExport{
+				NodeID: immutable.Some(0),
+				Config: client.BackupConfig{
+					Filepath: filepath,
+					Format:   format,
+				},
+			},
+			testUtils.BackupImport{
+				NodeID:   immutable.Some(1),
+				Filepath: filepath,
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `
+					query  {
+						User {
+							_docID
+							name
+							age
+						}
+					}`,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{
+							"_docID": testUtils.NewDocIndex(0, 0),
+							"name":   "John",
+							"age":    int64(30),
+						},
+						{
+							"_docID": testUtils.NewDocIndex(0, 1),
+							"name":   "Bob",
+							"age":    int64(40),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
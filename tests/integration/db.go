@@ -21,6 +21,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/crypto"
+	"github.com/sourcenetwork/defradb/internal/db"
 	"github.com/sourcenetwork/defradb/internal/kms"
 	"github.com/sourcenetwork/defradb/net"
 	"github.com/sourcenetwork/defradb/node"
@@ -144,6 +145,22 @@ func getDefaultNodeOpts() []node.Option {
 func setupNode(s *state, opts ...node.Option) (*nodeState, error) {
 	opts = append(getDefaultNodeOpts(), opts...)
 
+	if s.testCase.QueryMemoryBudget.HasValue() {
+		opts = append(opts, db.WithQueryMemoryBudget(s.testCase.QueryMemoryBudget.Value()))
+	}
+	if s.testCase.MaxRequestScannedDocs.HasValue() {
+		opts = append(opts, db.WithMaxRequestScannedDocs(s.testCase.MaxRequestScannedDocs.Value()))
+	}
+	if s.testCase.EnableBlockTimestamps {
+		opts = append(opts, db.WithBlockTimestamps(true))
+	}
+	if s.testCase.EnableBlockSigning {
+		opts = append(opts, db.WithBlockSigning(true))
+	}
+	if s.testCase.RequireBlockSignatures {
+		opts = append(opts, db.WithRequiredBlockSignatures(true))
+	}
+
 	switch acpType {
 	case LocalACPType:
 		opts = append(opts, node.WithACPType(node.LocalACPType))
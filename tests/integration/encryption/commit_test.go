@@ -374,3 +374,36 @@ func TestDocEncryption_IfTwoDocsHaveSameFieldValue_CipherTextShouldBeDifferent(t
 
 	testUtils.ExecuteTestCase(t, test)
 }
+
+func TestDocEncryption_WithBlockTimestamps_ShouldTimestampEncryptedCommits(t *testing.T) {
+	test := testUtils.TestCase{
+		EnableBlockTimestamps: true,
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Doc:            john21Doc,
+				IsDocEncrypted: true,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						commits {
+							fieldName
+							timestamp
+						}
+					}
+				`,
+				Asserter: testUtils.ResultAsserterFunc(func(t testing.TB, result map[string]any) (bool, string) {
+					commits := testUtils.ConvertToArrayOfMaps(t, result["commits"])
+					require.Len(t, commits, 3)
+					for _, commit := range commits {
+						require.NotNil(t, commit["timestamp"], "commit of field %v has no timestamp", commit["fieldName"])
+					}
+					return true, ""
+				}),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
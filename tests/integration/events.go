@@ -72,8 +72,13 @@ func waitForReplicatorConfigureEvent(s *state, cfg ConfigureReplicator) {
 		require.Fail(s.t, "timeout waiting for replicator event")
 	}
 
-	// all previous documents should be merged on the subscriber node
+	s.nodes[cfg.SourceNodeID].p2p.replicatorFilters[cfg.TargetNodeID] = cfg.Filters
+
+	// all previous documents matching the filters should be merged on the subscriber node
 	for key, val := range s.nodes[cfg.SourceNodeID].p2p.actualDAGHeads {
+		if !matchesReplicatorFilter(s, cfg.SourceNodeID, cfg.TargetNodeID, key) {
+			continue
+		}
 		s.nodes[cfg.TargetNodeID].p2p.expectedDAGHeads[key] = val.cid
 	}
 
@@ -288,12 +293,24 @@ func updateNetworkState(s *state, nodeID int, evt event.Update) {
 
 	// update the expected document heads of replicator targets
 	for id := range node.p2p.replicators {
+		// replicator target nodes reject updates pushed by source nodes they don't accept
+		if !acceptsPushesFrom(s, id, nodeID) {
+			continue
+		}
+		// replicator target nodes only receive the documents matching the replicator filter
+		if !matchesReplicatorFilter(s, nodeID, id, getUpdateEventKey(evt)) {
+			continue
+		}
 		// replicator target nodes push updates to source nodes
 		s.nodes[id].p2p.expectedDAGHeads[getUpdateEventKey(evt)] = evt.Cid
 	}
 
 	// update the expected document heads of connected nodes
 	for id := range node.p2p.connections {
+		// connected nodes reject updates pushed by nodes they don't accept
+		if !acceptsPushesFrom(s, id, nodeID) {
+			continue
+		}
 		// connected nodes share updates of documents they have in common
 		if _, ok := s.nodes[id].p2p.actualDAGHeads[getUpdateEventKey(evt)]; ok {
 			s.nodes[id].p2p.expectedDAGHeads[getUpdateEventKey(evt)] = evt.Cid
@@ -34,33 +34,33 @@ var (
 		"subType": {},
 
 		// These are all valid nodes.
-		"averageNode":   {},
-		"countNode":     {},
-		"createNode":    {},
-		"dagScanNode":   {},
-		"deleteNode":    {},
-		"groupNode":     {},
-		"limitNode":     {},
-		"maxNode":       {},
-		"minNode":       {},
-		"multiScanNode": {},
-		"orderNode":     {},
-		"parallelNode":  {},
-		"pipeNode":      {},
-		"scanNode":      {},
-		"selectNode":    {},
-		"selectTopNode": {},
-		"sumNode":       {},
-		"topLevelNode":  {},
-		"typeIndexJoin": {},
-		"typeJoinMany":  {},
-		"typeJoinOne":   {},
-		"updateNode":    {},
-		"upsertNode":    {},
-		"valuesNode":    {},
-		"viewNode":      {},
-		"lensNode":      {},
-		"operationNode": {},
+		"averageNode":    {},
+		"countNode":      {},
+		"createNode":     {},
+		"dagScanNode":    {},
+		"deleteNode":     {},
+		"groupNode":      {},
+		"limitNode":      {},
+		"maxNode":        {},
+		"minNode":        {},
+		"multiScanNode":  {},
+		"orderNode":      {},
+		"paginationNode": {},
+		"parallelNode":   {},
+		"pipeNode":       {},
+		"scanNode":       {},
+		"selectNode":     {},
+		"selectTopNode":  {},
+		"sumNode":        {},
+		"topLevelNode":   {},
+		"typeIndexJoin":  {},
+		"typeJoinMany":   {},
+		"typeJoinOne":    {},
+		"updateNode":     {},
+		"upsertNode":     {},
+		"viewNode":       {},
+		"lensNode":       {},
+		"operationNode":  {},
 	}
 )
 
@@ -0,0 +1,109 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+var paginationPattern = dataMap{
+	"explain": dataMap{
+		"operationNode": []dataMap{
+			{
+				"selectTopNode": dataMap{
+					"paginationNode": dataMap{
+						"selectNode": dataMap{
+							"scanNode": dataMap{},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestDefaultExplainRequestWithFirst(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with first.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Author(first: 2, order: {age: ASC}) {
+						name
+					}
+				}`,
+
+				ExpectedPatterns: paginationPattern,
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "paginationNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"first":  uint64(2),
+							"after":  nil,
+							"last":   nil,
+							"before": nil,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
+
+func TestDefaultExplainRequestWithCursorField(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request selecting the cursor without paginating.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Author {
+						name
+						_cursor
+					}
+				}`,
+
+				ExpectedPatterns: paginationPattern,
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "paginationNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"first":  nil,
+							"after":  nil,
+							"last":   nil,
+							"before": nil,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
@@ -36,6 +36,7 @@ func TestExecuteExplainMutationRequestWithCreate(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -46,10 +47,11 @@ func TestExecuteExplainMutationRequestWithCreate(t *testing.T) {
 											"iterations":    uint64(2),
 											"filterMatches": uint64(1),
 											"scanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(1),
-												"fieldFetches": uint64(1),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(1),
+												"estimatedRows": uint64(0),
+												"fieldFetches":  uint64(1),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
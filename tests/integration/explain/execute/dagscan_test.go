@@ -42,6 +42,7 @@ func TestExecuteExplainCommitsDagScan(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -91,6 +92,7 @@ func TestExecuteExplainLatestCommitsDagScan(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -39,6 +39,7 @@ func TestExecuteExplainMutationRequestWithDeleteUsingID(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -49,10 +50,11 @@ func TestExecuteExplainMutationRequestWithDeleteUsingID(t *testing.T) {
 											"iterations":    uint64(2),
 											"filterMatches": uint64(1),
 											"scanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(1),
-												"fieldFetches": uint64(1),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(1),
+												"estimatedRows": uint64(1),
+												"fieldFetches":  uint64(1),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -91,6 +93,7 @@ func TestExecuteExplainMutationRequestWithDeleteUsingFilter(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -101,10 +104,11 @@ func TestExecuteExplainMutationRequestWithDeleteUsingFilter(t *testing.T) {
 											"iterations":    uint64(2),
 											"filterMatches": uint64(1),
 											"scanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
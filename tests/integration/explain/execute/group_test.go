@@ -11,8 +11,11 @@
 package test_explain_execute
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/sourcenetwork/immutable"
+
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
 )
@@ -42,6 +45,7 @@ func TestExecuteExplainRequestWithGroup(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -53,14 +57,17 @@ func TestExecuteExplainRequestWithGroup(t *testing.T) {
 										"hiddenBeforeOffset":    uint64(0),
 										"hiddenAfterLimit":      uint64(0),
 										"hiddenChildSelections": uint64(0),
+										"spilledRuns":           uint64(0),
+										"spilledDocs":           uint64(0),
 										"selectNode": dataMap{
 											"iterations":    uint64(3),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(4),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(4),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -75,3 +82,120 @@ func TestExecuteExplainRequestWithGroup(t *testing.T) {
 
 	explainUtils.ExecuteTestCase(t, test)
 }
+
+func TestExecuteExplainRequestWithGroup_IfMemoryBudgetExceeded_ShouldSpill(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (execute) request with groupBy, exceeding the memory budget.",
+
+		QueryMemoryBudget: immutable.Some[int64](1),
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			// Books
+			create2AddressDocuments(),
+
+			testUtils.ExplainRequest{
+				Request: `query @explain(type: execute) {
+					ContactAddress(groupBy: [country]) {
+						country
+						_group {
+							city
+						}
+					}
+				}`,
+
+				ExpectedFullGraph: dataMap{
+					"explain": dataMap{
+						"executionSuccess": true,
+						"sizeOfResult":     1,
+						"memoryBudget":     int64(1),
+						"planExecutions":   uint64(2),
+						"operationNode": []dataMap{
+							{
+								"selectTopNode": dataMap{
+									"groupNode": dataMap{
+										"iterations":            uint64(2),
+										"groups":                uint64(1),
+										"childSelections":       uint64(1),
+										"hiddenBeforeOffset":    uint64(0),
+										"hiddenAfterLimit":      uint64(0),
+										"hiddenChildSelections": uint64(0),
+										"spilledRuns":           uint64(1),
+										"spilledDocs":           uint64(2),
+										"selectNode": dataMap{
+											"iterations":    uint64(3),
+											"filterMatches": uint64(2),
+											"scanNode": dataMap{
+												"iterations":    uint64(4),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
+
+func TestExecuteExplainRequestWithGroup_WithManyGroupsExceedingMemoryBudget_ShouldBoundSpilledRuns(t *testing.T) {
+	const groupCount = 200
+
+	actions := []any{
+		explainUtils.SchemaForExplainTests,
+	}
+	for i := 0; i < groupCount; i++ {
+		actions = append(actions, testUtils.CreateDoc{
+			CollectionID: 4,
+			Doc:          fmt.Sprintf(`{"city": "City %d", "country": "Country %d"}`, i, i),
+		})
+	}
+	actions = append(actions, testUtils.ExplainRequest{
+		Request: `query @explain(type: execute) {
+			ContactAddress(groupBy: [country]) {
+				country
+				_group {
+					city
+				}
+			}
+		}`,
+
+		ExpectedTargets: []testUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName: "groupNode",
+				ExpectedAttributes: dataMap{
+					"iterations":            uint64(groupCount + 1),
+					"groups":                uint64(groupCount),
+					"childSelections":       uint64(groupCount),
+					"hiddenBeforeOffset":    uint64(0),
+					"hiddenAfterLimit":      uint64(0),
+					"hiddenChildSelections": uint64(0),
+					// the groups are spilled in runs holding a share of the budget, rather
+					// than in a run per group
+					"spilledRuns": uint64(36),
+					"spilledDocs": uint64(380),
+				},
+			},
+		},
+	})
+
+	test := testUtils.TestCase{
+		Description: "Explain (execute) request with groupBy, with many groups exceeding the memory budget.",
+
+		QueryMemoryBudget: immutable.Some[int64](16384),
+
+		Actions: actions,
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
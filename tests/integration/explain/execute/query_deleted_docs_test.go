@@ -48,6 +48,7 @@ func TestExecuteExplainQueryDeletedDocs(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -56,10 +57,11 @@ func TestExecuteExplainQueryDeletedDocs(t *testing.T) {
 										"iterations":    uint64(3),
 										"filterMatches": uint64(2),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":    uint64(3),
+											"docFetches":    uint64(2),
+											"estimatedRows": uint64(1),
+											"fieldFetches":  uint64(4),
+											"indexFetches":  uint64(0),
 										},
 									},
 								},
@@ -57,6 +57,7 @@ func TestExecuteExplainRequestWithAllDocumentsMatching(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -65,10 +66,11 @@ func TestExecuteExplainRequestWithAllDocumentsMatching(t *testing.T) {
 										"iterations":    uint64(3),
 										"filterMatches": uint64(2),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":    uint64(3),
+											"docFetches":    uint64(2),
+											"estimatedRows": uint64(2),
+											"fieldFetches":  uint64(4),
+											"indexFetches":  uint64(0),
 										},
 									},
 								},
@@ -102,6 +104,7 @@ func TestExecuteExplainRequestWithNoDocuments(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -110,10 +113,11 @@ func TestExecuteExplainRequestWithNoDocuments(t *testing.T) {
 										"iterations":    uint64(1),
 										"filterMatches": uint64(0),
 										"scanNode": dataMap{
-											"iterations":   uint64(1),
-											"docFetches":   uint64(0),
-											"fieldFetches": uint64(0),
-											"indexFetches": uint64(0),
+											"iterations":    uint64(1),
+											"docFetches":    uint64(0),
+											"estimatedRows": uint64(0),
+											"fieldFetches":  uint64(0),
+											"indexFetches":  uint64(0),
 										},
 									},
 								},
@@ -169,6 +173,7 @@ func TestExecuteExplainRequestWithSomeDocumentsMatching(t *testing.T) {
 						"executionSuccess": true,
 						"planExecutions":   uint64(2),
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
@@ -176,10 +181,11 @@ func TestExecuteExplainRequestWithSomeDocumentsMatching(t *testing.T) {
 										"iterations":    uint64(2),
 										"filterMatches": uint64(1),
 										"scanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":    uint64(2),
+											"docFetches":    uint64(2),
+											"estimatedRows": uint64(2),
+											"fieldFetches":  uint64(4),
+											"indexFetches":  uint64(0),
 										},
 									},
 								},
@@ -235,6 +241,7 @@ func TestExecuteExplainRequestWithDocumentsButNoMatches(t *testing.T) {
 						"executionSuccess": true,
 						"planExecutions":   uint64(2),
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
@@ -242,10 +249,11 @@ func TestExecuteExplainRequestWithDocumentsButNoMatches(t *testing.T) {
 										"iterations":    uint64(1),
 										"filterMatches": uint64(0),
 										"scanNode": dataMap{
-											"iterations":   uint64(1),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":    uint64(1),
+											"docFetches":    uint64(2),
+											"estimatedRows": uint64(2),
+											"fieldFetches":  uint64(4),
+											"indexFetches":  uint64(0),
 										},
 									},
 								},
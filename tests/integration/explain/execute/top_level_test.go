@@ -58,6 +58,7 @@ func TestExecuteExplainTopLevelAverageRequest(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -68,10 +69,11 @@ func TestExecuteExplainTopLevelAverageRequest(t *testing.T) {
 												"iterations":    uint64(3),
 												"filterMatches": uint64(2),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(2),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
@@ -144,6 +146,7 @@ func TestExecuteExplainTopLevelCountRequest(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -154,10 +157,11 @@ func TestExecuteExplainTopLevelCountRequest(t *testing.T) {
 												"iterations":    uint64(3),
 												"filterMatches": uint64(2),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(4),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(4),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
@@ -221,6 +225,7 @@ func TestExecuteExplainTopLevelSumRequest(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -231,10 +236,11 @@ func TestExecuteExplainTopLevelSumRequest(t *testing.T) {
 												"iterations":    uint64(3),
 												"filterMatches": uint64(2),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(2),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
@@ -42,6 +42,7 @@ func TestExecuteExplainRequestWithAOneToOneJoin(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -52,16 +53,18 @@ func TestExecuteExplainRequestWithAOneToOneJoin(t *testing.T) {
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(3),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -106,6 +109,7 @@ func TestExecuteExplainWithMultipleOneToOneJoins(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -118,16 +122,18 @@ func TestExecuteExplainWithMultipleOneToOneJoins(t *testing.T) {
 												"typeIndexJoin": dataMap{
 													"iterations": uint64(3),
 													"scanNode": dataMap{
-														"iterations":   uint64(3),
-														"docFetches":   uint64(2),
-														"fieldFetches": uint64(2),
-														"indexFetches": uint64(0),
+														"iterations":    uint64(3),
+														"docFetches":    uint64(2),
+														"estimatedRows": uint64(2),
+														"fieldFetches":  uint64(2),
+														"indexFetches":  uint64(0),
 													},
 													"subTypeScanNode": dataMap{
-														"iterations":   uint64(2),
-														"docFetches":   uint64(2),
-														"fieldFetches": uint64(2),
-														"indexFetches": uint64(0),
+														"iterations":    uint64(2),
+														"docFetches":    uint64(2),
+														"estimatedRows": uint64(2),
+														"fieldFetches":  uint64(2),
+														"indexFetches":  uint64(0),
 													},
 												},
 											},
@@ -135,16 +141,18 @@ func TestExecuteExplainWithMultipleOneToOneJoins(t *testing.T) {
 												"typeIndexJoin": dataMap{
 													"iterations": uint64(3),
 													"scanNode": dataMap{
-														"iterations":   uint64(3),
-														"docFetches":   uint64(2),
-														"fieldFetches": uint64(2),
-														"indexFetches": uint64(0),
+														"iterations":    uint64(3),
+														"docFetches":    uint64(2),
+														"estimatedRows": uint64(2),
+														"fieldFetches":  uint64(2),
+														"indexFetches":  uint64(0),
 													},
 													"subTypeScanNode": dataMap{
-														"iterations":   uint64(2),
-														"docFetches":   uint64(2),
-														"fieldFetches": uint64(4),
-														"indexFetches": uint64(0),
+														"iterations":    uint64(2),
+														"docFetches":    uint64(2),
+														"estimatedRows": uint64(2),
+														"fieldFetches":  uint64(4),
+														"indexFetches":  uint64(0),
 													},
 												},
 											},
@@ -191,6 +199,7 @@ func TestExecuteExplainWithTwoLevelDeepNestedJoins(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -201,16 +210,18 @@ func TestExecuteExplainWithTwoLevelDeepNestedJoins(t *testing.T) {
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(3),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
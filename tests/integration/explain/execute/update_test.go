@@ -46,6 +46,7 @@ func TestExecuteExplainMutationRequestWithUpdateUsingIDs(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -57,10 +58,11 @@ func TestExecuteExplainMutationRequestWithUpdateUsingIDs(t *testing.T) {
 											"iterations":    uint64(6),
 											"filterMatches": uint64(4),
 											"scanNode": dataMap{
-												"iterations":   uint64(6),
-												"docFetches":   uint64(4),
-												"fieldFetches": uint64(8),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(6),
+												"docFetches":    uint64(4),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(8),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -106,6 +108,7 @@ func TestExecuteExplainMutationRequestWithUpdateUsingFilter(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -117,10 +120,11 @@ func TestExecuteExplainMutationRequestWithUpdateUsingFilter(t *testing.T) {
 											"iterations":    uint64(4),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(4),
-												"docFetches":   uint64(4),
-												"fieldFetches": uint64(6),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(4),
+												"docFetches":    uint64(4),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(6),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
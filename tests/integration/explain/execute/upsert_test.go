@@ -44,6 +44,7 @@ func TestExecuteExplainMutationRequest_WithUpsertAndMatchingFilter_Succeeds(t *t
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -53,10 +54,11 @@ func TestExecuteExplainMutationRequest_WithUpsertAndMatchingFilter_Succeeds(t *t
 											"iterations":    uint64(4),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(4),
-												"docFetches":   uint64(4),
-												"fieldFetches": uint64(6),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(4),
+												"docFetches":    uint64(4),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(6),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -96,6 +98,7 @@ func TestExecuteExplainMutationRequest_WithUpsertAndNoMatchingFilter_Succeeds(t
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -105,10 +108,11 @@ func TestExecuteExplainMutationRequest_WithUpsertAndNoMatchingFilter_Succeeds(t
 											"iterations":    uint64(3),
 											"filterMatches": uint64(1),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(1),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(3),
+												"docFetches":    uint64(1),
+												"estimatedRows": uint64(0),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
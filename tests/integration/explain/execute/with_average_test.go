@@ -42,6 +42,7 @@ func TestExecuteExplainAverageRequestOnArrayField(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -56,10 +57,11 @@ func TestExecuteExplainAverageRequestOnArrayField(t *testing.T) {
 													"iterations":    uint64(4),
 													"filterMatches": uint64(3),
 													"scanNode": dataMap{
-														"iterations":   uint64(4),
-														"docFetches":   uint64(3),
-														"fieldFetches": uint64(5),
-														"indexFetches": uint64(0),
+														"iterations":    uint64(4),
+														"docFetches":    uint64(3),
+														"estimatedRows": uint64(3),
+														"fieldFetches":  uint64(5),
+														"indexFetches":  uint64(0),
 													},
 												},
 											},
@@ -102,6 +104,7 @@ func TestExplainExplainAverageRequestOnJoinedField(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -118,16 +121,18 @@ func TestExplainExplainAverageRequestOnJoinedField(t *testing.T) {
 													"typeIndexJoin": dataMap{
 														"iterations": uint64(3),
 														"scanNode": dataMap{
-															"iterations":   uint64(3),
-															"docFetches":   uint64(2),
-															"fieldFetches": uint64(2),
-															"indexFetches": uint64(0),
+															"iterations":    uint64(3),
+															"docFetches":    uint64(2),
+															"estimatedRows": uint64(2),
+															"fieldFetches":  uint64(2),
+															"indexFetches":  uint64(0),
 														},
 														"subTypeScanNode": dataMap{
-															"iterations":   uint64(5),
-															"docFetches":   uint64(6),
-															"fieldFetches": uint64(12),
-															"indexFetches": uint64(0),
+															"iterations":    uint64(5),
+															"docFetches":    uint64(6),
+															"estimatedRows": uint64(3),
+															"fieldFetches":  uint64(12),
+															"indexFetches":  uint64(0),
 														},
 													},
 												},
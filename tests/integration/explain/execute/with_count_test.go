@@ -42,6 +42,7 @@ func TestExecuteExplainRequestWithCountOnOneToManyRelation(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -54,16 +55,18 @@ func TestExecuteExplainRequestWithCountOnOneToManyRelation(t *testing.T) {
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(2),
+													"indexFetches":  uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(5),
-													"docFetches":   uint64(6),
-													"fieldFetches": uint64(6),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(5),
+													"docFetches":    uint64(6),
+													"estimatedRows": uint64(3),
+													"fieldFetches":  uint64(6),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
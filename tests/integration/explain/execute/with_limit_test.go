@@ -41,6 +41,7 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParent(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -51,10 +52,11 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParent(t *testing.T) {
 											"iterations":    uint64(2),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(2),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(3),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -98,6 +100,7 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParentAndLimitOnChild(t *t
 						"executionSuccess": true,
 						"planExecutions":   uint64(2),
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
@@ -109,16 +112,18 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParentAndLimitOnChild(t *t
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(2),
 												"scanNode": dataMap{
-													"iterations":   uint64(2),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(2),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(2),
+													"indexFetches":  uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(2),
-													"docFetches":   uint64(3),
-													"fieldFetches": uint64(5),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(2),
+													"docFetches":    uint64(3),
+													"estimatedRows": uint64(3),
+													"fieldFetches":  uint64(5),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
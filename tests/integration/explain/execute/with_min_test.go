@@ -42,6 +42,7 @@ func TestExecuteExplainRequest_WithMinOfInlineArrayField_Succeeds(t *testing.T)
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -52,10 +53,11 @@ func TestExecuteExplainRequest_WithMinOfInlineArrayField_Succeeds(t *testing.T)
 											"iterations":    uint64(4),
 											"filterMatches": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(4),
-												"docFetches":   uint64(3),
-												"fieldFetches": uint64(5),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(4),
+												"docFetches":    uint64(3),
+												"estimatedRows": uint64(3),
+												"fieldFetches":  uint64(5),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -99,6 +101,7 @@ func TestExecuteExplainRequest_MinOfRelatedOneToManyField_Succeeds(t *testing.T)
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -111,16 +114,18 @@ func TestExecuteExplainRequest_MinOfRelatedOneToManyField_Succeeds(t *testing.T)
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(2),
+													"indexFetches":  uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(5),
-													"docFetches":   uint64(6),
-													"fieldFetches": uint64(9),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(5),
+													"docFetches":    uint64(6),
+													"estimatedRows": uint64(3),
+													"fieldFetches":  uint64(9),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
@@ -13,6 +13,8 @@ package test_explain_execute
 import (
 	"testing"
 
+	"github.com/sourcenetwork/immutable"
+
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
 )
@@ -41,20 +43,24 @@ func TestExecuteExplainRequestWithOrderFieldOnParent(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
 									"orderNode": dataMap{
-										"iterations": uint64(3),
+										"iterations":  uint64(3),
+										"spilledRuns": uint64(0),
+										"spilledDocs": uint64(0),
 										"selectNode": dataMap{
 											"filterMatches": uint64(2),
 											"iterations":    uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(3),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -127,20 +133,24 @@ func TestExecuteExplainRequestWithMultiOrderFieldsOnParent(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
 									"orderNode": dataMap{
-										"iterations": uint64(5),
+										"iterations":  uint64(5),
+										"spilledRuns": uint64(0),
+										"spilledDocs": uint64(0),
 										"selectNode": dataMap{
 											"filterMatches": uint64(4),
 											"iterations":    uint64(5),
 											"scanNode": dataMap{
-												"iterations":   uint64(5),
-												"docFetches":   uint64(4),
-												"fieldFetches": uint64(8),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(5),
+												"docFetches":    uint64(4),
+												"estimatedRows": uint64(4),
+												"fieldFetches":  uint64(8),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -183,6 +193,7 @@ func TestExecuteExplainRequestWithOrderFieldOnChild(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
@@ -193,16 +204,18 @@ func TestExecuteExplainRequestWithOrderFieldOnChild(t *testing.T) {
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(3),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(2),
+												"indexFetches":  uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(5),
-												"docFetches":   uint64(6),
-												"fieldFetches": uint64(9),
-												"indexFetches": uint64(0),
+												"iterations":    uint64(5),
+												"docFetches":    uint64(6),
+												"estimatedRows": uint64(3),
+												"fieldFetches":  uint64(9),
+												"indexFetches":  uint64(0),
 											},
 										},
 									},
@@ -246,28 +259,33 @@ func TestExecuteExplainRequestWithOrderFieldOnBothParentAndChild(t *testing.T) {
 					"explain": dataMap{
 						"executionSuccess": true,
 						"sizeOfResult":     1,
+						"memoryBudget":     int64(256 << 20),
 						"planExecutions":   uint64(2),
 						"operationNode": []dataMap{
 							{
 								"selectTopNode": dataMap{
 									"orderNode": dataMap{
-										"iterations": uint64(3),
+										"iterations":  uint64(3),
+										"spilledRuns": uint64(0),
+										"spilledDocs": uint64(0),
 										"selectNode": dataMap{
 											"iterations":    uint64(3),
 											"filterMatches": uint64(2),
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(4),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(3),
+													"docFetches":    uint64(2),
+													"estimatedRows": uint64(2),
+													"fieldFetches":  uint64(4),
+													"indexFetches":  uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(5),
-													"docFetches":   uint64(6),
-													"fieldFetches": uint64(9),
-													"indexFetches": uint64(0),
+													"iterations":    uint64(5),
+													"docFetches":    uint64(6),
+													"estimatedRows": uint64(3),
+													"fieldFetches":  uint64(9),
+													"indexFetches":  uint64(0),
 												},
 											},
 										},
@@ -318,3 +336,62 @@ func TestExecuteExplainRequestWhereParentFieldIsOrderedByChildField(t *testing.T
 
 	explainUtils.ExecuteTestCase(t, test)
 }
+
+func TestExecuteExplainRequestWithOrderFieldOnParent_IfMemoryBudgetExceeded_ShouldSpill(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (execute) with order field on parent, exceeding the memory budget.",
+
+		QueryMemoryBudget: immutable.Some[int64](1),
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			create2AddressDocuments(),
+			create2AuthorContactDocuments(),
+			create2AuthorDocuments(),
+
+			testUtils.ExplainRequest{
+				Request: `query @explain(type: execute) {
+					Author(order: {age: ASC}) {
+						name
+						age
+					}
+				}`,
+
+				ExpectedFullGraph: dataMap{
+					"explain": dataMap{
+						"executionSuccess": true,
+						"sizeOfResult":     1,
+						"memoryBudget":     int64(1),
+						"planExecutions":   uint64(2),
+						"operationNode": []dataMap{
+							{
+								"selectTopNode": dataMap{
+									"orderNode": dataMap{
+										"iterations":  uint64(3),
+										"spilledRuns": uint64(1),
+										"spilledDocs": uint64(2),
+										"selectNode": dataMap{
+											"filterMatches": uint64(2),
+											"iterations":    uint64(3),
+											"scanNode": dataMap{
+												"iterations":    uint64(3),
+												"docFetches":    uint64(2),
+												"estimatedRows": uint64(2),
+												"fieldFetches":  uint64(4),
+												"indexFetches":  uint64(0),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
@@ -21,10 +21,11 @@ import (
 )
 
 const (
-	iterationsProp   = "iterations"
-	docFetchesProp   = "docFetches"
-	fieldFetchesProp = "fieldFetches"
-	indexFetchesProp = "indexFetches"
+	iterationsProp    = "iterations"
+	docFetchesProp    = "docFetches"
+	fieldFetchesProp  = "fieldFetches"
+	indexFetchesProp  = "indexFetches"
+	estimatedRowsProp = "estimatedRows"
 )
 
 type dataMap = map[string]any
@@ -36,6 +37,7 @@ type ExplainResultAsserter struct {
 	docFetches     immutable.Option[int]
 	fieldFetches   immutable.Option[int]
 	indexFetches   immutable.Option[int]
+	estimatedRows  immutable.Option[int]
 	filterMatches  immutable.Option[int]
 	sizeOfResults  immutable.Option[int]
 	planExecutions immutable.Option[uint64]
@@ -73,7 +75,7 @@ func (a *ExplainResultAsserter) Assert(t testing.TB, result map[string]any) {
 	require.Len(t, operationNode, 1)
 	selectTopNode, ok := operationNode[0]["selectTopNode"].(dataMap)
 	require.True(t, ok, "Expected selectTopNode")
-	selectNode, ok := selectTopNode["selectNode"].(dataMap)
+	selectNode, ok := findSelectNode(selectTopNode)
 	require.True(t, ok, "Expected selectNode")
 
 	if a.filterMatches.HasValue() {
@@ -121,6 +123,25 @@ func (a *ExplainResultAsserter) Assert(t testing.TB, result map[string]any) {
 		assert.Equal(t, uint64(a.indexFetches.Value()), actual,
 			"Expected %d indexFetches, got %d", a.indexFetches.Value(), actual)
 	}
+	if a.estimatedRows.HasValue() {
+		actual := getScanNodesProp(estimatedRowsProp)
+		assert.Equal(t, uint64(a.estimatedRows.Value()), actual,
+			"Expected %d estimatedRows, got %d", a.estimatedRows.Value(), actual)
+	}
+}
+
+// findSelectNode returns the selectNode of the given selectTopNode, looking through any
+// nodes that may wrap it.
+func findSelectNode(node dataMap) (dataMap, bool) {
+	if selectNode, ok := node["selectNode"].(dataMap); ok {
+		return selectNode, true
+	}
+	for _, wrapperName := range []string{"limitNode", "orderNode", "paginationNode"} {
+		if wrapper, ok := node[wrapperName].(dataMap); ok {
+			return findSelectNode(wrapper)
+		}
+	}
+	return nil, false
 }
 
 func (a *ExplainResultAsserter) WithIterations(iterations int) *ExplainResultAsserter {
@@ -143,6 +164,11 @@ func (a *ExplainResultAsserter) WithIndexFetches(indexFetches int) *ExplainResul
 	return a
 }
 
+func (a *ExplainResultAsserter) WithEstimatedRows(estimatedRows int) *ExplainResultAsserter {
+	a.estimatedRows = immutable.Some[int](estimatedRows)
+	return a
+}
+
 func (a *ExplainResultAsserter) WithFilterMatches(filterMatches int) *ExplainResultAsserter {
 	a.filterMatches = immutable.Some[int](filterMatches)
 	return a
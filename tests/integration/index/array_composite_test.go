@@ -141,7 +141,7 @@ func TestArrayCompositeIndex_WithFilterOnIndexedArrayUsingAll_ShouldUseIndex(t *
 			testUtils.Request{
 				Request: makeExplainQuery(req),
 				// all "Shahzad" users have in total 5 numbers
-				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(5),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(4),
 			},
 		},
 	}
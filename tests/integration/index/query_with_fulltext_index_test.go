@@ -0,0 +1,304 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"fmt"
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithFullTextIndex_WithSearchFilter_ShouldOrderByRelevance(t *testing.T) {
+	req := `query {
+		Note(filter: {body: {_search: "fox"}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test full-text index yields documents ordered by relevance",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						body: String @index(type: FULLTEXT)
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "once",
+					"body": "The quick brown fox jumps over the lazy dog"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "thrice",
+					"body": "Fox, fox and another Fox"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "none",
+					"body": "A dog sleeps"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "twice",
+					"body": "fox meets fox"
+				}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"Note": []map[string]any{
+						{"title": "thrice"},
+						{"title": "twice"},
+						{"title": "once"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithMultipleKeywords_ShouldMatchDocsWithAllKeywords(t *testing.T) {
+	req := `query {
+		Note(filter: {body: {_search: "Lazy dog"}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test full-text index with several keywords returns only docs containing all of them",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						body: String @index(type: FULLTEXT)
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "fox",
+					"body": "The quick brown fox jumps over the lazy dog"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "cat",
+					"body": "A lazy cat"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "dog",
+					"body": "A dog sleeps"
+				}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"Note": []map[string]any{
+						{"title": "fox"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(4).WithDocFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_AfterUpdateAndDelete_ShouldUseCurrentValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test full-text index is kept in sync with updated and deleted documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						body: String @index(type: FULLTEXT)
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "first",
+					"body": "red apple"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "second",
+					"body": "red cherry"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"body": "green apple"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				DocID: 1,
+			},
+			testUtils.Request{
+				Request: `query {
+					Note(filter: {body: {_search: "red"}}) {
+						title
+					}
+				}`,
+				Results: map[string]any{
+					"Note": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Note(filter: {body: {_search: "green"}}) {
+						title
+					}
+				}`,
+				Results: map[string]any{
+					"Note": []map[string]any{
+						{"title": "first"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithEqFilter_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		Note(filter: {body: {_eq: "A dog sleeps"}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test full-text index is not used for non _search filters",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						body: String @index(type: FULLTEXT)
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "dog",
+					"body": "A dog sleeps"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "cat",
+					"body": "A cat sleeps"
+				}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"Note": []map[string]any{
+						{"title": "dog"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0).WithDocFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestFullTextIndex_OnNonStringField_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test full-text index can only be created on String fields",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						age: Int @index(type: FULLTEXT)
+					}`,
+				ExpectedError: "full-text index can only be created on a String field",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithDocsWithoutKeywords_ShouldWeighKeywordsByDocumentCount(t *testing.T) {
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Note {
+					title: String
+					body: String @index(type: FULLTEXT)
+				}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{"title": "bananas", "body": "apple banana banana banana"}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{"title": "apples", "body": "apple apple apple apple apple banana"}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{"title": "pie", "body": "apple pie"}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{"title": "juice", "body": "apple juice"}`,
+		},
+	}
+	// The notes without any of the keywords make banana less rare in relation to apple
+	// than it is amongst the notes containing either of them.
+	for i := 0; i < 16; i++ {
+		actions = append(actions, testUtils.CreateDoc{
+			Doc: fmt.Sprintf(`{"title": "cherry %d", "body": "cherry"}`, i),
+		})
+	}
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Note(filter: {body: {_search: "apple banana"}}) {
+				title
+			}
+		}`,
+		Results: map[string]any{
+			"Note": []map[string]any{
+				{"title": "apples"},
+				{"title": "bananas"},
+			},
+		},
+	})
+
+	test := testUtils.TestCase{
+		Description: "Test full-text index weighs keywords by the number of documents of the collection",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
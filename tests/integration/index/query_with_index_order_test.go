@@ -0,0 +1,302 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithOrderAndLimit_ShouldFetchOnlyLimitedDocs(t *testing.T) {
+	req := `query {
+		User(order: {age: ASC}, limit: 3) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index ordering with limit only fetches the limited documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Shahzad", "age": int64(20)},
+						{"name": "Bruno", "age": int64(23)},
+						{"name": "Fred", "age": int64(28)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithDescendingIndex_WithDescendingOrderAndLimit_ShouldFetchOnlyLimitedDocs(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 2, offset: 1) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test descending index ordering with limit and offset only fetches the needed documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index(direction: DESC)
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Keenan"},
+						{"name": "Roy"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderInOtherDirection_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index is not used for ordering in the direction opposite to the index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Chris"},
+						{"name": "Keenan"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(20).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderOnNillableField_ShouldYieldNilValuesFirst(t *testing.T) {
+	req := `query {
+		User(order: {age: ASC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index ordering yields nil values first as in-memory ordering does",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 35}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Andy"},
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithFilterAndOrderOnIndexedField_ShouldFetchOnlyLimitedDocs(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 30}}, order: {age: ASC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering and ordering on the same field with limit",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Islam"},
+						{"name": "Andy"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithInFilterAndOrderOnIndexedField_ShouldOrderResults(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_in: [44, 20, 32]}}, order: {age: ASC}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _in and ordering on the same field sorts the results",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Shahzad"},
+						{"name": "Islam"},
+						{"name": "Roy"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithOrderOnLeadingFields_ShouldYieldIndexOrder(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_lt: 30}}, order: [{name: ASC}, {age: DESC}], limit: 3) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index ordering with filter on its second field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(includes: [{field: "name"}, {field: "age", direction: DESC}]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 18}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 25}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 40}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "age": 20}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Shahzad", "age": 22}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Andy", "age": int64(20)},
+						{"name": "John", "age": int64(25)},
+						{"name": "John", "age": int64(18)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(0).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithAfterCursor_ShouldSeekUsingIndex(t *testing.T) {
+	// Roy is 44 years old
+	cursor, err := mapper.Cursor{
+		Values: []any{int64(44)},
+		DocID:  "bae-c7a7ae53-3f56-5c0b-bd6b-df2e3f7bd6ec",
+	}.Encode()
+	require.NoError(t, err)
+
+	req := `query {
+		User(first: 1, after: "` + cursor + `", order: {age: ASC}) {
+			name
+		}
+		_pageInfo(of: "User") {
+			hasNextPage
+			hasPreviousPage
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index seeking to the position of the after cursor",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{
+							"name": "Keenan",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage":     true,
+						"hasPreviousPage": true,
+					},
+				},
+			},
+			testUtils.Request{
+				Request: makeExplainQuery(req),
+				// Only Roy (the cursor document), Keenan and Chris are fetched
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueIndex_WithAfterCursor_ShouldSeekPastCursor(t *testing.T) {
+	// Roy is 44 years old
+	cursor, err := mapper.Cursor{
+		Values: []any{int64(44)},
+		DocID:  "bae-c7a7ae53-3f56-5c0b-bd6b-df2e3f7bd6ec",
+	}.Encode()
+	require.NoError(t, err)
+
+	req := `query {
+		User(first: 1, after: "` + cursor + `", order: {age: ASC}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test unique index seeking past the position of the after cursor",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index(unique: true)
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Keenan"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: makeExplainQuery(req),
+				// Only Keenan and Chris are fetched, as no other document may share the age of Roy
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithFirst_ShouldFetchOnlyFirstDocs(t *testing.T) {
+	req := `query {
+		User(first: 2, order: {age: ASC}) {
+			name
+		}
+		_pageInfo(of: "User") {
+			hasNextPage
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index ordering with first only fetches the page and the document following it",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{
+							"name": "Shahzad",
+						},
+						{
+							"name": "Bruno",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage": true,
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithFirstAndOrderNotCoveringIndex_ShouldFetchAllDocs(t *testing.T) {
+	req := `query {
+		User(first: 1, order: {name: ASC}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index is not used to page by a prefix of its fields",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(includes: [{field: "name"}, {field: "age"}]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Addo"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(10).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
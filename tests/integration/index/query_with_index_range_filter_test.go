@@ -0,0 +1,325 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithGreaterThanAndLessThanFilter_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 28, _lt: 42}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _gt and _lt filters on the same field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+						{"name": "Islam"},
+						{"name": "Andy"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithRangeFilterOnDateTime_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {birthday: {_ge: "2000-07-23T03:00:00-00:00", _lt: "2002-01-01T00:00:00-00:00"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _ge and _lt filters on a datetime field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						birthday: DateTime @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Shahzad", "birthday": "1999-01-05T03:00:00-00:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "birthday": "2000-07-23T03:00:00-00:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "birthday": "2001-08-23T03:00:00-00:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "birthday": "2002-01-01T00:00:00-00:00"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Fred"},
+						{"name": "Andy"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithDescendingIndex_WithGreaterThanFilter_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 40}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test descending index filtering with _gt filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index(direction: DESC)
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Chris"},
+						{"name": "Keenan"},
+						{"name": "Roy"},
+						{"name": "Addo"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(4).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithDescendingIndex_WithGreaterOrEqualAndLessOrEqualFilter_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_ge: 30, _le: 33}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test descending index filtering with _ge and _le filters on the same field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index(direction: DESC)
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Andy"},
+						{"name": "Islam"},
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithDescendingIndex_WithLessThanFilter_ShouldNotFetchNilValues(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_lt: 30}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test descending index filtering with _lt filter skips nil values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index(direction: DESC)
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 35}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Shahzad"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(1).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithLessThanFilter_ShouldNotFetchNilValues(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_lt: 30}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _lt filter skips nil values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 35}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Shahzad"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(1).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithEqualAndRangeFilter_ShouldSeekToRange(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, age: {_gt: 20, _le: 40}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq filter on the first field and range on the second",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(includes: [{field: "name"}, {field: "age", direction: DESC}]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 18}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 25}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 40}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 52}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 30}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John", "age": int64(40)},
+						{"name": "John", "age": int64(25)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithFieldFetches(0).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
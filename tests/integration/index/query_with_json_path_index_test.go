@@ -0,0 +1,362 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithJSONPathIndex_WithEqualFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["address", "city"], _eq: "Munich"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index filtering with _eq filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["address", "city"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"address": {"city": "Munich"}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"address": {"city": "Toronto"}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "custom": {"address": "Munich"}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Islam", "custom": "Munich"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Keenan"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithNestedObjectFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {address: {city: {_eq: "Munich"}}}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index filtering with a nested object filter on the path",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["address", "city"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"address": {"city": "Munich"}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"address": {"city": "Toronto"}}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithFilterOnOtherPath_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["address", "country"], _eq: "Germany"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index is not used if the filter targets another path",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["address", "city"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"address": {"city": "Munich", "country": "Germany"}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"address": {"city": "Toronto", "country": "Canada"}}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0).WithDocFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithRangeFilter_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["stats", "score"], _gt: 21, _le: 45}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index filtering with _gt and _le filters",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["stats", "score"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"stats": {"score": 21}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"stats": {"score": 32.5}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "custom": {"stats": {"score": 45}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Islam", "custom": {"stats": {"score": 60}}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Keenan", "custom": {"stats": {"score": "high"}}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Andy"},
+						{"name": "Fred"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithArrayAtPath_ShouldIndexEachElement(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["tags"], _any: {_eq: "go"}}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index stores an entry for every element of an array at the path",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["tags"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"tags": ["go", "rust", "go"]}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"tags": ["js"]}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "custom": {"tags": ["c", "go"]}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Fred"},
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithInFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["level"], _in: [1, 3]}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index filtering with _in filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["level"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"level": 1}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"level": 2}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "custom": {"level": 3}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+						{"name": "Fred"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_UpdateAndDeleteDoc_ShouldUpdateIndex(t *testing.T) {
+	req := `query {
+		User(filter: {custom: {_path: ["status"], _eq: "active"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test JSON path index is updated when documents are updated and deleted",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						custom: JSON @index(path: ["status"])
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "custom": {"status": "active"}}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Andy", "custom": {"status": "archived"}}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc:   `{"custom": {"status": "archived"}}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 1,
+				Doc:   `{"custom": {"status": "active"}}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Andy"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+			testUtils.DeleteDoc{
+				DocID: 1,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestJSONPathIndex_OnNonJSONField_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating a JSON path index on a non JSON field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(path: ["first"])
+					}`,
+				ExpectedError: "index JSON path can only be used on a JSON field",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
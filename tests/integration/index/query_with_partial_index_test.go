@@ -0,0 +1,366 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithPartialIndex_IfFilterImpliesIndexFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, status: {_eq: "active"}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test partial index is used if the query filter implies the index filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(filter: {status: {_eq: "active"}})
+						age: Int
+						status: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21, "status": "active"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 35, "status": "archived"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 28, "status": "active"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John", "age": int64(21)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_IfFilterDoesNotImplyIndexFilter_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test partial index is not used if the query filter does not imply the index filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(filter: {status: {_eq: "active"}})
+						age: Int
+						status: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21, "status": "active"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 35, "status": "archived"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred", "age": 28, "status": "active"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John", "age": int64(35)},
+						{"name": "John", "age": int64(21)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0).WithDocFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_WithRangeFilter_ShouldUseIndexIfRangeIsImplied(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, age: {_ge: 30}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test partial index with range filter is used if the query range is within the index range",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(includes: [{field: "name"}], filter: {age: {_ge: 18}}) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 12}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 18}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 35}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John", "age": int64(35)},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+			testUtils.Request{
+				Request: `query {
+					User(filter: {name: {_eq: "John"}, age: {_ge: 18}}) {
+						age
+					}
+				}`,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"age": int64(35)},
+						{"age": int64(18)},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_UpdateDocInAndOutOfIndex_ShouldUpdateIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, status: {_eq: "active"}}) {
+			name
+			status
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test updating a document in and out of a partial index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(filter: {status: {_eq: "active"}})
+						status: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "status": "active"}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{"status": "archived"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0),
+			},
+			testUtils.UpdateDoc{
+				Doc: `{"status": "active"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John", "status": "active"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_DeleteIndexedDoc_ShouldRemoveFromIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, status: {_eq: "active"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test deleting a document covered by a partial index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(filter: {status: {_eq: "active"}})
+						status: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "status": "active"}`,
+			},
+			testUtils.DeleteDoc{
+				DocID: 0,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_CreatedOnExistingDocs_ShouldIndexOnlyMatchingDocs(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, status: {_eq: "active"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test partial index created on existing documents indexes only the matching ones",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						status: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "status": "active"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "status": "archived"}`,
+			},
+			testUtils.CreateIndex{
+				FieldName: "name",
+				Filter: map[string]any{
+					"status": map[string]any{"_eq": "active"},
+				},
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "John"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_WithUniqueIndex_ShouldOnlyBeUniqueWithinFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test unique partial index only enforces uniqueness among the matching documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						email: String @index(unique: true, filter: {deleted: {_eq: false}})
+						deleted: Boolean
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "email": "john@example.com", "deleted": true}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Johnny", "email": "john@example.com", "deleted": false}`,
+			},
+			testUtils.CreateDoc{
+				Doc:           `{"name": "Jo", "email": "john@example.com", "deleted": false}`,
+				ExpectedError: "can not index a doc's field(s) that violates unique index",
+			},
+			testUtils.Request{
+				Request: `query {
+					User(filter: {email: {_eq: "john@example.com"}, deleted: {_eq: false}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Johnny"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestPartialIndex_WithFilterOnUnknownField_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test creating a partial index with a filter on an unknown field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+					}`,
+			},
+			testUtils.CreateIndex{
+				FieldName: "name",
+				Filter: map[string]any{
+					"status": map[string]any{"_eq": "active"},
+				},
+				ExpectedError: "index filter can only refer to scalar fields of the collection",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
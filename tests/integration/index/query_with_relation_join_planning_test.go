@@ -0,0 +1,392 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndexOnRelation_WithRelationFilterWithinAnd_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {_and: [
+			{age: {_gt: 30}},
+			{devices: {model: {_eq: "MacBook Pro"}}}
+		]}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter on indexed relation field within _and",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int
+						devices: [Device]
+					}
+
+					type Device {
+						model: String @index
+						owner: User
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Islam"},
+						{"name": "Keenan"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelation_WithOrOnSameRelationField_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {_or: [
+			{devices: {model: {_eq: "iPhone 10"}}},
+			{devices: {model: {_eq: "Walkman"}}}
+		]}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter on indexed relation field within _or on the same relation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int
+						devices: [Device]
+					}
+
+					type Device {
+						model: String @index
+						owner: User
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Addo"},
+						{"name": "Chris"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelation_WithOrOnRelationAndParentField_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {_or: [
+			{devices: {model: {_eq: "iPhone 10"}}},
+			{age: {_eq: 20}}
+		]}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter on indexed relation field within _or with a parent field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int
+						devices: [Device]
+					}
+
+					type Device {
+						model: String @index
+						owner: User
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Addo"},
+						{"name": "Shahzad"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelation_IfParentIndexIsMoreSelective_ShouldUseParentIndex(t *testing.T) {
+	req := `query {
+		User(filter: {
+			name: {_eq: "Islam"},
+			devices: {model: {_eq: "MacBook Pro"}}
+		}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter on both the indexed parent and relation fields drives the join from the parent",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(unique: true)
+						age: Int
+						devices: [Device]
+					}
+
+					type Device {
+						model: String @index
+						owner: User
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"User": []map[string]any{
+						{"name": "Islam"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelation_WithFilterThroughJoinCollection_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		Book(filter: {
+			authors: {author: {name: {_eq: "Agatha"}}}
+		}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter on indexed field of an object related through a join collection",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						title: String
+						authors: [BookAuthor]
+					}
+
+					type Author {
+						name: String @index
+						books: [BookAuthor]
+					}
+
+					type BookAuthor {
+						book: Book
+						author: Author @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"title": "Murder on the Orient Express"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"title": "Good Omens"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"title": "The Colour of Magic"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc:          `{"name": "Agatha"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc:          `{"name": "Terry"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc:          `{"name": "Neil"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 0),
+					"author": testUtils.NewDocIndex(1, 0),
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 1),
+					"author": testUtils.NewDocIndex(1, 1),
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 1),
+					"author": testUtils.NewDocIndex(1, 2),
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 2),
+					"author": testUtils.NewDocIndex(1, 1),
+				},
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"Book": []map[string]any{
+						{"title": "Murder on the Orient Express"},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelation_WithFilterThroughJoinCollection_ShouldReturnAllRelatedObjects(t *testing.T) {
+	req := `query {
+		Book(filter: {
+			authors: {author: {name: {_eq: "Neil"}}}
+		}) {
+			title
+			authors {
+				author {
+					name
+				}
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Filter through a join collection yields all the related objects of the matching documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						title: String
+						authors: [BookAuthor]
+					}
+
+					type Author {
+						name: String @index
+						books: [BookAuthor]
+					}
+
+					type BookAuthor {
+						book: Book
+						author: Author
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"title": "Good Omens"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"title": "The Colour of Magic"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc:          `{"name": "Terry"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc:          `{"name": "Neil"}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 0),
+					"author": testUtils.NewDocIndex(1, 0),
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 0),
+					"author": testUtils.NewDocIndex(1, 1),
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				DocMap: map[string]any{
+					"book":   testUtils.NewDocIndex(0, 1),
+					"author": testUtils.NewDocIndex(1, 0),
+				},
+			},
+			testUtils.Request{
+				Request: req,
+				Results: map[string]any{
+					"Book": []map[string]any{
+						{
+							"title": "Good Omens",
+							"authors": []map[string]any{
+								{"author": map[string]any{"name": "Terry"}},
+								{"author": map[string]any{"name": "Neil"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestCollectionStatistics_WithDocuments_ShouldCountDocumentsAndDistinctKeys(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index
+						age: Int @index(unique: true)
+						email: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 21, "email": "john@example.com"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John", "age": 30}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Islam", "age": 40}`,
+			},
+			testUtils.GetCollectionStatistics{
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "User",
+					DocumentCount:  3,
+					Indexes: []client.IndexStatistics{
+						{IndexName: "User_name_ASC", DistinctKeys: 2},
+						{IndexName: "User_age_ASC", DistinctKeys: 3},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestCollectionStatistics_AfterDocumentsAreCreatedAndDeleted_ShouldUpdateStatistics(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John"}`,
+			},
+			testUtils.GetCollectionStatistics{
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "User",
+					DocumentCount:  1,
+					Indexes: []client.IndexStatistics{
+						{IndexName: "User_name_ASC", DistinctKeys: 1},
+					},
+				},
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Islam"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Fred"}`,
+			},
+			testUtils.DeleteDoc{
+				DocID: 0,
+			},
+			testUtils.UpdateDoc{
+				DocID: 1,
+				Doc:   `{"name": "Keenan"}`,
+			},
+			testUtils.GetCollectionStatistics{
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "User",
+					DocumentCount:  2,
+					Indexes: []client.IndexStatistics{
+						// the sketch is rebuilt once half of its values are no longer indexed
+						{IndexName: "User_name_ASC", DistinctKeys: 2},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestCollectionStatistics_WithoutIndexes_ShouldOnlyCountDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "John"}`,
+			},
+			testUtils.GetCollectionStatistics{
+				ExpectedStatistics: client.CollectionStatistics{
+					CollectionName: "User",
+					DocumentCount:  1,
+					Indexes:        []client.IndexStatistics{},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+// makeStatusDocs returns actions creating the given number of users, all with the same status.
+func makeStatusDocs(count int) []any {
+	actions := make([]any, count)
+	for i := range actions {
+		actions[i] = testUtils.CreateDoc{
+			Doc: fmt.Sprintf(`{"name": "User %d", "status": "active"}`, i),
+		}
+	}
+	return actions
+}
+
+func TestQueryWithIndex_IfIndexMatchesMostDocuments_ShouldScanCollection(t *testing.T) {
+	req := `query {
+		User(filter: {status: {_eq: "active"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String
+					status: String @index
+				}`,
+		},
+	}
+	actions = append(actions, makeStatusDocs(120)...)
+	actions = append(actions,
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(120).WithIndexFetches(0).WithEstimatedRows(120),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Filter matching every document of a large collection does not use the index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithTwoIndexedFields_ShouldUseMostSelectiveIndex(t *testing.T) {
+	req := `query {
+		User(filter: {status: {_eq: "active"}, name: {_eq: "User 7"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					status: String @index
+					name: String @index
+				}`,
+		},
+	}
+	actions = append(actions, makeStatusDocs(120)...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: map[string]any{
+				"User": []map[string]any{
+					{"name": "User 7"},
+				},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(1),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Filter on two indexed fields uses the index with the most distinct values",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestGSetUpdate_WithNewElements_ShouldAddElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a G Set with new elements",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: gset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"tags": ["b", "a"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(add: {tags: ["c"]}) {
+						name
+						tags
+					}
+				}`,
+				Results: map[string]any{
+					"update_Users": []map[string]any{
+						{
+							"name": "John",
+							"tags": []string{"a", "b", "c"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestGSetUpdate_WithRemovedElement_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a G Set removing an element",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: gset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"tags": ["a"]
+				}`,
+				ExpectedError: "elements cannot be removed from a grow-only set",
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(remove: {tags: ["b"]}) {
+						name
+					}
+				}`,
+				ExpectedError: "elements cannot be removed from a grow-only set",
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+							"tags": []string{"a", "b"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,215 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestORSetUpdate_WithNewArray_ShouldAddAndRemoveElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR Set with a new array",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"tags": ["b", "c", "c"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+							"tags": []string{"b", "c"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddAndRemoveInput_ShouldAddAndRemoveElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR Set using the add and remove input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(add: {tags: ["c", "a"]}, remove: {tags: ["b", "d"]}) {
+						name
+						tags
+					}
+				}`,
+				Results: map[string]any{
+					"update_Users": []map[string]any{
+						{
+							"name": "John",
+							"tags": []string{"a", "c"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddInputAndFieldInput_ShouldUpdateBoth(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR Set using the add input along with other fields",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						points: [Int!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(input: {name: "Johnny"}, add: {points: [3, 1]}) {
+						name
+						points
+					}
+				}`,
+				Results: map[string]any{
+					"update_Users": []map[string]any{
+						{
+							"name":   "Johnny",
+							"points": []int64{1, 3},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddInputOnNonSetField_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a non set field using the add input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+						aliases: [String!]
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(add: {aliases: ["Johnny"]}) {
+						name
+					}
+				}`,
+				ExpectedError: `Argument "add" has invalid value {aliases: ["Johnny"]}`,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithNullValue_ShouldRemoveAllElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR Set with a null value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"tags": null
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+							"tags": []string{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
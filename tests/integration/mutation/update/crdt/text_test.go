@@ -0,0 +1,188 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package update
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestTextUpdate_WithNewValue_ShouldReplaceText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a Text with a new value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: text)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Groceries",
+					"body": "Buy milk"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"body": "Buy oat milk and eggs"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Notes {
+						title
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"Notes": []map[string]any{
+						{
+							"title": "Groceries",
+							"body":  "Buy oat milk and eggs",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestTextUpdate_WithPatchInput_ShouldApplyPatchesInOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a Text using the patch input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: text)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Groceries",
+					"body": "Buy milk"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(patch: {body: [
+						{index: 4, insert: "oat "},
+						{index: 12, insert: " and eggs"},
+						{index: 0, delete: 3, insert: "Get"}
+					]}) {
+						title
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"update_Notes": []map[string]any{
+						{
+							"title": "Groceries",
+							"body":  "Get oat milk and eggs",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestTextUpdate_WithPatchInputOnEmptyField_ShouldInsertText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an empty Text using the patch input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: text)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Groceries"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(patch: {body: [{index: 0, insert: "Buy milk 🥛"}]}) {
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"update_Notes": []map[string]any{
+						{
+							"body": "Buy milk 🥛",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(patch: {body: [{index: 8, delete: 2}]}) {
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"update_Notes": []map[string]any{
+						{
+							"body": "Buy milk",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestTextUpdate_WithPatchInputOutOfRange_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of a Text using an out of range patch",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						body: String @crdt(type: text)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"body": "Buy milk"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(patch: {body: [{index: 6, delete: 3}]}) {
+						body
+					}
+				}`,
+				ExpectedError: "text patch is out of range",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package access_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2PPeerAccessGetAll_WithAllowedAndDeniedPeers(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SetPeerAccess{
+				NodeID:      0,
+				PeerNodeIDs: []int{1, 2},
+				Access:      client.PeerAccessAllow,
+			},
+			testUtils.SetPeerAccess{
+				NodeID:      0,
+				PeerNodeIDs: []int{2},
+				Access:      client.PeerAccessDeny,
+			},
+			testUtils.GetAllPeerAccess{
+				NodeID: 0,
+				ExpectedAccess: map[int]client.PeerAccessKind{
+					1: client.PeerAccessAllow,
+					2: client.PeerAccessDeny,
+				},
+			},
+			testUtils.DeletePeerAccess{
+				NodeID:      0,
+				PeerNodeIDs: []int{1},
+			},
+			testUtils.GetAllPeerAccess{
+				NodeID: 0,
+				ExpectedAccess: map[int]client.PeerAccessKind{
+					2: client.PeerAccessDeny,
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PPeerAccessSet_WithInvalidAccess_Error(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SetPeerAccess{
+				NodeID:        0,
+				PeerNodeIDs:   []int{1},
+				Access:        "invalid",
+				ExpectedError: "invalid peer access",
+			},
+			testUtils.GetAllPeerAccess{
+				NodeID:         0,
+				ExpectedAccess: map[int]client.PeerAccessKind{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
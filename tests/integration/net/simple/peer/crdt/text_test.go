@@ -0,0 +1,100 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2PUpdate_WithTextSimultaneousEdits_ShouldKeepAllEdits(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: text)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create the note on all nodes
+				Doc: `{
+					"title": "Groceries",
+					"body": "Buy milk"
+				}`,
+			},
+			testUtils.Request{
+				// Edit the start of the note on the first node whilst the nodes are not connected
+				NodeID: immutable.Some(0),
+				Request: `mutation {
+					update_Notes(patch: {body: [{index: 0, delete: 1, insert: "Please b"}]}) {
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"update_Notes": []map[string]any{
+						{
+							"body": "Please buy milk",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				// Edit the end of the note on the second node whilst the nodes are not connected
+				NodeID: immutable.Some(1),
+				Request: `mutation {
+					update_Notes(patch: {body: [{index: 8, insert: " and eggs"}]}) {
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"update_Notes": []map[string]any{
+						{
+							"body": "Buy milk and eggs",
+						},
+					},
+				},
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 1,
+				TargetNodeID: 0,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Notes {
+						body
+					}
+				}`,
+				Results: map[string]any{
+					"Notes": []map[string]any{
+						{
+							"body": "Please buy milk and eggs",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -94,6 +94,60 @@ func TestP2PSubscribeAddSingle(t *testing.T) {
 	testUtils.ExecuteTestCase(t, test)
 }
 
+// TestP2PSubscribeAddSingle_WithDocCreatedBeforeSubscribing ensures that documents created
+// before the node subscribed to the P2P collection are caught up on.
+func TestP2PSubscribeAddSingle_WithDocCreatedBeforeSubscribing(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.SubscribeToCollection{
+				NodeID:        0,
+				CollectionIDs: []int{0},
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 1,
+				TargetNodeID: 0,
+			},
+			testUtils.SubscribeToCollection{
+				NodeID:        1,
+				CollectionIDs: []int{0},
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
 func TestP2PSubscribeAddMultiple(t *testing.T) {
 	test := testUtils.TestCase{
 		Actions: []any{
@@ -346,19 +346,22 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 				NodeID: immutable.Some(0),
 				DocID:  0,
 			},
-			testUtils.ConnectPeers{
-				SourceNodeID: 0,
-				TargetNodeID: 1,
-			},
 			testUtils.UpdateDoc{
-				// Update John's Age on the second node only
+				// Update John's Age on the second node only, concurrently with the first node's
+				// updates and delete
 				NodeID: immutable.Some(1),
 				DocID:  0,
 				Doc: `{
 					"Age": 66
 				}`,
 			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
 			testUtils.WaitForSync{},
+			// Both nodes catch up on each other's changes on connect, so the second node's concurrent update
+			// is merged with the first node's updates and delete to the same state on both nodes.
 			testUtils.Request{
 				NodeID: immutable.Some(0),
 				Request: `query {
@@ -383,8 +386,6 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 					},
 				},
 			},
-			// The target node currently won't receive the pre-connection updates from the source.
-			// We should look into adding a head exchange mechanic on connect.
 			testUtils.Request{
 				NodeID: immutable.Some(1),
 				Request: `query {
@@ -397,9 +398,9 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 				Results: map[string]any{
 					"Users": []map[string]any{
 						{
-							"_deleted": false,
+							"_deleted": true,
 							"Name":     "John",
-							"Age":      int64(66),
+							"Age":      int64(62),
 						},
 						{
 							"_deleted": false,
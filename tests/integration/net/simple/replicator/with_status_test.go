@@ -0,0 +1,118 @@
+// Copyright 2022 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replicator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2POneToOneReplicator_WithSuccessfulPush_ReportsStatus(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.GetReplicatorStatus{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.GetReplicatorStatus{
+				SourceNodeID:           0,
+				TargetNodeID:           1,
+				ExpectedSuccessfulPush: true,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2POneToOneReplicator_WithClosedTarget_ReportsPendingDocsUntilRetrySucceeds(t *testing.T) {
+	test := testUtils.TestCase{
+		SupportedDatabaseTypes: immutable.Some(
+			[]testUtils.DatabaseType{
+				// This test only supports file type databases since it requires the ability to
+				// stop and start a node without losing data.
+				testUtils.BadgerFileType,
+			},
+		),
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.Close{
+				NodeID: immutable.Some(1),
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John"
+				}`,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "Fred"
+				}`,
+			},
+			testUtils.GetReplicatorStatus{
+				SourceNodeID:        0,
+				TargetNodeID:        1,
+				ExpectedPendingDocs: 2,
+				ExpectedRetried:     true,
+				ExpectedLastError:   "failed to retry doc",
+			},
+			testUtils.Start{
+				NodeID: immutable.Some(1),
+			},
+			testUtils.WaitForSync{},
+			// The pending documents are pushed once the target node is back online.
+			testUtils.GetReplicatorStatus{
+				SourceNodeID:           0,
+				TargetNodeID:           1,
+				ExpectedSuccessfulPush: true,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
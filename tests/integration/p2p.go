@@ -14,9 +14,13 @@ import (
 	"time"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
 	"github.com/sourcenetwork/defradb/net"
 
 	"github.com/sourcenetwork/corelog"
+	"github.com/sourcenetwork/immutable"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -54,6 +58,11 @@ type ConfigureReplicator struct {
 	// TargetNodeID is the node ID (index) of the node to which data should be replicated.
 	TargetNodeID int
 
+	// Filters contains the filters of the documents to replicate, keyed by collection name.
+	//
+	// Optional. If not provided, all documents will be replicated.
+	Filters map[string]map[string]any
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -70,6 +79,34 @@ type DeleteReplicator struct {
 	TargetNodeID int
 }
 
+// GetReplicatorStatus gets the replicator of the source node that replicates to the target node
+// and compares its status against the expected status.
+//
+// The status is updated asynchronously, so it is fetched again until it matches the expected status
+// or the [eventTimeout] is reached.
+type GetReplicatorStatus struct {
+	// SourceNodeID is the node ID (index) of the node from which data is replicated.
+	SourceNodeID int
+
+	// TargetNodeID is the node ID (index) of the node to which data is replicated.
+	TargetNodeID int
+
+	// ExpectedPendingDocs is the number of documents expected to be waiting to be retried.
+	ExpectedPendingDocs uint64
+
+	// ExpectedRetried is true if the pending documents are expected to have been retried.
+	ExpectedRetried bool
+
+	// ExpectedSuccessfulPush is true if a push to the target node is expected to have succeeded.
+	ExpectedSuccessfulPush bool
+
+	// ExpectedLastError is the error of the last failed push expected. Optional.
+	//
+	// String can be a partial, and the test will pass if the error contains this string.
+	// If empty no error is expected.
+	ExpectedLastError string
+}
+
 const (
 	// NonExistentCollectionID can be used to represent a non-existent collection ID, it will be substituted
 	// for a non-existent collection ID when used in actions that support this.
@@ -128,6 +165,71 @@ type GetAllP2PCollections struct {
 	ExpectedCollectionIDs []int
 }
 
+// ReconcileWithPeer reconciles the given collections of the given node with another node.
+//
+// Documents of the collections that are missing from the node are pulled from the other node,
+// as long as the other node is subscribed to the collections.
+type ReconcileWithPeer struct {
+	// NodeID is the node ID (index) of the node in which to reconcile the collections.
+	NodeID int
+
+	// SourceNodeID is the node ID (index) of the node to reconcile the collections with.
+	SourceNodeID int
+
+	// CollectionIDs are the collection IDs (indexes) of the collections to reconcile.
+	//
+	// All the collections the node is subscribed to are reconciled if empty.
+	CollectionIDs []int
+
+	// The number of documents expected to be transferred from the source node. Optional.
+	ExpectedDocs immutable.Option[int]
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
+// SetPeerAccess sets the access of the given nodes to push blocks to the given node.
+type SetPeerAccess struct {
+	// NodeID is the node ID (index) of the node in which to set the access.
+	NodeID int
+
+	// PeerNodeIDs are the node IDs (indexes) of the nodes whose access is set.
+	PeerNodeIDs []int
+
+	// Access is the access given to the nodes.
+	Access client.PeerAccessKind
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
+// DeletePeerAccess deletes the access of the given nodes to push blocks to the given node.
+type DeletePeerAccess struct {
+	// NodeID is the node ID (index) of the node in which to delete the access.
+	NodeID int
+
+	// PeerNodeIDs are the node IDs (indexes) of the nodes whose access is deleted.
+	PeerNodeIDs []int
+}
+
+// GetAllPeerAccess gets the access of peers of the given node and compares it against the
+// expected results.
+//
+// Peers that have no access but had their pushed blocks rejected are ignored.
+type GetAllPeerAccess struct {
+	// NodeID is the node ID (index) of the node in which to get the access of peers.
+	NodeID int
+
+	// ExpectedAccess is the access expected for each node ID (index).
+	ExpectedAccess map[int]client.PeerAccessKind
+}
+
 // WaitForSync is an action that instructs the test framework to wait for all document synchronization
 // to complete before progressing.
 //
@@ -153,6 +255,11 @@ func connectPeers(
 		corelog.Any("Source", sourceNode.PeerInfo()),
 		corelog.Any("Target", targetNode.PeerInfo()))
 
+	// Expectations must be set before connecting, as the nodes start catching up
+	// with each other as soon as they are connected.
+	expectCatchUpHeadsOnConnect(s, cfg.SourceNodeID, cfg.TargetNodeID)
+	expectCatchUpHeadsOnConnect(s, cfg.TargetNodeID, cfg.SourceNodeID)
+
 	err := sourceNode.Connect(s.ctx, targetNode.PeerInfo())
 	require.NoError(s.t, err)
 
@@ -178,7 +285,8 @@ func configureReplicator(
 	targetNode := s.nodes[cfg.TargetNodeID]
 
 	err := sourceNode.SetReplicator(s.ctx, client.ReplicatorParams{
-		Info: targetNode.PeerInfo(),
+		Info:    targetNode.PeerInfo(),
+		Filters: cfg.Filters,
 	})
 
 	expectedErrorRaised := AssertError(s.t, s.testCase.Description, err, cfg.ExpectedError)
@@ -189,6 +297,41 @@ func configureReplicator(
 	}
 }
 
+func getReplicatorStatus(
+	s *state,
+	action GetReplicatorStatus,
+) {
+	sourceNode := s.nodes[action.SourceNodeID]
+	// the peer info is read from the state as the target node may be closed.
+	targetID := s.nodes[action.TargetNodeID].peerInfo.ID
+
+	assert.EventuallyWithT(s.t, func(c *assert.CollectT) {
+		reps, err := sourceNode.GetAllReplicators(s.ctx)
+		require.NoError(c, err)
+
+		var rep *client.Replicator
+		for i := range reps {
+			if reps[i].Info.ID == targetID {
+				rep = &reps[i]
+			}
+		}
+		require.NotNil(c, rep, "replicator not found")
+
+		assert.Equal(c, action.ExpectedPendingDocs, rep.PendingDocs)
+		if action.ExpectedPendingDocs > 0 {
+			assert.Equal(c, client.ReplicatorStatusInactive, rep.Status)
+			assert.False(c, rep.NextRetry.IsZero())
+		}
+		assert.Equal(c, action.ExpectedRetried, rep.RetryAttempt > 0)
+		assert.Equal(c, action.ExpectedSuccessfulPush, !rep.LastSuccessfulPush.IsZero())
+		if action.ExpectedLastError == "" {
+			assert.Empty(c, rep.LastError)
+		} else {
+			assert.Contains(c, rep.LastError, action.ExpectedLastError)
+		}
+	}, 10*eventTimeout, 10*time.Millisecond)
+}
+
 func deleteReplicator(
 	s *state,
 	cfg DeleteReplicator,
@@ -223,6 +366,21 @@ func subscribeToCollection(
 		schemaRoots = append(schemaRoots, col.SchemaRoot())
 	}
 
+	// Expectations must be set before subscribing, as the node starts catching up
+	// on the collections with the connected nodes as soon as it is subscribed.
+	if action.ExpectedError == "" {
+		for _, collectionIndex := range action.CollectionIDs {
+			if collectionIndex == NonExistentCollectionID {
+				continue
+			}
+			for sourceID := range n.p2p.connections {
+				if _, ok := s.nodes[sourceID].p2p.peerCollections[collectionIndex]; ok {
+					expectCatchUpHeads(s, action.NodeID, sourceID, getCollectionDAGKeys(s, action.NodeID, collectionIndex))
+				}
+			}
+		}
+	}
+
 	err := n.AddP2PCollections(s.ctx, schemaRoots)
 	if err == nil {
 		waitForSubscribeToCollectionEvent(s, action)
@@ -293,6 +451,189 @@ func getAllP2PCollections(
 }
 
 // reconnectPeers makes sure that all peers are connected after a node restart action.
+func reconcileWithPeer(
+	s *state,
+	action ReconcileWithPeer,
+) {
+	n := s.nodes[action.NodeID]
+	source := s.nodes[action.SourceNodeID]
+
+	collectionIndexes := action.CollectionIDs
+	if len(collectionIndexes) == 0 {
+		for collectionIndex := range n.p2p.peerCollections {
+			collectionIndexes = append(collectionIndexes, collectionIndex)
+		}
+	}
+
+	schemaRoots := []string{}
+	for _, collectionIndex := range action.CollectionIDs {
+		schemaRoots = append(schemaRoots, n.collections[collectionIndex].SchemaRoot())
+	}
+
+	// Expectations must be set before reconciling, as the pulled documents are merged
+	// asynchronously.
+	if action.ExpectedError == "" {
+		for _, collectionIndex := range collectionIndexes {
+			if _, ok := source.p2p.peerCollections[collectionIndex]; ok {
+				expectCatchUpHeads(s, action.NodeID, action.SourceNodeID, getCollectionDAGKeys(s, action.NodeID, collectionIndex))
+			}
+		}
+	}
+
+	res, err := n.ReconcileWithPeer(s.ctx, client.ReconcileParams{
+		Info:          source.PeerInfo(),
+		CollectionIDs: schemaRoots,
+	})
+
+	expectedErrorRaised := AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
+	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
+
+	if err == nil && action.ExpectedDocs.HasValue() {
+		assert.Equal(s.t, action.ExpectedDocs.Value(), res.Docs)
+	}
+}
+
+// setPeerAccess sets the access of the given nodes to push blocks to the given node.
+//
+// Any errors generated during this process will result in a test failure.
+func setPeerAccess(
+	s *state,
+	action SetPeerAccess,
+) {
+	n := s.nodes[action.NodeID]
+
+	peerIDs := []string{}
+	for _, nodeID := range action.PeerNodeIDs {
+		peerIDs = append(peerIDs, s.nodes[nodeID].peerInfo.ID.String())
+	}
+
+	err := n.SetPeerAccess(s.ctx, client.PeerAccessParams{
+		PeerIDs: peerIDs,
+		Access:  action.Access,
+	})
+
+	expectedErrorRaised := AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
+	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
+
+	if err == nil {
+		for _, nodeID := range action.PeerNodeIDs {
+			n.p2p.peerAccess[nodeID] = action.Access
+		}
+	}
+
+	// The access of peers is updated asynchronously by the network, we need to make sure
+	// this has finished before progressing.
+	time.Sleep(100 * time.Millisecond)
+}
+
+// deletePeerAccess deletes the access of the given nodes to push blocks to the given node.
+//
+// Any errors generated during this process will result in a test failure.
+func deletePeerAccess(
+	s *state,
+	action DeletePeerAccess,
+) {
+	n := s.nodes[action.NodeID]
+
+	peerIDs := []string{}
+	for _, nodeID := range action.PeerNodeIDs {
+		peerIDs = append(peerIDs, s.nodes[nodeID].peerInfo.ID.String())
+		delete(n.p2p.peerAccess, nodeID)
+	}
+
+	err := n.DeletePeerAccess(s.ctx, peerIDs)
+	require.NoError(s.t, err)
+
+	// The access of peers is updated asynchronously by the network, we need to make sure
+	// this has finished before progressing.
+	time.Sleep(100 * time.Millisecond)
+}
+
+// getAllPeerAccess gets the access of peers of the given node and compares it against the
+// given expected results.
+//
+// Any errors generated during this process will result in a test failure.
+func getAllPeerAccess(
+	s *state,
+	action GetAllPeerAccess,
+) {
+	expectedAccess := make(map[string]client.PeerAccessKind)
+	for nodeID, access := range action.ExpectedAccess {
+		expectedAccess[s.nodes[nodeID].peerInfo.ID.String()] = access
+	}
+
+	n := s.nodes[action.NodeID]
+	peerAccess, err := n.GetAllPeerAccess(s.ctx)
+	require.NoError(s.t, err)
+
+	actualAccess := make(map[string]client.PeerAccessKind)
+	for _, access := range peerAccess {
+		if access.Access != "" {
+			actualAccess[access.PeerID] = access.Access
+		}
+	}
+
+	assert.Equal(s.t, expectedAccess, actualAccess)
+}
+
+// acceptsPushesFrom returns true if the given node accepts the blocks pushed by the given
+// source node, or pulled from it.
+func acceptsPushesFrom(s *state, nodeID int, sourceID int) bool {
+	if s.testCase.RequireBlockSignatures && !s.testCase.EnableBlockSigning {
+		// the unsigned blocks of every node are rejected
+		return false
+	}
+	access := s.nodes[nodeID].p2p.peerAccess
+	hasAllowed := false
+	for _, kind := range access {
+		if kind == client.PeerAccessAllow {
+			hasAllowed = true
+		}
+	}
+	switch access[sourceID] {
+	case client.PeerAccessDeny:
+		return false
+	case client.PeerAccessAllow:
+		return true
+	default:
+		return !hasAllowed
+	}
+}
+
+// matchesReplicatorFilter returns true if the document with the given key matches the filter
+// of the replicator from the given source node to the given target node.
+func matchesReplicatorFilter(s *state, sourceID int, targetID int, key string) bool {
+	filters := s.nodes[sourceID].p2p.replicatorFilters[targetID]
+	if len(filters) == 0 {
+		return true
+	}
+	docID, err := client.NewDocIDFromString(key)
+	if err != nil {
+		// collection commits of branchable collections are not filtered
+		return true
+	}
+	for _, col := range s.nodes[sourceID].collections {
+		filter, ok := filters[col.Name().Value()]
+		if !ok {
+			continue
+		}
+		doc, err := col.Get(s.ctx, docID, true)
+		if errors.Is(err, client.ErrDocumentNotFoundOrNotAuthorized) {
+			continue
+		}
+		require.NoError(s.t, err)
+
+		values := map[string]any{}
+		for field, value := range doc.Values() {
+			values[field.Name()] = value.Value()
+		}
+		passes, _, err := mapper.RunFilterOnValues(request.Filter{Conditions: filter}, values)
+		require.NoError(s.t, err)
+		return passes
+	}
+	return true
+}
+
 func reconnectPeers(s *state) {
 	for i, n := range s.nodes {
 		for j := range n.p2p.connections {
@@ -317,3 +658,78 @@ func RandomNetworkingConfig() ConfigureNode {
 		}
 	}
 }
+
+// expectCatchUpHeadsOnConnect updates the expected DAG heads of the given node with the heads
+// it will pull from the given source node when they get connected.
+//
+// Nodes catch up on the collections they are both subscribed to, and on the documents
+// they have in common in the other collections.
+func expectCatchUpHeadsOnConnect(s *state, nodeID int, sourceID int) {
+	node := s.nodes[nodeID]
+	keys := []string{}
+	for collectionIndex := range node.collections {
+		if _, ok := node.p2p.peerCollections[collectionIndex]; ok {
+			if _, ok := s.nodes[sourceID].p2p.peerCollections[collectionIndex]; ok {
+				keys = append(keys, getCollectionDAGKeys(s, nodeID, collectionIndex)...)
+			}
+			continue
+		}
+		if collectionIndex >= len(s.docIDs) {
+			continue
+		}
+		for _, docID := range s.docIDs[collectionIndex] {
+			if _, ok := node.p2p.actualDAGHeads[docID.String()]; !ok {
+				continue
+			}
+			// The heads of branchable collections are caught up on for their documents.
+			if col := node.collections[collectionIndex]; col.Description().IsBranchable {
+				keys = append(keys, col.SchemaRoot())
+				break
+			}
+			keys = append(keys, docID.String())
+		}
+	}
+	expectCatchUpHeads(s, nodeID, sourceID, keys)
+}
+
+// expectCatchUpHeads updates the expected DAG heads of the given node with the heads of
+// the source node for the given keys, unless the node already has them.
+func expectCatchUpHeads(s *state, nodeID int, sourceID int, keys []string) {
+	node := s.nodes[nodeID]
+	source := s.nodes[sourceID]
+	if node.closed || source.closed || !acceptsPushesFrom(s, nodeID, sourceID) {
+		return
+	}
+	for _, key := range keys {
+		head, ok := source.p2p.actualDAGHeads[key]
+		if !ok {
+			continue
+		}
+		if actual, ok := node.p2p.actualDAGHeads[key]; ok && actual.cid.Equals(head.cid) {
+			continue
+		}
+		has, err := node.Blockstore().Has(s.ctx, head.cid)
+		require.NoError(s.t, err)
+		if !has {
+			node.p2p.expectedDAGHeads[key] = head.cid
+		}
+	}
+}
+
+// getCollectionDAGKeys returns the keys of the DAG heads of the collection with the given index.
+//
+// The DAGs of the documents of branchable collections are linked to the DAG of the collection,
+// so only the key of the collection is returned for those.
+func getCollectionDAGKeys(s *state, nodeID int, collectionIndex int) []string {
+	col := s.nodes[nodeID].collections[collectionIndex]
+	if col.Description().IsBranchable {
+		return []string{col.SchemaRoot()}
+	}
+	keys := []string{}
+	if collectionIndex < len(s.docIDs) {
+		for _, docID := range s.docIDs[collectionIndex] {
+			keys = append(keys, docID.String())
+		}
+	}
+	return keys
+}
@@ -48,12 +48,14 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 				}`,
 			},
 			testUtils.ConnectPeers{
+				// Connecting the nodes causes them to catch up on the collection, so the `Fred` and `Shahzad`
+				// commits, and their corresponding collection-level commits are synced to both nodes.
 				SourceNodeID: 1,
 				TargetNodeID: 0,
 			},
 			testUtils.WaitForSync{},
 			testUtils.UpdateDoc{
-				// Update node 1 after the peer connection has been established, this will cause the `Shahzad` commit
+				// Update node 1 after the peer connection has been established, this will cause the `Chris` commit
 				// to be synced to node 0, as well as the related collection commits.
 				NodeID: immutable.Some(1),
 				Doc: `{
@@ -63,8 +65,8 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 			testUtils.WaitForSync{},
 			testUtils.UpdateDoc{
 				// Update node 0 after `Chris` and `Shahzad` have synced to node 0.  As this update happens after the peer
-				// connection has been established, this will cause the `Fred` and `Addo` doc commits, and their corresponding
-				// collection-level commits to sync to node 1.
+				// connection has been established, this will cause the `Addo` doc commit, and its corresponding
+				// collection-level commit to sync to node 1.
 				//
 				// Now, all nodes should have a full history, including the 'offline' changes made before establishing the
 				// peer connection.
@@ -93,61 +95,58 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 								{
 									"cid": testUtils.NewUniqueCid("collection, node1 update2"),
 								},
-								{
-									"cid": testUtils.NewUniqueCid("collection, node1 update1"),
-								},
 								{
 									"cid": testUtils.NewUniqueCid("doc, node0 update3"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("collection, node1 update1"),
+							"cid": testUtils.NewUniqueCid("collection, node1 update2"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("collection, create"),
+									"cid": testUtils.NewUniqueCid("collection, node1 update1"),
 								},
 								{
-									"cid": testUtils.NewUniqueCid("doc, node1 update1"),
+									"cid": testUtils.NewUniqueCid("collection, node0 update1"),
+								},
+								{
+									"cid": testUtils.NewUniqueCid("doc, node1 update2"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("collection, create"),
+							"cid": testUtils.NewUniqueCid("collection, node0 update1"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("doc, create"),
+									"cid": testUtils.NewUniqueCid("collection, create"),
+								},
+								{
+									"cid": testUtils.NewUniqueCid("doc, node0 update1"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("collection, node1 update2"),
+							"cid": testUtils.NewUniqueCid("collection, create"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("collection, node0 update1"),
-								},
-								{
-									"cid": testUtils.NewUniqueCid("doc, node1 update2"),
+									"cid": testUtils.NewUniqueCid("doc, create"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("collection, node0 update1"),
+							"cid": testUtils.NewUniqueCid("collection, node1 update1"),
 							"links": []map[string]any{
 								{
 									"cid": testUtils.NewUniqueCid("collection, create"),
 								},
 								{
-									"cid": testUtils.NewUniqueCid("doc, node0 update1"),
+									"cid": testUtils.NewUniqueCid("doc, node1 update1"),
 								},
 							},
 						},
 						{
 							"cid": testUtils.NewUniqueCid("name, node0 update3"),
 							"links": []map[string]any{
-								{
-									"cid": testUtils.NewUniqueCid("name, node1 update1"),
-								},
 								{
 									"cid": testUtils.NewUniqueCid("name, node1 update2"),
 								},
@@ -159,10 +158,13 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 								{
 									"cid": testUtils.NewUniqueCid("name, node0 update1"),
 								},
+								{
+									"cid": testUtils.NewUniqueCid("name, node1 update1"),
+								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("name, node0 update1"),
+							"cid": testUtils.NewUniqueCid("name, node1 update1"),
 							"links": []map[string]any{
 								{
 									"cid": testUtils.NewUniqueCid("name, create"),
@@ -174,7 +176,7 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 							"links": []map[string]any{},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("name, node1 update1"),
+							"cid": testUtils.NewUniqueCid("name, node0 update1"),
 							"links": []map[string]any{
 								{
 									"cid": testUtils.NewUniqueCid("name, create"),
@@ -187,52 +189,52 @@ func TestQueryCommitsBranchables_HandlesConcurrentUpdatesAcrossPeerConnection(t
 								{
 									"cid": testUtils.NewUniqueCid("doc, node1 update2"),
 								},
-								{
-									"cid": testUtils.NewUniqueCid("doc, node1 update1"),
-								},
 								{
 									"cid": testUtils.NewUniqueCid("name, node0 update3"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("doc, node1 update1"),
+							"cid": testUtils.NewUniqueCid("doc, node1 update2"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("doc, create"),
+									"cid": testUtils.NewUniqueCid("doc, node1 update1"),
 								},
 								{
-									"cid": testUtils.NewUniqueCid("name, node1 update1"),
+									"cid": testUtils.NewUniqueCid("doc, node0 update1"),
+								},
+								{
+									"cid": testUtils.NewUniqueCid("name, node1 update2"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("doc, create"),
+							"cid": testUtils.NewUniqueCid("doc, node0 update1"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("name, create"),
+									"cid": testUtils.NewUniqueCid("doc, create"),
+								},
+								{
+									"cid": testUtils.NewUniqueCid("name, node0 update1"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("doc, node1 update2"),
+							"cid": testUtils.NewUniqueCid("doc, create"),
 							"links": []map[string]any{
 								{
-									"cid": testUtils.NewUniqueCid("doc, node0 update1"),
-								},
-								{
-									"cid": testUtils.NewUniqueCid("name, node1 update2"),
+									"cid": testUtils.NewUniqueCid("name, create"),
 								},
 							},
 						},
 						{
-							"cid": testUtils.NewUniqueCid("doc, node0 update1"),
+							"cid": testUtils.NewUniqueCid("doc, node1 update1"),
 							"links": []map[string]any{
 								{
 									"cid": testUtils.NewUniqueCid("doc, create"),
 								},
 								{
-									"cid": testUtils.NewUniqueCid("name, node0 update1"),
+									"cid": testUtils.NewUniqueCid("name, node1 update1"),
 								},
 							},
 						},
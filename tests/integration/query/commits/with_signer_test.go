@@ -0,0 +1,142 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package commits
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryCommitsWithSigner_WithNodeIdentity_SignedByNode(t *testing.T) {
+	test := testUtils.TestCase{
+		Description:        "Simple all commits query with signer, signed with the node identity",
+		EnableBlockSigning: true,
+		Actions: []any{
+			// only configured nodes have a node identity
+			testUtils.RandomNetworkingConfig(),
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits {
+						signer
+					}
+				}`,
+				Results: map[string]any{
+					"commits": []map[string]any{
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.NodeIdentity(0)),
+						},
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.NodeIdentity(0)),
+						},
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.NodeIdentity(0)),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryCommitsWithSigner_WithRequestIdentity_SignedByRequestIdentity(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple all commits query with signer, signed with the request identity",
+		SupportedClientTypes: immutable.Some(
+			[]testUtils.ClientType{
+				// Only the Go client holds the private key of the request identity, the
+				// other clients only send a token of it.
+				testUtils.GoClientType,
+			},
+		),
+		EnableBlockSigning: true,
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Identity: testUtils.ClientIdentity(1),
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits {
+						signer
+					}
+				}`,
+				Results: map[string]any{
+					"commits": []map[string]any{
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.ClientIdentity(1)),
+						},
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.ClientIdentity(1)),
+						},
+						{
+							"signer": testUtils.NewIdentityDID(testUtils.ClientIdentity(1)),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryCommitsWithSigner_WithBlockSigningDisabled_NotSigned(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple all commits query with signer, without block signing",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits {
+						signer
+					}
+				}`,
+				Results: map[string]any{
+					"commits": []map[string]any{
+						{
+							"signer": nil,
+						},
+						{
+							"signer": nil,
+						},
+						{
+							"signer": nil,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
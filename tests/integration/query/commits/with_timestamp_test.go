@@ -0,0 +1,97 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package commits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryCommitsWithTimestamp(t *testing.T) {
+	start := time.Now()
+
+	test := testUtils.TestCase{
+		Description:           "Simple all commits query with timestamp",
+		EnableBlockTimestamps: true,
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits {
+						timestamp
+					}
+				}`,
+				Asserter: testUtils.ResultAsserterFunc(func(t testing.TB, result map[string]any) (bool, string) {
+					commits := testUtils.ConvertToArrayOfMaps(t, result["commits"])
+					require.Len(t, commits, 3)
+
+					timestamp := testUtils.ConvertToTime(t, commits[0]["timestamp"])
+					require.False(t, timestamp.Before(start.Truncate(0)))
+					require.False(t, timestamp.After(time.Now()))
+					for _, commit := range commits {
+						// all the blocks of a single commit share the same timestamp
+						require.Equal(t, timestamp, testUtils.ConvertToTime(t, commit["timestamp"]))
+					}
+					return true, ""
+				}),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryCommitsWithTimestamp_WithBlockTimestampsDisabled(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple all commits query with timestamp, without block timestamps",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits {
+						timestamp
+					}
+				}`,
+				Results: map[string]any{
+					"commits": []map[string]any{
+						{
+							"timestamp": nil,
+						},
+						{
+							"timestamp": nil,
+						},
+						{
+							"timestamp": nil,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
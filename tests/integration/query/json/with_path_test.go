@@ -0,0 +1,225 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryJSON_WithPathEqualFilter_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with JSON _path and _eq filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Custom": {"address": {"city": "Munich"}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Andy",
+					"Custom": {"address": {"city": "Toronto"}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Fred",
+					"Custom": "Munich"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Custom: {_path: ["address", "city"], _eq: "Munich"}}) {
+						Name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithPathRangeFilter_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with JSON _path and range filters",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Custom": {"stats": {"score": 21}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Andy",
+					"Custom": {"stats": {"score": 32}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Fred",
+					"Custom": {"stats": {"score": 45}}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Custom: {_path: ["stats", "score"], _gt: 21, _le: 32}}) {
+						Name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Andy",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithPathWithinCompoundFilter_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with JSON _path filters within an _or filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Custom": {"tags": {"_id": "a"}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Andy",
+					"Custom": {"tags": {"_id": "b"}}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Fred",
+					"Custom": {"tags": {"_id": "c"}}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {_or: [
+						{Custom: {_path: ["tags", "_id"], _eq: "a"}},
+						{Custom: {_path: ["tags", "_id"], _eq: "c"}}
+					]}) {
+						Name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "John",
+						},
+						{
+							"Name": "Fred",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithEmptyPath_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with empty JSON _path filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Custom: JSON
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Custom: {_path: [], _eq: 5}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "_path must be a non-empty list of JSON object keys",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithNonStringPath_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with JSON _path filter containing a non string key",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Custom: JSON
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Custom: {_path: ["a", 1], _eq: 5}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "_path must be a non-empty list of JSON object keys",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
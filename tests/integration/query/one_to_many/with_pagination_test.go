@@ -0,0 +1,154 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryOneToManyWithChildFirst(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from many side with first",
+		Actions: []any{
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"rating": 4.9,
+					"author_id": "bae-e1ea288f-09fa-55fa-b0b5-0ac8941ea35b"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "A Time for Mercy",
+					"rating": 4.5,
+					"author_id": "bae-e1ea288f-09fa-55fa-b0b5-0ac8941ea35b"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Theif Lord",
+					"rating": 4.8,
+					"author_id": "bae-72e8c691-9f20-55e7-9228-8af1cf54cace"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "Cornelia Funke",
+					"age": 62,
+					"verified": false
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						published(first: 1, order: {rating: ASC}) {
+							name
+						}
+						_pageInfo(of: "published") {
+							hasNextPage
+						}
+					}
+				}`,
+				Results: map[string]any{
+					"Author": []map[string]any{
+						{
+							"name": "Cornelia Funke",
+							"published": []map[string]any{
+								{
+									"name": "Theif Lord",
+								},
+							},
+							"_pageInfo": map[string]any{
+								"hasNextPage": false,
+							},
+						},
+						{
+							"name": "John Grisham",
+							"published": []map[string]any{
+								{
+									"name": "A Time for Mercy",
+								},
+							},
+							"_pageInfo": map[string]any{
+								"hasNextPage": true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithChildFirst_WithoutChildren_ReturnsPageInfo(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from many side with first, without children",
+		Actions: []any{
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "Cornelia Funke",
+					"age": 62,
+					"verified": false
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						published(first: 1) {
+							name
+						}
+						_pageInfo(of: "published") {
+							hasNextPage
+							hasPreviousPage
+							startCursor
+							endCursor
+						}
+					}
+				}`,
+				Results: map[string]any{
+					"Author": []map[string]any{
+						{
+							"name":      "Cornelia Funke",
+							"published": []map[string]any{},
+							"_pageInfo": map[string]any{
+								"hasNextPage":     false,
+								"hasPreviousPage": false,
+								"startCursor":     nil,
+								"endCursor":       nil,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
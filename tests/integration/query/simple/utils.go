@@ -33,6 +33,8 @@ func executeTestCase(t *testing.T, test testUtils.TestCase) {
 		testUtils.TestCase{
 			Description:            test.Description,
 			SupportedMutationTypes: test.SupportedMutationTypes,
+			QueryMemoryBudget:      test.QueryMemoryBudget,
+			MaxRequestScannedDocs:  test.MaxRequestScannedDocs,
 			Actions: append(
 				[]any{
 					testUtils.SchemaUpdate{
@@ -0,0 +1,546 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// storeLatestCommitTime returns a request storing the timestamp of the latest composite commit,
+// moved by the given offset, as the 'time' variable of the given variables.
+func storeLatestCommitTime(variables map[string]any, offset time.Duration) testUtils.Request {
+	return testUtils.Request{
+		Request: `query {
+			commits(fieldId: "C", order: {height: DESC}, limit: 1) {
+				timestamp
+			}
+		}`,
+		Asserter: testUtils.ResultAsserterFunc(func(t testing.TB, result map[string]any) (bool, string) {
+			commits := testUtils.ConvertToArrayOfMaps(t, result["commits"])
+			require.Len(t, commits, 1)
+
+			timestamp := testUtils.ConvertToTime(t, commits[0]["timestamp"])
+			variables["time"] = timestamp.Add(offset).Format(time.RFC3339Nano)
+			return true, ""
+		}),
+	}
+}
+
+func TestQuerySimpleWithAsOfHeight(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf height",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"age": 30
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"age": 22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"name": "Johnny"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 2}) {
+						name
+						age
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+							"age":  int64(22),
+						},
+						{
+							"name": "Fred",
+							"age":  int64(30),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 1}) {
+						name
+						age
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+							"age":  int64(21),
+						},
+						{
+							"name": "Fred",
+							"age":  int64(30),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfHeightZero_ReturnsNoDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf height before the creation of any document",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 0}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf height and filter on a historic value",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"age": 30
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"age": 40
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 1}, filter: {age: {_lt: 25}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndDocID(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf height and docID",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"name": "Johnny"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 1}, docID: "bae-6845cfdf-cb0f-56a3-be3a-b5a67be5fbdc") {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfHeight_ReturnsDocumentsDeletedSince(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf height before the document was deleted",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				DocID: 0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 1}) {
+						name
+						_deleted
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name":     "John",
+							"_deleted": false,
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 2}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 2}, showDeleted: true) {
+						name
+						_deleted
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name":     "John",
+							"_deleted": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfHeightAndTime_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with both asOf height and time",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: 1, time: "2017-07-23T03:46:56.647Z"}) {
+						name
+					}
+				}`,
+				ExpectedError: "asOf requires exactly one of height or time",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithEmptyAsOf_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with empty asOf",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {}) {
+						name
+					}
+				}`,
+				ExpectedError: "asOf requires exactly one of height or time",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfAndCid_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf and cid",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(
+						asOf: {height: 1},
+						cid: "bafyreib7afkd5hepl45wdtwwpai433bhnbd3ps5m2rv3masctda7b6mmxe"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "asOf cannot be combined with cid",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithNegativeAsOfHeight_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with negative asOf height",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: {height: -1}) {
+						name
+					}
+				}`,
+				ExpectedError: "asOf height cannot be negative",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfTime(t *testing.T) {
+	// The variables are set by the requests storing the commit times, once the commits exist.
+	beforeCreate := map[string]any{}
+	atCreate := map[string]any{}
+	atUpdate := map[string]any{}
+
+	test := testUtils.TestCase{
+		Description:           "Simple query with asOf time",
+		EnableBlockTimestamps: true,
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			storeLatestCommitTime(beforeCreate, -time.Nanosecond),
+			storeLatestCommitTime(atCreate, 0),
+			testUtils.UpdateDoc{
+				Doc: `{
+					"name": "Johnny"
+				}`,
+			},
+			storeLatestCommitTime(atUpdate, 0),
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($time: DateTime) {
+					Users(asOf: {time: $time}) {
+						name
+					}
+				}`,
+				Variables: immutable.Some(beforeCreate),
+				Results: map[string]any{
+					"Users": []map[string]any{},
+				},
+			},
+			testUtils.Request{
+				Request: `query($time: DateTime) {
+					Users(asOf: {time: $time}) {
+						name
+					}
+				}`,
+				Variables: immutable.Some(atCreate),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query($time: DateTime) {
+					Users(asOf: {time: $time}) {
+						name
+					}
+				}`,
+				Variables: immutable.Some(atUpdate),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "Johnny",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQuerySimpleWithAsOfTime_WithBlockTimestampsDisabled_ReturnsCurrentDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf time, without block timestamps",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"name": "Johnny"
+				}`,
+			},
+			testUtils.Request{
+				// blocks without a timestamp are considered to have been created before any time
+				Request: `query {
+					Users(asOf: {time: "1970-01-01T00:00:00Z"}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"name": "Johnny",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
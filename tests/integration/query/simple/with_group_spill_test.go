@@ -0,0 +1,98 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithGroupBy_IfMemoryBudgetExceeded_ShouldSpillAndMergeGroups(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with group by, exceeding the memory budget",
+		// every document exceeds the budget, so each part of every group is spilled
+		QueryMemoryBudget: immutable.Some[int64](1),
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Shahzad",
+					"Age": 32
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(groupBy: [Age]) {
+						Age
+						_count(_group: {})
+						_group(order: {Name: ASC}, limit: 2) {
+							Name
+						}
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Age":    int64(55),
+							"_count": 1,
+							"_group": []map[string]any{
+								{"Name": "Carlo"},
+							},
+						},
+						{
+							"Age":    int64(19),
+							"_count": 1,
+							"_group": []map[string]any{
+								{"Name": "Alice"},
+							},
+						},
+						{
+							"Age":    int64(32),
+							"_count": 3,
+							"_group": []map[string]any{
+								{"Name": "Bob"},
+								{"Name": "John"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func createLimitsTestDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "John",
+				"Age": 21
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Bob",
+				"Age": 32
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Alice",
+				"Age": 19
+			}`,
+		},
+	}
+}
+
+func TestQuerySimple_WithMaxScannedDocsExceeded_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query, scanning more documents than allowed",
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxScannedDocs: immutable.Some[uint64](2),
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum number of scanned documents. Limit: 2",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimple_WithMaxScannedDocsNotExceeded_ShouldSucceed(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query, scanning as many documents as allowed",
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxScannedDocs: immutable.Some[uint64](3),
+				Request: `query {
+					Users(order: {Age: ASC}) {
+						Name
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{"Name": "Alice"},
+						{"Name": "John"},
+						{"Name": "Bob"},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFilter_WithMaxScannedDocsExceeded_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with filter, counting the documents filtered out as scanned",
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxScannedDocs: immutable.Some[uint64](2),
+				Request: `query {
+					Users(filter: {Name: {_eq: "John"}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum number of scanned documents. Limit: 2",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimple_WithNodeMaxScannedDocsExceeded_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description:           "Simple query, scanning more documents than allowed by the node default",
+		MaxRequestScannedDocs: immutable.Some[uint64](1),
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum number of scanned documents. Limit: 1",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimple_WithMaxScannedDocsAboveNodeLimit_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description:           "Simple query, with a request limit above the limit of the node",
+		MaxRequestScannedDocs: immutable.Some[uint64](1),
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxScannedDocs: immutable.Some[uint64](3),
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum number of scanned documents. Limit: 1",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimple_WithMaxScannedDocsBelowNodeLimit_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description:           "Simple query, with a request limit below the limit of the node",
+		MaxRequestScannedDocs: immutable.Some[uint64](3),
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxScannedDocs: immutable.Some[uint64](2),
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum number of scanned documents. Limit: 2",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimple_WithMaxDurationExceeded_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query, running for longer than allowed",
+		Actions: append(
+			createLimitsTestDocs(),
+			testUtils.Request{
+				MaxDuration: immutable.Some(time.Nanosecond),
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+				ExpectedError: "request exceeded its maximum duration",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,500 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/internal/planner/mapper"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+const (
+	johnDocID = "bae-d4303725-7db9-53d2-b324-f3ee44020e52"
+	bobDocID  = "bae-202df9ad-53f4-542b-a68f-fee6cd96f59e"
+	fredDocID = "bae-b1f63c11-53ae-5c9d-92f2-b9ff3066cb77"
+)
+
+func encodeCursor(t *testing.T, docID string, values ...any) string {
+	cursor, err := mapper.Cursor{Values: values, DocID: docID}.Encode()
+	require.NoError(t, err)
+	return cursor
+}
+
+func paginationTestDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "John",
+				"Age": 21
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Bob",
+				"Age": 32
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Fred",
+				"Age": 40
+			}`,
+		},
+	}
+}
+
+func TestQuerySimpleWithFirst(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and page info",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query {
+					Users(first: 2, order: {Age: ASC}) {
+						Name
+						_cursor
+					}
+					_pageInfo(of: "Users") {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}`,
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name":    "John",
+							"_cursor": encodeCursor(t, johnDocID, int64(21)),
+						},
+						{
+							"Name":    "Bob",
+							"_cursor": encodeCursor(t, bobDocID, int64(32)),
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage":     true,
+						"hasPreviousPage": false,
+						"startCursor":     encodeCursor(t, johnDocID, int64(21)),
+						"endCursor":       encodeCursor(t, bobDocID, int64(32)),
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndAfter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and after",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, after: $after, order: {Age: ASC}) {
+						Name
+					}
+					_pageInfo(of: "Users") {
+						hasNextPage
+						hasPreviousPage
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, johnDocID, int64(21)),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Bob",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage":     true,
+						"hasPreviousPage": true,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndAfter_LastPage(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and after, reaching the last page",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 2, after: $after, order: {Age: ASC}) {
+						Name
+					}
+					_pageInfo(of: "Users") {
+						hasNextPage
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, bobDocID, int64(32)),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Fred",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage": false,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndAfter_EmptyPage_ReturnsPageInfo(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and after the last document, with an aliased page info",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					users: Users(first: 2, after: $after, order: {Age: ASC}) {
+						Name
+					}
+					page: _pageInfo(of: "users") {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, fredDocID, int64(40)),
+				}),
+				Results: map[string]any{
+					"users": []map[string]any{},
+					"page": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": true,
+						"startCursor":     nil,
+						"endCursor":       nil,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPageInfo_OfUnknownSelection_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with page info of a selection that is not requested",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query {
+					Users(first: 1) {
+						Name
+					}
+					_pageInfo(of: "users") {
+						hasNextPage
+					}
+				}`,
+				ExpectedError: "page info must be of a sibling collection or relation list selection",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithLastAndBefore(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with last and before, descending order",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($before: String) {
+					Users(last: 1, before: $before, order: {Age: DESC}) {
+						Name
+					}
+					_pageInfo(of: "Users") {
+						hasNextPage
+						hasPreviousPage
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"before": encodeCursor(t, johnDocID, int64(21)),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Bob",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage":     true,
+						"hasPreviousPage": true,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithAfter_NoOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with after, ordered by document ID",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(after: $after) {
+						_docID
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, bobDocID),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"_docID": fredDocID,
+						},
+						{
+							"_docID": johnDocID,
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_TiesAreOrderedByDocID(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first, documents with equal order values are ordered by document ID",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, after: $after, order: {Age: ASC}) {
+						Name
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, "bae-7b0e451a-91a7-5880-b3d6-74b0b990c6d4", int64(21)),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_WithFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first, after and a filter on the order field",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, after: $after, order: {Age: ASC}, filter: {Age: {_lt: 40}}) {
+						Name
+					}
+					_pageInfo(of: "Users") {
+						hasNextPage
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, johnDocID, int64(21)),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Bob",
+						},
+					},
+					"_pageInfo": map[string]any{
+						"hasNextPage": false,
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_WithStringOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and after, ordered by a string field",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, after: $after, order: {Name: ASC}) {
+						Name
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, bobDocID, "Bob"),
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Fred",
+						},
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_InvalidCursor_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with an invalid cursor",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query {
+					Users(first: 1, after: "not a cursor") {
+						Name
+					}
+				}`,
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_CursorOfOtherOrder_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with a cursor that does not match the ordering",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, after: $after) {
+						Name
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, johnDocID, int64(21)),
+				}),
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_CursorValueOfOtherType_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with a cursor whose value is not of the type of the order field",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, order: {Age: ASC}, after: $after) {
+						Name
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, johnDocID, "21"),
+				}),
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirst_AliasedCursorValueOfOtherType_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query ordered by an alias with a cursor whose value is not of the type of the field",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query($after: String) {
+					Users(first: 1, order: {_alias: {years: ASC}}, after: $after) {
+						Name
+						years: Age
+					}
+				}`,
+				Variables: immutable.Some(map[string]any{
+					"after": encodeCursor(t, johnDocID, "21"),
+				}),
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithFirstAndLimit_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with first and limit",
+		Actions: append(
+			paginationTestDocs(),
+			testUtils.Request{
+				Request: `query {
+					Users(first: 1, limit: 1) {
+						Name
+					}
+				}`,
+				ExpectedError: "cursor pagination cannot be combined with limit or offset",
+			},
+		),
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+func TestQuerySimpleWithPreparedRequest(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with prepared request, executed with different variables",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Alice",
+					"Age": 40
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 21
+				}`,
+			},
+			testUtils.PrepareRequest{
+				Request: `query($age: Int!) {
+					Users(filter: {Age: {_lt: $age}}) {
+						Name
+					}
+				}`,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				Variables: immutable.Some(map[string]any{
+					"age": 30,
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Bob",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				Variables: immutable.Some(map[string]any{
+					"age": 20,
+				}),
+				Results: map[string]any{
+					"Users": []map[string]any{},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPreparedRequest_SeesNewDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with prepared request, executed after a document is created",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Alice",
+					"Age": 40
+				}`,
+			},
+			testUtils.PrepareRequest{
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Alice",
+						},
+					},
+				},
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Bob",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Bob",
+						},
+						{
+							"Name": "Alice",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPreparedRequest_SameRequestDifferentFormatting_SameResults(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with the same request prepared with a different formatting",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Alice",
+					"Age": 40
+				}`,
+			},
+			testUtils.PrepareRequest{
+				Request: `query {
+					Users {
+						Name
+					}
+				}`,
+			},
+			testUtils.PrepareRequest{
+				Request: `query { Users { Name } } # users`,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(1),
+				Results: map[string]any{
+					"Users": []map[string]any{
+						{
+							"Name": "Alice",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPreparedRequest_InvalidRequest_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with invalid prepared request",
+		Actions: []any{
+			testUtils.PrepareRequest{
+				Request: `query {
+					Users {
+						Foo
+					}
+				}`,
+				ExpectedError: `Cannot query field "Foo" on type "Users".`,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -133,6 +133,27 @@ func (ucid *UniqueCid) Validate(s *state, actualValue any, msgAndArgs ...any) {
 	}
 }
 
+// IdentityDID may be used as a `Results` field whose value must be the DID of the given identity.
+type IdentityDID struct {
+	// Identity is the identity whose DID is expected.
+	Identity immutable.Option[identity]
+}
+
+var _ Validator = (*IdentityDID)(nil)
+
+// NewIdentityDID creates a new [IdentityDID] of the given identity.
+//
+// Use `ClientIdentity` to reference a user identity and `NodeIdentity` to reference a node identity.
+func NewIdentityDID(identity immutable.Option[identity]) *IdentityDID {
+	return &IdentityDID{
+		Identity: identity,
+	}
+}
+
+func (d *IdentityDID) Validate(s *state, actualValue any, msgAndArgs ...any) {
+	assert.Equal(s.t, getIdentity(s, d.Identity).DID, actualValue, msgAndArgs...)
+}
+
 // areResultsEqual returns true if the expected and actual results are of equal value.
 //
 // Values of type json.Number and immutable.Option will be reduced to their underlying types.
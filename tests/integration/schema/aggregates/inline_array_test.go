@@ -1448,6 +1448,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_search",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1598,6 +1604,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_search",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -225,3 +225,93 @@ func TestSchemaCreate_ContainsPCounterTypeWithWrongKind_Error(t *testing.T) {
 
 	testUtils.ExecuteTestCase(t, test)
 }
+
+func TestSchemaCreate_ContainsORSetTypeWithArrayKind_NoError(t *testing.T) {
+	schemaVersionID := "bafkreig7gifpk3gvmvfdh6imp4btto46vufpzrrrz5am4gi4iz25tthcxu"
+
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.GetSchema{
+				VersionID: immutable.Some(schemaVersionID),
+				ExpectedResults: []client.SchemaDescription{
+					{
+						Name:      "Users",
+						VersionID: schemaVersionID,
+						Root:      schemaVersionID,
+						Fields: []client.SchemaFieldDescription{
+							{
+								Name: "_docID",
+								Kind: client.FieldKind_DocID,
+							},
+							{
+								Name: "tags",
+								Kind: client.FieldKind_STRING_ARRAY,
+								Typ:  client.OR_SET,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaCreate_ContainsORSetTypeWithWrongKind_Error(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						tags: String @crdt(type: orset)
+					}
+				`,
+				ExpectedError: "CRDT type orset can't be assigned to field kind String",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaCreate_ContainsGSetTypeWithWrongKind_Error(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						points: Int @crdt(type: gset)
+					}
+				`,
+				ExpectedError: "CRDT type gset can't be assigned to field kind Int",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaCreate_ContainsTextTypeWithWrongKind_Error(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						body: Int @crdt(type: text)
+					}
+				`,
+				ExpectedError: "CRDT type text can't be assigned to field kind Int",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -66,6 +66,10 @@ var DefaultFields = concat(
 		versionField,
 		groupField,
 		deletedField,
+		cursorField,
+		pageInfoField,
+		kindField,
+		changesField,
 	},
 	aggregateFields,
 )
@@ -95,6 +99,38 @@ var deletedField = Field{
 	},
 }
 
+var cursorField = Field{
+	"name": "_cursor",
+	"type": map[string]any{
+		"kind": "SCALAR",
+		"name": "String",
+	},
+}
+
+var pageInfoField = Field{
+	"name": "_pageInfo",
+	"type": map[string]any{
+		"kind": "OBJECT",
+		"name": "PageInfo",
+	},
+}
+
+var kindField = Field{
+	"name": "_kind",
+	"type": map[string]any{
+		"kind": "ENUM",
+		"name": "DocChangeKind",
+	},
+}
+
+var changesField = Field{
+	"name": "_changes",
+	"type": map[string]any{
+		"kind": "SCALAR",
+		"name": "JSON",
+	},
+}
+
 var versionField = Field{
 	"name": "_version",
 	"type": map[string]any{
@@ -156,6 +192,16 @@ var cidArg = Field{
 		"inputFields": nil,
 	},
 }
+var asOfArg = Field{
+	"name": "asOf",
+	"type": map[string]any{
+		"name": "AsOf",
+		"inputFields": []any{
+			makeInputObject("height", "Int", nil),
+			makeInputObject("time", "DateTime", nil),
+		},
+	},
+}
 var docIDArg = Field{
 	"name": request.DocIDArgName,
 	"type": map[string]any{
@@ -205,6 +251,42 @@ var offsetArg = Field{
 	},
 }
 
+var firstArg = Field{
+	"name": "first",
+	"type": map[string]any{
+		"name":        "Int",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var afterArg = Field{
+	"name": "after",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var lastArg = Field{
+	"name": "last",
+	"type": map[string]any{
+		"name":        "Int",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var beforeArg = Field{
+	"name": "before",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
 type argDef struct {
 	fieldName string
 	typeName  string
@@ -139,11 +139,16 @@ var testFilterForSimpleSchemaArgProps = map[string]any{
 var defaultUserArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
 		docIDArg,
 		showDeletedArg,
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		afterArg,
+		lastArg,
+		beforeArg,
 		buildOrderArg("Users"),
 	},
 	testFilterForSimpleSchemaArgProps,
@@ -292,11 +297,16 @@ var testFilterForOneToOneSchemaArgProps = map[string]any{
 var defaultBookArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
 		docIDArg,
 		showDeletedArg,
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		afterArg,
+		lastArg,
+		beforeArg,
 		buildOrderArg("Book"),
 	},
 	testFilterForOneToOneSchemaArgProps,
@@ -350,11 +360,16 @@ func TestSchemaFilterInputs_WithJSONField_Succeeds(t *testing.T) {
 										trimFields(
 											fields{
 												cidArg,
+												asOfArg,
 												docIDArg,
 												showDeletedArg,
 												groupByArg,
 												limitArg,
 												offsetArg,
+												firstArg,
+												afterArg,
+												lastArg,
+												beforeArg,
 												buildOrderArg("Users"),
 											},
 											map[string]any{
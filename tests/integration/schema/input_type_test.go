@@ -87,6 +87,10 @@ func TestInputTypeOfOrderFieldWhereSchemaHasManyRelationType(t *testing.T) {
 											groupByArg,
 											limitArg,
 											offsetArg,
+											firstArg,
+											afterArg,
+											lastArg,
+											beforeArg,
 										},
 										testInputTypeOfOrderFieldWhereSchemaHasRelationTypeArgProps,
 									),
@@ -237,6 +241,10 @@ var defaultGroupArgsWithoutOrder = trimFields(
 		groupByArg,
 		limitArg,
 		offsetArg,
+		firstArg,
+		afterArg,
+		lastArg,
+		beforeArg,
 	},
 	testInputTypeOfOrderFieldWhereSchemaHasRelationTypeArgProps,
 )
@@ -0,0 +1,67 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package updates
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaUpdates_WithPreparedRequestAndSetActiveSchemaToPreviousVersion_Errors(t *testing.T) {
+	schemaVersion1ID := "bafkreia3o3cetvcnnxyu5spucimoos77ifungfmacxdkva4zah2is3aooe"
+
+	test := testUtils.TestCase{
+		Description: "Test schema update, with prepared request revalidated after the active schema changes",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.PrepareRequest{
+				Request: `query {
+					Users {
+						name
+						email
+					}
+				}`,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				// The email field is queriable
+				Results: map[string]any{
+					"Users": []map[string]any{},
+				},
+			},
+			testUtils.SetActiveSchemaVersion{
+				SchemaVersionID: schemaVersion1ID,
+			},
+			testUtils.Request{
+				Prepared: immutable.Some(0),
+				// The email field is no longer queriable, the cached request must not be used
+				ExpectedError: `Cannot query field "email" on type "Users".`,
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
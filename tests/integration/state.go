@@ -37,11 +37,22 @@ type p2pState struct {
 	// The map key is the source node id.
 	replicators map[int]struct{}
 
+	// replicatorFilters contains the filters of the documents replicated to replicator targets.
+	//
+	// The map key is the target node id. The map value contains the filters keyed by
+	// collection name.
+	replicatorFilters map[int]map[string]map[string]any
+
 	// peerCollections contains all active peer collection subscriptions.
 	//
 	// The map key is the node id of the subscriber.
 	peerCollections map[int]struct{}
 
+	// peerAccess contains the access of the nodes to push blocks to the node.
+	//
+	// The map key is the node id of the peer.
+	peerAccess map[int]client.PeerAccessKind
+
 	// actualDAGHeads contains all DAG heads that exist on a node.
 	//
 	// The map key is the doc id. The map value is the doc head.
@@ -71,11 +82,13 @@ type docHeadState struct {
 // newP2PState returns a new empty p2p state.
 func newP2PState() *p2pState {
 	return &p2pState{
-		connections:      make(map[int]struct{}),
-		replicators:      make(map[int]struct{}),
-		peerCollections:  make(map[int]struct{}),
-		actualDAGHeads:   make(map[string]docHeadState),
-		expectedDAGHeads: make(map[string]cid.Cid),
+		connections:       make(map[int]struct{}),
+		replicators:       make(map[int]struct{}),
+		replicatorFilters: make(map[int]map[string]map[string]any),
+		peerCollections:   make(map[int]struct{}),
+		peerAccess:        make(map[int]client.PeerAccessKind),
+		actualDAGHeads:    make(map[string]docHeadState),
+		expectedDAGHeads:  make(map[string]cid.Cid),
 	}
 }
 
@@ -208,6 +221,11 @@ type state struct {
 	// Valid Cid string values by [UniqueCid] ID.
 	cids map[any]string
 
+	// The IDs of the requests prepared by [PrepareRequest] actions.
+	//
+	// This is order dependent and the property is accessed by index.
+	preparedRequestIDs []string
+
 	// isBench indicates wether the test is currently being benchmarked.
 	isBench bool
 
@@ -0,0 +1,350 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package subscription
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSubscriptionWithKind(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with change kind, create and update mutations",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User {
+						name
+						_kind
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"User": []map[string]any{
+							{
+								"name":  "John",
+								"_kind": "CREATE",
+							},
+						},
+					},
+					{
+						"User": []map[string]any{
+							{
+								"name":  "John",
+								"_kind": "UPDATE",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(input: {name: "John", age: 27, points: 42.1, verified: true}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"create_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_User(input: {points: 45}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"update_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithChanges(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with changes, update mutation",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27,
+					"verified": true,
+					"points": 42.1
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User {
+						name
+						_kind
+						changes: _changes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"User": []map[string]any{
+							{
+								"name":  "John",
+								"_kind": "UPDATE",
+								"changes": map[string]any{
+									"age": map[string]any{
+										"previous": int64(27),
+										"current":  int64(28),
+									},
+									"points": map[string]any{
+										"previous": float64(42.1),
+										"current":  float64(45),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_User(input: {age: 28, points: 45}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"update_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithShowDeleted(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with show deleted, delete mutation",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27,
+					"verified": true,
+					"points": 42.1
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(showDeleted: true) {
+						name
+						_deleted
+						_kind
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"User": []map[string]any{
+							{
+								"name":     "John",
+								"_deleted": true,
+								"_kind":    "DELETE",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(filter: {name: {_eq: "John"}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"delete_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithoutShowDeleted_DoesNotYieldDeletes(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription without show deleted, delete mutation",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27,
+					"verified": true,
+					"points": 42.1
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"User": []map[string]any{
+							{
+								"name": "Fred",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(filter: {name: {_eq: "John"}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"delete_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(input: {name: "Fred", age: 40, points: 10, verified: false}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"create_User": []map[string]any{
+						{
+							"name": "Fred",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithFilterAndShowDeleted_DoesNotYieldDeletesOutsideFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with filter and show deleted, delete mutation outside of the filter",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27,
+					"verified": true,
+					"points": 42.1
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Addo",
+					"age": 35,
+					"verified": true,
+					"points": 50
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(showDeleted: true, filter: {age: {_gt: 30}}) {
+						name
+						_kind
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"User": []map[string]any{
+							{
+								"name":  "Addo",
+								"_kind": "DELETE",
+							},
+						},
+					},
+					{
+						"User": []map[string]any{
+							{
+								"name":  "Fred",
+								"_kind": "CREATE",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(filter: {name: {_eq: "John"}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"delete_User": []map[string]any{
+						{
+							"name": "John",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(filter: {name: {_eq: "Addo"}}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"delete_User": []map[string]any{
+						{
+							"name": "Addo",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(input: {name: "Fred", age: 40, points: 10, verified: false}) {
+						name
+					}
+				}`,
+				Results: map[string]any{
+					"create_User": []map[string]any{
+						{
+							"name": "Fred",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
@@ -70,6 +70,25 @@ type TestCase struct {
 
 	// Configuration for KMS to be used in the test
 	KMS KMS
+
+	// If provided a value, QueryMemoryBudget sets the number of bytes the documents held in
+	// memory by a request may take on every node, past which they are spilled to disk.
+	QueryMemoryBudget immutable.Option[int64]
+
+	// If provided a value, MaxRequestScannedDocs sets the maximum number of documents a
+	// request may scan on every node.
+	MaxRequestScannedDocs immutable.Option[uint64]
+
+	// If true, EnableBlockTimestamps enables the timestamping of new blocks on every node.
+	EnableBlockTimestamps bool
+
+	// If true, EnableBlockSigning enables the signing of new blocks with the request identity,
+	// or else the node identity, on every node.
+	EnableBlockSigning bool
+
+	// If true, RequireBlockSignatures enables the rejection of unsigned blocks received from
+	// other nodes on every node.
+	RequireBlockSignatures bool
 }
 
 // KMS contains the configuration for KMS to be used in the test
@@ -500,6 +519,9 @@ type CreateIndex struct {
 	// If Unique is true, the index will be created as a unique index.
 	Unique bool
 
+	// The filter documents must match to be indexed. Optional.
+	Filter map[string]any
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -555,6 +577,36 @@ type GetIndexes struct {
 	ExpectedError string
 }
 
+// GetCollectionStatistics will attempt to get the estimated statistics of the given collection
+// using the store api.
+type GetCollectionStatistics struct {
+	// NodeID may hold the ID (index) of a node to get the statistics from.
+	//
+	// If a value is not provided the statistics will be retrieved from all nodes.
+	NodeID immutable.Option[int]
+
+	// The identity of this request. Optional.
+	//
+	// If an Identity is provided and the collection has a policy, then only the
+	// documents this Identity may read are counted.
+	//
+	// Use `UserIdentity` to create a user identity and `NodeIdentity` to create a node identity.
+	// Default value is `NoIdentity()`.
+	Identity immutable.Option[identity]
+
+	// The collection for which the statistics should be retrieved.
+	CollectionID int
+
+	// The expected statistics to be returned.
+	ExpectedStatistics client.CollectionStatistics
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
 // ResultAsserter is an interface that can be implemented to provide custom result
 // assertions.
 type ResultAsserter interface {
@@ -613,6 +665,16 @@ type Request struct {
 	// Variables sets the variables option for the request.
 	Variables immutable.Option[map[string]any]
 
+	// Prepared may hold the index of a [PrepareRequest] action, in which case the request prepared
+	// by that action is executed instead of Request.
+	Prepared immutable.Option[int]
+
+	// MaxDuration sets the maximum duration option for the request.
+	MaxDuration immutable.Option[time.Duration]
+
+	// MaxScannedDocs sets the maximum number of scanned documents option for the request.
+	MaxScannedDocs immutable.Option[uint64]
+
 	// The request to execute.
 	Request string
 
@@ -629,6 +691,24 @@ type Request struct {
 	ExpectedError string
 }
 
+// PrepareRequest is an action that prepares a request, so that it can be executed by
+// [Request] actions referencing it by index.
+type PrepareRequest struct {
+	// NodeID may hold the ID (index) of a node to prepare this request on.
+	//
+	// If a value is not provided the request will be prepared on all nodes.
+	NodeID immutable.Option[int]
+
+	// The request to prepare.
+	Request string
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
 // GenerateDocs is an action that will trigger generation of documents.
 type GenerateDocs struct {
 	// NodeID may hold the ID (index) of a node to execute the generation on.
@@ -765,6 +845,8 @@ type BackupExport struct {
 	Config client.BackupConfig
 
 	// Content expected to be found in the backup file.
+	//
+	// If empty the content of the backup file is not asserted.
 	ExpectedContent string
 
 	// Any error expected from the action. Optional.
@@ -786,6 +868,8 @@ type BackupImport struct {
 	Filepath string
 
 	// The backup file content.
+	//
+	// If empty the existing file at the given path is imported.
 	ImportContent string
 
 	// Any error expected from the action. Optional.
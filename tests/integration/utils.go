@@ -34,6 +34,7 @@ import (
 	"github.com/sourcenetwork/defradb/crypto"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/event"
 	"github.com/sourcenetwork/defradb/internal/db"
 	"github.com/sourcenetwork/defradb/internal/encryption"
 	"github.com/sourcenetwork/defradb/internal/request/graphql"
@@ -307,6 +308,9 @@ func performAction(
 	case DeleteReplicator:
 		deleteReplicator(s, action)
 
+	case GetReplicatorStatus:
+		getReplicatorStatus(s, action)
+
 	case SubscribeToCollection:
 		subscribeToCollection(s, action)
 
@@ -316,6 +320,18 @@ func performAction(
 	case GetAllP2PCollections:
 		getAllP2PCollections(s, action)
 
+	case ReconcileWithPeer:
+		reconcileWithPeer(s, action)
+
+	case SetPeerAccess:
+		setPeerAccess(s, action)
+
+	case DeletePeerAccess:
+		deletePeerAccess(s, action)
+
+	case GetAllPeerAccess:
+		getAllPeerAccess(s, action)
+
 	case SchemaUpdate:
 		updateSchema(s, action)
 
@@ -373,6 +389,9 @@ func performAction(
 	case GetIndexes:
 		getIndexes(s, action)
 
+	case GetCollectionStatistics:
+		getCollectionStatistics(s, action)
+
 	case BackupExport:
 		backupExport(s, action)
 
@@ -388,6 +407,9 @@ func performAction(
 	case Request:
 		executeRequest(s, action)
 
+	case PrepareRequest:
+		prepareRequest(s, action)
+
 	case ExplainRequest:
 		executeExplainRequest(s, action)
 
@@ -577,11 +599,20 @@ func closeNodes(
 ) {
 	_, nodes := getNodesWithIDs(action.NodeID, s.nodes)
 	for _, node := range nodes {
+		// Update events that have not been waited on, such as the events of large imports,
+		// may fill the subscription buffer and block the event bus from closing.
+		go drainSubscription(node.event.update)
 		node.Close()
 		node.closed = true
 	}
 }
 
+// drainSubscription discards all messages of the given subscription until it is closed.
+func drainSubscription(sub *event.Subscription) {
+	for range sub.Message() {
+	}
+}
+
 // getNodesWithIDs gets the applicable node(s) and their ID(s) for the given target nodeID.
 //
 // If nodeID has a value it will return that node and it's ID only. Otherwise all nodes will
@@ -935,6 +966,43 @@ func getIndexes(
 	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
 }
 
+func getCollectionStatistics(
+	s *state,
+	action GetCollectionStatistics,
+) {
+	if len(s.nodes) == 0 {
+		return
+	}
+
+	var expectedErrorRaised bool
+
+	nodeIDs, _ := getNodesWithIDs(action.NodeID, s.nodes)
+	for _, nodeID := range nodeIDs {
+		collectionName := s.nodes[nodeID].collections[action.CollectionID].Name().Value()
+		ctx := getContextWithIdentity(s.ctx, s, action.Identity, nodeID)
+		err := withRetryOnNode(
+			s.nodes[nodeID],
+			func() error {
+				actualStats, err := s.nodes[nodeID].GetCollectionStatistics(ctx, collectionName)
+				if err != nil {
+					return err
+				}
+				require.Equal(s.t, action.ExpectedStatistics.CollectionName, actualStats.CollectionName,
+					s.testCase.Description)
+				require.Equal(s.t, action.ExpectedStatistics.DocumentCount, actualStats.DocumentCount,
+					s.testCase.Description)
+				require.ElementsMatch(s.t, action.ExpectedStatistics.Indexes, actualStats.Indexes,
+					s.testCase.Description)
+				return nil
+			},
+		)
+		expectedErrorRaised = expectedErrorRaised ||
+			AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
+	}
+
+	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
+}
+
 func assertIndexesListsEqual(
 	expectedIndexes []client.IndexDescription,
 	actualIndexes []client.IndexDescription,
@@ -1584,6 +1652,7 @@ func createIndex(
 		}
 
 		indexDesc.Unique = action.Unique
+		indexDesc.Filter = action.Filter
 		err := withRetryOnNode(
 			node,
 			func() error {
@@ -1653,7 +1722,7 @@ func backupExport(
 		)
 		expectedErrorRaised = AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
 
-		if !expectedErrorRaised {
+		if !expectedErrorRaised && action.ExpectedContent != "" {
 			assertBackupContent(s.t, action.ExpectedContent, action.Config.Filepath)
 		}
 	}
@@ -1670,9 +1739,12 @@ func backupImport(
 		action.Filepath = s.t.TempDir() + testJSONFile
 	}
 
-	// we can avoid checking the error here as this would mean the filepath is invalid
-	// and we want to make sure that `BasicImport` fails in this case.
-	_ = os.WriteFile(action.Filepath, []byte(action.ImportContent), 0664)
+	// the content is only written if given so that a file exported by a previous action can be imported.
+	if action.ImportContent != "" {
+		// we can avoid checking the error here as this would mean the filepath is invalid
+		// and we want to make sure that `BasicImport` fails in this case.
+		_ = os.WriteFile(action.Filepath, []byte(action.ImportContent), 0664)
+	}
 
 	var expectedErrorRaised bool
 
@@ -1779,6 +1851,12 @@ func executeRequest(
 		if action.Variables.HasValue() {
 			options = append(options, client.WithVariables(action.Variables.Value()))
 		}
+		if action.MaxDuration.HasValue() {
+			options = append(options, client.WithMaxDuration(action.MaxDuration.Value()))
+		}
+		if action.MaxScannedDocs.HasValue() {
+			options = append(options, client.WithMaxScannedDocs(action.MaxScannedDocs.Value()))
+		}
 
 		if !expectedErrorRaised && viewType == MaterializedViewType {
 			err := node.RefreshViews(s.ctx, client.CollectionFetchOptions{})
@@ -1788,7 +1866,12 @@ func executeRequest(
 			}
 		}
 
-		result := node.ExecRequest(ctx, action.Request, options...)
+		var result *client.RequestResult
+		if action.Prepared.HasValue() {
+			result = node.ExecPreparedRequest(ctx, s.preparedRequestIDs[action.Prepared.Value()], options...)
+		} else {
+			result = node.ExecRequest(ctx, action.Request, options...)
+		}
 
 		expectedErrorRaised = assertRequestResults(
 			s,
@@ -1803,6 +1886,22 @@ func executeRequest(
 	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
 }
 
+// prepareRequest prepares the given request, recording the ID of the prepared request.
+func prepareRequest(
+	s *state,
+	action PrepareRequest,
+) {
+	var id string
+	_, nodes := getNodesWithIDs(action.NodeID, s.nodes)
+	for _, node := range nodes {
+		preparedID, err := node.PrepareRequest(s.ctx, action.Request)
+		expectedErrorRaised := AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
+		assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
+		id = preparedID
+	}
+	s.preparedRequestIDs = append(s.preparedRequestIDs, id)
+}
+
 // executeSubscriptionRequest executes the given subscription request, returning
 // a channel that will receive a single event once the subscription has been completed.
 //
@@ -2064,6 +2163,20 @@ func ConvertToArrayOfMaps(t testing.TB, value any) []map[string]any {
 	return valueArrayMap
 }
 
+// ConvertToTime converts the given datetime result value, which is a string if the result was
+// returned over the wire, into a time.
+func ConvertToTime(t testing.TB, value any) time.Time {
+	if timeValue, ok := value.(time.Time); ok {
+		return timeValue
+	}
+	timeString, ok := value.(string)
+	require.True(t, ok, "expected value to be a datetime %v", value)
+
+	timeValue, err := time.Parse(time.RFC3339Nano, timeString)
+	require.NoError(t, err)
+	return timeValue
+}
+
 func assertExpectedErrorRaised(t testing.TB, description string, expectedError string, wasRaised bool) {
 	if expectedError != "" && !wasRaised {
 		assert.Fail(t, "Expected an error however none was raised.", description)
@@ -2314,7 +2427,7 @@ func skipIfNetworkTest(t testing.TB, actions []any) {
 }
 
 func ParseSDL(gqlSDL string) (map[string]client.CollectionDefinition, error) {
-	parser, err := graphql.NewParser()
+	parser, err := graphql.NewParser(graphql.DefaultRequestCacheSize)
 	if err != nil {
 		return nil, err
 	}